@@ -0,0 +1,180 @@
+package vibelogger
+
+import (
+	"context"
+	"crypto/subtle"
+	"net/http"
+	"strings"
+)
+
+// Scope is a kind of access to logger endpoints
+type Scope string
+
+// Scopes checked by RequireAccess and the Receiver
+const (
+	ScopeRead  Scope = "read"  // Read entries, e.g. StreamHandler and the ui viewer
+	ScopeWrite Scope = "write" // Submit entries to a Receiver
+)
+
+// ProjectHeader selects the project of a Receiver request when the caller may
+// write to several
+const ProjectHeader = "X-Vibe-Project"
+
+// Principal is an authenticated caller of a logger endpoint
+type Principal struct {
+	Name     string   // Identifies the caller in diagnostics
+	Scopes   []Scope  // Granted scopes
+	Projects []string // Projects the caller may access; empty grants every project
+}
+
+// HasScope reports whether the principal was granted scope
+func (p *Principal) HasScope(scope Scope) bool {
+	for _, s := range p.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// CanAccess reports whether the principal may access project. Principals
+// restricted to some projects cannot access endpoints without a project.
+func (p *Principal) CanAccess(project string) bool {
+	if len(p.Projects) == 0 {
+		return true
+	}
+	for _, allowed := range p.Projects {
+		if allowed == project && project != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// Authenticator identifies the caller of a request
+type Authenticator interface {
+	// Authenticate returns the caller of r, false when r carries no known credentials
+	Authenticate(r *http.Request) (*Principal, bool)
+}
+
+// AuthenticatorFunc adapts a function to the Authenticator interface
+type AuthenticatorFunc func(r *http.Request) (*Principal, bool)
+
+// Authenticate calls f(r)
+func (f AuthenticatorFunc) Authenticate(r *http.Request) (*Principal, bool) {
+	return f(r)
+}
+
+// tokenAuthenticator identifies callers by bearer token
+type tokenAuthenticator struct {
+	tokens map[string]*Principal
+}
+
+// NewTokenAuthenticator identifies callers by their "Authorization: Bearer
+// <token>" header. Empty tokens are ignored.
+func NewTokenAuthenticator(tokens map[string]Principal) Authenticator {
+	a := &tokenAuthenticator{tokens: make(map[string]*Principal, len(tokens))}
+	for token, principal := range tokens {
+		if token != "" {
+			principal := principal
+			a.tokens[token] = &principal
+		}
+	}
+	return a
+}
+
+// Authenticate implements Authenticator
+func (a *tokenAuthenticator) Authenticate(r *http.Request) (*Principal, bool) {
+	presented, ok := bearerToken(r)
+	if !ok {
+		return nil, false
+	}
+	// Compare against every token so timing does not reveal which one matched
+	var found *Principal
+	for token, principal := range a.tokens {
+		if subtle.ConstantTimeCompare([]byte(presented), []byte(token)) == 1 {
+			found = principal
+		}
+	}
+	return found, found != nil
+}
+
+// bearerToken returns the token of the request's Authorization header
+func bearerToken(r *http.Request) (string, bool) {
+	auth := r.Header.Get("Authorization")
+	const prefix = "Bearer "
+	if len(auth) <= len(prefix) || !strings.EqualFold(auth[:len(prefix)], prefix) {
+		return "", false
+	}
+	return auth[len(prefix):], true
+}
+
+// NewClientCertAuthenticator identifies callers of a TLS server requiring
+// client certificates (mTLS) by the common name, DNS name or URI of the
+// verified certificate. The server's tls.Config must verify the certificates,
+// e.g. with ClientAuth set to tls.RequireAndVerifyClientCert.
+func NewClientCertAuthenticator(subjects map[string]Principal) Authenticator {
+	return AuthenticatorFunc(func(r *http.Request) (*Principal, bool) {
+		if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || len(r.TLS.VerifiedChains[0]) == 0 {
+			return nil, false
+		}
+		cert := r.TLS.VerifiedChains[0][0]
+		names := append([]string{cert.Subject.CommonName}, cert.DNSNames...)
+		for _, uri := range cert.URIs {
+			names = append(names, uri.String())
+		}
+		for _, name := range names {
+			if principal, ok := subjects[name]; ok && name != "" {
+				return &principal, true
+			}
+		}
+		return nil, false
+	})
+}
+
+// FirstAuthenticator tries the authenticators in order and returns the first
+// caller identified, e.g. to accept either a client certificate or a token
+func FirstAuthenticator(authenticators ...Authenticator) Authenticator {
+	return AuthenticatorFunc(func(r *http.Request) (*Principal, bool) {
+		for _, a := range authenticators {
+			if principal, ok := a.Authenticate(r); ok {
+				return principal, true
+			}
+		}
+		return nil, false
+	})
+}
+
+// principalKey is the context key of the authenticated principal
+type principalKey struct{}
+
+// PrincipalFromContext returns the caller authenticated by RequireAccess
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(*Principal)
+	return principal, ok
+}
+
+// RequireAccess serves next only to callers identified by auth that have
+// scope for project, typically the ProjectName of the logger behind next:
+//
+//	config := logger.Config()
+//	mux.Handle("/logs/", vibelogger.RequireAccess(auth, vibelogger.ScopeRead,
+//		config.ProjectName, http.StripPrefix("/logs", ui.Handler(logger))))
+//
+// Unknown callers receive 401 and callers without the scope or project 403.
+// The caller is available to next through PrincipalFromContext.
+func RequireAccess(auth Authenticator, scope Scope, project string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		principal, ok := auth.Authenticate(r)
+		if !ok {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="vibe-logger"`)
+			http.Error(w, "authentication required", http.StatusUnauthorized)
+			return
+		}
+		if !principal.HasScope(scope) || !principal.CanAccess(project) {
+			http.Error(w, "access denied", http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), principalKey{}, principal)))
+	})
+}
@@ -0,0 +1,76 @@
+package vibelogger
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRequireAccess(t *testing.T) {
+	auth := NewTokenAuthenticator(map[string]Principal{
+		"reader":  {Name: "dashboard", Scopes: []Scope{ScopeRead}, Projects: []string{"shop"}},
+		"writer":  {Name: "agent", Scopes: []Scope{ScopeWrite}},
+		"auditor": {Name: "auditor", Scopes: []Scope{ScopeRead}},
+	})
+	var seen string
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		principal, _ := PrincipalFromContext(r.Context())
+		seen = principal.Name
+	})
+
+	cases := []struct {
+		token   string
+		project string
+		status  int
+	}{
+		{"", "shop", http.StatusUnauthorized},
+		{"unknown", "shop", http.StatusUnauthorized},
+		{"reader", "shop", http.StatusOK},
+		{"reader", "billing", http.StatusForbidden},
+		{"reader", "", http.StatusForbidden},
+		{"writer", "shop", http.StatusForbidden},
+		{"auditor", "billing", http.StatusOK},
+		{"auditor", "", http.StatusOK},
+	}
+	for _, c := range cases {
+		request := httptest.NewRequest("GET", "/", nil)
+		if c.token != "" {
+			request.Header.Set("Authorization", "Bearer "+c.token)
+		}
+		recorder := httptest.NewRecorder()
+		RequireAccess(auth, ScopeRead, c.project, next).ServeHTTP(recorder, request)
+		if recorder.Code != c.status {
+			t.Errorf("token %q on project %q: expected %d, got %d", c.token, c.project, c.status, recorder.Code)
+		}
+	}
+	if seen != "auditor" {
+		t.Errorf("expected the principal in the request context, got %q", seen)
+	}
+}
+
+func TestClientCertAuthenticator(t *testing.T) {
+	auth := FirstAuthenticator(
+		NewClientCertAuthenticator(map[string]Principal{"collector.internal": {Name: "collector", Scopes: []Scope{ScopeWrite}}}),
+		NewTokenAuthenticator(map[string]Principal{"token": {Name: "token"}}),
+	)
+
+	request := httptest.NewRequest("POST", "/", nil)
+	cert := &x509.Certificate{Subject: pkix.Name{CommonName: "collector"}, DNSNames: []string{"collector.internal"}}
+	request.TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}
+	if principal, ok := auth.Authenticate(request); !ok || principal.Name != "collector" {
+		t.Errorf("expected the certificate's principal, got %+v", principal)
+	}
+
+	// Unverified certificates are not trusted
+	request.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}
+	if _, ok := auth.Authenticate(request); ok {
+		t.Error("expected an unverified certificate to be rejected")
+	}
+	request.Header.Set("Authorization", "Bearer token")
+	if principal, ok := auth.Authenticate(request); !ok || principal.Name != "token" {
+		t.Errorf("expected the token's principal, got %+v", principal)
+	}
+}
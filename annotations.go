@@ -0,0 +1,63 @@
+package vibelogger
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+)
+
+// Annotation kinds
+const (
+	AnnotationAITodo    = "ai_todo"
+	AnnotationHumanNote = "human_note"
+)
+
+// TimeRange limits entries to [Since, Until). Zero values are unbounded.
+type TimeRange struct {
+	Since time.Time
+	Until time.Time
+}
+
+// Annotation is an AI todo or human note together with the entry carrying it
+type Annotation struct {
+	Kind  string   `json:"kind"` // ai_todo or human_note
+	Text  string   `json:"text"`
+	Entry LogEntry `json:"entry"`
+}
+
+// ExtractTodos returns the AI todos and human notes written to the log files
+// of a project (logs/<project>, or logs/default for an empty project) within
+// timeRange, in time order
+func ExtractTodos(project string, timeRange TimeRange) ([]Annotation, error) {
+	if project == "" {
+		project = "default"
+	}
+	if !isValidProjectName(project) {
+		return nil, fmt.Errorf("invalid project name: %s", project)
+	}
+	entries, err := ReadLogDir(filepath.Join("logs", project))
+	if err != nil {
+		return nil, err
+	}
+	return CollectAnnotations(entries, timeRange), nil
+}
+
+// CollectAnnotations returns the AI todos and human notes of entries within
+// timeRange. An entry with both yields two annotations.
+func CollectAnnotations(entries []LogEntry, timeRange TimeRange) []Annotation {
+	query := Query{Since: timeRange.Since, Until: timeRange.Until}
+	var annotations []Annotation
+	for i := range entries {
+		entry := &entries[i]
+		if (entry.AITodo == "" && entry.HumanNote == "") || !query.Match(entry) {
+			continue
+		}
+		if entry.AITodo != "" {
+			annotations = append(annotations, Annotation{Kind: AnnotationAITodo, Text: entry.AITodo, Entry: *entry})
+		}
+		if entry.HumanNote != "" {
+			annotations = append(annotations, Annotation{Kind: AnnotationHumanNote, Text: entry.HumanNote, Entry: *entry})
+		}
+	}
+	return annotations
+}
@@ -0,0 +1,55 @@
+package vibelogger
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestCollectAnnotations(t *testing.T) {
+	now := time.Now()
+	entries := []LogEntry{
+		{Timestamp: now.Add(-2 * time.Hour), Operation: "old", AITodo: "Too old"},
+		{Timestamp: now, Operation: "checkout", AITodo: "Investigate slow checkout", HumanNote: "Seen after deploy"},
+		{Timestamp: now, Operation: "plain", Message: "No annotations"},
+	}
+
+	annotations := CollectAnnotations(entries, TimeRange{Since: now.Add(-time.Hour)})
+	if len(annotations) != 2 {
+		t.Fatalf("expected 2 annotations, got %+v", annotations)
+	}
+	if annotations[0].Kind != AnnotationAITodo || annotations[0].Entry.Operation != "checkout" {
+		t.Errorf("unexpected first annotation: %+v", annotations[0])
+	}
+	if annotations[1].Kind != AnnotationHumanNote || annotations[1].Text != "Seen after deploy" {
+		t.Errorf("unexpected second annotation: %+v", annotations[1])
+	}
+}
+
+func TestExtractTodos(t *testing.T) {
+	defer os.RemoveAll("logs/todo_test")
+
+	config := DefaultConfig()
+	config.ProjectName = "todo_test"
+	logger, err := CreateFileLoggerWithConfig("app", config)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	logger.Warn("payment", "Retry limit reached", WithAITodo("Check retry policy"))
+	logger.Info("startup", "Service ready")
+	logger.Close()
+
+	annotations, err := ExtractTodos("todo_test", TimeRange{})
+	if err != nil {
+		t.Fatalf("ExtractTodos failed: %v", err)
+	}
+	if len(annotations) != 1 || annotations[0].Text != "Check retry policy" {
+		t.Errorf("unexpected annotations: %+v", annotations)
+	}
+
+	for _, project := range []string{"../..", "a/b"} {
+		if _, err := ExtractTodos(project, TimeRange{}); err == nil {
+			t.Errorf("expected project %q to be rejected", project)
+		}
+	}
+}
@@ -0,0 +1,284 @@
+package vibelogger
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultQueueSize is the queue capacity used by NewAsyncSink for non-positive sizes
+const DefaultQueueSize = 1024
+
+// maxAsyncBatch limits how many queued entries are passed to one WriteBatch call
+const maxAsyncBatch = 100
+
+// ErrSinkClosed is returned when writing to a closed AsyncSink
+var ErrSinkClosed = errors.New("sink is closed")
+
+// QueueStats describes the queue of an asynchronous sink
+type QueueStats struct {
+	Sink              string        `json:"sink"`     // Type of the wrapped sink
+	Capacity          int           `json:"capacity"` // Maximum number of queued entries
+	Depth             int           `json:"depth"`    // Entries currently waiting
+	MaxDepth          int           `json:"max_depth"`
+	Enqueued          int64         `json:"enqueued"`
+	Written           int64         `json:"written"`
+	Failed            int64         `json:"failed"`
+	EnqueueLatencyAvg time.Duration `json:"enqueue_latency_avg"` // Time callers waited for queue space
+	EnqueueLatencyMax time.Duration `json:"enqueue_latency_max"`
+	FlushLatencyAvg   time.Duration `json:"flush_latency_avg"` // Time the wrapped sink took per write or batch
+	FlushLatencyMax   time.Duration `json:"flush_latency_max"`
+}
+
+// queueReporter is implemented by sinks that buffer entries, see Stats.Queues
+type queueReporter interface {
+	QueueStats() QueueStats
+}
+
+// AsyncSink writes entries to another sink from a background goroutine through
+// a bounded queue. When the queue is full, Write blocks until space is available,
+// so a slow destination slows down logging instead of losing entries; the time
+// spent waiting is reported as enqueue latency. If the wrapped sink is a
+// BatchSink, entries that queued up meanwhile are written in one batch.
+type AsyncSink struct {
+	sink  Sink
+	name  string
+	queue chan LogEntry
+	done  chan struct{}
+
+	closeMutex sync.RWMutex // Guards closed against sends racing with Close
+	closed     bool
+
+	mutex          sync.Mutex // Guards the counters below
+	maxDepth       int
+	enqueued       int64
+	written        int64
+	failed         int64
+	enqueueTotal   time.Duration
+	enqueueMax     time.Duration
+	flushes        int64
+	flushTotal     time.Duration
+	flushMax       time.Duration
+	lastWriteError error
+}
+
+// NewAsyncSink wraps sink with a queue of queueSize entries
+func NewAsyncSink(sink Sink, queueSize int) *AsyncSink {
+	if queueSize <= 0 {
+		queueSize = DefaultQueueSize
+	}
+	s := &AsyncSink{
+		sink:  sink,
+		name:  fmt.Sprintf("%T", sink),
+		queue: make(chan LogEntry, queueSize),
+		done:  make(chan struct{}),
+	}
+	go s.run()
+	return s
+}
+
+// Write queues a copy of the entry. Errors of the wrapped sink are not returned
+// here; they are counted in QueueStats.Failed and returned by Close.
+func (s *AsyncSink) Write(entry *LogEntry) error {
+	s.closeMutex.RLock()
+	defer s.closeMutex.RUnlock()
+	if s.closed {
+		return ErrSinkClosed
+	}
+
+	start := time.Now()
+	s.queue <- *entry
+	waited := time.Since(start)
+	depth := len(s.queue)
+
+	s.mutex.Lock()
+	s.enqueued++
+	s.enqueueTotal += waited
+	if waited > s.enqueueMax {
+		s.enqueueMax = waited
+	}
+	if depth > s.maxDepth {
+		s.maxDepth = depth
+	}
+	s.mutex.Unlock()
+	return nil
+}
+
+// run writes queued entries until the queue is closed
+func (s *AsyncSink) run() {
+	defer close(s.done)
+	batchSink, batching := s.sink.(BatchSink)
+	for entry := range s.queue {
+		batch := []LogEntry{entry}
+		if batching {
+			batch = s.drain(batch)
+		}
+
+		start := time.Now()
+		var err error
+		p := callSafely("sink", func() {
+			if batching {
+				err = batchSink.WriteBatch(batch)
+			} else {
+				err = s.sink.Write(&batch[0])
+			}
+		})
+		if p != nil {
+			err = p
+		}
+		elapsed := time.Since(start)
+
+		s.mutex.Lock()
+		if err != nil {
+			s.failed += int64(len(batch))
+			s.lastWriteError = err
+		} else {
+			s.written += int64(len(batch))
+		}
+		s.flushes++
+		s.flushTotal += elapsed
+		if elapsed > s.flushMax {
+			s.flushMax = elapsed
+		}
+		s.mutex.Unlock()
+	}
+}
+
+// drain appends entries that are already queued to batch without waiting
+func (s *AsyncSink) drain(batch []LogEntry) []LogEntry {
+	for len(batch) < maxAsyncBatch {
+		select {
+		case entry, ok := <-s.queue:
+			if !ok {
+				return batch
+			}
+			batch = append(batch, entry)
+		default:
+			return batch
+		}
+	}
+	return batch
+}
+
+// Close writes the remaining queued entries and closes the wrapped sink. It
+// returns the last write error of the wrapped sink, if any.
+func (s *AsyncSink) Close() error {
+	s.closeMutex.Lock()
+	if s.closed {
+		s.closeMutex.Unlock()
+		return nil
+	}
+	s.closed = true
+	close(s.queue)
+	s.closeMutex.Unlock()
+
+	<-s.done
+	err := s.sink.Close()
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.lastWriteError != nil {
+		return fmt.Errorf("failed to write %d queued entries: %w", s.failed, s.lastWriteError)
+	}
+	return err
+}
+
+// QueueStats returns a snapshot of the queue telemetry
+func (s *AsyncSink) QueueStats() QueueStats {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	stats := QueueStats{
+		Sink:              s.name,
+		Capacity:          cap(s.queue),
+		Depth:             len(s.queue),
+		MaxDepth:          s.maxDepth,
+		Enqueued:          s.enqueued,
+		Written:           s.written,
+		Failed:            s.failed,
+		EnqueueLatencyMax: s.enqueueMax,
+		FlushLatencyMax:   s.flushMax,
+	}
+	if s.enqueued > 0 {
+		stats.EnqueueLatencyAvg = s.enqueueTotal / time.Duration(s.enqueued)
+	}
+	if s.flushes > 0 {
+		stats.FlushLatencyAvg = s.flushTotal / time.Duration(s.flushes)
+	}
+	return stats
+}
+
+// queueStats collects the telemetry of all buffering sinks
+func (l *Logger) queueStats() []QueueStats {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	var queues []QueueStats
+	for _, sink := range l.sinks {
+		if reporter, ok := sink.(queueReporter); ok {
+			queues = append(queues, reporter.QueueStats())
+		}
+	}
+	return queues
+}
+
+// StartQueueStats writes a "queue_stats" entry with the telemetry of every
+// AsyncSink each interval. The entry is a WARN when a queue is at least 80% full,
+// so capacity problems in the logging path show up in the log itself. Calling it
+// again replaces the previous reporter; a non-positive interval only stops it.
+func (l *Logger) StartQueueStats(interval time.Duration) {
+	l.StopQueueStats()
+	if interval <= 0 {
+		return
+	}
+
+	task := startPeriodicTask(interval, l.writeQueueStats)
+	l.bgMutex.Lock()
+	l.queueReporter = task
+	l.bgMutex.Unlock()
+}
+
+// StopQueueStats stops the queue telemetry goroutine if one is running
+func (l *Logger) StopQueueStats() {
+	l.bgMutex.Lock()
+	task := l.queueReporter
+	l.queueReporter = nil
+	l.bgMutex.Unlock()
+
+	task.Stop()
+}
+
+// writeQueueStats logs the current queue telemetry, if there are any queues
+func (l *Logger) writeQueueStats() {
+	queues := l.queueStats()
+	if len(queues) == 0 {
+		return
+	}
+
+	level := INFO
+	message := "Log queue status"
+	details := make([]map[string]interface{}, 0, len(queues))
+	for _, q := range queues {
+		if q.Depth*10 >= q.Capacity*8 {
+			level = WARN
+			message = "Log queue almost full, destination is slower than the log rate"
+		}
+		details = append(details, map[string]interface{}{
+			"sink":                   q.Sink,
+			"capacity":               q.Capacity,
+			"depth":                  q.Depth,
+			"max_depth":              q.MaxDepth,
+			"enqueued":               q.Enqueued,
+			"written":                q.Written,
+			"failed":                 q.Failed,
+			"enqueue_latency_avg_ms": float64(q.EnqueueLatencyAvg) / float64(time.Millisecond),
+			"enqueue_latency_max_ms": float64(q.EnqueueLatencyMax) / float64(time.Millisecond),
+			"flush_latency_avg_ms":   float64(q.FlushLatencyAvg) / float64(time.Millisecond),
+			"flush_latency_max_ms":   float64(q.FlushLatencyMax) / float64(time.Millisecond),
+		})
+	}
+	l.Log(level, "queue_stats", message, WithContext(map[string]interface{}{
+		"queues": details,
+	}))
+}
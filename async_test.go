@@ -0,0 +1,187 @@
+package vibelogger
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// blockingSink waits on release before accepting each entry
+type blockingSink struct {
+	recordingSink
+	release chan struct{}
+}
+
+func (s *blockingSink) Write(entry *LogEntry) error {
+	<-s.release
+	return s.recordingSink.Write(entry)
+}
+
+func TestAsyncSinkWritesInOrder(t *testing.T) {
+	inner := &recordingSink{}
+	sink := NewAsyncSink(inner, 4)
+
+	for i := 0; i < 20; i++ {
+		if err := sink.Write(&LogEntry{Operation: "op", Message: string(rune('a' + i))}); err != nil {
+			t.Fatalf("Unexpected write error: %v", err)
+		}
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatalf("Unexpected close error: %v", err)
+	}
+
+	if len(inner.entries) != 20 || !inner.closed {
+		t.Fatalf("Expected 20 entries and a closed sink, got %d (closed=%v)", len(inner.entries), inner.closed)
+	}
+	for i, entry := range inner.entries {
+		if entry.Message != string(rune('a'+i)) {
+			t.Fatalf("Expected entries in order, got %q at %d", entry.Message, i)
+		}
+	}
+
+	stats := sink.QueueStats()
+	if stats.Enqueued != 20 || stats.Written != 20 || stats.Depth != 0 || stats.Capacity != 4 {
+		t.Errorf("Unexpected queue stats: %+v", stats)
+	}
+	if stats.Sink != "*vibelogger.recordingSink" {
+		t.Errorf("Expected the wrapped sink type, got %s", stats.Sink)
+	}
+	if err := sink.Write(&LogEntry{}); !errors.Is(err, ErrSinkClosed) {
+		t.Errorf("Expected ErrSinkClosed after Close, got %v", err)
+	}
+}
+
+func TestAsyncSinkBackpressure(t *testing.T) {
+	inner := &blockingSink{release: make(chan struct{})}
+	sink := NewAsyncSink(inner, 2)
+
+	// One entry is held by the worker, two fill the queue, the fourth must wait
+	for i := 0; i < 3; i++ {
+		sink.Write(&LogEntry{})
+	}
+	deadline := time.Now().Add(time.Second)
+	for sink.QueueStats().Depth < 2 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	written := make(chan struct{})
+	go func() {
+		sink.Write(&LogEntry{})
+		close(written)
+	}()
+	select {
+	case <-written:
+		t.Fatal("Expected Write to block while the queue is full")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	stats := sink.QueueStats()
+	if stats.MaxDepth != 2 {
+		t.Errorf("Expected max depth 2, got %d", stats.MaxDepth)
+	}
+
+	close(inner.release)
+	<-written
+	sink.Close()
+
+	stats = sink.QueueStats()
+	if stats.Written != 4 {
+		t.Errorf("Expected 4 written entries, got %d", stats.Written)
+	}
+	// The blocked write waited for the release; how long depends on scheduling
+	if stats.EnqueueLatencyMax <= 0 {
+		t.Errorf("Expected the blocked write in the enqueue latency, got %v", stats.EnqueueLatencyMax)
+	}
+}
+
+func TestAsyncSinkReportsWriteErrors(t *testing.T) {
+	inner := &recordingSink{writeErr: errors.New("collector unavailable")}
+	sink := NewAsyncSink(inner, 0)
+
+	if err := sink.Write(&LogEntry{}); err != nil {
+		t.Fatalf("Expected errors to be deferred, got %v", err)
+	}
+	if err := sink.Close(); err == nil {
+		t.Error("Expected Close to report the write error")
+	}
+	if stats := sink.QueueStats(); stats.Failed != 1 || stats.Capacity != DefaultQueueSize {
+		t.Errorf("Unexpected queue stats: %+v", stats)
+	}
+}
+
+func TestQueueStatsEntries(t *testing.T) {
+	config := &LoggerConfig{
+		AutoSave:        false,
+		EnableMemoryLog: true,
+		MemoryLogLimit:  10,
+	}
+	logger := NewLoggerWithConfig("queue_stats_test", config)
+	defer logger.Close()
+
+	// Without async sinks nothing is reported
+	logger.writeQueueStats()
+	if len(logger.GetMemoryLogs()) != 0 {
+		t.Fatal("Expected no queue stats entry without async sinks")
+	}
+
+	inner := &blockingSink{release: make(chan struct{})}
+	defer close(inner.release)
+	logger.AddSink(NewAsyncSink(inner, 5))
+	// One entry is held by the worker and four wait, leaving room for the report
+	for i := 0; i < 5; i++ {
+		logger.Info("test", "Entry")
+	}
+	deadline := time.Now().Add(time.Second)
+	for logger.Stats().Queues[0].Depth != 4 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	logger.writeQueueStats()
+	logs := logger.GetMemoryLogs()
+	last := logs[len(logs)-1]
+	if last.Operation != "queue_stats" || last.Level != WARN {
+		t.Fatalf("Expected a WARN queue_stats entry for a full queue, got %s %s", last.Level, last.Operation)
+	}
+	queues := last.Context["queues"].([]map[string]interface{})
+	if queues[0]["depth"] != 4 || queues[0]["capacity"] != 5 {
+		t.Errorf("Unexpected queue details: %v", queues[0])
+	}
+}
+
+// batchSink records the size of every batch
+type batchSink struct {
+	blockingSink
+	entered chan struct{}
+	batches []int
+}
+
+func (s *batchSink) WriteBatch(entries []LogEntry) error {
+	s.entered <- struct{}{}
+	<-s.release
+	s.batches = append(s.batches, len(entries))
+	return nil
+}
+
+func TestAsyncSinkBatches(t *testing.T) {
+	inner := &batchSink{
+		blockingSink: blockingSink{release: make(chan struct{})},
+		entered:      make(chan struct{}, 2),
+	}
+	sink := NewAsyncSink(inner, 10)
+
+	// The first entry is held by the worker while the others queue up
+	sink.Write(&LogEntry{})
+	<-inner.entered
+	for i := 0; i < 4; i++ {
+		sink.Write(&LogEntry{})
+	}
+	close(inner.release)
+	sink.Close()
+
+	if len(inner.batches) != 2 || inner.batches[0] != 1 || inner.batches[1] != 4 {
+		t.Errorf("Expected batches of 1 and 4 entries, got %v", inner.batches)
+	}
+	if stats := sink.QueueStats(); stats.Written != 5 {
+		t.Errorf("Expected 5 written entries, got %d", stats.Written)
+	}
+}
@@ -0,0 +1,78 @@
+package vibelogger
+
+import "time"
+
+// periodicTask is a background goroutine running a function at a fixed interval
+type periodicTask struct {
+	stop chan struct{}
+	done chan struct{}
+}
+
+// startPeriodicTask runs fn every interval until Stop is called
+func startPeriodicTask(interval time.Duration, fn func()) *periodicTask {
+	t := &periodicTask{
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+
+	go func() {
+		defer close(t.done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-t.stop:
+				return
+			case <-ticker.C:
+				fn()
+			}
+		}
+	}()
+	return t
+}
+
+// Stop stops the task and waits for a running fn to return. It is safe on a nil task.
+func (t *periodicTask) Stop() {
+	if t == nil {
+		return
+	}
+	close(t.stop)
+	<-t.done
+}
+
+// startBackgroundWorkers starts the periodic writers enabled in the configuration
+func (l *Logger) startBackgroundWorkers() {
+	if l.config.HeartbeatInterval > 0 {
+		l.StartHeartbeat(l.config.HeartbeatInterval)
+	}
+	if l.config.SummaryInterval > 0 {
+		l.StartSummaries(l.config.SummaryInterval)
+	}
+	if l.config.RuntimeMonitorInterval > 0 {
+		l.StartRuntimeMonitor(l.config.RuntimeMonitorInterval, RuntimeThresholds{
+			GCPause:      l.config.GCPauseThreshold,
+			SchedLatency: l.config.SchedLatencyThreshold,
+		})
+	}
+	if l.config.QueueStatsInterval > 0 {
+		l.StartQueueStats(l.config.QueueStatsInterval)
+	}
+	if l.config.JanitorInterval > 0 {
+		l.StartJanitor(l.config.JanitorInterval)
+	}
+	if l.config.HistogramInterval > 0 {
+		l.StartHistograms(l.config.HistogramInterval)
+	}
+}
+
+// stopBackgroundWorkers stops all periodic writers and profile captures
+func (l *Logger) stopBackgroundWorkers() {
+	l.StopHeartbeat()
+	l.StopSummaries()
+	l.StopRuntimeMonitor()
+	l.StopQueueStats()
+	l.StopJanitor()
+	l.StopHistograms()
+	l.SetProfileTrigger(nil)
+}
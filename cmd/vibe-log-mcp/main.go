@@ -0,0 +1,169 @@
+// Command vibe-log-mcp is a Model Context Protocol server that lets AI
+// assistants search and summarize vibe-logger files. It speaks JSON-RPC 2.0
+// over stdin/stdout, one message per line.
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/sumee-139/vibe-logger-go"
+)
+
+// protocolVersion is the MCP revision implemented by the server
+const protocolVersion = "2024-11-05"
+
+// JSON-RPC error codes
+const (
+	codeParseError     = -32700
+	codeInvalidRequest = -32600
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+)
+
+// request is a JSON-RPC request or notification
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// response is a JSON-RPC response
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+// rpcError is the error object of a JSON-RPC response
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// server answers MCP requests for the logs below dir
+type server struct {
+	dir  string
+	keys vibelogger.KeyProvider // Decrypts encrypted context values, nil without keys
+}
+
+func main() {
+	dir := flag.String("dir", "logs", "directory searched recursively for log files")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "Usage: vibe-log-mcp [options]")
+		fmt.Fprintln(flag.CommandLine.Output(), "Serves the search_logs, get_trace and summarize_errors tools over stdio.")
+		fmt.Fprintf(flag.CommandLine.Output(), "Encrypted context values are decrypted with the keys in %s (id=base64key,...).\n", vibelogger.KeysEnv)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	keys, err := vibelogger.KeysFromEnv()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "vibe-log-mcp: %v\n", err)
+		os.Exit(1)
+	}
+	s := &server{dir: *dir, keys: keys}
+	if err := s.serve(os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "vibe-log-mcp: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// serve processes requests until in is exhausted
+func (s *server) serve(in io.Reader, out io.Writer) error {
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	encoder := json.NewEncoder(out)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		if resp := s.handle(line); resp != nil {
+			if err := encoder.Encode(resp); err != nil {
+				return err
+			}
+		}
+	}
+	return scanner.Err()
+}
+
+// handle answers a single message; notifications get no response
+func (s *server) handle(line []byte) *response {
+	var req request
+	if err := json.Unmarshal(line, &req); err != nil {
+		return errorResponse(json.RawMessage("null"), codeParseError, "parse error: "+err.Error())
+	}
+	if len(req.ID) == 0 {
+		return nil
+	}
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		return errorResponse(req.ID, codeInvalidRequest, "invalid request")
+	}
+
+	switch req.Method {
+	case "initialize":
+		return result(req.ID, map[string]interface{}{
+			"protocolVersion": protocolVersion,
+			"capabilities":    map[string]interface{}{"tools": map[string]interface{}{}},
+			"serverInfo":      map[string]interface{}{"name": "vibe-log-mcp", "version": vibelogger.GetVersion()},
+		})
+	case "ping":
+		return result(req.ID, map[string]interface{}{})
+	case "tools/list":
+		return result(req.ID, map[string]interface{}{"tools": toolList()})
+	case "tools/call":
+		var params struct {
+			Name      string          `json:"name"`
+			Arguments json.RawMessage `json:"arguments"`
+		}
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return errorResponse(req.ID, codeInvalidParams, "invalid params: "+err.Error())
+		}
+		tool, ok := tools[params.Name]
+		if !ok {
+			return errorResponse(req.ID, codeInvalidParams, "unknown tool: "+params.Name)
+		}
+		return result(req.ID, s.callTool(tool, params.Arguments))
+	default:
+		return errorResponse(req.ID, codeMethodNotFound, "method not found: "+req.Method)
+	}
+}
+
+// callTool runs a tool and wraps its output as MCP text content. Tool failures
+// are reported in the result so the assistant can see them.
+func (s *server) callTool(t tool, arguments json.RawMessage) map[string]interface{} {
+	if len(arguments) == 0 {
+		arguments = json.RawMessage("{}")
+	}
+	output, err := t.run(s, arguments)
+	if err != nil {
+		return map[string]interface{}{
+			"content": []map[string]string{{"type": "text", "text": err.Error()}},
+			"isError": true,
+		}
+	}
+	text, err := json.MarshalIndent(output, "", "  ")
+	if err != nil {
+		text = []byte(err.Error())
+	}
+	return map[string]interface{}{
+		"content": []map[string]string{{"type": "text", "text": string(text)}},
+	}
+}
+
+// result builds a successful response
+func result(id json.RawMessage, value interface{}) *response {
+	return &response{JSONRPC: "2.0", ID: id, Result: value}
+}
+
+// errorResponse builds an error response
+func errorResponse(id json.RawMessage, code int, message string) *response {
+	return &response{JSONRPC: "2.0", ID: id, Error: &rpcError{Code: code, Message: message}}
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sumee-139/vibe-logger-go"
+)
+
+// testKeys encrypts the token of the login entry written by writeTestLogs
+var testKeys = vibelogger.StaticKeys{"k1": bytes.Repeat([]byte{7}, 32)}
+
+// writeTestLogs creates a log directory with a few entries
+func writeTestLogs(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	token, err := vibelogger.EncryptValue(testKeys, "k1", "s3cret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := vibelogger.DefaultConfig()
+	config.FilePath = filepath.Join(dir, "app", "app.log")
+	logger, err := vibelogger.CreateFileLoggerWithConfig("app", config)
+	if err != nil {
+		t.Fatalf("failed to create logger: %v", err)
+	}
+	logger.Info("login", "User logged in", vibelogger.WithCorrelationID("req-1"),
+		vibelogger.WithContext(map[string]interface{}{"password": vibelogger.RedactedValue, "token": token}))
+	logger.Error("db_query", "connection refused", vibelogger.WithCorrelationID("req-1"))
+	logger.Error("db_query", "connection refused again")
+	logger.Warn("cache", "Cache miss ratio high", vibelogger.WithDuration(2*time.Second))
+	if err := logger.Close(); err != nil {
+		t.Fatalf("failed to close logger: %v", err)
+	}
+	return dir
+}
+
+// exchange sends requests to a server and returns the decoded responses
+func exchange(t *testing.T, s *server, requests ...string) []map[string]interface{} {
+	t.Helper()
+	var out bytes.Buffer
+	if err := s.serve(strings.NewReader(strings.Join(requests, "\n")), &out); err != nil {
+		t.Fatalf("serve failed: %v", err)
+	}
+	var responses []map[string]interface{}
+	decoder := json.NewDecoder(&out)
+	for decoder.More() {
+		var resp map[string]interface{}
+		if err := decoder.Decode(&resp); err != nil {
+			t.Fatalf("invalid response: %v", err)
+		}
+		responses = append(responses, resp)
+	}
+	return responses
+}
+
+// toolText returns the text content of a tools/call response
+func toolText(t *testing.T, resp map[string]interface{}) string {
+	t.Helper()
+	result, ok := resp["result"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected result, got %v", resp)
+	}
+	content := result["content"].([]interface{})
+	return content[0].(map[string]interface{})["text"].(string)
+}
+
+func TestInitializeAndList(t *testing.T) {
+	s := &server{dir: t.TempDir()}
+	responses := exchange(t, s,
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{}}`,
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/list"}`,
+		`{"jsonrpc":"2.0","id":3,"method":"unknown"}`,
+		`not json`,
+	)
+	if len(responses) != 4 {
+		t.Fatalf("expected 4 responses (no reply to notifications), got %d", len(responses))
+	}
+	if responses[0]["result"].(map[string]interface{})["protocolVersion"] != protocolVersion {
+		t.Errorf("unexpected initialize result: %v", responses[0])
+	}
+	tools := responses[1]["result"].(map[string]interface{})["tools"].([]interface{})
+	if len(tools) != 3 || tools[0].(map[string]interface{})["name"] != "get_trace" {
+		t.Errorf("unexpected tools: %v", tools)
+	}
+	if responses[2]["error"].(map[string]interface{})["code"].(float64) != codeMethodNotFound {
+		t.Errorf("expected method not found, got %v", responses[2])
+	}
+	if responses[3]["error"].(map[string]interface{})["code"].(float64) != codeParseError {
+		t.Errorf("expected parse error, got %v", responses[3])
+	}
+}
+
+func TestTools(t *testing.T) {
+	s := &server{dir: writeTestLogs(t), keys: testKeys}
+	responses := exchange(t, s,
+		`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"search_logs","arguments":{"levels":"error","limit":1}}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"get_trace","arguments":{"id":"req-1"}}}`,
+		`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"summarize_errors"}}`,
+		`{"jsonrpc":"2.0","id":4,"method":"tools/call","params":{"name":"get_trace","arguments":{}}}`,
+		`{"jsonrpc":"2.0","id":5,"method":"tools/call","params":{"name":"search_logs","arguments":{"redacted":true}}}`,
+		`{"jsonrpc":"2.0","id":6,"method":"tools/call","params":{"name":"search_logs","arguments":{"min_duration":"1s"}}}`,
+	)
+
+	var search struct {
+		Total   int                   `json:"total"`
+		Entries []vibelogger.LogEntry `json:"entries"`
+	}
+	json.Unmarshal([]byte(toolText(t, responses[0])), &search)
+	if search.Total != 2 || len(search.Entries) != 1 || search.Entries[0].Message != "connection refused again" {
+		t.Errorf("unexpected search result: %+v", search)
+	}
+
+	var trace struct {
+		Entries []vibelogger.LogEntry `json:"entries"`
+	}
+	json.Unmarshal([]byte(toolText(t, responses[1])), &trace)
+	if len(trace.Entries) != 2 {
+		t.Errorf("expected 2 entries for req-1, got %d", len(trace.Entries))
+	}
+
+	var summary struct {
+		Groups []errorGroup `json:"groups"`
+	}
+	json.Unmarshal([]byte(toolText(t, responses[2])), &summary)
+	if len(summary.Groups) != 2 || summary.Groups[0].Count != 2 || summary.Groups[0].Operation != "db_query" {
+		t.Errorf("unexpected summary: %+v", summary.Groups)
+	}
+
+	result := responses[3]["result"].(map[string]interface{})
+	if result["isError"] != true {
+		t.Errorf("missing id should be reported as a tool error: %v", result)
+	}
+
+	json.Unmarshal([]byte(toolText(t, responses[4])), &search)
+	if search.Total != 1 || search.Entries[0].Operation != "login" || search.Entries[0].Context["token"] != "s3cret" {
+		t.Errorf("unexpected redacted search result: %+v", search)
+	}
+
+	json.Unmarshal([]byte(toolText(t, responses[5])), &search)
+	if search.Total != 1 || search.Entries[0].Operation != "cache" || search.Entries[0].Duration != 2*time.Second {
+		t.Errorf("unexpected duration search result: %+v", search)
+	}
+}
@@ -0,0 +1,284 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/sumee-139/vibe-logger-go"
+)
+
+// defaultLimit caps the entries returned by a search unless the caller asks otherwise
+const defaultLimit = 50
+
+// tool is an MCP tool backed by the log files
+type tool struct {
+	description string
+	schema      map[string]interface{}
+	run         func(s *server, arguments json.RawMessage) (interface{}, error)
+}
+
+// stringProperty describes a string argument in a JSON schema
+func stringProperty(description string) map[string]interface{} {
+	return map[string]interface{}{"type": "string", "description": description}
+}
+
+// tools lists the tools by name
+var tools = map[string]tool{
+	"search_logs": {
+		description: "Search log entries by level, operation, correlation or session ID, tags, time range and full text. Returns the most recent matches.",
+		schema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"text":           stringProperty("Case-insensitive text searched in operation, message and context"),
+				"levels":         stringProperty("Comma-separated levels, e.g. warn,error"),
+				"operation":      stringProperty("Exact operation name"),
+				"correlation_id": stringProperty("Exact correlation ID"),
+				"session_id":     stringProperty("Exact session ID, e.g. of one agent task"),
+				"tags":           stringProperty("Comma-separated tags the entries must all carry, e.g. billing,migration"),
+				"since":          stringProperty("RFC 3339 time or duration such as 1h; entries at or after it"),
+				"until":          stringProperty("RFC 3339 time; entries before it"),
+				"min_duration":   stringProperty("Duration such as 500ms; entries whose recorded duration is at least this"),
+				"max_duration":   stringProperty("Duration such as 2s; entries whose recorded duration is at most this"),
+				"redacted":       map[string]interface{}{"type": "boolean", "description": "Only entries with context values redacted as [REDACTED]"},
+				"limit":          map[string]interface{}{"type": "integer", "description": "Maximum entries returned (default 50)"},
+			},
+		},
+		run: searchLogs,
+	},
+	"get_trace": {
+		description: "Return all entries of one request, matched by correlation ID or W3C trace ID, in time order.",
+		schema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"id": stringProperty("Correlation ID or trace ID"),
+			},
+			"required": []string{"id"},
+		},
+		run: getTrace,
+	},
+	"summarize_errors": {
+		description: "Group ERROR and WARN entries by error fingerprint with counts, first and last occurrence and a sample.",
+		schema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"since":  stringProperty("RFC 3339 time or duration such as 24h"),
+				"levels": stringProperty("Comma-separated levels (default warn,error)"),
+			},
+		},
+		run: summarizeErrors,
+	},
+}
+
+// toolList returns the tool descriptions for tools/list, sorted by name
+func toolList() []map[string]interface{} {
+	names := make([]string, 0, len(tools))
+	for name := range tools {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	list := make([]map[string]interface{}, 0, len(names))
+	for _, name := range names {
+		list = append(list, map[string]interface{}{
+			"name":        name,
+			"description": tools[name].description,
+			"inputSchema": tools[name].schema,
+		})
+	}
+	return list
+}
+
+// loadEntries reads every log file below the server directory, ordered by time.
+// Values that cannot be decrypted are returned encrypted rather than failing
+// the whole search.
+func (s *server) loadEntries() ([]vibelogger.LogEntry, error) {
+	// Exact numbers are returned as written, so large IDs are not rounded
+	entries, err := vibelogger.ReadLogDir(s.dir, vibelogger.WithNumberMode(vibelogger.NumbersExact))
+	if err != nil {
+		return nil, err
+	}
+	vibelogger.DecryptEntries(entries, s.keys)
+	return entries, nil
+}
+
+// parseSince accepts an RFC 3339 time or a duration before now
+func parseSince(s string, now time.Time) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if d, err := time.ParseDuration(s); err == nil {
+		return now.Add(-d), nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %q (must be RFC 3339 or a duration like 1h)", s)
+	}
+	return t, nil
+}
+
+// parseDuration parses an optional duration argument
+func parseDuration(name, s string) (time.Duration, error) {
+	if s == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q (must be a duration like 500ms)", name, s)
+	}
+	return d, nil
+}
+
+// searchLogs implements the search_logs tool
+func searchLogs(s *server, arguments json.RawMessage) (interface{}, error) {
+	var args struct {
+		Text          string `json:"text"`
+		Levels        string `json:"levels"`
+		Operation     string `json:"operation"`
+		CorrelationID string `json:"correlation_id"`
+		SessionID     string `json:"session_id"`
+		Tags          string `json:"tags"`
+		Since         string `json:"since"`
+		Until         string `json:"until"`
+		MinDuration   string `json:"min_duration"`
+		MaxDuration   string `json:"max_duration"`
+		Redacted      bool   `json:"redacted"`
+		Limit         int    `json:"limit"`
+	}
+	if err := json.Unmarshal(arguments, &args); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	query := vibelogger.Query{
+		Levels:        vibelogger.ParseLevels(args.Levels),
+		Operation:     args.Operation,
+		CorrelationID: args.CorrelationID,
+		SessionID:     args.SessionID,
+		Tags:          vibelogger.ParseTags(args.Tags),
+		Text:          args.Text,
+		Redacted:      args.Redacted,
+	}
+	var err error
+	if query.Since, err = parseSince(args.Since, time.Now()); err != nil {
+		return nil, err
+	}
+	if args.Until != "" {
+		if query.Until, err = time.Parse(time.RFC3339, args.Until); err != nil {
+			return nil, fmt.Errorf("invalid until %q (must be RFC 3339)", args.Until)
+		}
+	}
+	if query.MinDuration, err = parseDuration("min_duration", args.MinDuration); err != nil {
+		return nil, err
+	}
+	if query.MaxDuration, err = parseDuration("max_duration", args.MaxDuration); err != nil {
+		return nil, err
+	}
+
+	entries, err := s.loadEntries()
+	if err != nil {
+		return nil, err
+	}
+	matched := query.Filter(entries)
+	total := len(matched)
+	limit := args.Limit
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+	if len(matched) > limit {
+		matched = matched[len(matched)-limit:]
+	}
+	return map[string]interface{}{"total": total, "entries": matched}, nil
+}
+
+// getTrace implements the get_trace tool
+func getTrace(s *server, arguments json.RawMessage) (interface{}, error) {
+	var args struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(arguments, &args); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+	if args.ID == "" {
+		return nil, fmt.Errorf("id is required")
+	}
+
+	entries, err := s.loadEntries()
+	if err != nil {
+		return nil, err
+	}
+	var trace []vibelogger.LogEntry
+	for _, entry := range entries {
+		if entry.CorrelationID == args.ID || entry.Context["trace_id"] == args.ID {
+			trace = append(trace, entry)
+		}
+	}
+	return map[string]interface{}{"id": args.ID, "entries": trace}, nil
+}
+
+// errorGroup summarizes entries sharing an error fingerprint
+type errorGroup struct {
+	Fingerprint string              `json:"fingerprint"`
+	Count       int                 `json:"count"`
+	Operation   string              `json:"operation"`
+	Pattern     string              `json:"pattern,omitempty"`
+	Level       vibelogger.LogLevel `json:"level"`
+	FirstSeen   time.Time           `json:"first_seen"`
+	LastSeen    time.Time           `json:"last_seen"`
+	Sample      string              `json:"sample"`
+	Suggestion  string              `json:"suggestion,omitempty"`
+	RunbookURL  string              `json:"runbook_url,omitempty"`
+}
+
+// summarizeErrors implements the summarize_errors tool
+func summarizeErrors(s *server, arguments json.RawMessage) (interface{}, error) {
+	var args struct {
+		Since  string `json:"since"`
+		Levels string `json:"levels"`
+	}
+	if err := json.Unmarshal(arguments, &args); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+	if args.Levels == "" {
+		args.Levels = "warn,error"
+	}
+	query := vibelogger.Query{Levels: vibelogger.ParseLevels(args.Levels)}
+	var err error
+	if query.Since, err = parseSince(args.Since, time.Now()); err != nil {
+		return nil, err
+	}
+
+	entries, err := s.loadEntries()
+	if err != nil {
+		return nil, err
+	}
+	groups := make(map[string]*errorGroup)
+	var order []string
+	for _, entry := range query.Filter(entries) {
+		fingerprint := vibelogger.ErrorFingerprint(&entry)
+		group, ok := groups[fingerprint]
+		if !ok {
+			group = &errorGroup{
+				Fingerprint: fingerprint,
+				Operation:   entry.Operation,
+				Pattern:     entry.Pattern,
+				Level:       entry.Level,
+				FirstSeen:   entry.Timestamp,
+			}
+			groups[fingerprint] = group
+			order = append(order, fingerprint)
+		}
+		group.Count++
+		group.LastSeen = entry.Timestamp
+		group.Sample = entry.FullMessage()
+		group.Suggestion = entry.Suggestion
+		group.RunbookURL = entry.RunbookURL
+	}
+
+	summary := make([]*errorGroup, 0, len(order))
+	for _, fingerprint := range order {
+		summary = append(summary, groups[fingerprint])
+	}
+	sort.SliceStable(summary, func(i, j int) bool { return summary[i].Count > summary[j].Count })
+	return map[string]interface{}{"groups": summary}, nil
+}
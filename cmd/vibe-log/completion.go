@@ -0,0 +1,167 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// completionShells lists the shells "vibe-log completion" generates scripts for
+var completionShells = []string{"bash", "zsh", "fish"}
+
+// newCompletionFlags defines the options of "vibe-log completion"
+func newCompletionFlags() (*flag.FlagSet, *string) {
+	fs := flag.NewFlagSet("completion", flag.ContinueOnError)
+	output := outputFlag(fs)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: vibe-log completion [options] <bash|zsh|fish>")
+		fmt.Fprintln(fs.Output(), "Prints a shell completion script, e.g. source <(vibe-log completion bash).")
+		fmt.Fprintln(fs.Output(), "With -output json the commands and their flags are printed instead.")
+		fs.PrintDefaults()
+	}
+	return fs, output
+}
+
+// commandSpec describes a subcommand for completion
+type commandSpec struct {
+	Name    string     `json:"name"`
+	Summary string     `json:"summary"`
+	Flags   []flagSpec `json:"flags"`
+}
+
+// flagSpec describes a flag for completion
+type flagSpec struct {
+	Name   string   `json:"name"`
+	Usage  string   `json:"usage"`
+	Bool   bool     `json:"bool"`             // Takes no value
+	Values []string `json:"values,omitempty"` // Accepted values, when fixed
+}
+
+// runCompletion implements "vibe-log completion"
+func runCompletion(args []string) error {
+	fs, output := newCompletionFlags()
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if err := checkOutput(*output); err != nil {
+		return err
+	}
+	specs := commandSpecs()
+	if *output == outputJSON {
+		return writeJSON(os.Stdout, specs)
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return fmt.Errorf("expected one shell: %s", strings.Join(completionShells, ", "))
+	}
+	return writeCompletion(os.Stdout, fs.Arg(0), specs)
+}
+
+// commandSpecs describes every subcommand and its flags
+func commandSpecs() []commandSpec {
+	specs := make([]commandSpec, 0, len(commands))
+	for _, cmd := range commands {
+		spec := commandSpec{Name: cmd.name, Summary: cmd.summary, Flags: []flagSpec{}}
+		cmd.flags().VisitAll(func(f *flag.Flag) {
+			option := flagSpec{Name: f.Name, Usage: f.Usage}
+			if b, ok := f.Value.(interface{ IsBoolFlag() bool }); ok && b.IsBoolFlag() {
+				option.Bool = true
+			}
+			if f.Name == "output" {
+				option.Values = outputFormats
+			}
+			spec.Flags = append(spec.Flags, option)
+		})
+		specs = append(specs, spec)
+	}
+	return specs
+}
+
+// writeCompletion prints the completion script of a shell
+func writeCompletion(out io.Writer, shell string, specs []commandSpec) error {
+	switch shell {
+	case "bash":
+		writeBashCompletion(out, specs)
+	case "zsh":
+		// zsh runs the bash script through its compatibility layer
+		fmt.Fprintln(out, "#compdef vibe-log")
+		fmt.Fprintln(out, "autoload -U +X bashcompinit && bashcompinit")
+		writeBashCompletion(out, specs)
+	case "fish":
+		writeFishCompletion(out, specs)
+	default:
+		return fmt.Errorf("unsupported shell %q (%s)", shell, strings.Join(completionShells, ", "))
+	}
+	return nil
+}
+
+// writeBashCompletion prints a bash completion function
+func writeBashCompletion(out io.Writer, specs []commandSpec) {
+	names := make([]string, len(specs))
+	for i, spec := range specs {
+		names[i] = spec.Name
+	}
+
+	fmt.Fprintln(out, "# bash completion for vibe-log")
+	fmt.Fprintln(out, "_vibe_log() {")
+	fmt.Fprintln(out, `	local cur="${COMP_WORDS[COMP_CWORD]}" prev="${COMP_WORDS[COMP_CWORD-1]}" flags=""`)
+	fmt.Fprintln(out, `	if [ "$COMP_CWORD" -eq 1 ]; then`)
+	fmt.Fprintf(out, "\t\tCOMPREPLY=($(compgen -W %q -- \"$cur\"))\n", strings.Join(names, " "))
+	fmt.Fprintln(out, "\t\treturn")
+	fmt.Fprintln(out, "\tfi")
+	fmt.Fprintln(out, `	case "${COMP_WORDS[1]}" in`)
+	for _, spec := range specs {
+		var flags []string
+		for _, f := range spec.Flags {
+			flags = append(flags, "-"+f.Name)
+		}
+		fmt.Fprintf(out, "\t%s) flags=%q ;;\n", spec.Name, strings.Join(flags, " "))
+	}
+	fmt.Fprintln(out, "\tesac")
+	fmt.Fprintln(out, `	case "$prev" in`)
+	fmt.Fprintf(out, "\t-output) COMPREPLY=($(compgen -W %q -- \"$cur\")); return ;;\n", strings.Join(outputFormats, " "))
+	fmt.Fprintln(out, "\tesac")
+	fmt.Fprintln(out, `	if [ "${COMP_WORDS[1]}" = completion ] && [[ "$cur" != -* ]]; then`)
+	fmt.Fprintf(out, "\t\tCOMPREPLY=($(compgen -W %q -- \"$cur\"))\n", strings.Join(completionShells, " "))
+	fmt.Fprintln(out, `	elif [[ "$cur" == -* ]]; then`)
+	fmt.Fprintln(out, `		COMPREPLY=($(compgen -W "$flags" -- "$cur"))`)
+	fmt.Fprintln(out, "\telse")
+	fmt.Fprintln(out, `		COMPREPLY=($(compgen -f -- "$cur"))`)
+	fmt.Fprintln(out, "\tfi")
+	fmt.Fprintln(out, "}")
+	fmt.Fprintln(out, "complete -o filenames -F _vibe_log vibe-log")
+}
+
+// writeFishCompletion prints fish complete commands
+func writeFishCompletion(out io.Writer, specs []commandSpec) {
+	fmt.Fprintln(out, "# fish completion for vibe-log")
+	for _, spec := range specs {
+		fmt.Fprintf(out, "complete -c vibe-log -f -n __fish_use_subcommand -a %s -d %s\n", spec.Name, fishQuote(spec.Summary))
+	}
+	for _, spec := range specs {
+		condition := fishQuote("__fish_seen_subcommand_from " + spec.Name)
+		for _, f := range spec.Flags {
+			arg := ""
+			switch {
+			case len(f.Values) > 0:
+				arg = " -x -a " + fishQuote(strings.Join(f.Values, " "))
+			case !f.Bool:
+				arg = " -r"
+			}
+			fmt.Fprintf(out, "complete -c vibe-log -n %s -o %s%s -d %s\n", condition, f.Name, arg, fishQuote(f.Usage))
+		}
+	}
+	fmt.Fprintf(out, "complete -c vibe-log -f -n %s -a %s\n",
+		fishQuote("__fish_seen_subcommand_from completion"), fishQuote(strings.Join(completionShells, " ")))
+}
+
+// fishQuote quotes s as a single-quoted fish string
+func fishQuote(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s) + "'"
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestCommandSpecs(t *testing.T) {
+	specs := commandSpecs()
+	if len(specs) != len(commands) {
+		t.Fatalf("Expected a spec per command, got %d", len(specs))
+	}
+	for _, spec := range specs {
+		var output *flagSpec
+		for i := range spec.Flags {
+			if spec.Flags[i].Name == "output" {
+				output = &spec.Flags[i]
+			}
+		}
+		if output == nil || strings.Join(output.Values, ",") != "text,json" {
+			t.Errorf("Expected %s to accept -output text/json, got %+v", spec.Name, spec.Flags)
+		}
+	}
+}
+
+func TestWriteCompletion(t *testing.T) {
+	specs := commandSpecs()
+	for _, shell := range completionShells {
+		var out bytes.Buffer
+		if err := writeCompletion(&out, shell, specs); err != nil {
+			t.Fatalf("Failed to write %s completion: %v", shell, err)
+		}
+		for _, want := range []string{"errors", "fail-on-new", "json"} {
+			if !strings.Contains(out.String(), want) {
+				t.Errorf("Expected %s completion to mention %q", shell, want)
+			}
+		}
+	}
+	if err := writeCompletion(&bytes.Buffer{}, "powershell", specs); err == nil {
+		t.Error("Expected an error for an unsupported shell")
+	}
+	if got := fishQuote("it's"); got != `'it\'s'` {
+		t.Errorf("Unexpected fish quoting: %s", got)
+	}
+}
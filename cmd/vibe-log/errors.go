@@ -0,0 +1,257 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sumee-139/vibe-logger-go"
+)
+
+// errorsFlags are the options of "vibe-log errors"
+type errorsFlags struct {
+	*flag.FlagSet
+	project   *string
+	levels    *string
+	since     *time.Duration
+	baseline  *string
+	failOnNew *bool
+	output    *string
+}
+
+// newErrorsFlags defines the options of "vibe-log errors"
+func newErrorsFlags() *errorsFlags {
+	fs := flag.NewFlagSet("errors", flag.ContinueOnError)
+	f := &errorsFlags{
+		FlagSet:   fs,
+		project:   fs.String("project", "", "project whose logs/<project> directory is read when no path is given (default: default)"),
+		levels:    fs.String("levels", "error", "levels to group, e.g. warn,error"),
+		since:     fs.Duration("since", 0, "only entries from this period, e.g. 24h (default: all)"),
+		baseline:  fs.String("baseline", "", "known fingerprints: a previous -output json result or one fingerprint per line"),
+		failOnNew: fs.Bool("fail-on-new", false, "exit with status 1 when a fingerprint is not in the baseline"),
+		output:    outputFlag(fs),
+	}
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: vibe-log errors [options] [log-file-or-dir ...]")
+		fmt.Fprintln(fs.Output(), "Groups error entries by fingerprint, e.g. to fail CI when new errors appear in test logs.")
+		fs.PrintDefaults()
+	}
+	return f
+}
+
+// errorGroup summarizes entries sharing an error fingerprint
+type errorGroup struct {
+	Fingerprint string              `json:"fingerprint"`
+	Count       int                 `json:"count"`
+	Level       vibelogger.LogLevel `json:"level"`
+	Operation   string              `json:"operation"`
+	Pattern     string              `json:"pattern,omitempty"`
+	FirstSeen   time.Time           `json:"first_seen"`
+	LastSeen    time.Time           `json:"last_seen"`
+	Sample      string              `json:"sample"`
+	New         bool                `json:"new"` // Not in the baseline; always false without one
+}
+
+// errorsReport is the -output json form of "vibe-log errors"
+type errorsReport struct {
+	Groups []*errorGroup `json:"groups"`
+	New    int           `json:"new"`
+}
+
+// runErrors implements "vibe-log errors"
+func runErrors(args []string) error {
+	f := newErrorsFlags()
+	if err := f.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if err := checkOutput(*f.output); err != nil {
+		return err
+	}
+
+	paths := f.Args()
+	if len(paths) == 0 {
+		project := *f.project
+		if project == "" {
+			project = "default"
+		}
+		paths = []string{filepath.Join("logs", project)}
+	}
+	query := vibelogger.Query{Levels: vibelogger.ParseLevels(*f.levels)}
+	if *f.since > 0 {
+		query.Since = time.Now().Add(-*f.since)
+	}
+
+	// Stream the logs so large directories are grouped in constant memory
+	it, err := openPaths(paths)
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+	grouper := newErrorGrouper()
+	for it.Next() {
+		if entry := it.Entry(); query.Match(entry) {
+			grouper.add(entry)
+		}
+	}
+	if err := it.Err(); err != nil {
+		return err
+	}
+	report := errorsReport{Groups: grouper.result()}
+
+	if *f.baseline != "" {
+		known, err := readBaseline(*f.baseline)
+		if err != nil {
+			return err
+		}
+		for _, group := range report.Groups {
+			if group.New = !known[group.Fingerprint]; group.New {
+				report.New++
+			}
+		}
+	} else if *f.failOnNew {
+		// Without a baseline every fingerprint is new
+		report.New = len(report.Groups)
+	}
+
+	if *f.output == outputJSON {
+		if err := writeJSON(os.Stdout, report); err != nil {
+			return err
+		}
+	} else {
+		printErrorGroups(os.Stdout, report, *f.baseline != "")
+	}
+	if *f.failOnNew && report.New > 0 {
+		return fmt.Errorf("%d new error fingerprints", report.New)
+	}
+	return nil
+}
+
+// openPaths streams the entries of log files and directories merged by time
+func openPaths(paths []string) (vibelogger.EntryIterator, error) {
+	var iterators []vibelogger.EntryIterator
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		var it vibelogger.EntryIterator
+		switch {
+		case err != nil:
+		case info.IsDir():
+			it, err = vibelogger.OpenLogDirIterator(path)
+		default:
+			it, err = vibelogger.OpenLogIterator(path)
+		}
+		if err != nil {
+			vibelogger.MergeEntries(iterators...).Close()
+			return nil, err
+		}
+		iterators = append(iterators, it)
+	}
+	return vibelogger.MergeEntries(iterators...), nil
+}
+
+// errorGrouper groups entries by fingerprint as they are read
+type errorGrouper struct {
+	groups  map[string]*errorGroup
+	summary []*errorGroup
+}
+
+// newErrorGrouper creates an empty grouper
+func newErrorGrouper() *errorGrouper {
+	return &errorGrouper{groups: make(map[string]*errorGroup), summary: []*errorGroup{}}
+}
+
+// add counts an entry; entries must arrive in time order
+func (g *errorGrouper) add(entry *vibelogger.LogEntry) {
+	fingerprint := vibelogger.ErrorFingerprint(entry)
+	group, ok := g.groups[fingerprint]
+	if !ok {
+		group = &errorGroup{
+			Fingerprint: fingerprint,
+			Level:       entry.Level,
+			Operation:   entry.Operation,
+			Pattern:     entry.Pattern,
+			FirstSeen:   entry.Timestamp,
+		}
+		g.groups[fingerprint] = group
+		g.summary = append(g.summary, group)
+	}
+	group.Count++
+	group.LastSeen = entry.Timestamp
+	group.Sample = entry.FullMessage()
+}
+
+// result returns the groups, most frequent first
+func (g *errorGrouper) result() []*errorGroup {
+	sort.SliceStable(g.summary, func(i, j int) bool { return g.summary[i].Count > g.summary[j].Count })
+	return g.summary
+}
+
+// groupErrors groups entries by fingerprint, most frequent first
+func groupErrors(entries []vibelogger.LogEntry) []*errorGroup {
+	grouper := newErrorGrouper()
+	for i := range entries {
+		grouper.add(&entries[i])
+	}
+	return grouper.result()
+}
+
+// readBaseline reads known fingerprints from a previous JSON report or a
+// plain list with one fingerprint per line; blank lines and # comments are ignored
+func readBaseline(path string) (map[string]bool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read baseline: %w", err)
+	}
+
+	known := make(map[string]bool)
+	if trimmed := strings.TrimSpace(string(data)); strings.HasPrefix(trimmed, "{") {
+		var report errorsReport
+		if err := json.Unmarshal(data, &report); err != nil {
+			return nil, fmt.Errorf("invalid baseline %s: %w", path, err)
+		}
+		for _, group := range report.Groups {
+			known[group.Fingerprint] = true
+		}
+		return known, nil
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(string(data)))
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" && !strings.HasPrefix(line, "#") {
+			known[line] = true
+		}
+	}
+	return known, nil
+}
+
+// printErrorGroups writes one line per fingerprint
+func printErrorGroups(out io.Writer, report errorsReport, withBaseline bool) {
+	if len(report.Groups) == 0 {
+		fmt.Fprintln(out, "No errors found.")
+		return
+	}
+	for _, group := range report.Groups {
+		marker := ""
+		if withBaseline {
+			marker = "    "
+			if group.New {
+				marker = "NEW "
+			}
+		}
+		fmt.Fprintf(out, "%s%6d  %-5s %s  %s: %s\n",
+			marker, group.Count, group.Level, group.Fingerprint, group.Operation, truncate(group.Sample, 80))
+	}
+	if withBaseline {
+		fmt.Fprintf(out, "%d new of %d fingerprints\n", report.New, len(report.Groups))
+	}
+}
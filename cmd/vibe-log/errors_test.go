@@ -0,0 +1,71 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sumee-139/vibe-logger-go"
+)
+
+func TestGroupErrors(t *testing.T) {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	groups := groupErrors([]vibelogger.LogEntry{
+		{Timestamp: base, Level: vibelogger.ERROR, Operation: "api", Message: "Timeout"},
+		{Timestamp: base.Add(time.Second), Level: vibelogger.ERROR, Operation: "db", Message: "Connection refused"},
+		{Timestamp: base.Add(2 * time.Second), Level: vibelogger.ERROR, Operation: "db", Message: "Connection reset"},
+	})
+	if len(groups) != 2 || groups[0].Operation != "db" || groups[0].Count != 2 {
+		t.Fatalf("Expected the db group first with 2 entries, got %+v", groups)
+	}
+	if !groups[0].FirstSeen.Equal(base.Add(time.Second)) || groups[0].Sample != "Connection reset" {
+		t.Errorf("Unexpected group details: %+v", groups[0])
+	}
+}
+
+func TestReadBaseline(t *testing.T) {
+	dir := t.TempDir()
+	report := filepath.Join(dir, "report.json")
+	os.WriteFile(report, []byte(`{"groups":[{"fingerprint":"aaa"},{"fingerprint":"bbb"}],"new":0}`), 0644)
+	list := filepath.Join(dir, "known.txt")
+	os.WriteFile(list, []byte("# accepted errors\naaa\n\n  ccc  \n"), 0644)
+
+	known, err := readBaseline(report)
+	if err != nil || !known["aaa"] || !known["bbb"] || len(known) != 2 {
+		t.Errorf("Expected fingerprints of the JSON report, got %v (%v)", known, err)
+	}
+	known, err = readBaseline(list)
+	if err != nil || !known["aaa"] || !known["ccc"] || len(known) != 2 {
+		t.Errorf("Expected fingerprints of the list, got %v (%v)", known, err)
+	}
+	if _, err := readBaseline(filepath.Join(dir, "missing")); err == nil {
+		t.Error("Expected an error for a missing baseline")
+	}
+}
+
+func TestPrintErrorGroups(t *testing.T) {
+	var out bytes.Buffer
+	printErrorGroups(&out, errorsReport{}, false)
+	if !strings.Contains(out.String(), "No errors") {
+		t.Errorf("Expected empty message, got %q", out.String())
+	}
+
+	out.Reset()
+	printErrorGroups(&out, errorsReport{Groups: []*errorGroup{
+		{Fingerprint: "aaa", Count: 3, Level: vibelogger.ERROR, Operation: "db", Sample: "Connection refused"},
+		{Fingerprint: "bbb", Count: 1, Level: vibelogger.ERROR, Operation: "api", Sample: "Timeout", New: true},
+	}, New: 1}, true)
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Expected 2 groups and a total, got %q", out.String())
+	}
+	if strings.HasPrefix(lines[0], "NEW") || !strings.HasPrefix(lines[1], "NEW") || !strings.Contains(lines[1], "bbb  api: Timeout") {
+		t.Errorf("Expected only the second group to be marked new, got %q", out.String())
+	}
+	if lines[2] != "1 new of 2 fingerprints" {
+		t.Errorf("Unexpected total line: %q", lines[2])
+	}
+}
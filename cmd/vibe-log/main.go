@@ -0,0 +1,66 @@
+// Command vibe-log is a toolbox for working with vibe-logger files.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+)
+
+// command is a vibe-log subcommand
+type command struct {
+	name    string
+	summary string
+	run     func(args []string) error
+	flags   func() *flag.FlagSet // Flags of the command, for shell completion
+}
+
+// commands lists the available subcommands in the order shown by usage
+var commands = []command{
+	{"tui", "Interactively browse a log file", runTUI, func() *flag.FlagSet { return newTUIFlags().FlagSet }},
+	{"todos", "List AI todos and human notes", runTodos, func() *flag.FlagSet { return newTodosFlags().FlagSet }},
+	{"errors", "Group errors by fingerprint and check them against a baseline", runErrors, func() *flag.FlagSet { return newErrorsFlags().FlagSet }},
+	{"verify", "Check rotated files against their recorded checksums", runVerify, func() *flag.FlagSet { return newVerifyFlags().FlagSet }},
+}
+
+func init() {
+	// Registered here since completion reads the command list itself
+	commands = append(commands, command{"completion", "Print shell completion scripts", runCompletion, func() *flag.FlagSet {
+		fs, _ := newCompletionFlags()
+		return fs
+	}})
+}
+
+func main() {
+	if len(os.Args) < 2 || os.Args[1] == "-h" || os.Args[1] == "--help" || os.Args[1] == "help" {
+		usage()
+		return
+	}
+
+	for _, cmd := range commands {
+		if cmd.name == os.Args[1] {
+			if err := cmd.run(os.Args[2:]); err != nil {
+				fmt.Fprintf(os.Stderr, "vibe-log %s: %v\n", cmd.name, err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "vibe-log: unknown command %q\n\n", os.Args[1])
+	usage()
+	os.Exit(2)
+}
+
+// usage prints the list of subcommands
+func usage() {
+	fmt.Fprintln(os.Stderr, "Usage: vibe-log <command> [arguments]")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "Commands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(os.Stderr, "  %-10s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "Run 'vibe-log <command> -h' for the options of a command.")
+	fmt.Fprintln(os.Stderr, "Every command accepts -output json for scripting.")
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+)
+
+// Formats accepted by the -output flag of every subcommand
+const (
+	outputText = "text"
+	outputJSON = "json"
+)
+
+// outputFormats lists the -output values, also offered by shell completion
+var outputFormats = []string{outputText, outputJSON}
+
+// outputFlag registers the -output flag on fs
+func outputFlag(fs *flag.FlagSet) *string {
+	return fs.String("output", outputText, "output format: text or json")
+}
+
+// checkOutput validates the value of an -output flag
+func checkOutput(output string) error {
+	for _, format := range outputFormats {
+		if output == format {
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported output format %q (text or json)", output)
+}
+
+// writeJSON prints v as indented JSON for scripts
+func writeJSON(out io.Writer, v interface{}) error {
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
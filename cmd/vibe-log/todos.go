@@ -0,0 +1,100 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/sumee-139/vibe-logger-go"
+)
+
+// todosFlags are the options of "vibe-log todos"
+type todosFlags struct {
+	*flag.FlagSet
+	project *string
+	dir     *string
+	since   *time.Duration
+	asJSON  *bool
+	output  *string
+}
+
+// newTodosFlags defines the options of "vibe-log todos"
+func newTodosFlags() *todosFlags {
+	fs := flag.NewFlagSet("todos", flag.ContinueOnError)
+	f := &todosFlags{
+		FlagSet: fs,
+		project: fs.String("project", "", "project whose logs/<project> directory is read (default: default)"),
+		dir:     fs.String("dir", "", "read this directory instead of the project directory"),
+		since:   fs.Duration("since", 0, "only annotations from this period, e.g. 24h (default: all)"),
+		asJSON:  fs.Bool("json", false, "print annotations as JSON (same as -output json)"),
+		output:  outputFlag(fs),
+	}
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: vibe-log todos [options]")
+		fmt.Fprintln(fs.Output(), "Lists the AI todos and human notes written to the logs.")
+		fs.PrintDefaults()
+	}
+	return f
+}
+
+// runTodos implements "vibe-log todos"
+func runTodos(args []string) error {
+	f := newTodosFlags()
+	if err := f.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if err := checkOutput(*f.output); err != nil {
+		return err
+	}
+
+	var timeRange vibelogger.TimeRange
+	if *f.since > 0 {
+		timeRange.Since = time.Now().Add(-*f.since)
+	}
+
+	var annotations []vibelogger.Annotation
+	if *f.dir != "" {
+		entries, err := vibelogger.ReadLogDir(*f.dir)
+		if err != nil {
+			return err
+		}
+		annotations = vibelogger.CollectAnnotations(entries, timeRange)
+	} else {
+		var err error
+		if annotations, err = vibelogger.ExtractTodos(*f.project, timeRange); err != nil {
+			return err
+		}
+	}
+
+	if *f.asJSON || *f.output == outputJSON {
+		// Keep an empty result an array for scripts
+		if annotations == nil {
+			annotations = []vibelogger.Annotation{}
+		}
+		return writeJSON(os.Stdout, annotations)
+	}
+	printAnnotations(os.Stdout, annotations)
+	return nil
+}
+
+// printAnnotations writes one line per annotation
+func printAnnotations(out io.Writer, annotations []vibelogger.Annotation) {
+	if len(annotations) == 0 {
+		fmt.Fprintln(out, "No AI todos or human notes found.")
+		return
+	}
+	for _, a := range annotations {
+		kind := "TODO"
+		if a.Kind == vibelogger.AnnotationHumanNote {
+			kind = "NOTE"
+		}
+		fmt.Fprintf(out, "%s  %-4s  %-5s %s: %s\n",
+			a.Entry.Timestamp.Local().Format("2006-01-02 15:04:05"), kind, a.Entry.Level, a.Entry.Operation, a.Text)
+	}
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sumee-139/vibe-logger-go"
+)
+
+func TestPrintAnnotations(t *testing.T) {
+	var out bytes.Buffer
+	printAnnotations(&out, nil)
+	if !strings.Contains(out.String(), "No AI todos") {
+		t.Errorf("Expected empty message, got %q", out.String())
+	}
+
+	out.Reset()
+	entry := vibelogger.LogEntry{Timestamp: time.Now(), Level: vibelogger.WARN, Operation: "payment"}
+	printAnnotations(&out, []vibelogger.Annotation{
+		{Kind: vibelogger.AnnotationAITodo, Text: "Check retry policy", Entry: entry},
+		{Kind: vibelogger.AnnotationHumanNote, Text: "Seen after deploy", Entry: entry},
+	})
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 lines, got %q", out.String())
+	}
+	if !strings.Contains(lines[0], "TODO") || !strings.Contains(lines[0], "payment: Check retry policy") {
+		t.Errorf("Unexpected todo line: %q", lines[0])
+	}
+	if !strings.Contains(lines[1], "NOTE") {
+		t.Errorf("Unexpected note line: %q", lines[1])
+	}
+}
@@ -0,0 +1,472 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sumee-139/vibe-logger-go"
+)
+
+// ANSI escape sequences used by the viewer
+const (
+	ansiClear = "\033[H\033[2J"
+	ansiReset = "\033[0m"
+	ansiBold  = "\033[1m"
+)
+
+// levelColors highlights levels in the entry list
+var levelColors = map[vibelogger.LogLevel]string{
+	vibelogger.DEBUG: "\033[90m",
+	vibelogger.INFO:  "\033[36m",
+	vibelogger.WARN:  "\033[33m",
+	vibelogger.ERROR: "\033[31m",
+}
+
+const tuiHelp = `Commands:
+  <Enter>, n      next page            p          previous page
+  <number>        show entry details   x          close details
+  /text           full-text search     /          clear search
+  l warn,error    filter levels        l          clear level filter
+  t billing       filter tags          t          clear tag filter
+  R               toggle entries with redacted fields only
+  c [id]          pivot on correlation ID (selected entry when omitted)
+  f               toggle live follow   r          reset all filters
+  ?               this help            q          quit`
+
+// viewer is the state of the interactive log browser
+type viewer struct {
+	path     string
+	entries  []vibelogger.LogEntry
+	query    vibelogger.Query
+	visible  []vibelogger.LogEntry
+	page     int
+	pageSize int
+	selected int // Index into visible of the entry shown in the detail pane, -1 for none
+	follow   bool
+	color    bool
+	keys     vibelogger.KeyProvider // Decrypts encrypted context values, nil without keys
+	status   string
+	corrupt  []vibelogger.CorruptRecord // Corrupt records of the last reload
+}
+
+// tuiFlags are the options of "vibe-log tui"
+type tuiFlags struct {
+	*flag.FlagSet
+	pageSize *int
+	follow   *bool
+	interval *time.Duration
+	noColor  *bool
+	levels   *string
+	tags     *string
+	search   *string
+	redacted *bool
+	output   *string
+}
+
+// newTUIFlags defines the options of "vibe-log tui"
+func newTUIFlags() *tuiFlags {
+	fs := flag.NewFlagSet("tui", flag.ContinueOnError)
+	f := &tuiFlags{
+		FlagSet:  fs,
+		pageSize: fs.Int("page-size", 20, "entries per page"),
+		follow:   fs.Bool("follow", false, "start in live follow mode"),
+		interval: fs.Duration("interval", time.Second, "polling interval of live follow"),
+		noColor:  fs.Bool("no-color", false, "disable colored output"),
+		levels:   fs.String("levels", "", "initial level filter, e.g. warn,error"),
+		tags:     fs.String("tags", "", "initial tag filter; entries must carry all listed tags"),
+		search:   fs.String("search", "", "initial full-text search"),
+		redacted: fs.Bool("redacted", false, "only show entries with redacted context values"),
+		output:   outputFlag(fs),
+	}
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: vibe-log tui [options] <log-file>")
+		fmt.Fprintln(fs.Output(), "With -output json the filtered entries are printed instead of starting the viewer.")
+		fs.PrintDefaults()
+		fmt.Fprintln(fs.Output())
+		fmt.Fprintf(fs.Output(), "Encrypted context values are decrypted with the keys in %s (id=base64key,...).\n", vibelogger.KeysEnv)
+		fmt.Fprintln(fs.Output())
+		fmt.Fprintln(fs.Output(), tuiHelp)
+	}
+	return f
+}
+
+// runTUI implements "vibe-log tui"
+func runTUI(args []string) error {
+	f := newTUIFlags()
+	if err := f.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if err := checkOutput(*f.output); err != nil {
+		return err
+	}
+	if f.NArg() != 1 {
+		f.Usage()
+		return fmt.Errorf("expected exactly one log file")
+	}
+
+	keys, err := vibelogger.KeysFromEnv()
+	if err != nil {
+		return err
+	}
+
+	v := newViewer(f.Arg(0), *f.pageSize)
+	v.follow = *f.follow
+	v.color = !*f.noColor
+	v.keys = keys
+	v.query = vibelogger.Query{
+		Levels:   vibelogger.ParseLevels(*f.levels),
+		Tags:     vibelogger.ParseTags(*f.tags),
+		Text:     *f.search,
+		Redacted: *f.redacted,
+	}
+	if *f.output == outputJSON {
+		return exportEntries(os.Stdout, v.path, v.query, keys)
+	}
+	if err := v.reload(); err != nil {
+		return err
+	}
+	v.lastPage()
+	return v.loop(os.Stdin, os.Stdout, *f.interval)
+}
+
+// exportEntries writes the entries of path matching query as JSON for
+// scripts: {"path", "entries", "total", "corrupt"}, where total counts the
+// entries before filtering. The file is streamed, so exports of large logs
+// do not hold all entries in memory. Values that cannot be decrypted with keys
+// are exported encrypted.
+func exportEntries(out io.Writer, path string, query vibelogger.Query, keys vibelogger.KeyProvider) error {
+	it, err := vibelogger.OpenLogIterator(path)
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+
+	w := bufio.NewWriter(out)
+	encodedPath, _ := json.Marshal(path)
+	fmt.Fprintf(w, "{\n  \"path\": %s,\n  \"entries\": [", encodedPath)
+	total, written := 0, 0
+	var corrupt []vibelogger.CorruptRecord
+	for it.Next() {
+		corrupt = append(corrupt, it.Corrupt()...)
+		total++
+		if keys != nil {
+			it.Entry().Decrypt(keys)
+		}
+		if !query.Match(it.Entry()) {
+			continue
+		}
+		data, err := json.MarshalIndent(it.Entry(), "    ", "  ")
+		if err != nil {
+			return err
+		}
+		if written > 0 {
+			w.WriteString(",")
+		}
+		w.WriteString("\n    ")
+		w.Write(data)
+		written++
+	}
+	corrupt = append(corrupt, it.Corrupt()...)
+	if err := it.Err(); err != nil {
+		return err
+	}
+	if written > 0 {
+		w.WriteString("\n  ")
+	}
+	fmt.Fprintf(w, "],\n  \"total\": %d", total)
+	if len(corrupt) > 0 {
+		data, err := json.MarshalIndent(corrupt, "  ", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, ",\n  \"corrupt\": %s", data)
+	}
+	w.WriteString("\n}\n")
+	return w.Flush()
+}
+
+// newViewer creates a viewer for the given file
+func newViewer(path string, pageSize int) *viewer {
+	if pageSize <= 0 {
+		pageSize = 20
+	}
+	return &viewer{path: path, pageSize: pageSize, selected: -1}
+}
+
+// loop renders the viewer and processes commands until the user quits
+func (v *viewer) loop(in io.Reader, out io.Writer, interval time.Duration) error {
+	lines := make(chan string)
+	go func() {
+		scanner := bufio.NewScanner(in)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+		close(lines)
+	}()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	lastSize := fileSize(v.path)
+
+	v.render(out)
+	for {
+		select {
+		case line, ok := <-lines:
+			if !ok || !v.execute(line) {
+				return nil
+			}
+		case <-ticker.C:
+			// Only redraw when following and the file changed, to not disturb typing
+			if !v.follow {
+				continue
+			}
+			size := fileSize(v.path)
+			if size == lastSize {
+				continue
+			}
+			lastSize = size
+			if err := v.reload(); err != nil {
+				v.status = err.Error()
+			}
+			v.lastPage()
+		}
+		v.render(out)
+	}
+}
+
+// reload reads the log file and reapplies the current query
+func (v *viewer) reload() error {
+	result, err := vibelogger.ReadLogFile(v.path)
+	if err != nil {
+		return err
+	}
+	v.entries = result.Entries
+	v.corrupt = result.Corrupt
+	if len(result.Corrupt) > 0 {
+		v.status = fmt.Sprintf("%d corrupt records skipped", len(result.Corrupt))
+	}
+	if err := vibelogger.DecryptEntries(v.entries, v.keys); err != nil {
+		v.status = err.Error()
+	}
+	v.apply()
+	return nil
+}
+
+// apply filters the entries with the current query
+func (v *viewer) apply() {
+	v.visible = v.query.Filter(v.entries)
+	v.selected = -1
+	if v.page >= v.pageCount() {
+		v.lastPage()
+	}
+}
+
+// execute runs a single command line and reports whether the viewer should continue
+func (v *viewer) execute(line string) bool {
+	line = strings.TrimSpace(line)
+	v.status = ""
+
+	switch {
+	case line == "q":
+		return false
+	case line == "" || line == "n":
+		if v.page < v.pageCount()-1 {
+			v.page++
+		}
+	case line == "p":
+		if v.page > 0 {
+			v.page--
+		}
+	case line == "x":
+		v.selected = -1
+	case line == "f":
+		v.follow = !v.follow
+	case line == "r":
+		v.query = vibelogger.Query{}
+		v.apply()
+		v.page = 0
+	case line == "?":
+		v.status = tuiHelp
+	case strings.HasPrefix(line, "/"):
+		v.query.Text = strings.TrimPrefix(line, "/")
+		v.apply()
+		v.page = 0
+	case line == "l" || strings.HasPrefix(line, "l "):
+		v.query.Levels = vibelogger.ParseLevels(strings.TrimPrefix(line, "l"))
+		v.apply()
+		v.page = 0
+	case line == "t" || strings.HasPrefix(line, "t "):
+		v.query.Tags = vibelogger.ParseTags(strings.TrimPrefix(line, "t"))
+		v.apply()
+		v.page = 0
+	case line == "R":
+		v.query.Redacted = !v.query.Redacted
+		v.apply()
+		v.page = 0
+	case line == "c" || strings.HasPrefix(line, "c "):
+		v.pivot(strings.TrimSpace(strings.TrimPrefix(line, "c")))
+	default:
+		index, err := strconv.Atoi(line)
+		if err != nil || index < 1 || index > len(v.visible) {
+			v.status = fmt.Sprintf("unknown command or entry %q (? for help)", line)
+			break
+		}
+		v.selected = index - 1
+		v.page = v.selected / v.pageSize
+	}
+	return true
+}
+
+// pivot filters on a correlation ID, taken from the selected entry when id is empty
+func (v *viewer) pivot(id string) {
+	if id == "" && v.selected >= 0 {
+		id = v.visible[v.selected].CorrelationID
+	}
+	if id == "" {
+		if v.query.CorrelationID == "" {
+			v.status = "no correlation ID to pivot on"
+		}
+		v.query.CorrelationID = ""
+	} else {
+		v.query.CorrelationID = id
+	}
+	v.apply()
+	v.page = 0
+}
+
+// pageCount returns the number of pages, at least one
+func (v *viewer) pageCount() int {
+	if len(v.visible) == 0 {
+		return 1
+	}
+	return (len(v.visible) + v.pageSize - 1) / v.pageSize
+}
+
+// lastPage moves to the last page
+func (v *viewer) lastPage() {
+	v.page = v.pageCount() - 1
+}
+
+// render draws the list, the detail pane and the status line
+func (v *viewer) render(w io.Writer) {
+	fmt.Fprint(w, ansiClear)
+	fmt.Fprintf(w, "%s%s%s  %d/%d entries  page %d/%d%s\n",
+		v.style(ansiBold), v.path, v.style(ansiReset),
+		len(v.visible), len(v.entries), v.page+1, v.pageCount(), v.filterSummary())
+	fmt.Fprintln(w, strings.Repeat("─", 80))
+
+	start := v.page * v.pageSize
+	end := start + v.pageSize
+	if end > len(v.visible) {
+		end = len(v.visible)
+	}
+	for i := start; i < end; i++ {
+		entry := v.visible[i]
+		marker := " "
+		if i == v.selected {
+			marker = ">"
+		}
+		message := truncate(entry.Message, 60)
+		if n := len(entry.MessageLines); n > 0 {
+			message += fmt.Sprintf(" (+%d lines)", n)
+		}
+		fmt.Fprintf(w, "%s%5d %s %s%-5s%s %-20s %s\n", marker, i+1,
+			entry.Timestamp.Local().Format("15:04:05.000"),
+			v.style(levelColors[entry.Level]), entry.Level, v.style(ansiReset),
+			truncate(entry.Operation, 20), message)
+	}
+
+	if v.selected >= 0 {
+		entry := v.visible[v.selected]
+		fmt.Fprintln(w, strings.Repeat("─", 80))
+		// Restore folded multi-line messages before the raw record
+		if len(entry.MessageLines) > 0 {
+			fmt.Fprintln(w, entry.FullMessage())
+			fmt.Fprintln(w)
+		}
+		// Redacted and undecryptable values are hidden, not missing
+		if fields := entry.RedactedFields(); len(fields) > 0 {
+			fmt.Fprintf(w, "Redacted: %s\n\n", strings.Join(fields, ", "))
+		}
+		if fields := entry.EncryptedFields(); len(fields) > 0 {
+			fmt.Fprintf(w, "Encrypted: %s\n\n", strings.Join(fields, ", "))
+		}
+		if data, err := json.MarshalIndent(entry, "", "  "); err == nil {
+			fmt.Fprintln(w, string(data))
+		}
+	}
+
+	fmt.Fprintln(w, strings.Repeat("─", 80))
+	if v.status != "" {
+		fmt.Fprintln(w, v.status)
+	}
+	fmt.Fprint(w, "command (? for help)> ")
+}
+
+// filterSummary describes the active filters for the header line
+func (v *viewer) filterSummary() string {
+	var parts []string
+	if len(v.query.Levels) > 0 {
+		levels := make([]string, len(v.query.Levels))
+		for i, level := range v.query.Levels {
+			levels[i] = string(level)
+		}
+		parts = append(parts, "levels="+strings.Join(levels, ","))
+	}
+	if v.query.Text != "" {
+		parts = append(parts, fmt.Sprintf("search=%q", v.query.Text))
+	}
+	if len(v.query.Tags) > 0 {
+		parts = append(parts, "tags="+strings.Join(v.query.Tags, ","))
+	}
+	if v.query.CorrelationID != "" {
+		parts = append(parts, "correlation="+v.query.CorrelationID)
+	}
+	if v.query.Redacted {
+		parts = append(parts, "redacted")
+	}
+	if v.follow {
+		parts = append(parts, "following")
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return "  [" + strings.Join(parts, " ") + "]"
+}
+
+// style returns the escape sequence when colors are enabled
+func (v *viewer) style(code string) string {
+	if !v.color {
+		return ""
+	}
+	return code
+}
+
+// truncate shortens s to at most n runes
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n-1]) + "…"
+}
+
+// fileSize returns the size of the file, or -1 if it cannot be read
+func fileSize(path string) int64 {
+	info, err := os.Stat(path)
+	if err != nil {
+		return -1
+	}
+	return info.Size()
+}
@@ -0,0 +1,216 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sumee-139/vibe-logger-go"
+)
+
+func newTestViewer() *viewer {
+	v := newViewer("test.log", 2)
+	v.entries = []vibelogger.LogEntry{
+		{Level: vibelogger.INFO, Operation: "startup", Message: "Service started"},
+		{Level: vibelogger.ERROR, Operation: "db_query", Message: "Connection refused", CorrelationID: "req-1"},
+		{Level: vibelogger.WARN, Operation: "api_call", Message: "Slow response", CorrelationID: "req-1"},
+		{Level: vibelogger.INFO, Operation: "api_call", Message: "Request done", CorrelationID: "req-2", Tags: []string{"billing"}},
+	}
+	v.apply()
+	return v
+}
+
+func TestViewerCommands(t *testing.T) {
+	v := newTestViewer()
+
+	v.execute("n")
+	if v.page != 1 {
+		t.Errorf("Expected page 1 after n, got %d", v.page)
+	}
+	v.execute("n")
+	if v.page != 1 {
+		t.Errorf("Expected to stay on the last page, got %d", v.page)
+	}
+
+	v.execute("l warn,error")
+	if len(v.visible) != 2 || v.page != 0 {
+		t.Errorf("Expected 2 entries on page 0 after level filter, got %d on page %d", len(v.visible), v.page)
+	}
+
+	v.execute("r")
+	v.execute("/slow")
+	if len(v.visible) != 1 || v.visible[0].Operation != "api_call" {
+		t.Errorf("Expected search to match the slow api_call, got %v", v.visible)
+	}
+
+	v.execute("r")
+	v.execute("t billing")
+	if len(v.visible) != 1 || v.visible[0].Message != "Request done" || !strings.Contains(v.filterSummary(), "tags=billing") {
+		t.Errorf("Expected the tag filter to match the billing entry, got %v", v.visible)
+	}
+	v.execute("t")
+	if len(v.visible) != 4 {
+		t.Errorf("Expected t without tags to clear the filter, got %d entries", len(v.visible))
+	}
+
+	if !v.execute("x") || v.execute("q") {
+		t.Error("Expected only q to stop the viewer")
+	}
+}
+
+func TestViewerCorrelationPivot(t *testing.T) {
+	v := newTestViewer()
+
+	v.execute("2")
+	if v.selected != 1 {
+		t.Fatalf("Expected entry 2 to be selected, got %d", v.selected)
+	}
+	v.execute("c")
+	if v.query.CorrelationID != "req-1" || len(v.visible) != 2 {
+		t.Errorf("Expected pivot on req-1 with 2 entries, got %q with %d", v.query.CorrelationID, len(v.visible))
+	}
+
+	// Without a selection, c clears the pivot
+	v.execute("c")
+	if v.query.CorrelationID != "" || len(v.visible) != 4 {
+		t.Errorf("Expected pivot to be cleared, got %q with %d entries", v.query.CorrelationID, len(v.visible))
+	}
+
+	v.execute("c req-2")
+	if len(v.visible) != 1 {
+		t.Errorf("Expected 1 entry for req-2, got %d", len(v.visible))
+	}
+}
+
+func TestViewerRender(t *testing.T) {
+	v := newTestViewer()
+	v.execute("1")
+
+	var buf bytes.Buffer
+	v.render(&buf)
+	out := buf.String()
+	for _, want := range []string{"4/4 entries", "page 1/2", "startup", `"operation": "startup"`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected output to contain %q", want)
+		}
+	}
+}
+
+func TestViewerRenderRestoresMultilineMessages(t *testing.T) {
+	v := newTestViewer()
+	v.entries[0].MessageLines = []string{"second line", "third line"}
+	v.apply()
+	v.execute("1")
+
+	var buf bytes.Buffer
+	v.render(&buf)
+	out := buf.String()
+	if !strings.Contains(out, "Service started (+2 lines)") {
+		t.Error("Expected the list to show the number of folded lines")
+	}
+	if !strings.Contains(out, "Service started\nsecond line\nthird line\n") {
+		t.Error("Expected the detail pane to restore the multi-line message")
+	}
+}
+
+func TestViewerRedactedFields(t *testing.T) {
+	v := newTestViewer()
+	v.entries[1].Context = map[string]interface{}{
+		"password": vibelogger.RedactedValue,
+		"db":       map[string]interface{}{"dsn": vibelogger.RedactedValue, "host": "db1"},
+	}
+	v.apply()
+
+	v.execute("R")
+	if len(v.visible) != 1 || v.visible[0].Operation != "db_query" || !strings.Contains(v.filterSummary(), "redacted") {
+		t.Fatalf("Expected only the redacted entry, got %v", v.visible)
+	}
+	v.execute("1")
+	var buf bytes.Buffer
+	v.render(&buf)
+	if !strings.Contains(buf.String(), "Redacted: db.dsn, password\n") {
+		t.Error("Expected the detail pane to list the redacted fields")
+	}
+
+	v.execute("R")
+	if len(v.visible) != 4 {
+		t.Errorf("Expected R to clear the filter, got %d entries", len(v.visible))
+	}
+}
+
+func TestViewerDecryptsEntries(t *testing.T) {
+	keys := vibelogger.StaticKeys{"k1": bytes.Repeat([]byte{1}, 32)}
+	token, err := vibelogger.EncryptValue(keys, "k1", "s3cret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := vibelogger.DefaultConfig()
+	config.FilePath = filepath.Join(t.TempDir(), "app.log")
+	logger, err := vibelogger.CreateFileLoggerWithConfig("tui_test", config)
+	if err != nil {
+		t.Fatalf("failed to create logger: %v", err)
+	}
+	logger.Info("login", "User logged in", vibelogger.WithContext(map[string]interface{}{"token": token}))
+	logger.Close()
+
+	// Without keys the value stays encrypted and is listed as such
+	v := newViewer(config.FilePath, 20)
+	if err := v.reload(); err != nil {
+		t.Fatal(err)
+	}
+	v.execute("1")
+	var buf bytes.Buffer
+	v.render(&buf)
+	if !strings.Contains(buf.String(), "Encrypted: token\n") {
+		t.Error("Expected the detail pane to list the encrypted fields")
+	}
+
+	v.keys = keys
+	if err := v.reload(); err != nil {
+		t.Fatal(err)
+	}
+	if v.execute("/s3cret"); len(v.visible) != 1 || v.visible[0].Context["token"] != "s3cret" {
+		t.Errorf("Expected the decrypted value to be searchable, got %v", v.visible)
+	}
+}
+
+func TestExportEntries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.log")
+	var data bytes.Buffer
+	for _, entry := range newTestViewer().entries {
+		line, _ := json.Marshal(entry)
+		data.Write(append(line, '\n'))
+	}
+	data.WriteString("{\"level\": \"ERROR\", truncated\n")
+	if err := os.WriteFile(path, data.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := exportEntries(&out, path, vibelogger.Query{Levels: []vibelogger.LogLevel{vibelogger.ERROR}}, nil); err != nil {
+		t.Fatalf("exportEntries: %v", err)
+	}
+	var export struct {
+		Path    string                     `json:"path"`
+		Total   int                        `json:"total"`
+		Entries []vibelogger.LogEntry      `json:"entries"`
+		Corrupt []vibelogger.CorruptRecord `json:"corrupt"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &export); err != nil {
+		t.Fatalf("Export is not valid JSON: %v\n%s", err, out.String())
+	}
+	if export.Path != path || export.Total != 4 || len(export.Entries) != 1 || export.Entries[0].Operation != "db_query" || len(export.Corrupt) != 1 {
+		t.Errorf("Expected the filtered entries, got %+v", export)
+	}
+
+	out.Reset()
+	if err := exportEntries(&out, path, vibelogger.Query{Text: "nothing matches"}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), `"entries": []`) {
+		t.Errorf("Expected an empty array rather than null for scripts, got %s", out.String())
+	}
+}
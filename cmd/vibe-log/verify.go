@@ -0,0 +1,112 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/sumee-139/vibe-logger-go"
+)
+
+// verifyFlags are the options of "vibe-log verify"
+type verifyFlags struct {
+	*flag.FlagSet
+	project *string
+	strict  *bool
+	output  *string
+}
+
+// newVerifyFlags defines the options of "vibe-log verify"
+func newVerifyFlags() *verifyFlags {
+	fs := flag.NewFlagSet("verify", flag.ContinueOnError)
+	f := &verifyFlags{
+		FlagSet: fs,
+		project: fs.String("project", "", "project whose logs/<project> directory is checked when no path is given (default: default)"),
+		strict:  fs.Bool("strict", false, "also fail for rotated files without a recorded checksum"),
+		output:  outputFlag(fs),
+	}
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: vibe-log verify [options] [log-file-or-dir ...]")
+		fmt.Fprintln(fs.Output(), "Checks rotated files against the SHA-256 recorded at rotation; exits with status 1 on changes.")
+		fs.PrintDefaults()
+	}
+	return f
+}
+
+// runVerify implements "vibe-log verify"
+func runVerify(args []string) error {
+	f := newVerifyFlags()
+	if err := f.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if err := checkOutput(*f.output); err != nil {
+		return err
+	}
+
+	paths := f.Args()
+	if len(paths) == 0 {
+		project := *f.project
+		if project == "" {
+			project = "default"
+		}
+		paths = []string{filepath.Join("logs", project)}
+	}
+
+	results := []vibelogger.FileVerification{}
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return err
+		}
+		var verified []vibelogger.FileVerification
+		if info.IsDir() {
+			verified, err = vibelogger.VerifyLogDir(path)
+		} else {
+			verified, err = vibelogger.VerifyRotatedFiles(path)
+		}
+		if err != nil {
+			return err
+		}
+		results = append(results, verified...)
+	}
+
+	if *f.output == outputJSON {
+		if err := writeJSON(os.Stdout, results); err != nil {
+			return err
+		}
+	} else {
+		printVerifications(os.Stdout, results)
+	}
+
+	failed := 0
+	for _, result := range results {
+		if result.Failed() || (*f.strict && result.Status == vibelogger.IntegrityUnrecorded) {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d rotated files failed verification", failed, len(results))
+	}
+	return nil
+}
+
+// printVerifications writes one line per rotated file
+func printVerifications(out io.Writer, results []vibelogger.FileVerification) {
+	if len(results) == 0 {
+		fmt.Fprintln(out, "No rotated files with recorded state found.")
+		return
+	}
+	for _, result := range results {
+		fmt.Fprintf(out, "%-10s %s", result.Status, result.Path)
+		if result.Status == vibelogger.IntegrityModified {
+			fmt.Fprintf(out, " (size %d, expected %d)", result.ActualSize, result.ExpectedSize)
+		}
+		fmt.Fprintln(out)
+	}
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/sumee-139/vibe-logger-go"
+)
+
+func TestPrintVerifications(t *testing.T) {
+	var out bytes.Buffer
+	printVerifications(&out, nil)
+	if !strings.Contains(out.String(), "No rotated files") {
+		t.Errorf("Expected empty message, got %q", out.String())
+	}
+
+	out.Reset()
+	printVerifications(&out, []vibelogger.FileVerification{
+		{Path: "logs/app.log.20250101_000000", Status: vibelogger.IntegrityOK},
+		{Path: "logs/app.log.20250102_000000", Status: vibelogger.IntegrityModified, ActualSize: 10, ExpectedSize: 12},
+	})
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], "ok ") {
+		t.Fatalf("Unexpected output: %q", out.String())
+	}
+	if !strings.HasPrefix(lines[1], "modified") || !strings.HasSuffix(lines[1], "(size 10, expected 12)") {
+		t.Errorf("Expected the size difference of the modified file, got %q", lines[1])
+	}
+}
@@ -1,11 +1,13 @@
 package vibelogger
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
-	"strconv"
 	"strings"
+	"sync"
+	"time"
 )
 
 // Security and resource limits
@@ -13,149 +15,178 @@ const (
 	MaxFileSizeLimit  = 1 * 1024 * 1024 * 1024 // 1GB maximum file size
 	MaxMemoryLogLimit = 10000                  // 10k entries maximum
 	MaxFilePathLength = 255                    // 255 characters maximum
+	MaxWriterShards   = 64                     // Encoding goroutines per logger
 )
 
-// LoggerConfig represents configuration options for the logger
+// Output modes
+const (
+	ModeFile   = "file"   // Write entries to log files (default)
+	ModeStdout = "stdout" // Write compact NDJSON to stdout only, for container log collection
+)
+
+// LoggerConfig represents configuration options for the logger.
+// Fields tagged with env can be set from environment variables and command-line flags.
 type LoggerConfig struct {
-	MaxFileSize     int64  `json:"max_file_size"`     // Maximum file size in bytes (0 = unlimited)
-	AutoSave        bool   `json:"auto_save"`         // Enable/disable auto-save functionality
-	EnableMemoryLog bool   `json:"enable_memory_log"` // Enable in-memory logging
-	MemoryLogLimit  int    `json:"memory_log_limit"`  // Maximum number of entries in memory log
-	FilePath        string `json:"file_path"`         // Custom log file path
-	Environment     string `json:"environment"`       // Environment name (dev/prod/test)
-	ProjectName     string `json:"project_name"`      // Project name for multi-project log organization
+	MaxFileSize     int64  `json:"max_file_size" env:"MAX_FILE_SIZE" check:"file_size"`      // Maximum file size in bytes (0 = unlimited)
+	AutoSave        bool   `json:"auto_save" env:"AUTO_SAVE"`                                // Enable/disable auto-save functionality
+	EnableMemoryLog bool   `json:"enable_memory_log" env:"ENABLE_MEMORY"`                    // Enable in-memory logging
+	MemoryLogLimit  int    `json:"memory_log_limit" env:"MEMORY_LIMIT" check:"memory_limit"` // Maximum number of entries in memory log
+	FilePath        string `json:"file_path" env:"FILE_PATH" check:"file_path"`              // Custom log file path
+	Environment     string `json:"environment" env:"ENVIRONMENT" check:"environment"`        // Environment name (dev/prod/test)
+	ProjectName     string `json:"project_name" env:"PROJECT_NAME" check:"project_name"`     // Project name for multi-project log organization
 	// Log rotation settings
-	RotationEnabled bool `json:"rotation_enabled"`  // Enable/disable log rotation
-	MaxRotatedFiles int  `json:"max_rotated_files"` // Maximum number of rotated files to keep (0 = keep all)
+	RotationEnabled bool `json:"rotation_enabled" env:"ROTATION_ENABLED"`                         // Enable/disable log rotation
+	MaxRotatedFiles int  `json:"max_rotated_files" env:"MAX_ROTATED_FILES" check:"rotated_files"` // Maximum number of rotated files to keep (0 = keep all)
+	// Error file settings
+	SplitErrorFile       bool  `json:"split_error_file" env:"SPLIT_ERROR_FILE"`                                     // Also write ERROR entries to <name>_error.log
+	ErrorMaxFileSize     int64 `json:"error_max_file_size" env:"ERROR_MAX_FILE_SIZE" check:"file_size"`             // Size limit of the error file (0 = same as MaxFileSize)
+	ErrorMaxRotatedFiles int   `json:"error_max_rotated_files" env:"ERROR_MAX_ROTATED_FILES" check:"rotated_files"` // Rotated error files to keep (0 = same as MaxRotatedFiles)
+	// Runbook links
+	RunbookURLs string `json:"runbook_urls" env:"RUNBOOK_URLS" check:"runbook_urls"` // Attach runbook_url by pattern or error_code, e.g. auth_error=https://wiki.example.com/auth
+	// Metrics settings
+	MetricsFile bool `json:"metrics_file" env:"METRICS_FILE"` // Also write WithMetrics and duration values to <name>_metrics.log
+	// Category routing
+	CategoryRoutes string `json:"category_routes" env:"CATEGORY_ROUTES" check:"category_routes"` // Copy categories to their own files, e.g. audit=audit.log,database=diagnostics.log
+	// Enrichment settings
+	IncludeBuildInfo    bool `json:"include_build_info" env:"INCLUDE_BUILD_INFO"`         // Attach module version and VCS revision to every entry
+	IncludeProcessInfo  bool `json:"include_process_info" env:"INCLUDE_PROCESS_INFO"`     // Attach hostname, executable, pid and start time to every entry
+	RuntimeStatsOnError bool `json:"runtime_stats_on_error" env:"RUNTIME_STATS_ON_ERROR"` // Attach runtime memory statistics to ERROR entries
+	SourceSnippets      bool `json:"source_snippets" env:"SOURCE_SNIPPETS"`               // Attach source lines around the top application frame to ERROR entries
+	// Stack trace settings
+	StackPrefixes     string `json:"stack_prefixes" env:"STACK_PREFIXES"`           // Function prefixes of application code, e.g. github.com/acme/; other frames are dropped
+	StackTraceVerbose bool   `json:"stack_trace_verbose" env:"STACK_TRACE_VERBOSE"` // Keep library and runtime frames when StackPrefixes is set
+	// File marker settings
+	WriteFileMarkers bool `json:"write_file_markers" env:"FILE_MARKERS"` // Write header/footer records to each log file
+	// Output mode
+	Mode         string `json:"mode" env:"MODE" check:"mode"`                            // file (default) or stdout
+	OutputFormat string `json:"output_format" env:"OUTPUT_FORMAT" check:"output_format"` // Encoding of file records: pretty (default), jsonl (alias compact) or docker
+	LevelFormats string `json:"level_formats" env:"LEVEL_FORMATS" check:"level_formats"` // Per-level encodings overriding OutputFormat, e.g. debug=compact,info=compact,error=pretty
+	// Console echo of file records; stdout mode writes to stdout regardless
+	ConsoleOutput   string `json:"console_output" env:"CONSOLE" check:"console_output"`        // off, stdout (default) or stderr
+	ConsoleFormat   string `json:"console_format" env:"CONSOLE_FORMAT" check:"console_format"` // auto (default; colored text on terminals, JSON otherwise), json or text
+	ConsoleMinLevel string `json:"console_min_level" env:"CONSOLE_MIN_LEVEL" check:"level"`    // Echo only entries at or above this level (empty = all written entries)
+	// Level filtering, sampling and redaction; child loggers inherit them, see WithSettings
+	MinLevel   string  `json:"min_level" env:"MIN_LEVEL" check:"level"`           // Drop entries below this level (empty = keep all)
+	SampleRate float64 `json:"sample_rate" env:"SAMPLE_RATE" check:"sample_rate"` // Fraction of DEBUG and INFO entries kept (0 = keep all)
+	RedactKeys string  `json:"redact_keys" env:"REDACT_KEYS"`                     // Comma-separated context keys whose values are replaced by [REDACTED]
+	// Concurrency settings
+	WriterShards      int  `json:"writer_shards" env:"WRITER_SHARDS" check:"writer_shards"` // Encode entries on N goroutines merged by one file writer (0 = write synchronously)
+	WriteAheadJournal bool `json:"write_ahead_journal" env:"WRITE_AHEAD_JOURNAL"`           // Journal entries queued on the shards so a crash does not lose them
+	// Entry checks and sanitization
+	EntryValidation string `json:"entry_validation" env:"ENTRY_VALIDATION" check:"entry_validation"` // off (default), fix or reject
+	ControlChars    string `json:"control_chars" env:"CONTROL_CHARS" check:"control_chars"`          // keep (default), strip or escape control characters in strings
+	EscapeNonASCII  bool   `json:"escape_non_ascii" env:"ESCAPE_NON_ASCII"`                          // Write non-ASCII characters of the JSON output as \uXXXX escapes
+	FoldMultiline   bool   `json:"fold_multiline" env:"FOLD_MULTILINE"`                              // Store message lines after the first in message_lines
+	EnvironmentDiff bool   `json:"environment_diff" env:"ENVIRONMENT_DIFF"`                          // Record the environment in the file header; entries only carry changed fields
+	// Test-only failures injected into file writes, see ParseFaultInjection
+	FaultInjection string `json:"fault_injection" env:"FAULT_INJECTION" check:"fault_injection"` // e.g. write_error_rate=0.1,rotation_delay=200ms (empty = none)
+	// Embedding in libraries, see HardenedConfig
+	Hardened   bool   `json:"hardened" env:"HARDENED"`       // Never panic or write to stderr; failures go to OnError handlers and returned errors
+	SandboxDir string `json:"sandbox_dir" env:"SANDBOX_DIR"` // Every file of the logger is created below this directory (empty = no sandbox)
+	// Operations and context fields not matching SetOperationRegistry and SetContextSchema
+	UnknownOperations string `json:"unknown_operations" env:"UNKNOWN_OPERATIONS" check:"unknown_operations"`    // allow (default), warn or normalize
+	ContextSchemaMode string `json:"context_schema_mode" env:"CONTEXT_SCHEMA_MODE" check:"context_schema_mode"` // coerce (default) or reject
+	// Deprecation and feature flag entries, see LogDeprecation
+	UsageDedup         bool          `json:"usage_dedup" env:"USAGE_DEDUP"`                                    // Log each deprecation call site and flag variant once instead of on every call
+	UsageDedupInterval time.Duration `json:"usage_dedup_interval" env:"USAGE_DEDUP_INTERVAL" check:"interval"` // Repeat deduplicated entries after this long with the number of calls in between (0 = never)
+	// Periodic entries
+	HeartbeatInterval  time.Duration `json:"heartbeat_interval" env:"HEARTBEAT_INTERVAL" check:"interval"`     // Interval of alive entries (0 = disabled)
+	SummaryInterval    time.Duration `json:"summary_interval" env:"SUMMARY_INTERVAL" check:"interval"`         // Interval of summary entries (0 = disabled)
+	QueueStatsInterval time.Duration `json:"queue_stats_interval" env:"QUEUE_STATS_INTERVAL" check:"interval"` // Interval of async queue telemetry entries (0 = disabled)
+	HistogramInterval  time.Duration `json:"histogram_interval" env:"HISTOGRAM_INTERVAL" check:"interval"`     // Interval of duration histograms aggregated by RecordDuration (0 = one entry per measurement)
+	// Janitor applying retention to every project below logs/, see StartJanitor
+	JanitorInterval time.Duration `json:"janitor_interval" env:"JANITOR_INTERVAL" check:"interval"`   // Interval of retention passes over the logs tree (0 = disabled)
+	RetentionMaxAge time.Duration `json:"retention_max_age" env:"RETENTION_MAX_AGE" check:"interval"` // Log files not modified for longer are removed by the janitor (0 = no age limit)
+	// Runtime monitor
+	RuntimeMonitorInterval time.Duration `json:"runtime_monitor_interval" env:"RUNTIME_MONITOR_INTERVAL" check:"interval"` // Sampling interval of runtime/metrics (0 = disabled)
+	GCPauseThreshold       time.Duration `json:"gc_pause_threshold" env:"GC_PAUSE_THRESHOLD" check:"interval"`             // GC pause reported as slow (0 = not checked)
+	SchedLatencyThreshold  time.Duration `json:"sched_latency_threshold" env:"SCHED_LATENCY_THRESHOLD" check:"interval"`   // Scheduling latency reported as slow (0 = not checked)
+
+	// Environment variable or flag each field was set from, see EffectiveSettings
+	sources map[string]string
 }
 
 // DefaultConfig returns a LoggerConfig with sensible defaults
 func DefaultConfig() *LoggerConfig {
 	return &LoggerConfig{
-		MaxFileSize:     10 * 1024 * 1024, // 10MB default
-		AutoSave:        true,             // Auto-save enabled by default
-		EnableMemoryLog: false,            // Memory log disabled by default
-		MemoryLogLimit:  1000,             // 1000 entries default
-		FilePath:        "",               // Use default path generation
-		Environment:     "development",    // Default environment
-		ProjectName:     "",               // Use default project organization
-		RotationEnabled: true,             // Log rotation enabled by default
-		MaxRotatedFiles: 5,                // Keep 5 rotated files by default
+		MaxFileSize:        10 * 1024 * 1024, // 10MB default
+		AutoSave:           true,             // Auto-save enabled by default
+		EnableMemoryLog:    false,            // Memory log disabled by default
+		MemoryLogLimit:     1000,             // 1000 entries default
+		FilePath:           "",               // Use default path generation
+		Environment:        "development",    // Default environment
+		ProjectName:        "",               // Use default project organization
+		RotationEnabled:    true,             // Log rotation enabled by default
+		MaxRotatedFiles:    5,                // Keep 5 rotated files by default
+		IncludeBuildInfo:   true,             // Self-identify the producing binary by default
+		IncludeProcessInfo: true,             // Identify the host and process by default
+		WriteFileMarkers:   true,             // Header and clean-shutdown footer by default
+		Mode:               ModeFile,         // File output by default
+		OutputFormat:       FormatPretty,     // Human-readable JSON by default
+		ConsoleOutput:      ConsoleStdout,    // Echo file records to stdout by default
+		ConsoleFormat:      ConsoleAuto,      // Text on terminals, JSON when piped by default
+		EntryValidation:    ValidationOff,    // Entries are written as given by default
+		ControlChars:       ControlCharsKeep, // Strings are written as given by default
+		EnvironmentDiff:    true,             // Environment recorded once per file by default
+		UsageDedup:         true,             // Deprecations and flag exposures logged once by default
+		// Runtime monitor thresholds, used once RuntimeMonitorInterval is set
+		GCPauseThreshold:      100 * time.Millisecond,
+		SchedLatencyThreshold: 50 * time.Millisecond,
 	}
 }
 
-// LoadFromEnvironment loads configuration from environment variables with validation
-func (c *LoggerConfig) LoadFromEnvironment() error {
-	var validationErrors []string
+// DefaultEnvPrefix is the prefix of the configuration environment variables
+const DefaultEnvPrefix = "VIBE_LOG_"
 
-	// Validate VIBE_LOG_MAX_FILE_SIZE
-	if val := os.Getenv("VIBE_LOG_MAX_FILE_SIZE"); val != "" {
-		if size, err := strconv.ParseInt(val, 10, 64); err == nil {
-			if size < 0 {
-				validationErrors = append(validationErrors, "VIBE_LOG_MAX_FILE_SIZE cannot be negative")
-			} else if size > MaxFileSizeLimit {
-				validationErrors = append(validationErrors, fmt.Sprintf("VIBE_LOG_MAX_FILE_SIZE exceeds limit: %d > %d", size, MaxFileSizeLimit))
-			} else {
-				c.MaxFileSize = size
-			}
-		} else {
-			validationErrors = append(validationErrors, fmt.Sprintf("invalid VIBE_LOG_MAX_FILE_SIZE format: %s", val))
-		}
-	}
-
-	// Validate VIBE_LOG_AUTO_SAVE
-	if val := os.Getenv("VIBE_LOG_AUTO_SAVE"); val != "" {
-		if autoSave, err := strconv.ParseBool(val); err == nil {
-			c.AutoSave = autoSave
-		} else {
-			validationErrors = append(validationErrors, fmt.Sprintf("invalid VIBE_LOG_AUTO_SAVE format: %s (must be true/false)", val))
-		}
-	}
+// EnvOption customizes how configuration is loaded from environment variables
+type EnvOption func(*envOptions)
 
-	// Validate VIBE_LOG_ENABLE_MEMORY
-	if val := os.Getenv("VIBE_LOG_ENABLE_MEMORY"); val != "" {
-		if enableMemory, err := strconv.ParseBool(val); err == nil {
-			c.EnableMemoryLog = enableMemory
-		} else {
-			validationErrors = append(validationErrors, fmt.Sprintf("invalid VIBE_LOG_ENABLE_MEMORY format: %s (must be true/false)", val))
-		}
-	}
+// envOptions holds the settings applied by EnvOption values
+type envOptions struct {
+	prefix string
+}
 
-	// Validate VIBE_LOG_MEMORY_LIMIT
-	if val := os.Getenv("VIBE_LOG_MEMORY_LIMIT"); val != "" {
-		if limit, err := strconv.Atoi(val); err == nil {
-			if limit < 0 {
-				validationErrors = append(validationErrors, "VIBE_LOG_MEMORY_LIMIT cannot be negative")
-			} else if limit > MaxMemoryLogLimit {
-				validationErrors = append(validationErrors, fmt.Sprintf("VIBE_LOG_MEMORY_LIMIT exceeds limit: %d > %d", limit, MaxMemoryLogLimit))
-			} else {
-				c.MemoryLogLimit = limit
-			}
-		} else {
-			validationErrors = append(validationErrors, fmt.Sprintf("invalid VIBE_LOG_MEMORY_LIMIT format: %s", val))
+// WithEnvPrefix reads configuration from variables with the given prefix
+// (e.g. "MYAPP_LOG" reads MYAPP_LOG_MAX_FILE_SIZE) instead of VIBE_LOG_*.
+// An empty prefix keeps the default.
+func WithEnvPrefix(prefix string) EnvOption {
+	return func(o *envOptions) {
+		if prefix == "" {
+			return
 		}
-	}
-
-	// Validate VIBE_LOG_FILE_PATH
-	if val := os.Getenv("VIBE_LOG_FILE_PATH"); val != "" {
-		if len(val) > MaxFilePathLength {
-			validationErrors = append(validationErrors, fmt.Sprintf("VIBE_LOG_FILE_PATH too long: %d > %d", len(val), MaxFilePathLength))
-		} else {
-			// Temporarily set to validate path security
-			oldPath := c.FilePath
-			c.FilePath = val
-			if err := c.validateFilePath(); err != nil {
-				validationErrors = append(validationErrors, fmt.Sprintf("VIBE_LOG_FILE_PATH validation failed: %v", err))
-				c.FilePath = oldPath // Restore old path on error
-			}
+		if !strings.HasSuffix(prefix, "_") {
+			prefix += "_"
 		}
+		o.prefix = prefix
 	}
+}
 
-	// Validate VIBE_LOG_ENVIRONMENT
-	if val := os.Getenv("VIBE_LOG_ENVIRONMENT"); val != "" {
-		// Environment names should be reasonable length and safe characters
-		if len(val) > 50 {
-			validationErrors = append(validationErrors, "VIBE_LOG_ENVIRONMENT too long (max 50 characters)")
-		} else if !isValidEnvironmentName(val) {
-			validationErrors = append(validationErrors, fmt.Sprintf("VIBE_LOG_ENVIRONMENT contains invalid characters: %s", val))
-		} else {
-			c.Environment = val
-		}
+// newEnvOptions applies the given options on top of the defaults
+func newEnvOptions(options []EnvOption) *envOptions {
+	opts := &envOptions{prefix: DefaultEnvPrefix}
+	for _, opt := range options {
+		opt(opts)
 	}
+	return opts
+}
 
-	// Validate VIBE_LOG_PROJECT_NAME
-	if val := os.Getenv("VIBE_LOG_PROJECT_NAME"); val != "" {
-		// Project names should be reasonable length and safe characters
-		if len(val) > 50 {
-			validationErrors = append(validationErrors, "VIBE_LOG_PROJECT_NAME too long (max 50 characters)")
-		} else if !isValidProjectName(val) {
-			validationErrors = append(validationErrors, fmt.Sprintf("VIBE_LOG_PROJECT_NAME contains invalid characters: %s", val))
-		} else {
-			c.ProjectName = val
-		}
-	}
+// key returns the full environment variable name for a setting
+func (o *envOptions) key(name string) string {
+	return o.prefix + name
+}
 
-	// Validate VIBE_LOG_ROTATION_ENABLED
-	if val := os.Getenv("VIBE_LOG_ROTATION_ENABLED"); val != "" {
-		if rotation, err := strconv.ParseBool(val); err == nil {
-			c.RotationEnabled = rotation
-		} else {
-			validationErrors = append(validationErrors, fmt.Sprintf("invalid VIBE_LOG_ROTATION_ENABLED format: %s (must be true/false)", val))
-		}
-	}
+// LoadFromEnvironment loads configuration from environment variables with validation.
+// Every LoggerConfig field with an env tag is read from <prefix><NAME>.
+func (c *LoggerConfig) LoadFromEnvironment(options ...EnvOption) error {
+	var validationErrors []string
+	opts := newEnvOptions(options)
 
-	// Validate VIBE_LOG_MAX_ROTATED_FILES
-	if val := os.Getenv("VIBE_LOG_MAX_ROTATED_FILES"); val != "" {
-		if files, err := strconv.Atoi(val); err == nil {
-			if files < 0 {
-				validationErrors = append(validationErrors, "VIBE_LOG_MAX_ROTATED_FILES cannot be negative")
-			} else if files > 100 {
-				validationErrors = append(validationErrors, "VIBE_LOG_MAX_ROTATED_FILES too large (max 100)")
-			} else {
-				c.MaxRotatedFiles = files
+	for _, field := range configFields() {
+		key := opts.key(field.name)
+		if val := os.Getenv(key); val != "" {
+			if err := c.setField(field, key, val); err != nil {
+				validationErrors = append(validationErrors, err.Error())
 			}
-		} else {
-			validationErrors = append(validationErrors, fmt.Sprintf("invalid VIBE_LOG_MAX_ROTATED_FILES format: %s", val))
 		}
 	}
 
@@ -181,6 +212,14 @@ func isValidEnvironmentName(env string) bool {
 	return true
 }
 
+// projectName returns the project a configuration logs to
+func projectName(config *LoggerConfig) string {
+	if config.ProjectName != "" {
+		return config.ProjectName
+	}
+	return "default"
+}
+
 // isValidProjectName checks if project name contains only safe characters
 func isValidProjectName(project string) bool {
 	// Allow alphanumeric, underscore, and hyphen (no dots for directory safety)
@@ -195,15 +234,60 @@ func isValidProjectName(project string) bool {
 	return true
 }
 
+// isValidMode checks if the output mode is supported
+func isValidMode(mode string) bool {
+	return mode == ModeFile || mode == ModeStdout
+}
+
 // NewConfigFromEnvironment creates a new LoggerConfig with environment variables applied
-func NewConfigFromEnvironment() (*LoggerConfig, error) {
+func NewConfigFromEnvironment(options ...EnvOption) (*LoggerConfig, error) {
 	config := DefaultConfig()
-	if err := config.LoadFromEnvironment(); err != nil {
+	if err := config.LoadFromEnvironment(options...); err != nil {
 		return nil, fmt.Errorf("failed to load configuration from environment: %w", err)
 	}
 	return config, nil
 }
 
+// ConfigValidator enforces an additional policy on a configuration
+type ConfigValidator func(*LoggerConfig) error
+
+var (
+	validatorsMutex sync.RWMutex
+	customValidator []ConfigValidator
+)
+
+// RegisterValidator adds a validator that runs at the end of every Validate call,
+// allowing organizations to enforce policies such as "rotation must be enabled in
+// production". Validators run in registration order.
+func RegisterValidator(validator ConfigValidator) {
+	if validator == nil {
+		return
+	}
+	validatorsMutex.Lock()
+	defer validatorsMutex.Unlock()
+	customValidator = append(customValidator, validator)
+}
+
+// runCustomValidators executes all registered validators and joins their errors
+func (c *LoggerConfig) runCustomValidators() error {
+	validatorsMutex.RLock()
+	validators := make([]ConfigValidator, len(customValidator))
+	copy(validators, customValidator)
+	validatorsMutex.RUnlock()
+
+	var errs []error
+	for _, validator := range validators {
+		var err error
+		if p := callSafely("config_validator", func() { err = validator(c) }); p != nil {
+			err = p
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
 // Validate checks if the configuration is valid and secure
 func (c *LoggerConfig) Validate() error {
 	// Validate file size limits
@@ -222,6 +306,11 @@ func (c *LoggerConfig) Validate() error {
 		return fmt.Errorf("memory log limit exceeds maximum: %d > %d", c.MemoryLogLimit, MaxMemoryLogLimit)
 	}
 
+	// Resolve the sandbox before the paths checked against it
+	if err := c.normalizeSandbox(); err != nil {
+		return err
+	}
+
 	// Validate file path security
 	if err := c.validateFilePath(); err != nil {
 		return fmt.Errorf("file path validation failed: %w", err)
@@ -232,6 +321,155 @@ func (c *LoggerConfig) Validate() error {
 		c.Environment = "development"
 	}
 
+	// Validate output mode
+	if c.Mode == "" {
+		c.Mode = ModeFile
+	}
+	if !isValidMode(c.Mode) {
+		return fmt.Errorf("invalid mode: %s (must be %s or %s)", c.Mode, ModeFile, ModeStdout)
+	}
+
+	// Validate entry validation mode
+	if c.EntryValidation == "" {
+		c.EntryValidation = ValidationOff
+	}
+	if !isValidEntryValidation(c.EntryValidation) {
+		return fmt.Errorf("invalid entry validation mode: %s (must be %s, %s or %s)",
+			c.EntryValidation, ValidationOff, ValidationFix, ValidationReject)
+	}
+
+	// Validate string sanitization
+	if c.ControlChars == "" {
+		c.ControlChars = ControlCharsKeep
+	}
+	if !isValidControlChars(c.ControlChars) {
+		return fmt.Errorf("invalid control character mode: %s (must be %s, %s or %s)",
+			c.ControlChars, ControlCharsKeep, ControlCharsStrip, ControlCharsEscape)
+	}
+
+	// Validate injected faults
+	if _, err := ParseFaultInjection(c.FaultInjection); err != nil {
+		return fmt.Errorf("invalid fault injection: %w", err)
+	}
+
+	// Validate unknown operation handling
+	if c.UnknownOperations == "" {
+		c.UnknownOperations = UnknownOperationsAllow
+	}
+	if !isValidUnknownOperations(c.UnknownOperations) {
+		return fmt.Errorf("invalid unknown operations mode: %s (must be %s, %s or %s)",
+			c.UnknownOperations, UnknownOperationsAllow, UnknownOperationsWarn, UnknownOperationsNormalize)
+	}
+
+	// Validate context schema handling
+	if c.ContextSchemaMode == "" {
+		c.ContextSchemaMode = SchemaCoerce
+	}
+	if !isValidSchemaMode(c.ContextSchemaMode) {
+		return fmt.Errorf("invalid context schema mode: %s (must be %s or %s)", c.ContextSchemaMode, SchemaCoerce, SchemaReject)
+	}
+
+	// Validate category routes
+	if _, err := ParseCategoryRoutes(c.CategoryRoutes); err != nil {
+		return fmt.Errorf("invalid category routes: %w", err)
+	}
+
+	// Validate runbook links
+	if _, err := ParseRunbookURLs(c.RunbookURLs); err != nil {
+		return fmt.Errorf("invalid runbook URLs: %w", err)
+	}
+
+	// Validate periodic intervals
+	if c.HeartbeatInterval < 0 {
+		c.HeartbeatInterval = 0 // 0 means disabled
+	}
+	if c.SummaryInterval < 0 {
+		c.SummaryInterval = 0
+	}
+	if c.QueueStatsInterval < 0 {
+		c.QueueStatsInterval = 0
+	}
+	if c.UsageDedupInterval < 0 {
+		c.UsageDedupInterval = 0
+	}
+	if c.HistogramInterval < 0 {
+		c.HistogramInterval = 0
+	}
+	if c.JanitorInterval < 0 {
+		c.JanitorInterval = 0
+	}
+	if c.RetentionMaxAge < 0 {
+		c.RetentionMaxAge = 0
+	}
+	if c.RuntimeMonitorInterval < 0 {
+		c.RuntimeMonitorInterval = 0
+	}
+	if c.GCPauseThreshold < 0 {
+		c.GCPauseThreshold = 0
+	}
+	if c.SchedLatencyThreshold < 0 {
+		c.SchedLatencyThreshold = 0
+	}
+
+	// Validate output format
+	if c.OutputFormat == "" {
+		c.OutputFormat = FormatPretty
+	}
+	if !isValidOutputFormat(c.OutputFormat) {
+		return fmt.Errorf("invalid output format: %s", c.OutputFormat)
+	}
+	if _, err := ParseLevelFormats(c.LevelFormats); err != nil {
+		return fmt.Errorf("invalid level formats: %w", err)
+	}
+	if c.ConsoleOutput == "" {
+		c.ConsoleOutput = ConsoleStdout
+	}
+	if !isValidConsoleOutput(c.ConsoleOutput) {
+		return fmt.Errorf("invalid console output: %s (must be %s, %s or %s)", c.ConsoleOutput, ConsoleOff, ConsoleStdout, ConsoleStderr)
+	}
+	if c.Hardened && c.ConsoleOutput == ConsoleStderr {
+		return fmt.Errorf("hardened mode cannot echo to stderr")
+	}
+	if c.ConsoleFormat == "" {
+		c.ConsoleFormat = ConsoleAuto
+	}
+	if !isValidConsoleFormat(c.ConsoleFormat) {
+		return fmt.Errorf("invalid console format: %s (must be %s, %s or %s)", c.ConsoleFormat, ConsoleAuto, ConsoleJSON, ConsoleText)
+	}
+
+	if c.ConsoleMinLevel != "" {
+		level, err := ParseLevel(c.ConsoleMinLevel)
+		if err != nil {
+			return fmt.Errorf("invalid console min level: %w", err)
+		}
+		c.ConsoleMinLevel = string(level)
+	}
+
+	// Validate level filtering and sampling
+	if c.MinLevel != "" {
+		level, err := ParseLevel(c.MinLevel)
+		if err != nil {
+			return fmt.Errorf("invalid min level: %w", err)
+		}
+		c.MinLevel = string(level)
+	}
+	if c.SampleRate < 0 || c.SampleRate > 1 {
+		return fmt.Errorf("sample rate must be between 0 and 1: %v", c.SampleRate)
+	}
+
+	// Validate sharded writing
+	if c.WriterShards < 0 {
+		c.WriterShards = 0 // 0 means synchronous writes
+	}
+	if c.WriterShards > MaxWriterShards {
+		return fmt.Errorf("writer shards exceed maximum: %d > %d", c.WriterShards, MaxWriterShards)
+	}
+
+	// Enforce registered policies on the normalized configuration
+	if err := c.runCustomValidators(); err != nil {
+		return fmt.Errorf("config policy violation: %w", err)
+	}
+
 	return nil
 }
 
@@ -251,6 +489,16 @@ func (c *LoggerConfig) validateFilePath() error {
 		return fmt.Errorf("file path contains path traversal characters: %s", c.FilePath)
 	}
 
+	// A sandbox replaces the default safe directories
+	if c.SandboxDir != "" {
+		path, err := c.sandboxPath(c.FilePath)
+		if err != nil {
+			return err
+		}
+		c.FilePath = path
+		return nil
+	}
+
 	// Clean the path to normalize it
 	cleanPath := filepath.Clean(c.FilePath)
 
@@ -0,0 +1,338 @@
+package vibelogger
+
+import (
+	"flag"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// configField describes a LoggerConfig field that can be set from the
+// environment or the command line. Fields opt in with an `env:"NAME"` tag and
+// may name a checker in a `check:"..."` tag.
+type configField struct {
+	name  string // Setting name without prefix, e.g. MAX_FILE_SIZE
+	field string // Go field name
+	index int
+	kind  reflect.Kind
+	typ   reflect.Type
+	check string
+}
+
+// durationType is bound from strings like "30s" rather than plain integers
+var durationType = reflect.TypeOf(time.Duration(0))
+
+var (
+	configFieldsOnce sync.Once
+	boundConfigField []configField
+)
+
+// configFields returns the bindable LoggerConfig fields in declaration order
+func configFields() []configField {
+	configFieldsOnce.Do(func() {
+		t := reflect.TypeOf(LoggerConfig{})
+		for i := 0; i < t.NumField(); i++ {
+			sf := t.Field(i)
+			name := sf.Tag.Get("env")
+			if name == "" {
+				continue
+			}
+			boundConfigField = append(boundConfigField, configField{
+				name:  name,
+				field: sf.Name,
+				index: i,
+				kind:  sf.Type.Kind(),
+				typ:   sf.Type,
+				check: sf.Tag.Get("check"),
+			})
+		}
+	})
+	return boundConfigField
+}
+
+// configCheckers validate (and may normalize) parsed setting values.
+// Errors are prefixed with the variable or flag name by the caller.
+var configCheckers = map[string]func(value interface{}) (interface{}, error){
+	"file_size": func(value interface{}) (interface{}, error) {
+		size := value.(int64)
+		if size < 0 {
+			return nil, fmt.Errorf("cannot be negative")
+		}
+		if size > MaxFileSizeLimit {
+			return nil, fmt.Errorf("exceeds limit: %d > %d", size, MaxFileSizeLimit)
+		}
+		return size, nil
+	},
+	"memory_limit": func(value interface{}) (interface{}, error) {
+		limit := value.(int64)
+		if limit < 0 {
+			return nil, fmt.Errorf("cannot be negative")
+		}
+		if limit > MaxMemoryLogLimit {
+			return nil, fmt.Errorf("exceeds limit: %d > %d", limit, MaxMemoryLogLimit)
+		}
+		return limit, nil
+	},
+	"rotated_files": func(value interface{}) (interface{}, error) {
+		files := value.(int64)
+		if files < 0 {
+			return nil, fmt.Errorf("cannot be negative")
+		}
+		if files > 100 {
+			return nil, fmt.Errorf("too large (max 100)")
+		}
+		return files, nil
+	},
+	"file_path": func(value interface{}) (interface{}, error) {
+		path := value.(string)
+		if len(path) > MaxFilePathLength {
+			return nil, fmt.Errorf("too long: %d > %d", len(path), MaxFilePathLength)
+		}
+		probe := &LoggerConfig{FilePath: path}
+		if err := probe.validateFilePath(); err != nil {
+			return nil, fmt.Errorf("validation failed: %v", err)
+		}
+		return probe.FilePath, nil
+	},
+	"environment": func(value interface{}) (interface{}, error) {
+		// Environment names should be reasonable length and safe characters
+		env := value.(string)
+		if len(env) > 50 {
+			return nil, fmt.Errorf("too long (max 50 characters)")
+		}
+		if !isValidEnvironmentName(env) {
+			return nil, fmt.Errorf("contains invalid characters: %s", env)
+		}
+		return env, nil
+	},
+	"project_name": func(value interface{}) (interface{}, error) {
+		// Project names should be reasonable length and safe characters
+		project := value.(string)
+		if len(project) > 50 {
+			return nil, fmt.Errorf("too long (max 50 characters)")
+		}
+		if !isValidProjectName(project) {
+			return nil, fmt.Errorf("contains invalid characters: %s", project)
+		}
+		return project, nil
+	},
+	"mode": func(value interface{}) (interface{}, error) {
+		mode := value.(string)
+		if !isValidMode(mode) {
+			return nil, fmt.Errorf("must be %s or %s: %s", ModeFile, ModeStdout, mode)
+		}
+		return mode, nil
+	},
+	"interval": func(value interface{}) (interface{}, error) {
+		if value.(int64) < 0 {
+			return nil, fmt.Errorf("cannot be negative")
+		}
+		return value, nil
+	},
+	"entry_validation": func(value interface{}) (interface{}, error) {
+		mode := value.(string)
+		if !isValidEntryValidation(mode) {
+			return nil, fmt.Errorf("must be %s, %s or %s: %s", ValidationOff, ValidationFix, ValidationReject, mode)
+		}
+		return mode, nil
+	},
+	"console_output": func(value interface{}) (interface{}, error) {
+		output := value.(string)
+		if !isValidConsoleOutput(output) {
+			return nil, fmt.Errorf("must be %s, %s or %s: %s", ConsoleOff, ConsoleStdout, ConsoleStderr, output)
+		}
+		return output, nil
+	},
+	"console_format": func(value interface{}) (interface{}, error) {
+		format := value.(string)
+		if !isValidConsoleFormat(format) {
+			return nil, fmt.Errorf("must be %s, %s or %s: %s", ConsoleAuto, ConsoleJSON, ConsoleText, format)
+		}
+		return format, nil
+	},
+	"control_chars": func(value interface{}) (interface{}, error) {
+		mode := value.(string)
+		if !isValidControlChars(mode) {
+			return nil, fmt.Errorf("must be %s, %s or %s: %s", ControlCharsKeep, ControlCharsStrip, ControlCharsEscape, mode)
+		}
+		return mode, nil
+	},
+	"fault_injection": func(value interface{}) (interface{}, error) {
+		if _, err := ParseFaultInjection(value.(string)); err != nil {
+			return nil, err
+		}
+		return value, nil
+	},
+	"category_routes": func(value interface{}) (interface{}, error) {
+		if _, err := ParseCategoryRoutes(value.(string)); err != nil {
+			return nil, err
+		}
+		return value, nil
+	},
+	"runbook_urls": func(value interface{}) (interface{}, error) {
+		if _, err := ParseRunbookURLs(value.(string)); err != nil {
+			return nil, err
+		}
+		return value, nil
+	},
+	"unknown_operations": func(value interface{}) (interface{}, error) {
+		mode := value.(string)
+		if !isValidUnknownOperations(mode) {
+			return nil, fmt.Errorf("must be %s, %s or %s: %s", UnknownOperationsAllow, UnknownOperationsWarn, UnknownOperationsNormalize, mode)
+		}
+		return mode, nil
+	},
+	"context_schema_mode": func(value interface{}) (interface{}, error) {
+		mode := value.(string)
+		if !isValidSchemaMode(mode) {
+			return nil, fmt.Errorf("must be %s or %s: %s", SchemaCoerce, SchemaReject, mode)
+		}
+		return mode, nil
+	},
+	"output_format": func(value interface{}) (interface{}, error) {
+		format := value.(string)
+		if !isValidOutputFormat(format) {
+			return nil, fmt.Errorf("unsupported output format: %s", format)
+		}
+		return format, nil
+	},
+	"writer_shards": func(value interface{}) (interface{}, error) {
+		shards := value.(int64)
+		if shards < 0 {
+			return nil, fmt.Errorf("cannot be negative")
+		}
+		if shards > MaxWriterShards {
+			return nil, fmt.Errorf("too large (max %d)", MaxWriterShards)
+		}
+		return shards, nil
+	},
+	"level": func(value interface{}) (interface{}, error) {
+		level, err := ParseLevel(value.(string))
+		if err != nil {
+			return nil, err
+		}
+		return string(level), nil
+	},
+	"sample_rate": func(value interface{}) (interface{}, error) {
+		rate := value.(float64)
+		if rate < 0 || rate > 1 {
+			return nil, fmt.Errorf("must be between 0 and 1: %v", rate)
+		}
+		return rate, nil
+	},
+	"level_formats": func(value interface{}) (interface{}, error) {
+		if _, err := ParseLevelFormats(value.(string)); err != nil {
+			return nil, err
+		}
+		return value, nil
+	},
+}
+
+// setField parses raw for the given field, checks it and stores it in c.
+// source is the variable or flag name used in error messages.
+func (c *LoggerConfig) setField(field configField, source, raw string) error {
+	var value interface{}
+	switch field.kind {
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("invalid %s format: %s (must be true/false)", source, raw)
+		}
+		value = b
+	case reflect.Int, reflect.Int64:
+		if field.typ == durationType {
+			d, err := time.ParseDuration(raw)
+			if err != nil {
+				return fmt.Errorf("invalid %s format: %s (must be a duration like 30s)", source, raw)
+			}
+			value = int64(d)
+			break
+		}
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid %s format: %s", source, raw)
+		}
+		value = n
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return fmt.Errorf("invalid %s format: %s", source, raw)
+		}
+		value = f
+	case reflect.String:
+		value = raw
+	default:
+		return fmt.Errorf("%s: unsupported field type %s", source, field.kind)
+	}
+
+	if field.check != "" {
+		checker, ok := configCheckers[field.check]
+		if !ok {
+			return fmt.Errorf("%s: unknown checker %q", source, field.check)
+		}
+		checked, err := checker(value)
+		if err != nil {
+			return fmt.Errorf("%s %v", source, err)
+		}
+		value = checked
+	}
+
+	target := reflect.ValueOf(c).Elem().Field(field.index)
+	switch field.kind {
+	case reflect.Bool:
+		target.SetBool(value.(bool))
+	case reflect.Int, reflect.Int64:
+		target.SetInt(value.(int64))
+	case reflect.Float64:
+		target.SetFloat(value.(float64))
+	case reflect.String:
+		target.SetString(value.(string))
+	}
+	c.recordSource(field.field, source)
+	return nil
+}
+
+// flagName converts an environment variable name into a command-line flag name
+func flagName(envKey string) string {
+	return strings.ToLower(strings.ReplaceAll(envKey, "_", "-"))
+}
+
+// BindFlags registers a command-line flag for every configurable field on fs,
+// e.g. --vibe-log-max-file-size. Parsed flags are validated like environment
+// variables and written directly into c. WithEnvPrefix changes the flag prefix too.
+func (c *LoggerConfig) BindFlags(fs *flag.FlagSet, options ...EnvOption) {
+	opts := newEnvOptions(options)
+	for _, field := range configFields() {
+		key := opts.key(field.name)
+		fs.Var(&configFlag{config: c, field: field, name: flagName(key)}, flagName(key),
+			fmt.Sprintf("sets LoggerConfig.%s (env %s)", field.field, key))
+	}
+}
+
+// configFlag adapts a LoggerConfig field to flag.Value
+type configFlag struct {
+	config *LoggerConfig
+	field  configField
+	name   string
+}
+
+// String returns the current value of the bound field
+func (f *configFlag) String() string {
+	if f == nil || f.config == nil {
+		return ""
+	}
+	return fmt.Sprint(reflect.ValueOf(f.config).Elem().Field(f.field.index).Interface())
+}
+
+// Set parses and validates a flag value
+func (f *configFlag) Set(raw string) error {
+	return f.config.setField(f.field, "--"+f.name, raw)
+}
+
+// IsBoolFlag allows boolean settings to be passed without a value
+func (f *configFlag) IsBoolFlag() bool {
+	return f.field.kind == reflect.Bool
+}
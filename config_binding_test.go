@@ -0,0 +1,98 @@
+package vibelogger
+
+import (
+	"flag"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestConfigFieldsFromTags(t *testing.T) {
+	names := make(map[string]bool)
+	for _, field := range configFields() {
+		names[field.name] = true
+		if field.check != "" {
+			if _, ok := configCheckers[field.check]; !ok {
+				t.Errorf("Field %s references unknown checker %q", field.field, field.check)
+			}
+		}
+	}
+
+	expected := []string{"MAX_FILE_SIZE", "AUTO_SAVE", "ENABLE_MEMORY", "MEMORY_LIMIT", "FILE_PATH",
+		"ENVIRONMENT", "PROJECT_NAME", "ROTATION_ENABLED", "MAX_ROTATED_FILES"}
+	for _, name := range expected {
+		if !names[name] {
+			t.Errorf("Expected %s to be bound from struct tags", name)
+		}
+	}
+}
+
+func TestBindFlags(t *testing.T) {
+	config := DefaultConfig()
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	config.BindFlags(fs)
+
+	args := []string{
+		"--vibe-log-max-file-size=2048",
+		"--vibe-log-enable-memory",
+		"--vibe-log-project-name", "flag-project",
+		"--vibe-log-file-path=logs/./flags.log",
+	}
+	if err := fs.Parse(args); err != nil {
+		t.Fatalf("Failed to parse flags: %v", err)
+	}
+
+	if config.MaxFileSize != 2048 {
+		t.Errorf("Expected MaxFileSize 2048, got %d", config.MaxFileSize)
+	}
+	if !config.EnableMemoryLog {
+		t.Error("Expected boolean flag without value to enable memory log")
+	}
+	if config.ProjectName != "flag-project" {
+		t.Errorf("Expected ProjectName 'flag-project', got '%s'", config.ProjectName)
+	}
+	if config.FilePath != "logs/flags.log" {
+		t.Errorf("Expected cleaned FilePath 'logs/flags.log', got '%s'", config.FilePath)
+	}
+}
+
+func TestBindFlagsValidation(t *testing.T) {
+	tests := []struct {
+		arg      string
+		contains string
+	}{
+		{"--vibe-log-max-file-size=-1", "cannot be negative"},
+		{"--vibe-log-memory-limit=abc", "invalid --vibe-log-memory-limit format"},
+		{"--vibe-log-file-path=../etc/passwd", "validation failed"},
+		{"--vibe-log-environment=prod;rm", "contains invalid characters"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.arg, func(t *testing.T) {
+			config := DefaultConfig()
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			fs.SetOutput(io.Discard)
+			config.BindFlags(fs)
+
+			err := fs.Parse([]string{tt.arg})
+			if err == nil || !strings.Contains(err.Error(), tt.contains) {
+				t.Errorf("Expected error containing %q, got %v", tt.contains, err)
+			}
+		})
+	}
+}
+
+func TestBindFlagsWithPrefix(t *testing.T) {
+	config := DefaultConfig()
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	config.BindFlags(fs, WithEnvPrefix("MYAPP_LOG"))
+
+	if err := fs.Parse([]string{"--myapp-log-max-rotated-files=9"}); err != nil {
+		t.Fatalf("Failed to parse prefixed flag: %v", err)
+	}
+	if config.MaxRotatedFiles != 9 {
+		t.Errorf("Expected MaxRotatedFiles 9, got %d", config.MaxRotatedFiles)
+	}
+}
@@ -0,0 +1,209 @@
+package vibelogger
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ConfigVersion is the layout version of LoggerConfig understood by
+// MigrateConfig. Maps without a "config_version" key are treated as version 1
+// (the v1.0 release) when they only use keys that release knew.
+const ConfigVersion = 2
+
+// configVersionKey is the map key recording the layout version
+const configVersionKey = "config_version"
+
+// v1ConfigKeys are the keys of the v1.0 LoggerConfig
+var v1ConfigKeys = map[string]bool{
+	"max_file_size": true, "auto_save": true, "enable_memory_log": true, "memory_log_limit": true,
+	"file_path": true, "environment": true, "project_name": true,
+	"rotation_enabled": true, "max_rotated_files": true,
+}
+
+// configDefaultNotes explains defaults introduced after v1.0 that change what
+// is written, reported when migrating a v1.0 layout that does not set them
+var configDefaultNotes = []struct {
+	key  string
+	note string
+}{
+	{"include_build_info", "module version and VCS revision are attached to every entry"},
+	{"include_process_info", "hostname, executable and pid are attached to every entry"},
+	{"write_file_markers", "log files start with a header and end with a footer record"},
+	{"environment_diff", "the environment is recorded in the file header and entries only carry changed fields"},
+}
+
+// MigrateConfig upgrades a configuration written for an earlier release, e.g.
+// a decoded JSON or YAML file or a map of environment variables, to the current
+// LoggerConfig. Keys may be JSON names (max_file_size), Go field names
+// (MaxFileSize) or environment variable names with or without the VIBE_LOG_
+// prefix; values may be typed or strings as found in the environment. Fields
+// not present keep the DefaultConfig values.
+//
+// The returned warnings describe deprecated or unknown keys and new defaults
+// that change behavior compared to the release the layout came from. Invalid
+// values are reported as an error together with the partially migrated config.
+func MigrateConfig(old map[string]interface{}) (*LoggerConfig, []string, error) {
+	config := DefaultConfig()
+	var warnings []string
+	var errs []string
+
+	version, err := configLayoutVersion(old)
+	if err != nil {
+		return config, nil, err
+	}
+	if version > ConfigVersion {
+		warnings = append(warnings, fmt.Sprintf("config_version %d is newer than supported version %d; unknown keys are ignored", version, ConfigVersion))
+	}
+
+	fields := migrationFields()
+	keys := make([]string, 0, len(old))
+	for key := range old {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	seen := make(map[string]string)
+	for _, key := range keys {
+		if key == configVersionKey {
+			continue
+		}
+		target, alias, ok := fields.lookup(key)
+		if !ok {
+			warnings = append(warnings, fmt.Sprintf("%s: unknown option ignored", key))
+			continue
+		}
+		if alias {
+			warnings = append(warnings, fmt.Sprintf("%s: deprecated name, use %s", key, target.jsonKey))
+		}
+		if previous, dup := seen[target.jsonKey]; dup {
+			warnings = append(warnings, fmt.Sprintf("%s: overrides %s", key, previous))
+		}
+		seen[target.jsonKey] = key
+
+		raw, err := migrationValue(old[key], target.field.typ)
+		if err == nil && raw == "" {
+			continue // Empty values keep the default, as in the environment
+		}
+		if err == nil {
+			err = config.setField(target.field, key, raw)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", key, err))
+		}
+	}
+
+	if version == 1 {
+		for _, d := range configDefaultNotes {
+			if _, set := seen[d.key]; !set {
+				warnings = append(warnings, fmt.Sprintf("%s: new default %v since v1.0: %s", d.key, fields.defaultValue(d.key), d.note))
+			}
+		}
+	}
+
+	// Values came from a file, not from the environment or flags
+	config.sources = nil
+	if len(errs) > 0 {
+		return config, warnings, fmt.Errorf("config migration errors: %v", errs)
+	}
+	if err := config.Validate(); err != nil {
+		return config, warnings, err
+	}
+	return config, warnings, nil
+}
+
+// configLayoutVersion returns the layout version of a configuration map
+func configLayoutVersion(old map[string]interface{}) (int, error) {
+	if raw, ok := old[configVersionKey]; ok {
+		s, err := migrationValue(raw, reflect.TypeOf(0))
+		if err != nil {
+			return 0, fmt.Errorf("invalid %s: %v", configVersionKey, err)
+		}
+		version, err := strconv.Atoi(s)
+		if err != nil || version < 1 {
+			return 0, fmt.Errorf("invalid %s: %v", configVersionKey, raw)
+		}
+		return version, nil
+	}
+	for key := range old {
+		if !v1ConfigKeys[key] {
+			return ConfigVersion, nil
+		}
+	}
+	return 1, nil
+}
+
+// migrationField is a settable field together with its JSON name
+type migrationField struct {
+	field   configField
+	jsonKey string
+}
+
+// migrationFieldSet resolves the names a field may appear under
+type migrationFieldSet map[string]migrationField
+
+// migrationFields indexes the bindable fields by JSON name, Go name and
+// environment variable name
+func migrationFields() migrationFieldSet {
+	t := reflect.TypeOf(LoggerConfig{})
+	set := make(migrationFieldSet)
+	for _, field := range configFields() {
+		jsonKey, _, _ := strings.Cut(t.Field(field.index).Tag.Get("json"), ",")
+		f := migrationField{field: field, jsonKey: jsonKey}
+		set[jsonKey] = f
+		set[field.field] = f
+		set[field.name] = f
+	}
+	return set
+}
+
+// lookup finds the field of a key, accepting the VIBE_LOG_ prefix of
+// environment variables. alias reports lower-case environment names such as
+// memory_limit, which older configuration files copied from the variables.
+func (s migrationFieldSet) lookup(key string) (field migrationField, alias bool, ok bool) {
+	if f, ok := s[key]; ok {
+		return f, false, true
+	}
+	upper := strings.ToUpper(key)
+	f, ok := s[strings.TrimPrefix(upper, DefaultEnvPrefix)]
+	return f, upper != key, ok
+}
+
+// defaultValue returns the DefaultConfig value of the field with the given JSON name
+func (s migrationFieldSet) defaultValue(jsonKey string) interface{} {
+	return reflect.ValueOf(DefaultConfig()).Elem().Field(s[jsonKey].field.index).Interface()
+}
+
+// migrationValue renders a decoded value in the string form setField parses
+func migrationValue(value interface{}, typ reflect.Type) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return strings.TrimSpace(v), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case float64:
+		// Durations are encoded by encoding/json as nanoseconds
+		if typ == durationType {
+			return time.Duration(v).String(), nil
+		}
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case int:
+		if typ == durationType {
+			return time.Duration(v).String(), nil
+		}
+		return strconv.Itoa(v), nil
+	case int64:
+		if typ == durationType {
+			return time.Duration(v).String(), nil
+		}
+		return strconv.FormatInt(v, 10), nil
+	case time.Duration:
+		return v.String(), nil
+	case nil:
+		return "", fmt.Errorf("missing value")
+	}
+	return "", fmt.Errorf("unsupported value type %T", value)
+}
@@ -0,0 +1,77 @@
+package vibelogger
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMigrateConfigFromV1File(t *testing.T) {
+	var old map[string]interface{}
+	json.Unmarshal([]byte(`{"max_file_size": 2097152, "memory_log_limit": 50, "project_name": "billing", "rotation_enabled": false}`), &old)
+
+	config, warnings, err := MigrateConfig(old)
+	if err != nil {
+		t.Fatalf("MigrateConfig failed: %v", err)
+	}
+	if config.MaxFileSize != 2097152 || config.MemoryLogLimit != 50 || config.ProjectName != "billing" || config.RotationEnabled {
+		t.Errorf("Expected the v1 values to be kept, got %+v", config)
+	}
+	if config.MaxRotatedFiles != 5 || !config.WriteFileMarkers {
+		t.Errorf("Expected defaults for absent fields, got %+v", config)
+	}
+	if len(warnings) != len(configDefaultNotes) || !strings.Contains(warnings[2], "write_file_markers: new default true") {
+		t.Errorf("Expected a note per behavior-changing default, got %v", warnings)
+	}
+	for _, setting := range config.EffectiveSettings() {
+		if setting.Source == ConfigSourceEnv || setting.Source == ConfigSourceFlag {
+			t.Errorf("Expected migrated values not to be attributed to the environment: %+v", setting)
+		}
+	}
+}
+
+func TestMigrateConfigLayouts(t *testing.T) {
+	config, warnings, err := MigrateConfig(map[string]interface{}{
+		"config_version":         2,
+		"VIBE_LOG_MIN_LEVEL":     "warn",
+		"SAMPLE_RATE":            "0.5",
+		"HeartbeatInterval":      "30s",
+		"summary_interval":       float64(time.Minute),
+		"memory_limit":           200,
+		"write_file_markers":     false,
+		"legacy_colorize_output": true,
+	})
+	if err != nil {
+		t.Fatalf("MigrateConfig failed: %v", err)
+	}
+	if config.MinLevel != "WARN" || config.SampleRate != 0.5 || config.HeartbeatInterval != 30*time.Second ||
+		config.SummaryInterval != time.Minute || config.MemoryLogLimit != 200 || config.WriteFileMarkers {
+		t.Errorf("Expected every key layout to be applied, got %+v", config)
+	}
+	joined := strings.Join(warnings, "\n")
+	if len(warnings) != 2 || !strings.Contains(joined, "memory_limit: deprecated name, use memory_log_limit") ||
+		!strings.Contains(joined, "legacy_colorize_output: unknown option ignored") {
+		t.Errorf("Unexpected warnings: %v", warnings)
+	}
+}
+
+func TestMigrateConfigErrors(t *testing.T) {
+	_, _, err := MigrateConfig(map[string]interface{}{"max_file_size": -1, "mode": "syslog", "auto_save": "maybe"})
+	if err == nil {
+		t.Fatal("Expected invalid values to be reported")
+	}
+	for _, want := range []string{"max_file_size", "mode", "auto_save"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected %s in the error, got %v", want, err)
+		}
+	}
+
+	if _, _, err := MigrateConfig(map[string]interface{}{"config_version": "two"}); err == nil {
+		t.Error("Expected an invalid config_version to be rejected")
+	}
+	_, warnings, err := MigrateConfig(map[string]interface{}{"config_version": 3})
+	if err != nil || len(warnings) != 1 || !strings.Contains(warnings[0], "newer than supported") {
+		t.Errorf("Expected a warning for a newer layout, got %v (%v)", warnings, err)
+	}
+}
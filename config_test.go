@@ -1,7 +1,9 @@
 package vibelogger
 
 import (
+	"fmt"
 	"os"
+	"strings"
 	"testing"
 )
 
@@ -335,3 +337,88 @@ func TestSecurePathValidation(t *testing.T) {
 		}
 	}
 }
+
+func TestLoadFromEnvironmentWithPrefix(t *testing.T) {
+	os.Setenv("MYAPP_LOG_MEMORY_LIMIT", "42")
+	os.Setenv("MYAPP_LOG_PROJECT_NAME", "myapp")
+	os.Setenv("VIBE_LOG_MEMORY_LIMIT", "7")
+	defer func() {
+		os.Unsetenv("MYAPP_LOG_MEMORY_LIMIT")
+		os.Unsetenv("MYAPP_LOG_PROJECT_NAME")
+		os.Unsetenv("VIBE_LOG_MEMORY_LIMIT")
+	}()
+
+	config, err := NewConfigFromEnvironment(WithEnvPrefix("MYAPP_LOG"))
+	if err != nil {
+		t.Fatalf("Failed to create config from environment: %v", err)
+	}
+	if config.MemoryLogLimit != 42 {
+		t.Errorf("Expected MemoryLogLimit 42 from prefixed variable, got %d", config.MemoryLogLimit)
+	}
+	if config.ProjectName != "myapp" {
+		t.Errorf("Expected ProjectName 'myapp', got '%s'", config.ProjectName)
+	}
+
+	// Default prefix is still used without the option
+	config, err = NewConfigFromEnvironment()
+	if err != nil {
+		t.Fatalf("Failed to create config from environment: %v", err)
+	}
+	if config.MemoryLogLimit != 7 {
+		t.Errorf("Expected MemoryLogLimit 7 from default prefix, got %d", config.MemoryLogLimit)
+	}
+
+	// Validation errors name the prefixed variable
+	os.Setenv("MYAPP_LOG_MEMORY_LIMIT", "invalid")
+	_, err = NewConfigFromEnvironment(WithEnvPrefix("MYAPP_LOG_"))
+	if err == nil || !strings.Contains(err.Error(), "MYAPP_LOG_MEMORY_LIMIT") {
+		t.Errorf("Expected error mentioning MYAPP_LOG_MEMORY_LIMIT, got %v", err)
+	}
+}
+
+func TestRegisterValidator(t *testing.T) {
+	// Restore the global registry after the test
+	validatorsMutex.Lock()
+	saved := customValidator
+	validatorsMutex.Unlock()
+	defer func() {
+		validatorsMutex.Lock()
+		customValidator = saved
+		validatorsMutex.Unlock()
+	}()
+
+	RegisterValidator(func(c *LoggerConfig) error {
+		if c.Environment == "production" && !c.RotationEnabled {
+			return fmt.Errorf("rotation must be enabled in production")
+		}
+		return nil
+	})
+	RegisterValidator(func(c *LoggerConfig) error {
+		if c.FilePath != "" && !strings.HasPrefix(c.FilePath, "logs/") {
+			return fmt.Errorf("file path must be under logs/")
+		}
+		return nil
+	})
+	RegisterValidator(nil) // Ignored
+
+	config := DefaultConfig()
+	config.Environment = "production"
+	if err := config.Validate(); err != nil {
+		t.Errorf("Expected compliant config to pass, got %v", err)
+	}
+
+	config.RotationEnabled = false
+	config.FilePath = "/tmp/outside.log"
+	err := config.Validate()
+	if err == nil {
+		t.Fatal("Expected policy violation")
+	}
+	if !strings.Contains(err.Error(), "rotation must be enabled") || !strings.Contains(err.Error(), "must be under logs/") {
+		t.Errorf("Expected all violations to be reported, got %v", err)
+	}
+
+	// File loggers must not be created when a policy is violated
+	if _, err := CreateFileLoggerWithConfig("policy_test", config); err == nil {
+		t.Error("Expected CreateFileLoggerWithConfig to fail on policy violation")
+	}
+}
@@ -0,0 +1,249 @@
+package vibelogger
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Console echo destinations, see LoggerConfig.ConsoleOutput
+const (
+	ConsoleOff    = "off"    // No echo
+	ConsoleStdout = "stdout" // Echo to stdout (default)
+	ConsoleStderr = "stderr" // Echo to stderr
+)
+
+// Console output formats, see LoggerConfig.ConsoleFormat
+const (
+	ConsoleAuto = "auto" // Human-readable text when the console output is a terminal, JSON otherwise (default)
+	ConsoleJSON = "json" // Always JSON
+	ConsoleText = "text" // Always human-readable text
+)
+
+// Defaults of ConsoleFormatter
+const (
+	DefaultConsoleTimeFormat     = "15:04:05.000"
+	DefaultConsoleOperationWidth = 20
+)
+
+// maxConsoleValue truncates long context values so an entry stays on one line
+const maxConsoleValue = 120
+
+// ANSI escapes of the console formatter
+const (
+	ansiReset = "\033[0m"
+	ansiBold  = "\033[1m"
+	ansiDim   = "\033[2m"
+)
+
+// consoleLevelColors are the ANSI colors of the levels
+var consoleLevelColors = map[LogLevel]string{
+	DEBUG: "\033[90m",
+	INFO:  "\033[36m",
+	WARN:  "\033[33m",
+	ERROR: "\033[31m",
+}
+
+// isValidConsoleOutput checks if the console echo destination is supported
+func isValidConsoleOutput(output string) bool {
+	return output == "" || output == ConsoleOff || output == ConsoleStdout || output == ConsoleStderr
+}
+
+// isValidConsoleFormat checks if the console format is supported
+func isValidConsoleFormat(format string) bool {
+	return format == "" || format == ConsoleAuto || format == ConsoleJSON || format == ConsoleText
+}
+
+// consoleWriter returns the destination of the console echo of config, nil
+// when it is off. Stdout mode always writes to stdout.
+func consoleWriter(config *LoggerConfig) io.Writer {
+	switch {
+	case config.Mode == ModeStdout:
+		return os.Stdout
+	case config.ConsoleOutput == ConsoleOff:
+		return nil
+	case config.ConsoleOutput == ConsoleStderr:
+		return os.Stderr
+	default:
+		return os.Stdout
+	}
+}
+
+// ConsoleFormatter renders entries as one human-readable line for local
+// development: time, level, operation and message in aligned columns followed
+// by the context collapsed into key=value pairs. Continuation lines of folded
+// messages follow indented. It is used for console output according to
+// LoggerConfig.ConsoleFormat and can be passed to SetFormatter.
+type ConsoleFormatter struct {
+	Color          bool   // Color levels and dim secondary fields with ANSI escapes
+	TimeFormat     string // Layout of the timestamp (DefaultConsoleTimeFormat when empty)
+	OperationWidth int    // Column width of the operation (DefaultConsoleOperationWidth when 0)
+}
+
+// NewConsoleFormatter creates a formatter for output to w, coloring it when w
+// is a terminal and neither NO_COLOR is set nor TERM is dumb
+func NewConsoleFormatter(w io.Writer) *ConsoleFormatter {
+	return &ConsoleFormatter{Color: supportsColor(w)}
+}
+
+// IsTerminal reports whether w is a character device such as a terminal
+func IsTerminal(w io.Writer) bool {
+	file, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := file.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// supportsColor reports whether ANSI colors should be written to w
+func supportsColor(w io.Writer) bool {
+	if _, ok := os.LookupEnv("NO_COLOR"); ok || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	return IsTerminal(w)
+}
+
+// setConsole sets up the console echo of config. The caller must hold the
+// mutex once the logger is in use.
+func (l *Logger) setConsole(config *LoggerConfig) {
+	l.consoleOut = consoleWriter(config)
+	l.console = nil
+	if l.consoleOut != nil {
+		l.console = newConsoleFormatter(config.ConsoleFormat, l.consoleOut)
+	}
+}
+
+// newConsoleFormatter returns the formatter of console output to w according
+// to the console setting, nil when the output stays JSON
+func newConsoleFormatter(console string, w io.Writer) *ConsoleFormatter {
+	switch console {
+	case ConsoleText:
+		return NewConsoleFormatter(w)
+	case ConsoleJSON:
+		return nil
+	default:
+		if !IsTerminal(w) {
+			return nil
+		}
+		return NewConsoleFormatter(w)
+	}
+}
+
+// Format renders entry as text
+func (f *ConsoleFormatter) Format(entry *LogEntry) ([]byte, error) {
+	timeFormat := f.TimeFormat
+	if timeFormat == "" {
+		timeFormat = DefaultConsoleTimeFormat
+	}
+	width := f.OperationWidth
+	if width <= 0 {
+		width = DefaultConsoleOperationWidth
+	}
+
+	var b strings.Builder
+	b.WriteString(f.paint(ansiDim, entry.Timestamp.Local().Format(timeFormat)))
+	b.WriteByte(' ')
+	b.WriteString(f.paint(consoleLevelColors[entry.Level], fmt.Sprintf("%-5s", entry.Level)))
+	b.WriteByte(' ')
+	b.WriteString(f.paint(ansiBold, fmt.Sprintf("%-*s", width, entry.Operation)))
+	b.WriteByte(' ')
+	b.WriteString(entry.Message)
+
+	for _, pair := range consolePairs(entry) {
+		b.WriteString("  ")
+		b.WriteString(f.paint(ansiDim, pair[0]+"="))
+		b.WriteString(pair[1])
+	}
+	for _, line := range entry.MessageLines {
+		b.WriteString("\n    ")
+		b.WriteString(line)
+	}
+	return []byte(b.String()), nil
+}
+
+// paint wraps s in an ANSI escape when coloring is enabled
+func (f *ConsoleFormatter) paint(escape, s string) string {
+	if !f.Color || escape == "" {
+		return s
+	}
+	return escape + s + ansiReset
+}
+
+// consolePairs returns the key=value pairs shown after the message: the
+// context flattened with dotted keys in sorted order, then the fields
+// correlating the entry
+func consolePairs(entry *LogEntry) [][2]string {
+	var pairs [][2]string
+	flattenConsoleContext("", entry.Context, &pairs)
+	sort.Slice(pairs, func(i, j int) bool { return pairs[i][0] < pairs[j][0] })
+
+	if entry.Duration > 0 {
+		pairs = append(pairs, [2]string{"duration", entry.Duration.Round(time.Microsecond).String()})
+	}
+	if entry.CorrelationID != "" {
+		pairs = append(pairs, [2]string{"correlation_id", consoleValue(entry.CorrelationID)})
+	}
+	if entry.SessionID != "" {
+		pairs = append(pairs, [2]string{"session_id", consoleValue(entry.SessionID)})
+	}
+	if len(entry.Tags) > 0 {
+		pairs = append(pairs, [2]string{"tags", consoleValue(strings.Join(entry.Tags, ","))})
+	}
+	return pairs
+}
+
+// flattenConsoleContext appends the values of context, descending into nested maps
+func flattenConsoleContext(prefix string, context map[string]interface{}, pairs *[][2]string) {
+	for key, value := range context {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		if nested, ok := value.(map[string]interface{}); ok && len(nested) > 0 {
+			flattenConsoleContext(key, nested, pairs)
+			continue
+		}
+		*pairs = append(*pairs, [2]string{key, consoleValue(value)})
+	}
+}
+
+// consoleValue renders a context value, quoting strings that would be
+// ambiguous and truncating long ones
+func consoleValue(value interface{}) string {
+	var s string
+	switch v := value.(type) {
+	case string:
+		s = v
+	case error:
+		s = v.Error()
+	case nil:
+		return "null"
+	case bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64, time.Duration:
+		return fmt.Sprint(v)
+	default:
+		// Slices and structs are shown as JSON, which is unambiguous already
+		data, err := json.Marshal(v)
+		if err != nil {
+			return truncateConsoleValue(fmt.Sprint(v))
+		}
+		return truncateConsoleValue(string(data))
+	}
+	s = truncateConsoleValue(s)
+	if s == "" || strings.ContainsAny(s, " =\"\t\n\r") || strconv.Quote(s) != `"`+s+`"` {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+// truncateConsoleValue shortens s to maxConsoleValue characters
+func truncateConsoleValue(s string) string {
+	if runes := []rune(s); len(runes) > maxConsoleValue {
+		return string(runes[:maxConsoleValue]) + "…"
+	}
+	return s
+}
@@ -0,0 +1,131 @@
+package vibelogger
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestConsoleFormatter(t *testing.T) {
+	entry := &LogEntry{
+		Timestamp: time.Date(2024, 1, 2, 15, 4, 5, 0, time.Local),
+		Level:     WARN,
+		Operation: "checkout",
+		Message:   "Payment retried",
+		Context: map[string]interface{}{
+			"attempt": 2,
+			"user":    map[string]interface{}{"id": "u-1", "plan": "pro plan"},
+			"error":   errors.New("timeout"),
+			"items":   []string{"a", "b"},
+		},
+		CorrelationID: "req-1",
+		MessageLines:  []string{"second line"},
+	}
+
+	plain, err := (&ConsoleFormatter{OperationWidth: 10}).Format(entry)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := `15:04:05.000 WARN  checkout   Payment retried  attempt=2  error=timeout  items=["a","b"]  user.id=u-1  user.plan="pro plan"  correlation_id=req-1` +
+		"\n    second line"
+	if string(plain) != expected {
+		t.Errorf("unexpected text:\n%s\nexpected:\n%s", plain, expected)
+	}
+
+	colored, _ := (&ConsoleFormatter{Color: true}).Format(entry)
+	if !strings.Contains(string(colored), consoleLevelColors[WARN]+"WARN ") || !strings.HasSuffix(strings.Split(string(colored), "\n")[0], "req-1") {
+		t.Errorf("expected a colored level, got %q", colored)
+	}
+}
+
+func TestConsoleValue(t *testing.T) {
+	cases := map[interface{}]string{
+		"plain":         "plain",
+		"":              `""`,
+		"a=b":           `"a=b"`,
+		"tab\there":     `"tab\there"`,
+		3.5:             "3.5",
+		true:            "true",
+		time.Second * 2: "2s",
+	}
+	for value, expected := range cases {
+		if got := consoleValue(value); got != expected {
+			t.Errorf("consoleValue(%v) = %s, expected %s", value, got, expected)
+		}
+	}
+	if got := consoleValue(strings.Repeat("x", 200)); len([]rune(got)) != maxConsoleValue+1 {
+		t.Errorf("expected a truncated value, got %d characters", len([]rune(got)))
+	}
+}
+
+func TestConsoleSetting(t *testing.T) {
+	var buf bytes.Buffer
+	if newConsoleFormatter(ConsoleAuto, &buf) != nil || newConsoleFormatter(ConsoleJSON, &buf) != nil {
+		t.Error("expected JSON for a writer that is not a terminal")
+	}
+	if f := newConsoleFormatter(ConsoleText, &buf); f == nil || f.Color {
+		t.Errorf("expected uncolored text, got %+v", f)
+	}
+
+	config := DefaultConfig()
+	config.ConsoleFormat = "fancy"
+	if err := config.Validate(); err == nil {
+		t.Error("expected an error for an unknown console format")
+	}
+}
+
+func TestConsoleOutput(t *testing.T) {
+	cases := map[string]interface{}{ConsoleOff: nil, ConsoleStdout: os.Stdout, ConsoleStderr: os.Stderr}
+	for output, expected := range cases {
+		config := DefaultConfig()
+		config.ConsoleOutput = output
+		if got := consoleWriter(config); got != expected {
+			t.Errorf("%s: expected %v, got %v", output, expected, got)
+		}
+	}
+	config := DefaultConfig()
+	config.Mode = ModeStdout
+	config.ConsoleOutput = ConsoleOff
+	if consoleWriter(config) != os.Stdout {
+		t.Error("expected stdout mode to write to stdout regardless")
+	}
+
+	logger := NewLoggerWithConfig("console_echo", &LoggerConfig{AutoSave: false, ConsoleOutput: ConsoleOff})
+	if logger.consoleOut != nil {
+		t.Fatal("expected no console echo")
+	}
+	var buf bytes.Buffer
+	logger.consoleOut = &buf
+	logger.Info("checkout", "Order placed")
+	if !strings.Contains(buf.String(), `"Order placed"`) || !strings.HasSuffix(buf.String(), "\n") {
+		t.Errorf("expected the record echoed as a line, got %q", buf.String())
+	}
+
+	// UpdateConfig turns the echo off again
+	logger.consoleOut = &buf
+	update := DefaultConfig()
+	update.AutoSave = false
+	update.ConsoleOutput = ConsoleOff
+	if err := logger.UpdateConfig(update); err != nil {
+		t.Fatalf("failed to update config: %v", err)
+	}
+	buf.Reset()
+	logger.Info("checkout", "Order shipped")
+	if logger.consoleOut != nil || buf.Len() != 0 {
+		t.Errorf("expected no echo after UpdateConfig, got %q", buf.String())
+	}
+
+	t.Setenv("VIBE_LOG_CONSOLE", "off")
+	config = DefaultConfig()
+	if err := config.LoadFromEnvironment(); err != nil || config.ConsoleOutput != ConsoleOff {
+		t.Errorf("expected VIBE_LOG_CONSOLE to select the echo, got %q (%v)", config.ConsoleOutput, err)
+	}
+	hardened := HardenedConfig(t.TempDir())
+	hardened.ConsoleOutput = ConsoleStderr
+	if err := hardened.Validate(); err == nil {
+		t.Error("expected hardened mode to reject an echo to stderr")
+	}
+}
@@ -0,0 +1,135 @@
+package vibelogger
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// DebugWindow describes a temporary change of the filter settings
+type DebugWindow struct {
+	Level      LogLevel // Minimum level while the window is open; DEBUG when empty
+	SampleRate float64  // Fraction of DEBUG and INFO entries kept while open; 0 keeps all
+}
+
+// debugWindows tracks the open windows of a logger. Overlapping windows are
+// combined: the lowest level and the highest sample rate win.
+type debugWindows struct {
+	mutex  sync.Mutex
+	next   int
+	active map[int]DebugWindow
+}
+
+// open registers a window and returns its ID
+func (d *debugWindows) open(window DebugWindow) int {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if d.active == nil {
+		d.active = make(map[int]DebugWindow)
+	}
+	d.next++
+	d.active[d.next] = window
+	return d.next
+}
+
+// close removes a window
+func (d *debugWindows) close(id int) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	delete(d.active, id)
+}
+
+// apply lowers level and raises rate according to the open windows
+func (d *debugWindows) apply(level LogLevel, rate float64) (LogLevel, float64) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	for _, window := range d.active {
+		windowLevel := window.Level
+		if windowLevel == "" {
+			windowLevel = DEBUG
+		}
+		// An empty level already keeps everything
+		if level != "" && getSeverityScore(windowLevel) < getSeverityScore(level) {
+			level = windowLevel
+		}
+		if windowRate := sampleRate(window.SampleRate); windowRate > rate {
+			rate = windowRate
+		}
+	}
+	return level, rate
+}
+
+// sampleRate maps a configured rate to the fraction of entries kept
+func sampleRate(rate float64) float64 {
+	if rate <= 0 || rate >= 1 {
+		return 1
+	}
+	return rate
+}
+
+// shouldLog applies the effective minimum level and sample rate to an entry of
+// the given level
+func shouldLog(level LogLevel, settings EffectiveSettings) bool {
+	if settings.MinLevel != "" && getSeverityScore(level) < getSeverityScore(settings.MinLevel) {
+		return false
+	}
+	// WARN and ERROR are never sampled out
+	if settings.SampleRate < 1 && getSeverityScore(level) <= getSeverityScore(INFO) && rand.Float64() >= settings.SampleRate {
+		return false
+	}
+	return true
+}
+
+// OpenDebugWindow lowers the effective level and raises sampling as described
+// by window until ctx is done or the returned stop function is called, then
+// reverts automatically. Stop may be called more than once. Opening and closing
+// are logged under the debug_window operation.
+func (l *Logger) OpenDebugWindow(ctx context.Context, window DebugWindow) (stop func()) {
+	id := l.windows.open(window)
+	fields := map[string]interface{}{
+		"level":       window.Level,
+		"sample_rate": sampleRate(window.SampleRate),
+	}
+	if window.Level == "" {
+		fields["level"] = DEBUG
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		fields["until"] = deadline.UTC()
+	}
+	l.Info("debug_window", "Debug window opened", WithFields(fields))
+
+	stopped := make(chan struct{})
+	var once sync.Once
+	stop = func() {
+		once.Do(func() {
+			close(stopped)
+			l.Info("debug_window", "Debug window closed", WithFields(fields))
+			l.windows.close(id)
+		})
+	}
+	go func() {
+		select {
+		case <-ctx.Done():
+			stop()
+		case <-stopped:
+		}
+	}()
+	return stop
+}
+
+// EnableDebugFor logs every level without sampling for d. The returned function
+// ends the window early.
+func (l *Logger) EnableDebugFor(d time.Duration) (stop func()) {
+	ctx, cancel := context.WithTimeout(context.Background(), d)
+	stopWindow := l.OpenDebugWindow(ctx, DebugWindow{})
+	return func() {
+		stopWindow()
+		cancel()
+	}
+}
+
+// EnableDebugUntil logs every level without sampling until ctx is done
+func (l *Logger) EnableDebugUntil(ctx context.Context) (stop func()) {
+	return l.OpenDebugWindow(ctx, DebugWindow{})
+}
@@ -0,0 +1,128 @@
+package vibelogger
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+)
+
+// countOperation counts memory log entries with the given operation
+func countOperation(logger *Logger, operation string) int {
+	count := 0
+	for _, entry := range logger.GetMemoryLogs() {
+		if entry.Operation == operation {
+			count++
+		}
+	}
+	return count
+}
+
+func TestMinLevelAndSampling(t *testing.T) {
+	defer os.RemoveAll("test_logs")
+
+	config := DefaultConfig()
+	config.FilePath = "test_logs/min_level_test.log"
+	config.EnableMemoryLog = true
+	config.MinLevel = "warn"
+
+	logger, err := CreateFileLoggerWithConfig("min_level_test", config)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	defer logger.Close()
+
+	logger.Debug("filtered", "Dropped")
+	logger.Info("filtered", "Dropped")
+	logger.Warn("filtered", "Kept")
+	logger.Error("filtered", "Kept")
+	if got := countOperation(logger, "filtered"); got != 2 {
+		t.Errorf("Expected 2 entries at WARN and above, got %d", got)
+	}
+
+	// Sampling never drops WARN and ERROR
+	sampled := *config
+	sampled.FilePath = "" // Keep the file; test_logs is outside logs/
+	sampled.MinLevel = ""
+	sampled.SampleRate = 0.000001
+	if err := logger.UpdateConfig(&sampled); err != nil {
+		t.Fatalf("Failed to update config: %v", err)
+	}
+	for i := 0; i < 100; i++ {
+		logger.Info("sampled", "Almost always dropped")
+		logger.Error("sampled", "Always kept")
+	}
+	if got := countOperation(logger, "sampled"); got < 100 || got > 101 {
+		t.Errorf("Expected the 100 errors to be kept and nearly all infos dropped, got %d", got)
+	}
+
+	normalized := DefaultConfig()
+	normalized.MinLevel = "warn"
+	if err := normalized.Validate(); err != nil || normalized.MinLevel != "WARN" {
+		t.Errorf("Expected MinLevel to be normalized to WARN, got %s (%v)", normalized.MinLevel, err)
+	}
+	invalid := DefaultConfig()
+	invalid.MinLevel = "verbose"
+	if err := invalid.Validate(); err == nil {
+		t.Error("Expected an error for an unknown level")
+	}
+	invalid = DefaultConfig()
+	invalid.SampleRate = 1.5
+	if err := invalid.Validate(); err == nil {
+		t.Error("Expected an error for a sample rate above 1")
+	}
+}
+
+func TestDebugWindow(t *testing.T) {
+	defer os.RemoveAll("test_logs")
+
+	config := DefaultConfig()
+	config.FilePath = "test_logs/debug_window_test.log"
+	config.EnableMemoryLog = true
+	config.MinLevel = "ERROR"
+	config.SampleRate = 0.000001
+
+	logger, err := CreateFileLoggerWithConfig("debug_window_test", config)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	defer logger.Close()
+
+	stop := logger.EnableDebugFor(time.Hour)
+	logger.Debug("window", "Kept while the window is open")
+	stop()
+	stop() // Idempotent
+	logger.Debug("window", "Dropped after the window")
+	if got := countOperation(logger, "window"); got != 1 {
+		t.Errorf("Expected 1 debug entry, got %d", got)
+	}
+	if got := countOperation(logger, "debug_window"); got != 2 {
+		t.Errorf("Expected open and close entries, got %d", got)
+	}
+
+	// A cancelled context closes the window
+	ctx, cancel := context.WithCancel(context.Background())
+	logger.OpenDebugWindow(ctx, DebugWindow{Level: WARN})
+	logger.Warn("ctx_window", "Kept")
+	logger.Debug("ctx_window", "Below the window level")
+	cancel()
+	deadline := time.Now().Add(2 * time.Second)
+	for countOperation(logger, "debug_window") < 4 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	logger.Warn("ctx_window", "Dropped after cancellation")
+	if got := countOperation(logger, "ctx_window"); got != 1 {
+		t.Errorf("Expected 1 entry inside the WARN window, got %d", got)
+	}
+
+	// Overlapping windows combine to the widest settings
+	first := logger.OpenDebugWindow(context.Background(), DebugWindow{Level: WARN})
+	second := logger.OpenDebugWindow(context.Background(), DebugWindow{Level: INFO})
+	second()
+	logger.Warn("overlap", "Kept by the remaining window")
+	logger.Info("overlap", "Dropped once the INFO window closed")
+	first()
+	if got := countOperation(logger, "overlap"); got != 1 {
+		t.Errorf("Expected 1 entry from overlapping windows, got %d", got)
+	}
+}
@@ -0,0 +1,94 @@
+package vibelogger
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Configuration sources reported by EffectiveConfig
+const (
+	ConfigSourceDefault = "default" // Value of DefaultConfig or filled in by Validate
+	ConfigSourceEnv     = "env"     // Loaded by LoadFromEnvironment
+	ConfigSourceFlag    = "flag"    // Parsed from a flag registered by BindFlags
+	ConfigSourceCode    = "code"    // Set directly by the application
+)
+
+// ConfigSetting is the resolved value of one LoggerConfig field and where it came from
+type ConfigSetting struct {
+	Field  string      `json:"field"`
+	Key    string      `json:"key"` // JSON name of the field
+	Value  interface{} `json:"value"`
+	Source string      `json:"source"`
+	Origin string      `json:"origin,omitempty"` // Environment variable or flag name
+}
+
+// Config returns a copy of the logger's current configuration.
+// Modifying it has no effect on the logger; use UpdateConfig instead.
+func (l *Logger) Config() LoggerConfig {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.config.clone()
+}
+
+// EffectiveConfig returns every configuration field with its resolved value and
+// provenance, for exposing the logging configuration in diagnostics endpoints
+func (l *Logger) EffectiveConfig() []ConfigSetting {
+	config := l.Config()
+	return config.EffectiveSettings()
+}
+
+// EffectiveSettings returns every field of c with its value and provenance.
+// Fields not set from the environment or flags are reported as default when
+// they equal DefaultConfig and as code otherwise.
+func (c *LoggerConfig) EffectiveSettings() []ConfigSetting {
+	defaults := reflect.ValueOf(DefaultConfig()).Elem()
+	current := reflect.ValueOf(c).Elem()
+	t := current.Type()
+
+	var settings []ConfigSetting
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		setting := ConfigSetting{
+			Field: sf.Name,
+			Key:   strings.Split(sf.Tag.Get("json"), ",")[0],
+			Value: current.Field(i).Interface(),
+		}
+		if origin, ok := c.sources[sf.Name]; ok {
+			setting.Origin = origin
+			setting.Source = ConfigSourceEnv
+			if strings.HasPrefix(origin, "--") {
+				setting.Source = ConfigSourceFlag
+			}
+		} else if reflect.DeepEqual(setting.Value, defaults.Field(i).Interface()) {
+			setting.Source = ConfigSourceDefault
+		} else {
+			setting.Source = ConfigSourceCode
+		}
+		settings = append(settings, setting)
+	}
+	return settings
+}
+
+// clone returns a copy of c that shares no mutable state with it
+func (c *LoggerConfig) clone() LoggerConfig {
+	copied := *c
+	if c.sources != nil {
+		copied.sources = make(map[string]string, len(c.sources))
+		for field, origin := range c.sources {
+			copied.sources[field] = origin
+		}
+	}
+	return copied
+}
+
+// recordSource remembers the variable or flag a field was last set from
+func (c *LoggerConfig) recordSource(field, origin string) {
+	if c.sources == nil {
+		c.sources = make(map[string]string)
+	}
+	c.sources[field] = origin
+}
@@ -0,0 +1,65 @@
+package vibelogger
+
+import (
+	"flag"
+	"os"
+	"testing"
+)
+
+func TestLoggerConfigReturnsCopy(t *testing.T) {
+	config := DefaultConfig()
+	config.AutoSave = false
+	logger := NewLoggerWithConfig("config_copy_test", config)
+
+	snapshot := logger.Config()
+	snapshot.MaxFileSize = 1
+	if logger.Config().MaxFileSize == 1 {
+		t.Error("Modifying the returned config should not affect the logger")
+	}
+}
+
+func TestEffectiveConfigProvenance(t *testing.T) {
+	os.Setenv("VIBE_LOG_MEMORY_LIMIT", "42")
+	defer os.Unsetenv("VIBE_LOG_MEMORY_LIMIT")
+
+	config, err := NewConfigFromEnvironment()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	config.BindFlags(fs)
+	if err := fs.Parse([]string{"--vibe-log-project-name=svc"}); err != nil {
+		t.Fatalf("Failed to parse flags: %v", err)
+	}
+	config.AutoSave = false
+
+	logger := NewLoggerWithConfig("effective_config_test", config)
+	settings := make(map[string]ConfigSetting)
+	for _, setting := range logger.EffectiveConfig() {
+		settings[setting.Field] = setting
+	}
+
+	tests := []struct {
+		field, source, origin string
+		value                 interface{}
+	}{
+		{"MemoryLogLimit", ConfigSourceEnv, "VIBE_LOG_MEMORY_LIMIT", 42},
+		{"ProjectName", ConfigSourceFlag, "--vibe-log-project-name", "svc"},
+		{"AutoSave", ConfigSourceCode, "", false},
+		{"MaxFileSize", ConfigSourceDefault, "", int64(10 * 1024 * 1024)},
+	}
+	for _, tt := range tests {
+		setting, ok := settings[tt.field]
+		if !ok {
+			t.Errorf("Missing setting %s", tt.field)
+			continue
+		}
+		if setting.Source != tt.source || setting.Origin != tt.origin || setting.Value != tt.value {
+			t.Errorf("%s: expected %v from %s (%s), got %v from %s (%s)", tt.field,
+				tt.value, tt.source, tt.origin, setting.Value, setting.Source, setting.Origin)
+		}
+	}
+	if settings["MemoryLogLimit"].Key != "memory_log_limit" {
+		t.Errorf("Expected JSON key memory_log_limit, got %s", settings["MemoryLogLimit"].Key)
+	}
+}
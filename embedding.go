@@ -0,0 +1,271 @@
+package vibelogger
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strings"
+)
+
+// EmbeddingDocument is the flattened text of an entry or error group, ready
+// to be fed to an embedding model
+type EmbeddingDocument struct {
+	ID       string            `json:"id"`
+	Text     string            `json:"text"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// Embedder turns texts into vectors, e.g. by calling an embedding API. It must
+// return one vector per text, in order.
+type Embedder interface {
+	Embed(texts []string) ([][]float32, error)
+}
+
+// EntryDocuments returns one document per entry. The text combines the fields
+// that carry meaning; identifiers and timestamps go to the metadata.
+func EntryDocuments(entries []LogEntry) []EmbeddingDocument {
+	docs := make([]EmbeddingDocument, 0, len(entries))
+	for i := range entries {
+		entry := &entries[i]
+		id := entry.ID
+		if id == "" {
+			id = fmt.Sprintf("entry-%d", i)
+		}
+		docs = append(docs, EmbeddingDocument{
+			ID:       id,
+			Text:     entryDocumentText(entry),
+			Metadata: entryMetadata(entry),
+		})
+	}
+	return docs
+}
+
+// ErrorGroupDocuments returns one document per ErrorFingerprint among the
+// WARN and ERROR entries, so recurring problems are embedded once
+func ErrorGroupDocuments(entries []LogEntry) []EmbeddingDocument {
+	type group struct {
+		first *LogEntry
+		count int
+		last  *LogEntry
+	}
+	groups := make(map[string]*group)
+	var order []string
+	for i := range entries {
+		entry := &entries[i]
+		if getSeverityScore(entry.Level) < getSeverityScore(WARN) {
+			continue
+		}
+		fingerprint := ErrorFingerprint(entry)
+		g, ok := groups[fingerprint]
+		if !ok {
+			g = &group{first: entry}
+			groups[fingerprint] = g
+			order = append(order, fingerprint)
+		}
+		g.count++
+		g.last = entry
+	}
+
+	docs := make([]EmbeddingDocument, 0, len(order))
+	for _, fingerprint := range order {
+		g := groups[fingerprint]
+		metadata := entryMetadata(g.first)
+		metadata["fingerprint"] = fingerprint
+		metadata["count"] = fmt.Sprint(g.count)
+		metadata["last_seen"] = g.last.Timestamp.UTC().Format("2006-01-02T15:04:05Z")
+		docs = append(docs, EmbeddingDocument{
+			ID:       "group-" + fingerprint,
+			Text:     entryDocumentText(g.first),
+			Metadata: metadata,
+		})
+	}
+	return docs
+}
+
+// entryDocumentText flattens the meaningful parts of an entry into text
+func entryDocumentText(entry *LogEntry) string {
+	parts := []string{
+		fmt.Sprintf("%s %s: %s", entry.Level, entry.Operation, entry.FullMessage()),
+	}
+	for _, field := range []struct{ name, value string }{
+		{"category", entry.Category},
+		{"pattern", entry.Pattern},
+		{"suggestion", entry.Suggestion},
+		{"note", entry.HumanNote},
+		{"todo", entry.AITodo},
+	} {
+		if field.value != "" && field.value != "unknown_pattern" {
+			parts = append(parts, field.name+": "+field.value)
+		}
+	}
+	if context := compactContext(entry.Context); context != "" {
+		parts = append(parts, "context: "+context)
+	}
+	if frame, ok := topAppFrame(entry.StackTrace); ok {
+		parts = append(parts, "at: "+frame.Function)
+	}
+	return strings.Join(parts, "\n")
+}
+
+// entryMetadata returns the identifying fields of an entry
+func entryMetadata(entry *LogEntry) map[string]string {
+	metadata := map[string]string{
+		"level":     string(entry.Level),
+		"operation": entry.Operation,
+		"timestamp": entry.Timestamp.UTC().Format("2006-01-02T15:04:05Z"),
+	}
+	if entry.ID != "" {
+		metadata["entry_id"] = entry.ID
+	}
+	if entry.CorrelationID != "" {
+		metadata["correlation_id"] = entry.CorrelationID
+	}
+	return metadata
+}
+
+// WriteEmbeddingDocuments writes documents as JSON lines for external pipelines
+func WriteEmbeddingDocuments(w io.Writer, docs []EmbeddingDocument) error {
+	encoder := json.NewEncoder(w)
+	for i := range docs {
+		if err := encoder.Encode(&docs[i]); err != nil {
+			return fmt.Errorf("failed to write document %s: %w", docs[i].ID, err)
+		}
+	}
+	return nil
+}
+
+// VectorRecord is a document with its embedding
+type VectorRecord struct {
+	EmbeddingDocument
+	Vector []float32 `json:"vector"`
+}
+
+// VectorIndex holds embedded documents for semantic search
+type VectorIndex struct {
+	Records []VectorRecord
+}
+
+// SearchResult is a document found by VectorIndex.Search
+type SearchResult struct {
+	Document EmbeddingDocument
+	Score    float64 // Cosine similarity, 1 for identical directions
+}
+
+// BuildVectorIndex embeds documents in batches of batchSize (all at once when
+// batchSize is 0 or less)
+func BuildVectorIndex(docs []EmbeddingDocument, embedder Embedder, batchSize int) (*VectorIndex, error) {
+	if batchSize <= 0 {
+		batchSize = len(docs)
+	}
+	index := &VectorIndex{Records: make([]VectorRecord, 0, len(docs))}
+	for start := 0; start < len(docs); start += batchSize {
+		end := start + batchSize
+		if end > len(docs) {
+			end = len(docs)
+		}
+		texts := make([]string, 0, end-start)
+		for _, doc := range docs[start:end] {
+			texts = append(texts, doc.Text)
+		}
+		vectors, err := embedder.Embed(texts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to embed documents: %w", err)
+		}
+		if len(vectors) != len(texts) {
+			return nil, fmt.Errorf("embedder returned %d vectors for %d documents", len(vectors), len(texts))
+		}
+		for i, doc := range docs[start:end] {
+			index.Records = append(index.Records, VectorRecord{EmbeddingDocument: doc, Vector: vectors[i]})
+		}
+	}
+	return index, nil
+}
+
+// Search returns the k documents most similar to vector, best first
+func (idx *VectorIndex) Search(vector []float32, k int) []SearchResult {
+	results := make([]SearchResult, 0, len(idx.Records))
+	for _, record := range idx.Records {
+		if score, ok := cosineSimilarity(vector, record.Vector); ok {
+			results = append(results, SearchResult{Document: record.EmbeddingDocument, Score: score})
+		}
+	}
+	sort.SliceStable(results, func(i, j int) bool { return results[i].Score > results[j].Score })
+	if k > 0 && len(results) > k {
+		results = results[:k]
+	}
+	return results
+}
+
+// SearchText embeds text and returns the k most similar documents
+func (idx *VectorIndex) SearchText(embedder Embedder, text string, k int) ([]SearchResult, error) {
+	vectors, err := embedder.Embed([]string{text})
+	if err != nil {
+		return nil, fmt.Errorf("failed to embed query: %w", err)
+	}
+	if len(vectors) != 1 {
+		return nil, fmt.Errorf("embedder returned %d vectors for 1 query", len(vectors))
+	}
+	return idx.Search(vectors[0], k), nil
+}
+
+// Save writes the index as JSON lines, one VectorRecord per line
+func (idx *VectorIndex) Save(path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create vector index: %w", err)
+	}
+	w := bufio.NewWriter(file)
+	encoder := json.NewEncoder(w)
+	for i := range idx.Records {
+		if err := encoder.Encode(&idx.Records[i]); err != nil {
+			file.Close()
+			return fmt.Errorf("failed to write vector index: %w", err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write vector index: %w", err)
+	}
+	return file.Close()
+}
+
+// LoadVectorIndex reads an index written by Save
+func LoadVectorIndex(path string) (*VectorIndex, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open vector index: %w", err)
+	}
+	defer file.Close()
+
+	index := &VectorIndex{}
+	decoder := json.NewDecoder(bufio.NewReader(file))
+	for decoder.More() {
+		var record VectorRecord
+		if err := decoder.Decode(&record); err != nil {
+			return nil, fmt.Errorf("failed to read vector index: %w", err)
+		}
+		index.Records = append(index.Records, record)
+	}
+	return index, nil
+}
+
+// cosineSimilarity compares vectors of equal, non-zero length
+func cosineSimilarity(a, b []float32) (float64, bool) {
+	if len(a) == 0 || len(a) != len(b) {
+		return 0, false
+	}
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0, false
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB)), true
+}
@@ -0,0 +1,93 @@
+package vibelogger
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// keywordEmbedder embeds texts as counts of a few keywords
+type keywordEmbedder struct {
+	calls int
+}
+
+func (e *keywordEmbedder) Embed(texts []string) ([][]float32, error) {
+	e.calls++
+	vectors := make([][]float32, len(texts))
+	for i, text := range texts {
+		text = strings.ToLower(text)
+		vectors[i] = []float32{
+			float32(strings.Count(text, "database")),
+			float32(strings.Count(text, "login")),
+			float32(strings.Count(text, "cache")) + 0.1,
+		}
+	}
+	return vectors, nil
+}
+
+func embeddingEntries() []LogEntry {
+	now := time.Now()
+	return []LogEntry{
+		{ID: "a", Timestamp: now, Level: ERROR, Operation: "database_query", Message: "database connection refused", Pattern: "database_error"},
+		{ID: "b", Timestamp: now, Level: INFO, Operation: "login", Message: "User login succeeded", CorrelationID: "req-1"},
+		{ID: "c", Timestamp: now, Level: ERROR, Operation: "database_query", Message: "database connection refused again", Pattern: "database_error"},
+	}
+}
+
+func TestEntryDocuments(t *testing.T) {
+	docs := EntryDocuments(embeddingEntries())
+	if len(docs) != 3 || docs[1].ID != "b" || docs[1].Metadata["correlation_id"] != "req-1" {
+		t.Fatalf("unexpected documents: %+v", docs)
+	}
+	if !strings.Contains(docs[0].Text, "pattern: database_error") {
+		t.Errorf("text should include the pattern: %q", docs[0].Text)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteEmbeddingDocuments(&buf, docs); err != nil {
+		t.Fatalf("WriteEmbeddingDocuments failed: %v", err)
+	}
+	var first EmbeddingDocument
+	json.Unmarshal([]byte(strings.SplitN(buf.String(), "\n", 2)[0]), &first)
+	if first.ID != "a" {
+		t.Errorf("unexpected first line: %+v", first)
+	}
+}
+
+func TestErrorGroupDocuments(t *testing.T) {
+	docs := ErrorGroupDocuments(embeddingEntries())
+	if len(docs) != 1 || docs[0].Metadata["count"] != "2" || !strings.HasPrefix(docs[0].ID, "group-") {
+		t.Errorf("unexpected group documents: %+v", docs)
+	}
+}
+
+func TestVectorIndex(t *testing.T) {
+	embedder := &keywordEmbedder{}
+	index, err := BuildVectorIndex(EntryDocuments(embeddingEntries()), embedder, 2)
+	if err != nil {
+		t.Fatalf("BuildVectorIndex failed: %v", err)
+	}
+	if embedder.calls != 2 {
+		t.Errorf("expected 2 batches, got %d", embedder.calls)
+	}
+
+	path := filepath.Join(t.TempDir(), "vectors.jsonl")
+	if err := index.Save(path); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	loaded, err := LoadVectorIndex(path)
+	if err != nil {
+		t.Fatalf("LoadVectorIndex failed: %v", err)
+	}
+
+	results, err := loaded.SearchText(embedder, "why did login fail", 1)
+	if err != nil {
+		t.Fatalf("SearchText failed: %v", err)
+	}
+	if len(results) != 1 || results[0].Document.ID != "b" {
+		t.Errorf("expected the login entry, got %+v", results)
+	}
+}
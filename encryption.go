@@ -0,0 +1,197 @@
+package vibelogger
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// EncryptedPrefix starts every context value encrypted with EncryptValue. The
+// full format is "enc:v1:<key id>:<nonce and AES-GCM ciphertext in base64url>".
+const EncryptedPrefix = "enc:v1:"
+
+// KeysEnv holds the decryption keys of the vibe-log tools, in the format
+// accepted by ParseKeys
+const KeysEnv = "VIBE_LOG_KEYS"
+
+// ErrUnknownKey is returned by key providers for a key ID they do not hold
+var ErrUnknownKey = errors.New("unknown encryption key")
+
+// KeyProvider looks up the AES key (16, 24 or 32 bytes) of a key ID. Key IDs
+// are recorded with every encrypted value, so keys can be rotated by adding
+// a new ID while readers keep the old ones.
+type KeyProvider interface {
+	Key(id string) ([]byte, error)
+}
+
+// StaticKeys is a KeyProvider backed by a fixed set of keys
+type StaticKeys map[string][]byte
+
+// Key returns the key of id, wrapping ErrUnknownKey when there is none
+func (k StaticKeys) Key(id string) ([]byte, error) {
+	key, ok := k[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownKey, id)
+	}
+	return key, nil
+}
+
+// ParseKeys parses comma-separated id=key pairs with base64-encoded keys,
+// e.g. "2024=q83v...,2025=8Xk2..."
+func ParseKeys(s string) (StaticKeys, error) {
+	keys := StaticKeys{}
+	for _, pair := range strings.Split(s, ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+		id, encoded, ok := strings.Cut(pair, "=")
+		if !ok || id == "" {
+			return nil, fmt.Errorf("invalid key %q (must be id=base64)", pair)
+		}
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("invalid key %s: %w", id, err)
+		}
+		if _, err := aes.NewCipher(key); err != nil {
+			return nil, fmt.Errorf("invalid key %s: %w", id, err)
+		}
+		keys[id] = key
+	}
+	return keys, nil
+}
+
+// KeysFromEnv returns the keys set in KeysEnv, nil when it is empty
+func KeysFromEnv() (KeyProvider, error) {
+	value := os.Getenv(KeysEnv)
+	if value == "" {
+		return nil, nil
+	}
+	keys, err := ParseKeys(value)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", KeysEnv, err)
+	}
+	return keys, nil
+}
+
+// EncryptValue encrypts plaintext with the key keyID of provider and returns
+// the value to put in the context of an entry. Readers holding the key restore
+// it with LogEntry.Decrypt; everyone else only sees that the value is
+// encrypted, see LogEntry.EncryptedFields.
+func EncryptValue(provider KeyProvider, keyID, plaintext string) (string, error) {
+	if keyID == "" || strings.Contains(keyID, ":") {
+		return "", fmt.Errorf("invalid key ID %q", keyID)
+	}
+	aead, err := newAEAD(provider, keyID)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	// The key ID is authenticated so a value cannot be moved to another key
+	sealed := aead.Seal(nonce, nonce, []byte(plaintext), []byte(keyID))
+	return EncryptedPrefix + keyID + ":" + base64.RawURLEncoding.EncodeToString(sealed), nil
+}
+
+// decryptValue returns the plaintext of a value written by EncryptValue
+func decryptValue(provider KeyProvider, value string) (string, error) {
+	keyID, encoded, ok := strings.Cut(strings.TrimPrefix(value, EncryptedPrefix), ":")
+	if !ok {
+		return "", fmt.Errorf("malformed encrypted value")
+	}
+	aead, err := newAEAD(provider, keyID)
+	if err != nil {
+		return "", err
+	}
+	sealed, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return "", fmt.Errorf("malformed encrypted value")
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, []byte(keyID))
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt value with key %s: %w", keyID, err)
+	}
+	return string(plaintext), nil
+}
+
+// newAEAD returns the AES-GCM cipher of a key
+func newAEAD(provider KeyProvider, keyID string) (cipher.AEAD, error) {
+	key, err := provider.Key(keyID)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid key %s: %w", keyID, err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// isEncrypted reports whether a context value was written by EncryptValue
+func isEncrypted(value string) bool {
+	return strings.HasPrefix(value, EncryptedPrefix)
+}
+
+// Decrypt replaces the encrypted context values of the entry, including values
+// of nested maps, by their plaintext. Values encrypted with a key the provider
+// does not hold stay encrypted. A value that cannot be decrypted otherwise,
+// e.g. because it was altered, also stays encrypted and the first such error
+// is returned after all values were tried.
+func (e *LogEntry) Decrypt(provider KeyProvider) error {
+	return decryptContext(e.Context, provider)
+}
+
+// decryptContext decrypts the values of context in place
+func decryptContext(context map[string]interface{}, provider KeyProvider) error {
+	var firstErr error
+	for key, value := range context {
+		var err error
+		switch v := value.(type) {
+		case string:
+			if !isEncrypted(v) {
+				continue
+			}
+			var plaintext string
+			if plaintext, err = decryptValue(provider, v); err == nil {
+				context[key] = plaintext
+			} else if errors.Is(err, ErrUnknownKey) {
+				err = nil
+			}
+		case map[string]interface{}:
+			err = decryptContext(v, provider)
+		}
+		if err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("%s: %w", key, err)
+		}
+	}
+	return firstErr
+}
+
+// EncryptedFields returns the context keys whose values are still encrypted,
+// sorted, with nested keys joined by dots. After Decrypt these are the values
+// the key provider could not decrypt.
+func (e *LogEntry) EncryptedFields() []string {
+	return contextFields(e.Context, isEncrypted)
+}
+
+// DecryptEntries decrypts every entry with LogEntry.Decrypt and returns the
+// first error. A nil provider leaves the entries unchanged.
+func DecryptEntries(entries []LogEntry, provider KeyProvider) error {
+	if provider == nil {
+		return nil
+	}
+	var firstErr error
+	for i := range entries {
+		if err := entries[i].Decrypt(provider); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("entry %s: %w", entries[i].ID, err)
+		}
+	}
+	return firstErr
+}
@@ -0,0 +1,95 @@
+package vibelogger
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestEncryptedValueRoundTrip(t *testing.T) {
+	keys := StaticKeys{"2024": bytes.Repeat([]byte{1}, 32), "2025": bytes.Repeat([]byte{2}, 16)}
+	card, err := EncryptValue(keys, "2024", "4111-1111-1111-1111")
+	if err != nil {
+		t.Fatal(err)
+	}
+	email, _ := EncryptValue(keys, "2025", "jane@example.com")
+	if !strings.HasPrefix(card, EncryptedPrefix+"2024:") || strings.Contains(card, "4111") {
+		t.Fatalf("unexpected encrypted value %q", card)
+	}
+
+	entry := LogEntry{Context: map[string]interface{}{
+		"card":  card,
+		"user":  map[string]interface{}{"email": email, "plan": "pro"},
+		"count": 3,
+	}}
+	if fields := entry.EncryptedFields(); !reflect.DeepEqual(fields, []string{"card", "user.email"}) {
+		t.Errorf("unexpected encrypted fields %v", fields)
+	}
+
+	// Values of keys the provider does not hold stay encrypted
+	if err := entry.Decrypt(StaticKeys{"2024": keys["2024"]}); err != nil {
+		t.Fatalf("decrypt failed: %v", err)
+	}
+	if entry.Context["card"] != "4111-1111-1111-1111" {
+		t.Errorf("expected the card to be decrypted, got %v", entry.Context["card"])
+	}
+	if fields := entry.EncryptedFields(); !reflect.DeepEqual(fields, []string{"user.email"}) {
+		t.Errorf("expected only the email to stay encrypted, got %v", fields)
+	}
+
+	entries := []LogEntry{entry}
+	if err := DecryptEntries(entries, keys); err != nil {
+		t.Fatalf("decrypt failed: %v", err)
+	}
+	if email := entries[0].Context["user"].(map[string]interface{})["email"]; email != "jane@example.com" {
+		t.Errorf("expected the nested email to be decrypted, got %v", email)
+	}
+}
+
+func TestDecryptRejectsAlteredValues(t *testing.T) {
+	keys := StaticKeys{"k1": bytes.Repeat([]byte{1}, 32), "k2": bytes.Repeat([]byte{2}, 32)}
+	value, _ := EncryptValue(keys, "k1", "secret")
+
+	// A value moved to another key ID fails authentication
+	moved := strings.Replace(value, ":k1:", ":k2:", 1)
+	entry := LogEntry{Context: map[string]interface{}{"token": moved}}
+	if err := entry.Decrypt(keys); err == nil {
+		t.Error("expected an error for a value moved to another key")
+	}
+	if entry.Context["token"] != moved {
+		t.Error("expected the altered value to stay encrypted")
+	}
+
+	if _, err := EncryptValue(keys, "missing", "secret"); !errors.Is(err, ErrUnknownKey) {
+		t.Errorf("expected ErrUnknownKey, got %v", err)
+	}
+	if _, err := EncryptValue(keys, "k:1", "secret"); err == nil {
+		t.Error("expected key IDs with colons to be rejected")
+	}
+}
+
+func TestParseKeys(t *testing.T) {
+	key := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, 32))
+	keys, err := ParseKeys("2024=" + key + ", ")
+	if err != nil || len(keys["2024"]) != 32 {
+		t.Fatalf("unexpected keys %v (err: %v)", keys, err)
+	}
+
+	for _, invalid := range []string{"2024", "=" + key, "2024=not base64", "2024=" + base64.StdEncoding.EncodeToString([]byte("short"))} {
+		if _, err := ParseKeys(invalid); err == nil {
+			t.Errorf("expected %q to be rejected", invalid)
+		}
+	}
+
+	t.Setenv(KeysEnv, "")
+	if provider, err := KeysFromEnv(); provider != nil || err != nil {
+		t.Errorf("expected no provider without %s, got %v (err: %v)", KeysEnv, provider, err)
+	}
+	t.Setenv(KeysEnv, "2024="+key)
+	if provider, err := KeysFromEnv(); provider == nil || err != nil {
+		t.Errorf("expected a provider from %s (err: %v)", KeysEnv, err)
+	}
+}
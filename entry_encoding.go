@@ -0,0 +1,243 @@
+package vibelogger
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// Limits of the encoded string cache. Only short strings are cached and the
+// cache stops growing when full, so high-cardinality values such as request
+// IDs cannot turn it into a leak; they are encoded as before.
+const (
+	maxCachedStrings   = 4096
+	maxCachedStringLen = 128
+)
+
+// fragmentCache maps strings that repeat across entries (levels, operations,
+// categories, patterns, context keys, static context values and stack frames)
+// to their encoded JSON form, so the hot path appends prepared bytes instead of
+// re-escaping the same strings for every entry
+type fragmentCache struct {
+	mutex     sync.RWMutex
+	fragments map[string][]byte
+}
+
+// encodedStrings is shared by all loggers; the writer shards encode concurrently
+var encodedStrings = &fragmentCache{fragments: make(map[string][]byte)}
+
+// appendString appends s as a JSON string, using the cache for short strings
+func (c *fragmentCache) appendString(buf []byte, s string) []byte {
+	if len(s) > maxCachedStringLen {
+		return appendJSONString(buf, s)
+	}
+	c.mutex.RLock()
+	fragment, ok := c.fragments[s]
+	c.mutex.RUnlock()
+	if ok {
+		return append(buf, fragment...)
+	}
+
+	start := len(buf)
+	buf = appendJSONString(buf, s)
+	c.mutex.Lock()
+	if len(c.fragments) < maxCachedStrings {
+		c.fragments[s] = append([]byte(nil), buf[start:]...)
+	}
+	c.mutex.Unlock()
+	return buf
+}
+
+// appendJSONString appends s encoded exactly as encoding/json encodes strings,
+// including its HTML and invalid UTF-8 escaping
+func appendJSONString(buf []byte, s string) []byte {
+	encoded, _ := json.Marshal(s) // Strings always encode
+	return append(buf, encoded...)
+}
+
+// appendJSONValue appends v as encoding/json would encode it
+func appendJSONValue(buf []byte, v interface{}) ([]byte, error) {
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return append(buf, encoded...), nil
+}
+
+// entryWriter appends the fields of an entry, tracking the separating commas
+type entryWriter struct {
+	buf   []byte
+	first bool
+}
+
+// key appends a field name
+func (w *entryWriter) key(name string) {
+	if !w.first {
+		w.buf = append(w.buf, ',')
+	}
+	w.first = false
+	w.buf = append(w.buf, '"')
+	w.buf = append(w.buf, name...)
+	w.buf = append(w.buf, '"', ':')
+}
+
+// cached appends a string field through the fragment cache
+func (w *entryWriter) cached(name, value string) {
+	w.key(name)
+	w.buf = encodedStrings.appendString(w.buf, value)
+}
+
+// plain appends a string field that rarely repeats, such as a message
+func (w *entryWriter) plain(name, value string) {
+	w.key(name)
+	w.buf = appendJSONString(w.buf, value)
+}
+
+// strings appends a string array, caching its items when they repeat
+func (w *entryWriter) strings(name string, values []string, cache bool) {
+	w.key(name)
+	w.buf = append(w.buf, '[')
+	for i, value := range values {
+		if i > 0 {
+			w.buf = append(w.buf, ',')
+		}
+		if cache {
+			w.buf = encodedStrings.appendString(w.buf, value)
+		} else {
+			w.buf = appendJSONString(w.buf, value)
+		}
+	}
+	w.buf = append(w.buf, ']')
+}
+
+// marshalEntry encodes an entry as compact JSON, producing the same bytes as
+// json.Marshal but reusing the encoded form of repeated strings
+func marshalEntry(entry *LogEntry) ([]byte, error) {
+	w := entryWriter{buf: make([]byte, 0, 512), first: true}
+	w.buf = append(w.buf, '{')
+
+	if entry.ID != "" {
+		w.plain("id", entry.ID)
+	}
+	w.key("timestamp")
+	timestamp, err := entry.Timestamp.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	w.buf = append(w.buf, timestamp...)
+	if entry.EventTime != nil {
+		w.key("event_time")
+		eventTime, err := entry.EventTime.MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		w.buf = append(w.buf, eventTime...)
+	}
+	if entry.Duration != 0 {
+		w.key("duration_ns")
+		w.buf = strconv.AppendInt(w.buf, int64(entry.Duration), 10)
+	}
+	w.cached("level", string(entry.Level))
+	w.cached("operation", entry.Operation)
+	w.plain("message", entry.Message)
+	if len(entry.MessageLines) > 0 {
+		w.strings("message_lines", entry.MessageLines, false)
+	}
+	if len(entry.Context) > 0 {
+		w.key("context")
+		if w.buf, err = appendContext(w.buf, entry.Context); err != nil {
+			return nil, err
+		}
+	}
+	if entry.HumanNote != "" {
+		w.plain("human_note", entry.HumanNote)
+	}
+	if entry.AITodo != "" {
+		w.plain("ai_todo", entry.AITodo)
+	}
+	if len(entry.StackTrace) > 0 {
+		w.strings("stack_trace", entry.StackTrace, true)
+	}
+	if len(entry.Environment) > 0 {
+		w.key("environment")
+		w.buf = appendStringMap(w.buf, entry.Environment)
+	}
+	if entry.CorrelationID != "" {
+		w.plain("correlation_id", entry.CorrelationID)
+	}
+	if entry.SessionID != "" {
+		w.cached("session_id", entry.SessionID)
+	}
+	if len(entry.Tags) > 0 {
+		w.strings("tags", entry.Tags, true)
+	}
+	w.key("severity")
+	w.buf = strconv.AppendInt(w.buf, int64(entry.Severity), 10)
+	if entry.Category != "" {
+		w.cached("category", entry.Category)
+	}
+	if entry.Searchable != "" {
+		w.plain("searchable", entry.Searchable)
+	}
+	if entry.Pattern != "" {
+		w.cached("pattern", entry.Pattern)
+	}
+	if entry.Suggestion != "" {
+		w.cached("suggestion", entry.Suggestion)
+	}
+	if entry.RunbookURL != "" {
+		w.cached("runbook_url", entry.RunbookURL)
+	}
+
+	return append(w.buf, '}'), nil
+}
+
+// appendContext appends a context map with sorted keys like encoding/json.
+// Keys and string values go through the cache; global fields such as the
+// hostname or build version repeat on every entry.
+func appendContext(buf []byte, context map[string]interface{}) ([]byte, error) {
+	keys := make([]string, 0, len(context))
+	for key := range context {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	buf = append(buf, '{')
+	for i, key := range keys {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		buf = encodedStrings.appendString(buf, key)
+		buf = append(buf, ':')
+		if s, ok := context[key].(string); ok {
+			buf = encodedStrings.appendString(buf, s)
+			continue
+		}
+		var err error
+		if buf, err = appendJSONValue(buf, context[key]); err != nil {
+			return nil, err
+		}
+	}
+	return append(buf, '}'), nil
+}
+
+// appendStringMap appends a string map with sorted keys, caching both sides
+func appendStringMap(buf []byte, values map[string]string) []byte {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	buf = append(buf, '{')
+	for i, key := range keys {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		buf = encodedStrings.appendString(buf, key)
+		buf = append(buf, ':')
+		buf = encodedStrings.appendString(buf, values[key])
+	}
+	return append(buf, '}')
+}
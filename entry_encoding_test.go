@@ -0,0 +1,82 @@
+package vibelogger
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMarshalEntryMatchesEncodingJSON(t *testing.T) {
+	eventTime := time.Date(2024, 3, 1, 12, 0, 0, 5, time.FixedZone("JST", 9*3600))
+	entries := []LogEntry{
+		{Timestamp: time.Date(2024, 3, 1, 3, 0, 0, 0, time.UTC), Level: INFO, Operation: "startup", Message: "ready"},
+		{
+			ID:            "01HX",
+			Timestamp:     time.Now(),
+			EventTime:     &eventTime,
+			Duration:      1500 * time.Millisecond,
+			Level:         ERROR,
+			Operation:     "db<query>",
+			Message:       "failed & retried \u2028 \"quoted\"",
+			MessageLines:  []string{"line 2", "\tline 3"},
+			Context:       map[string]interface{}{"b": 1.5, "a": "static", "html": "<b>", "nested": map[string]interface{}{"z": nil, "y": []int{1}}, "ok": true},
+			HumanNote:     "check pool",
+			AITodo:        "suggest a fix",
+			StackTrace:    []string{"main.go:10", "db.go:42"},
+			Environment:   map[string]string{"os": "linux", "arch": "amd64"},
+			CorrelationID: "req-1",
+			SessionID:     "sess-1",
+			Tags:          []string{"db", "timeout"},
+			Severity:      4,
+			Category:      "database",
+			Searchable:    "error db failed",
+			Pattern:       "db_timeout",
+			Suggestion:    "raise the timeout",
+			RunbookURL:    "https://example.com/runbooks/db?a=1&b=2",
+		},
+		{Timestamp: time.Now(), Level: WARN, Operation: strings.Repeat("long", 50), Message: "invalid \xff utf-8", Context: map[string]interface{}{"k": strings.Repeat("v", 200)}},
+	}
+
+	// Encode twice so the second pass is served from the cache
+	for pass := 0; pass < 2; pass++ {
+		for i := range entries {
+			want, err := json.Marshal(entries[i])
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := marshalEntry(&entries[i])
+			if err != nil {
+				t.Fatalf("marshalEntry: %v", err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("pass %d entry %d:\n got %s\nwant %s", pass, i, got, want)
+			}
+
+			wantPretty, _ := json.MarshalIndent(entries[i], "", "  ")
+			gotPretty, err := encodeRecord(&entries[i], FormatPretty, time.Time{}, "stdout")
+			if err != nil || !bytes.Equal(gotPretty, wantPretty) {
+				t.Errorf("pass %d entry %d: pretty output differs (%v)", pass, i, err)
+			}
+		}
+	}
+}
+
+func TestMarshalEntryUnsupportedContext(t *testing.T) {
+	entry := LogEntry{Timestamp: time.Now(), Level: INFO, Context: map[string]interface{}{"ch": make(chan int)}}
+	if _, err := marshalEntry(&entry); err == nil {
+		t.Error("expected an error for an unencodable context value")
+	}
+}
+
+func TestFragmentCacheBounded(t *testing.T) {
+	cache := &fragmentCache{fragments: make(map[string][]byte)}
+	for i := 0; i < maxCachedStrings+100; i++ {
+		cache.appendString(nil, strings.Repeat("x", i%maxCachedStringLen)+string(rune('a'+i%26))+time.Duration(i).String())
+	}
+	cache.appendString(nil, strings.Repeat("y", maxCachedStringLen+1))
+	if len(cache.fragments) > maxCachedStrings {
+		t.Errorf("cache grew to %d entries, limit %d", len(cache.fragments), maxCachedStrings)
+	}
+}
@@ -0,0 +1,78 @@
+package vibelogger
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"sync"
+	"time"
+)
+
+// crockford is the Crockford base32 alphabet used by ULIDs
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// ulidGenerator creates monotonic ULIDs: IDs created within the same millisecond
+// increment the random part, so IDs sort in creation order within a process
+type ulidGenerator struct {
+	mutex   sync.Mutex
+	lastMs  uint64
+	entropy [10]byte
+}
+
+var entryIDs ulidGenerator
+
+// NewEntryID returns a new ULID, the format of LogEntry.ID
+func NewEntryID() string {
+	return entryIDs.next(time.Now())
+}
+
+// next returns the ULID for t
+func (g *ulidGenerator) next(t time.Time) string {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	ms := uint64(t.UnixMilli())
+	if ms < g.lastMs {
+		ms = g.lastMs // Keep IDs ordered if the clock goes backwards
+	}
+	if ms > g.lastMs || !g.increment() {
+		if _, err := rand.Read(g.entropy[:]); err != nil {
+			// Fall back to the clock so IDs stay unique within this process
+			binary.BigEndian.PutUint64(g.entropy[2:], uint64(t.UnixNano()))
+		}
+	}
+	g.lastMs = ms
+
+	var id [16]byte
+	id[0] = byte(ms >> 40)
+	id[1] = byte(ms >> 32)
+	id[2] = byte(ms >> 24)
+	id[3] = byte(ms >> 16)
+	id[4] = byte(ms >> 8)
+	id[5] = byte(ms)
+	copy(id[6:], g.entropy[:])
+	return encodeULID(id)
+}
+
+// increment adds one to the random part and reports false on overflow
+func (g *ulidGenerator) increment() bool {
+	for i := len(g.entropy) - 1; i >= 0; i-- {
+		g.entropy[i]++
+		if g.entropy[i] != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// encodeULID renders 128 bits as 26 base32 characters, most significant first
+func encodeULID(id [16]byte) string {
+	var out [26]byte
+	hi := binary.BigEndian.Uint64(id[:8])
+	lo := binary.BigEndian.Uint64(id[8:])
+	for i := 25; i >= 0; i-- {
+		out[i] = crockford[lo&31]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(out[:])
+}
@@ -0,0 +1,70 @@
+package vibelogger
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEntryIDFormat(t *testing.T) {
+	var g ulidGenerator
+	id := g.next(time.UnixMilli(1469918176385))
+	if len(id) != 26 {
+		t.Fatalf("Expected 26 characters, got %q", id)
+	}
+	if !strings.HasPrefix(id, "01ARYZ6S41") {
+		t.Errorf("Expected the timestamp to encode as 01ARYZ6S41, got %s", id)
+	}
+	for _, c := range id {
+		if !strings.ContainsRune(crockford, c) {
+			t.Fatalf("Unexpected character %q in %s", c, id)
+		}
+	}
+}
+
+func TestEntryIDsAreMonotonic(t *testing.T) {
+	var g ulidGenerator
+	now := time.Now()
+	previous := g.next(now)
+	for i := 0; i < 1000; i++ {
+		// Same millisecond, later millisecond and a clock going backwards
+		at := now
+		switch i % 3 {
+		case 1:
+			at = now.Add(time.Duration(i) * time.Millisecond)
+		case 2:
+			at = now.Add(-time.Second)
+		}
+		id := g.next(at)
+		if id <= previous {
+			t.Fatalf("Expected %s to sort after %s", id, previous)
+		}
+		previous = id
+	}
+}
+
+func TestLogAssignsEntryIDs(t *testing.T) {
+	config := &LoggerConfig{
+		AutoSave:        false,
+		EnableMemoryLog: true,
+		MemoryLogLimit:  10,
+	}
+	logger := NewLoggerWithConfig("entry_id_test", config)
+
+	logger.Info("test", "First")
+	logger.Info("test", "Second")
+	logger.Info("test", "Replayed", func(entry *LogEntry) { entry.ID = "01ARYZ6S41TSV4RRFFQ69G5FAV" })
+
+	logs := logger.GetMemoryLogs()
+	if logs[0].ID == "" || logs[0].ID == logs[1].ID {
+		t.Errorf("Expected distinct entry IDs, got %q and %q", logs[0].ID, logs[1].ID)
+	}
+	if logs[2].ID != "01ARYZ6S41TSV4RRFFQ69G5FAV" {
+		t.Errorf("Expected a given ID to be kept, got %s", logs[2].ID)
+	}
+
+	query := Query{ID: logs[1].ID}
+	if matched := query.Filter(logs); len(matched) != 1 || matched[0].Message != "Second" {
+		t.Errorf("Expected the query to find the entry by ID, got %v", matched)
+	}
+}
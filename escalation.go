@@ -0,0 +1,125 @@
+package vibelogger
+
+import (
+	"fmt"
+	"path"
+	"sync"
+	"time"
+)
+
+// EscalationRule raises the level of entries that repeat too often, e.g.
+// WARN → ERROR when more than 20 auth_error entries arrive within 5 minutes.
+// Empty match fields match any entry.
+type EscalationRule struct {
+	Pattern   string        // Detected pattern such as "auth_error"
+	Operation string        // Operation name or path.Match pattern
+	Category  string        // Entry category
+	Tag       string        // Tag the entry must carry, see WithTags
+	From      LogLevel      // Level of the entries that are counted
+	To        LogLevel      // Level matching entries are raised to
+	Count     int           // Entries allowed within Window before escalating
+	Window    time.Duration // Period over which entries are counted
+}
+
+// matches reports whether the rule applies to the entry
+func (r *EscalationRule) matches(entry *LogEntry) bool {
+	if entry.Level != r.From {
+		return false
+	}
+	if r.Pattern != "" && entry.Pattern != r.Pattern {
+		return false
+	}
+	if r.Category != "" && entry.Category != r.Category {
+		return false
+	}
+	if r.Tag != "" && !entry.HasTag(r.Tag) {
+		return false
+	}
+	if r.Operation != "" {
+		if ok, _ := path.Match(r.Operation, entry.Operation); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// escalationState counts matching entries per rule in a sliding window
+type escalationState struct {
+	mutex sync.Mutex
+	rules []EscalationRule
+	seen  [][]time.Time
+}
+
+// SetEscalationRules replaces the escalation rules of the logger. Rules are
+// checked in order and the first rule that escalates an entry wins. Calling it
+// without rules disables escalation.
+func (l *Logger) SetEscalationRules(rules ...EscalationRule) error {
+	for i, rule := range rules {
+		if getSeverityScore(rule.To) <= getSeverityScore(rule.From) {
+			return fmt.Errorf("escalation rule %d: %s is not above %s", i, rule.To, rule.From)
+		}
+		if rule.Count < 0 || rule.Window <= 0 {
+			return fmt.Errorf("escalation rule %d: count must not be negative and window must be positive", i)
+		}
+		if _, err := path.Match(rule.Operation, ""); err != nil {
+			return fmt.Errorf("escalation rule %d: invalid operation pattern %q: %w", i, rule.Operation, err)
+		}
+	}
+
+	var state *escalationState
+	if len(rules) > 0 {
+		state = &escalationState{
+			rules: append([]EscalationRule(nil), rules...),
+			seen:  make([][]time.Time, len(rules)),
+		}
+	}
+	l.mutex.Lock()
+	l.escalation = state
+	l.mutex.Unlock()
+	return nil
+}
+
+// escalate raises the entry's level when a rule's threshold is exceeded and
+// records the original level and the count in the escalation context field
+func (l *Logger) escalate(entry *LogEntry) bool {
+	l.mutex.Lock()
+	state := l.escalation
+	l.mutex.Unlock()
+	if state == nil {
+		return false
+	}
+
+	state.mutex.Lock()
+	defer state.mutex.Unlock()
+
+	for i := range state.rules {
+		rule := &state.rules[i]
+		if !rule.matches(entry) {
+			continue
+		}
+
+		// Drop timestamps that fell out of the window
+		cutoff := entry.Timestamp.Add(-rule.Window)
+		seen := state.seen[i]
+		for len(seen) > 0 && !seen[0].After(cutoff) {
+			seen = seen[1:]
+		}
+		seen = append(seen, entry.Timestamp)
+		state.seen[i] = seen
+
+		if len(seen) <= rule.Count {
+			continue
+		}
+		if entry.Context == nil {
+			entry.Context = make(map[string]interface{})
+		}
+		entry.Context["escalation"] = map[string]interface{}{
+			"escalated_from": string(entry.Level),
+			"count":          len(seen),
+			"window":         rule.Window.String(),
+		}
+		entry.Level = rule.To
+		return true
+	}
+	return false
+}
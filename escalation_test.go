@@ -0,0 +1,105 @@
+package vibelogger
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEscalationRule(t *testing.T) {
+	logger := NewLoggerWithConfig("escalation", &LoggerConfig{AutoSave: false, EnableMemoryLog: true, MemoryLogLimit: 10})
+	defer logger.Close()
+
+	err := logger.SetEscalationRules(EscalationRule{
+		Pattern: "auth_error",
+		From:    WARN,
+		To:      ERROR,
+		Count:   2,
+		Window:  time.Minute,
+	})
+	if err != nil {
+		t.Fatalf("SetEscalationRules failed: %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		logger.Warn("login", "Unauthorized request")
+	}
+	logger.Warn("db_query", "Slow response")
+	logger.Info("login", "Unauthorized request")
+
+	logs := logger.GetMemoryLogs()
+	if len(logs) != 5 {
+		t.Fatalf("expected 5 entries, got %d", len(logs))
+	}
+	for i, entry := range logs[:2] {
+		if entry.Level != WARN {
+			t.Errorf("entry %d should stay WARN, got %s", i, entry.Level)
+		}
+	}
+
+	escalated := logs[2]
+	if escalated.Level != ERROR || escalated.Severity != getSeverityScore(ERROR) {
+		t.Errorf("third entry should be escalated to ERROR, got %s (severity %d)", escalated.Level, escalated.Severity)
+	}
+	info, ok := escalated.Context["escalation"].(map[string]interface{})
+	if !ok || info["escalated_from"] != "WARN" || info["count"] != 3 {
+		t.Errorf("unexpected escalation context: %v", escalated.Context["escalation"])
+	}
+	if len(escalated.StackTrace) == 0 {
+		t.Error("escalated ERROR entry should carry a stack trace")
+	}
+
+	if logs[3].Level != WARN || logs[4].Level != INFO {
+		t.Errorf("non-matching entries should keep their level, got %s and %s", logs[3].Level, logs[4].Level)
+	}
+}
+
+func TestEscalationWindow(t *testing.T) {
+	logger := NewLoggerWithConfig("escalation", &LoggerConfig{AutoSave: false, EnableMemoryLog: true, MemoryLogLimit: 10})
+	defer logger.Close()
+
+	logger.SetEscalationRules(EscalationRule{Operation: "job_*", From: WARN, To: ERROR, Count: 1, Window: time.Minute})
+
+	now := time.Now()
+	old := LogEntry{Timestamp: now.Add(-2 * time.Minute), Level: WARN, Operation: "job_run"}
+	if logger.escalate(&old) {
+		t.Fatal("first entry should not escalate")
+	}
+	recent := LogEntry{Timestamp: now, Level: WARN, Operation: "job_run"}
+	if logger.escalate(&recent) {
+		t.Error("entries outside the window should not be counted")
+	}
+	again := LogEntry{Timestamp: now.Add(time.Second), Level: WARN, Operation: "job_run"}
+	if !logger.escalate(&again) || again.Level != ERROR {
+		t.Error("second entry within the window should escalate")
+	}
+}
+
+func TestEscalationRuleTag(t *testing.T) {
+	logger := NewLoggerWithConfig("escalation", &LoggerConfig{AutoSave: false, EnableMemoryLog: true, MemoryLogLimit: 10})
+	defer logger.Close()
+
+	logger.SetEscalationRules(EscalationRule{Tag: "billing", From: WARN, To: ERROR, Count: 0, Window: time.Minute})
+
+	logger.Warn("charge", "Retrying charge", WithTags("billing"))
+	logger.Warn("search", "Slow index")
+	logs := logger.GetMemoryLogs()
+	if logs[0].Level != ERROR || logs[1].Level != WARN {
+		t.Errorf("Expected only the tagged entry to escalate, got %s and %s", logs[0].Level, logs[1].Level)
+	}
+}
+
+func TestSetEscalationRulesInvalid(t *testing.T) {
+	logger := NewLoggerWithConfig("escalation", &LoggerConfig{AutoSave: false})
+	defer logger.Close()
+
+	invalid := []EscalationRule{
+		{From: ERROR, To: WARN, Count: 1, Window: time.Minute},
+		{From: WARN, To: ERROR, Count: 1},
+		{Operation: "[", From: WARN, To: ERROR, Count: 1, Window: time.Minute},
+	}
+	for i, rule := range invalid {
+		if err := logger.SetEscalationRules(rule); err == nil {
+			t.Errorf("rule %d: expected error", i)
+		}
+	}
+}
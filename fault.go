@@ -0,0 +1,142 @@
+package vibelogger
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// ErrInjectedFault is wrapped by the errors of failures injected by
+// LoggerConfig.FaultInjection, next to the simulated system error
+var ErrInjectedFault = errors.New("injected fault")
+
+// FaultInjection describes the failures injected into a file logger to test
+// how an application, and the logger's own recovery, cope with logging
+// misbehaving. It is meant for tests and chaos experiments, never production.
+type FaultInjection struct {
+	WriteErrorRate  float64       // Fraction of file writes failing with EIO
+	RotationDelay   time.Duration // Delay added to every rotation, blocking writers meanwhile
+	DiskFullAfter   int64         // Bytes written before every further write fails with ENOSPC (0 = never)
+	DeleteFileAfter int64         // Entries written before the current file is deleted from disk once (0 = never)
+}
+
+// ParseFaultInjection parses faults such as
+// "write_error_rate=0.1,rotation_delay=200ms,disk_full_after=1048576,delete_file_after=100"
+func ParseFaultInjection(s string) (FaultInjection, error) {
+	var faults FaultInjection
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(part, "=")
+		name, value = strings.TrimSpace(name), strings.TrimSpace(value)
+		if !ok || value == "" {
+			return faults, fmt.Errorf("invalid fault %q (must be name=value)", part)
+		}
+		var err error
+		switch name {
+		case "write_error_rate":
+			faults.WriteErrorRate, err = strconv.ParseFloat(value, 64)
+			if err == nil && (faults.WriteErrorRate < 0 || faults.WriteErrorRate > 1) {
+				err = fmt.Errorf("must be between 0 and 1")
+			}
+		case "rotation_delay":
+			faults.RotationDelay, err = time.ParseDuration(value)
+			if err == nil && faults.RotationDelay < 0 {
+				err = fmt.Errorf("cannot be negative")
+			}
+		case "disk_full_after":
+			faults.DiskFullAfter, err = parseFaultCount(value)
+		case "delete_file_after":
+			faults.DeleteFileAfter, err = parseFaultCount(value)
+		default:
+			return faults, fmt.Errorf("unknown fault: %s", name)
+		}
+		if err != nil {
+			return faults, fmt.Errorf("invalid %s %q: %w", name, value, err)
+		}
+	}
+	return faults, nil
+}
+
+// parseFaultCount parses a non-negative number of bytes or entries
+func parseFaultCount(value string) (int64, error) {
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err == nil && n < 0 {
+		err = fmt.Errorf("cannot be negative")
+	}
+	return n, err
+}
+
+// faultInjector applies a FaultInjection to the writes of a logger
+type faultInjector struct {
+	faults  FaultInjection
+	mutex   sync.Mutex
+	written int64 // Bytes written to the log files so far
+	entries int64 // Entries written so far
+	deleted bool  // DeleteFileAfter was applied
+}
+
+// newFaultInjector creates the injector of an already validated
+// LoggerConfig.FaultInjection, nil when no fault is configured
+func newFaultInjector(s string) *faultInjector {
+	faults, _ := ParseFaultInjection(s)
+	if faults == (FaultInjection{}) {
+		return nil
+	}
+	return &faultInjector{faults: faults}
+}
+
+// beforeWrite returns the failure injected into a write of size bytes to
+// path, deleting path first once DeleteFileAfter entries were written
+func (f *faultInjector) beforeWrite(path string, size int64) error {
+	if f == nil {
+		return nil
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if f.faults.DeleteFileAfter > 0 && !f.deleted && f.entries >= f.faults.DeleteFileAfter {
+		f.deleted = true
+		os.Remove(path)
+	}
+	if f.faults.DiskFullAfter > 0 && f.written+size > f.faults.DiskFullAfter {
+		return fmt.Errorf("%w: %w", ErrInjectedFault, &os.PathError{Op: "write", Path: path, Err: syscall.ENOSPC})
+	}
+	if f.faults.WriteErrorRate > 0 && rand.Float64() < f.faults.WriteErrorRate {
+		return fmt.Errorf("%w: %w", ErrInjectedFault, &os.PathError{Op: "write", Path: path, Err: syscall.EIO})
+	}
+	f.written += size
+	f.entries++
+	return nil
+}
+
+// delayRotation sleeps for RotationDelay
+func (f *faultInjector) delayRotation() {
+	if f != nil && f.faults.RotationDelay > 0 {
+		time.Sleep(f.faults.RotationDelay)
+	}
+}
+
+// enableFaultInjection starts injecting the faults of LoggerConfig.FaultInjection,
+// announcing them first so a forgotten setting does not go unnoticed
+func (l *Logger) enableFaultInjection() {
+	faults := newFaultInjector(l.config.FaultInjection)
+	if faults == nil {
+		return
+	}
+	l.Warn("fault_injection", "Fault injection is enabled; log writes will fail on purpose",
+		WithContext(map[string]interface{}{"faults": l.config.FaultInjection}))
+	l.Flush()
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.faults = faults
+}
@@ -0,0 +1,120 @@
+package vibelogger
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// newFaultLogger creates a file logger injecting faults in a temporary directory
+func newFaultLogger(t *testing.T, faults string, maxFileSize int64) *Logger {
+	t.Helper()
+	config := DefaultConfig()
+	config.FilePath = filepath.Join(t.TempDir(), "app.log")
+	config.FaultInjection = faults
+	config.MaxFileSize = maxFileSize
+	logger, err := CreateFileLoggerWithConfig("fault", config)
+	if err != nil {
+		t.Fatalf("failed to create logger: %v", err)
+	}
+	t.Cleanup(func() { logger.Close() })
+	return logger
+}
+
+func TestParseFaultInjection(t *testing.T) {
+	faults, err := ParseFaultInjection("write_error_rate=0.25, rotation_delay=200ms,disk_full_after=1024,delete_file_after=10")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := FaultInjection{WriteErrorRate: 0.25, RotationDelay: 200 * time.Millisecond, DiskFullAfter: 1024, DeleteFileAfter: 10}
+	if faults != expected {
+		t.Errorf("expected %+v, got %+v", expected, faults)
+	}
+
+	for _, invalid := range []string{"write_error_rate=2", "rotation_delay=soon", "disk_full_after=-1", "melt_cpu=1", "delete_file_after"} {
+		if _, err := ParseFaultInjection(invalid); err == nil {
+			t.Errorf("expected an error for %q", invalid)
+		}
+	}
+	config := DefaultConfig()
+	config.FaultInjection = "write_error_rate=2"
+	if err := config.Validate(); err == nil {
+		t.Error("expected the config to be rejected")
+	}
+}
+
+func TestFaultInjectionWriteErrors(t *testing.T) {
+	logger := newFaultLogger(t, "write_error_rate=1", 0)
+	err := logger.Info("checkout", "Order placed")
+	if !errors.Is(err, ErrInjectedFault) || !errors.Is(err, syscall.EIO) {
+		t.Fatalf("expected an injected EIO, got %v", err)
+	}
+
+	// The announcement is written before faults are injected
+	logger.Close()
+	result, err := ReadLogFile(logger.GetFilePath())
+	if err != nil {
+		t.Fatalf("failed to read log: %v", err)
+	}
+	if len(result.Entries) != 1 || result.Entries[0].Operation != "fault_injection" {
+		t.Errorf("expected only the fault injection warning, got %+v", result.Entries)
+	}
+}
+
+func TestFaultInjectionDiskFull(t *testing.T) {
+	logger := newFaultLogger(t, "disk_full_after=4096", 0)
+	var written int
+	var err error
+	for written = 0; written < 100; written++ {
+		if err = logger.Info("checkout", "Order placed"); err != nil {
+			break
+		}
+	}
+	if written == 0 || written == 100 || !errors.Is(err, syscall.ENOSPC) {
+		t.Fatalf("expected ENOSPC after some writes, got %v after %d", err, written)
+	}
+	if err := logger.Info("checkout", "Order placed"); !errors.Is(err, syscall.ENOSPC) {
+		t.Errorf("expected the disk to stay full, got %v", err)
+	}
+}
+
+func TestFaultInjectionDeletedFileRecovery(t *testing.T) {
+	logger := newFaultLogger(t, "delete_file_after=1,rotation_delay=20ms", 4096)
+	logger.Info("checkout", "Order placed")
+	logger.Info("checkout", "Order placed")
+	if _, err := os.Stat(logger.GetFilePath()); !os.IsNotExist(err) {
+		t.Fatalf("expected the log file to be deleted, got %v", err)
+	}
+
+	// Rotation starts a new file instead of failing on the missing one
+	message := strings.Repeat("x", 500)
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		if err := logger.Info("checkout", message); err != nil {
+			t.Fatalf("write %d failed: %v", i, err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+		t.Errorf("expected the rotation to be delayed, took %v", elapsed)
+	}
+	rotated := logger.GetRotatedFiles()
+	logger.Close()
+
+	var recovered bool
+	for _, path := range append(rotated, logger.GetFilePath()) {
+		result, err := ReadLogFile(path)
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", path, err)
+		}
+		for _, entry := range result.Entries {
+			recovered = recovered || entry.Operation == "rotation_recovery"
+		}
+	}
+	if !recovered {
+		t.Error("expected a rotation_recovery warning after the rotation")
+	}
+}
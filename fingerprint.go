@@ -0,0 +1,26 @@
+package vibelogger
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// ErrorFingerprint groups entries describing the same problem. It combines the
+// operation, the detected pattern and the function of the top application
+// frame, so the same error logged with different messages or context shares a
+// fingerprint. An explicit "fingerprint" context value takes precedence.
+func ErrorFingerprint(entry *LogEntry) string {
+	if fingerprint, ok := entry.Context["fingerprint"].(string); ok && fingerprint != "" {
+		return fingerprint
+	}
+
+	h := sha256.New()
+	h.Write([]byte(entry.Operation))
+	h.Write([]byte{0})
+	h.Write([]byte(entry.Pattern))
+	if frame, ok := topAppFrame(entry.StackTrace); ok {
+		h.Write([]byte{0})
+		h.Write([]byte(frame.Function))
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
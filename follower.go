@@ -0,0 +1,277 @@
+package vibelogger
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultFollowInterval is how often a ProjectFollower polls for new entries
+const DefaultFollowInterval = 500 * time.Millisecond
+
+// FollowOptions configures a ProjectFollower
+type FollowOptions struct {
+	Interval      time.Duration // Polling interval (0 = DefaultFollowInterval)
+	FromStart     bool          // Also stream the entries already in the files when following starts
+	ReorderWindow time.Duration // Entries are held this long so late writes of other loggers can be ordered before them (0 = order per poll only)
+	ReadOptions   []ReadOption  // Options for decoding the entries, such as WithMaxRecordSize
+}
+
+// ProjectFollower streams the entries of all loggers of a project directory
+// as they are written, merged into one feed ordered by timestamp. Files
+// created after following started are picked up, and rotated files are
+// recognized by their identity so renaming never replays entries. Corrupt
+// records are skipped; a record still being written is read once complete.
+type ProjectFollower struct {
+	dir     string
+	options FollowOptions
+	readOpt readOptions
+	entries chan LogEntry
+	files   []*followedFile
+	pending []LogEntry // Entries held back by the reorder window, ordered by time
+	stop    chan struct{}
+	done    chan struct{}
+
+	mutex sync.Mutex
+	err   error
+}
+
+// followedFile is the read position in a log file
+type followedFile struct {
+	path   string
+	info   os.FileInfo // Identity of the file, kept across renames
+	offset int64
+	header *FileHeader
+}
+
+// FollowProject follows logs/{project}/. The directory does not need to exist
+// yet; it is picked up once the first logger of the project writes.
+func FollowProject(project string, options FollowOptions) (*ProjectFollower, error) {
+	if !isValidProjectName(project) {
+		return nil, fmt.Errorf("invalid project name %q", project)
+	}
+	return FollowDir(filepath.Join("logs", project), options)
+}
+
+// FollowDir follows every log file in dir, see ProjectFollower. Call Close
+// to stop following; the channel returned by Entries is closed then.
+func FollowDir(dir string, options FollowOptions) (*ProjectFollower, error) {
+	readOpt, err := newReadOptions(options.ReadOptions)
+	if err != nil {
+		return nil, err
+	}
+	if options.Interval <= 0 {
+		options.Interval = DefaultFollowInterval
+	}
+
+	f := &ProjectFollower{
+		dir:     dir,
+		options: options,
+		readOpt: readOpt,
+		entries: make(chan LogEntry, 256),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	// Files existing now are the starting point; their entries are only replayed with FromStart
+	f.scan(!options.FromStart)
+	go f.run()
+	return f, nil
+}
+
+// Entries returns the merged feed of new entries
+func (f *ProjectFollower) Entries() <-chan LogEntry {
+	return f.entries
+}
+
+// Err returns the error of the last poll, such as an unreadable directory,
+// nil once polling succeeds again
+func (f *ProjectFollower) Err() error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.err
+}
+
+// Close stops following and closes the entries channel. Entries still held
+// by the reorder window are dropped.
+func (f *ProjectFollower) Close() error {
+	select {
+	case <-f.stop:
+	default:
+		close(f.stop)
+	}
+	<-f.done
+	return nil
+}
+
+// run polls the directory until Close is called
+func (f *ProjectFollower) run() {
+	defer close(f.done)
+	defer close(f.entries)
+	ticker := time.NewTicker(f.options.Interval)
+	defer ticker.Stop()
+
+	for {
+		if !f.emit() {
+			return
+		}
+		select {
+		case <-f.stop:
+			return
+		case <-ticker.C:
+			f.scan(false)
+		}
+	}
+}
+
+// emit sends the entries that left the reorder window, reporting false once
+// the follower was closed
+func (f *ProjectFollower) emit() bool {
+	cutoff := time.Now().Add(-f.options.ReorderWindow)
+	n := 0
+	for n < len(f.pending) && (f.options.ReorderWindow <= 0 || !f.pending[n].Timestamp.After(cutoff)) {
+		select {
+		case f.entries <- f.pending[n]:
+			n++
+		case <-f.stop:
+			return false
+		}
+	}
+	f.pending = append(f.pending[:0], f.pending[n:]...)
+	return true
+}
+
+// scan reads what was appended to the log files of the directory since the
+// previous scan. With skip, new files are positioned at their end instead.
+func (f *ProjectFollower) scan(skip bool) {
+	dirEntries, err := os.ReadDir(f.dir)
+	if os.IsNotExist(err) {
+		err = nil // The project has not written logs yet
+	}
+	f.mutex.Lock()
+	f.err = err
+	f.mutex.Unlock()
+	if err != nil {
+		return
+	}
+
+	var files []*followedFile
+	var added []LogEntry
+	for _, d := range dirEntries {
+		if !isLogFileName(d) {
+			continue
+		}
+		path := filepath.Join(f.dir, d.Name())
+		info, err := os.Stat(path)
+		if err != nil {
+			continue // Removed or rotated away since ReadDir
+		}
+
+		file := f.tracked(info)
+		if file == nil {
+			file = &followedFile{info: info}
+			if skip {
+				file.header = f.readHeader(path)
+				file.offset = info.Size()
+			}
+		}
+		file.path = path
+		file.info = info
+		if info.Size() < file.offset {
+			// Truncated in place, start over
+			file.offset = 0
+			file.header = nil
+		}
+		if info.Size() > file.offset {
+			added = f.read(file, info.Size(), added)
+		}
+		files = append(files, file)
+	}
+	// Files that disappeared are forgotten; a file rotated away reappears under its new name
+	f.files = files
+
+	f.pending = append(f.pending, added...)
+	sort.SliceStable(f.pending, func(i, j int) bool {
+		return f.pending[i].Timestamp.Before(f.pending[j].Timestamp)
+	})
+}
+
+// tracked returns the followed file with the identity of info, nil if the file is new
+func (f *ProjectFollower) tracked(info os.FileInfo) *followedFile {
+	for _, file := range f.files {
+		if os.SameFile(file.info, info) {
+			return file
+		}
+	}
+	return nil
+}
+
+// read appends the complete entries between the file's offset and size to
+// entries and advances the offset past them
+func (f *ProjectFollower) read(file *followedFile, size int64, entries []LogEntry) []LogEntry {
+	fh, err := os.Open(file.path)
+	if err != nil {
+		return entries
+	}
+	defer fh.Close()
+	if _, err := fh.Seek(file.offset, io.SeekStart); err != nil {
+		return entries
+	}
+
+	scanner := newRecordScanner(io.LimitReader(fh, size-file.offset))
+	scanner.maxRecord = f.readOpt.maxRecordSize
+	scanner.maxNesting = f.readOpt.maxNesting
+	consumed := size - file.offset
+	for scanner.Next() {
+		rec := scanner.Record()
+		if rec.reason != "" {
+			continue
+		}
+		if !rec.complete {
+			// Only the last record can still be in the middle of being written
+			consumed = rec.offset
+			continue
+		}
+		consumed = size - file.offset
+
+		var entry LogEntry
+		header, footer, err := decodeRecord(rec.data, &f.readOpt, &entry)
+		switch {
+		case err != nil, footer != nil:
+		case header != nil:
+			file.header = header
+		default:
+			if file.header != nil && file.header.Environment != nil {
+				entry.Environment = applyEnvironment(file.header.Environment, entry.Environment)
+			}
+			entries = append(entries, entry)
+		}
+	}
+	file.offset += consumed
+	return entries
+}
+
+// readHeader returns the header record at the start of a log file, nil if it
+// has none
+func (f *ProjectFollower) readHeader(path string) *FileHeader {
+	fh, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer fh.Close()
+
+	scanner := newRecordScanner(fh)
+	if !scanner.Next() {
+		return nil
+	}
+	rec := scanner.Record()
+	if !rec.complete {
+		return nil
+	}
+	var entry LogEntry
+	header, _, _ := decodeRecord(rec.data, &f.readOpt, &entry)
+	return header
+}
@@ -0,0 +1,146 @@
+package vibelogger
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+// appendLog appends raw data to a log file
+func appendLog(t *testing.T, path, data string) {
+	t.Helper()
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	if _, err := file.WriteString(data); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// followedEntry formats an entry written at the given second offset
+func followedEntry(operation string, second int) string {
+	ts := time.Date(2024, 1, 1, 0, 0, second, 0, time.UTC).Format(time.RFC3339)
+	return fmt.Sprintf(`{"timestamp": %q, "level": "INFO", "operation": %q, "message": "m", "severity": 2}`+"\n", ts, operation)
+}
+
+// receive collects the operations of n entries from the follower
+func receive(t *testing.T, f *ProjectFollower, n int) []string {
+	t.Helper()
+	var operations []string
+	timeout := time.After(5 * time.Second)
+	for len(operations) < n {
+		select {
+		case entry := <-f.Entries():
+			operations = append(operations, entry.Operation)
+		case <-timeout:
+			t.Fatalf("timed out after %v", operations)
+		}
+	}
+	return operations
+}
+
+func TestProjectFollower(t *testing.T) {
+	dir := t.TempDir()
+	app := filepath.Join(dir, "app.log")
+	appendLog(t, app, `{"record_type": "header", "schema_version": 2, "environment": {"os": "linux"}}`+"\n"+followedEntry("old", 0))
+
+	f, err := FollowDir(dir, FollowOptions{Interval: 10 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	// Entries of all loggers are followed; ordering across polls is covered by the reorder window test
+	appendLog(t, app, followedEntry("a2", 2)+followedEntry("a4", 4))
+	appendLog(t, filepath.Join(dir, "worker.log"), followedEntry("w3", 3)+followedEntry("w1", 1))
+	got := receive(t, f, 4)
+	sort.Strings(got)
+	if strings.Join(got, ",") != "a2,a4,w1,w3" {
+		t.Errorf("expected the new entries of both files without replaying existing ones, got %v", got)
+	}
+
+	// A record is only read once it is complete
+	entry := followedEntry("partial", 5)
+	appendLog(t, app, entry[:20])
+	time.Sleep(50 * time.Millisecond)
+	appendLog(t, app, entry[20:])
+
+	// Rotation renames the file and the logger continues in a new one
+	if err := os.Rename(app, app+".20240101_000006"); err != nil {
+		t.Fatal(err)
+	}
+	appendLog(t, app, followedEntry("rotated", 7))
+	got = receive(t, f, 2)
+	if strings.Join(got, ",") != "partial,rotated" {
+		t.Errorf("expected the completed and the new entry once, got %v", got)
+	}
+
+	select {
+	case entry := <-f.Entries():
+		t.Errorf("unexpected entry %s", entry.Operation)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	f.Close()
+	if _, ok := <-f.Entries(); ok {
+		t.Error("Close should close the entries channel")
+	}
+}
+
+func TestProjectFollowerFromStart(t *testing.T) {
+	dir := t.TempDir()
+	appendLog(t, filepath.Join(dir, "app.log"), `{"record_type": "header", "schema_version": 2, "environment": {"os": "linux"}}`+"\n"+followedEntry("a1", 1))
+	appendLog(t, filepath.Join(dir, "worker.log"), followedEntry("w0", 0))
+
+	f, err := FollowDir(dir, FollowOptions{Interval: 10 * time.Millisecond, FromStart: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	first := <-f.Entries()
+	second := <-f.Entries()
+	if first.Operation != "w0" || second.Operation != "a1" {
+		t.Errorf("expected existing entries in time order, got %s, %s", first.Operation, second.Operation)
+	}
+	if second.Environment["os"] != "linux" {
+		t.Errorf("expected the header environment to be applied, got %v", second.Environment)
+	}
+}
+
+func TestProjectFollowerReorderWindow(t *testing.T) {
+	dir := t.TempDir()
+	f, err := FollowDir(dir, FollowOptions{Interval: 10 * time.Millisecond, ReorderWindow: 200 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	now := time.Now().UTC()
+	line := func(operation string, ts time.Time) string {
+		return fmt.Sprintf(`{"timestamp": %q, "level": "INFO", "operation": %q, "message": "m", "severity": 2}`+"\n", ts.Format(time.RFC3339Nano), operation)
+	}
+	appendLog(t, filepath.Join(dir, "app.log"), line("late", now.Add(-50*time.Millisecond)))
+	time.Sleep(50 * time.Millisecond)
+	// Written after "late" by another logger, but stamped before it
+	appendLog(t, filepath.Join(dir, "worker.log"), line("early", now.Add(-100*time.Millisecond)))
+
+	if got := strings.Join(receive(t, f, 2), ","); got != "early,late" {
+		t.Errorf("expected the window to order late writes, got %s", got)
+	}
+}
+
+func TestFollowProjectValidation(t *testing.T) {
+	if _, err := FollowProject("../etc", FollowOptions{}); err == nil {
+		t.Error("expected an invalid project name to be rejected")
+	}
+	if _, err := FollowDir(t.TempDir(), FollowOptions{ReadOptions: []ReadOption{WithNumberMode("bogus")}}); err == nil {
+		t.Error("expected invalid read options to be rejected")
+	}
+}
@@ -0,0 +1,222 @@
+package vibelogger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Output formats for file output
+const (
+	FormatPretty  = "pretty"  // Indented JSON, one record spanning several lines (default)
+	FormatDocker  = "docker"  // Docker json-file lines wrapping the compact entry
+	FormatCompact = "compact" // Single-line JSON, one record per line
+	FormatJSONL   = "jsonl"   // JSON Lines, the same encoding as compact
+)
+
+// dockerLine mirrors a line written by Docker's json-file logging driver
+type dockerLine struct {
+	Log    string `json:"log"`
+	Stream string `json:"stream"`
+	Time   string `json:"time"`
+}
+
+// isValidOutputFormat checks if the output format is supported
+func isValidOutputFormat(format string) bool {
+	return format == FormatPretty || format == FormatDocker || format == FormatCompact || format == FormatJSONL
+}
+
+// levelFormats maps levels to the output format overriding LoggerConfig.OutputFormat
+type levelFormats map[LogLevel]string
+
+// ParseLevelFormats parses per-level output formats such as
+// "debug=compact,info=compact,error=pretty". Levels are case-insensitive.
+func ParseLevelFormats(s string) (map[LogLevel]string, error) {
+	formats := make(map[LogLevel]string)
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, format, ok := strings.Cut(part, "=")
+		level, format := LogLevel(strings.ToUpper(strings.TrimSpace(name))), strings.TrimSpace(format)
+		if !ok || level == "" || format == "" {
+			return nil, fmt.Errorf("invalid level format %q (must be level=format)", part)
+		}
+		if !containsLevel([]LogLevel{DEBUG, INFO, WARN, ERROR}, level) {
+			return nil, fmt.Errorf("unknown level: %s", name)
+		}
+		if !isValidOutputFormat(format) {
+			return nil, fmt.Errorf("unsupported output format for %s: %s", level, format)
+		}
+		if _, dup := formats[level]; dup {
+			return nil, fmt.Errorf("duplicate format for level %s", level)
+		}
+		formats[level] = format
+	}
+	return formats, nil
+}
+
+// newLevelFormats builds the lookup from an already validated LoggerConfig.LevelFormats
+func newLevelFormats(s string) levelFormats {
+	formats, _ := ParseLevelFormats(s)
+	if len(formats) == 0 {
+		return nil
+	}
+	return formats
+}
+
+// format returns the output format of level, falling back to the default
+func (f levelFormats) format(level LogLevel, fallback string) string {
+	if format, ok := f[level]; ok {
+		return format
+	}
+	return fallback
+}
+
+// Formatter encodes the entries of a Logger, see SetFormatter
+type Formatter interface {
+	// Format returns the record of an entry without a trailing newline. It is
+	// called concurrently when WriterShards is set and must not modify entry.
+	Format(entry *LogEntry) ([]byte, error)
+}
+
+// FormatterFunc adapts a function to the Formatter interface
+type FormatterFunc func(entry *LogEntry) ([]byte, error)
+
+// Format calls f(entry)
+func (f FormatterFunc) Format(entry *LogEntry) ([]byte, error) {
+	return f(entry)
+}
+
+// SetFormatter replaces the built-in JSON encoding of entries in the log file,
+// or on stdout in stdout mode, with a custom one such as logfmt or templated
+// text; nil restores the built-in encoding. OutputFormat and LevelFormats are
+// ignored while a formatter is set. The file header and footer keep their JSON
+// encoding, and ReadLogFile only reads entries in the built-in formats. Sinks,
+// subscriptions and the memory log receive entries, not records, and are not
+// affected.
+func (l *Logger) SetFormatter(formatter Formatter) {
+	if formatter == nil {
+		l.formatter.Store(nil)
+		return
+	}
+	l.formatter.Store(&formatter)
+}
+
+// customFormatter returns the formatter set by SetFormatter, nil without one
+func (l *Logger) customFormatter() Formatter {
+	if f := l.formatter.Load(); f != nil {
+		return *f
+	}
+	return nil
+}
+
+// encodeFileEntry serializes an entry for the log file. When the file header
+// records the environment, the entry only carries the fields differing from it;
+// without a header it carries the full environment.
+func (l *Logger) encodeFileEntry(entry *LogEntry) ([]byte, error) {
+	if base := l.headerEnv.Load(); base != nil && l.config.EnvironmentDiff {
+		diffed := *entry
+		diffed.Environment = diffEnvironment(*base, entry.Environment)
+		entry = &diffed
+	}
+	if formatter := l.customFormatter(); formatter != nil {
+		return formatter.Format(entry)
+	}
+	data, err := encodeEntry(entry, l.levelFormats.format(entry.Level, l.config.OutputFormat))
+	if err == nil && l.config.EscapeNonASCII {
+		data = escapeNonASCII(data)
+	}
+	return data, err
+}
+
+// diffEnvironment returns the fields of env differing from base, nil when
+// there are none. Fields missing from env are recorded with an empty value.
+func diffEnvironment(base, env map[string]string) map[string]string {
+	var diff map[string]string
+	for key, value := range env {
+		if baseValue, ok := base[key]; !ok || baseValue != value {
+			if diff == nil {
+				diff = make(map[string]string)
+			}
+			diff[key] = value
+		}
+	}
+	for key := range base {
+		if _, ok := env[key]; !ok {
+			if diff == nil {
+				diff = make(map[string]string)
+			}
+			diff[key] = ""
+		}
+	}
+	return diff
+}
+
+// applyEnvironment reconstitutes the full environment of an entry from the
+// header baseline and the changed fields recorded with the entry
+func applyEnvironment(base, diff map[string]string) map[string]string {
+	env := make(map[string]string, len(base)+len(diff))
+	for key, value := range base {
+		env[key] = value
+	}
+	for key, value := range diff {
+		if value == "" {
+			delete(env, key)
+		} else {
+			env[key] = value
+		}
+	}
+	return env
+}
+
+// encodeEntry serializes a log entry in the given output format, without trailing newline
+func encodeEntry(entry *LogEntry, format string) ([]byte, error) {
+	stream := "stdout"
+	if entry.Level == ERROR {
+		stream = "stderr"
+	}
+	return encodeRecord(entry, format, entry.Timestamp, stream)
+}
+
+// encodeRecord serializes any record (entry, header or footer) in the given output format
+func encodeRecord(record interface{}, format string, timestamp time.Time, stream string) ([]byte, error) {
+	switch format {
+	case FormatDocker:
+		compact, err := marshalRecord(record)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(dockerLine{
+			Log:    string(compact) + "\n",
+			Stream: stream,
+			Time:   timestamp.UTC().Format(time.RFC3339Nano),
+		})
+	case FormatCompact, FormatJSONL:
+		return marshalRecord(record)
+	case FormatPretty, "":
+		compact, err := marshalRecord(record)
+		if err != nil {
+			return nil, err
+		}
+		var indented bytes.Buffer
+		if err := json.Indent(&indented, compact, "", "  "); err != nil {
+			return nil, err
+		}
+		return indented.Bytes(), nil
+	default:
+		return nil, fmt.Errorf("unsupported output format: %s", format)
+	}
+}
+
+// marshalRecord encodes a record as compact JSON, using the cached entry
+// encoder for log entries
+func marshalRecord(record interface{}) ([]byte, error) {
+	if entry, ok := record.(*LogEntry); ok {
+		return marshalEntry(entry)
+	}
+	return json.Marshal(record)
+}
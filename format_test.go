@@ -0,0 +1,237 @@
+package vibelogger
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestDockerOutputFormat(t *testing.T) {
+	defer os.RemoveAll("test_logs")
+
+	config := DefaultConfig()
+	config.FilePath = "test_logs/docker_format_test.log"
+	config.OutputFormat = FormatDocker
+
+	logger, err := CreateFileLoggerWithConfig("docker_format_test", config)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	logger.Info("first_op", "Info entry")
+	logger.Error("second_op", "Error entry")
+	logger.Close()
+
+	file, err := os.Open(config.FilePath)
+	if err != nil {
+		t.Fatalf("Failed to open log file: %v", err)
+	}
+	defer file.Close()
+
+	// Every line must follow Docker's json-file schema
+	var lines []dockerLine
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 1024*1024), 1024*1024)
+	for scanner.Scan() {
+		var line dockerLine
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			t.Fatalf("Line is not valid JSON: %v", err)
+		}
+		if _, err := time.Parse(time.RFC3339Nano, line.Time); err != nil {
+			t.Errorf("Invalid time field %q: %v", line.Time, err)
+		}
+		if line.Log == "" || line.Log[len(line.Log)-1] != '\n' {
+			t.Errorf("Expected log field to end with a newline, got %q", line.Log)
+		}
+		lines = append(lines, line)
+	}
+
+	// Header, two entries and footer
+	if len(lines) != 4 {
+		t.Fatalf("Expected 4 lines, got %d", len(lines))
+	}
+	if lines[1].Stream != "stdout" || lines[2].Stream != "stderr" {
+		t.Errorf("Expected INFO on stdout and ERROR on stderr, got %q and %q", lines[1].Stream, lines[2].Stream)
+	}
+
+	var entry LogEntry
+	if err := json.Unmarshal([]byte(lines[1].Log), &entry); err != nil {
+		t.Fatalf("Wrapped entry is not valid JSON: %v", err)
+	}
+	if entry.Operation != "first_op" {
+		t.Errorf("Expected wrapped entry operation 'first_op', got %q", entry.Operation)
+	}
+
+	// The reader unwraps Docker lines transparently
+	result, err := ReadLogFile(config.FilePath)
+	if err != nil {
+		t.Fatalf("Failed to read log file: %v", err)
+	}
+	if len(result.Entries) != 2 || !result.CleanShutdown() {
+		t.Errorf("Expected 2 entries and a clean shutdown, got %d entries, clean=%v",
+			len(result.Entries), result.CleanShutdown())
+	}
+}
+
+func TestJSONLOutputFormat(t *testing.T) {
+	defer os.RemoveAll("test_logs")
+
+	config := DefaultConfig()
+	config.FilePath = "test_logs/jsonl_format_test.log"
+	config.OutputFormat = FormatJSONL
+
+	logger, err := CreateFileLoggerWithConfig("jsonl_format_test", config)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	logger.Info("first_op", "Info entry", WithContext(map[string]interface{}{"nested": map[string]interface{}{"key": "value"}}))
+	logger.Error("second_op", "Error entry")
+	logger.Close()
+
+	data, err := os.ReadFile(config.FilePath)
+	if err != nil {
+		t.Fatalf("Failed to read log file: %v", err)
+	}
+
+	// Header, two entries and footer, each a JSON object on its own line
+	lines := bytes.Split(bytes.TrimSuffix(data, []byte("\n")), []byte("\n"))
+	if len(lines) != 4 {
+		t.Fatalf("Expected 4 lines, got %d", len(lines))
+	}
+	for i, line := range lines {
+		var record map[string]interface{}
+		if err := json.Unmarshal(line, &record); err != nil {
+			t.Errorf("Line %d is not a JSON object: %v", i+1, err)
+		}
+	}
+
+	result, err := ReadLogFile(config.FilePath)
+	if err != nil {
+		t.Fatalf("Failed to read log file: %v", err)
+	}
+	if len(result.Entries) != 2 || result.Entries[0].Operation != "first_op" {
+		t.Errorf("Expected 2 entries, got %d", len(result.Entries))
+	}
+}
+
+// logfmtFormatter writes entries as logfmt lines
+var logfmtFormatter = FormatterFunc(func(entry *LogEntry) ([]byte, error) {
+	return []byte(fmt.Sprintf("level=%s op=%s msg=%q", entry.Level, entry.Operation, entry.Message)), nil
+})
+
+func TestSetFormatter(t *testing.T) {
+	defer os.RemoveAll("test_logs")
+
+	config := DefaultConfig()
+	config.FilePath = "test_logs/formatter_test.log"
+	config.WriteFileMarkers = false
+
+	logger, err := CreateFileLoggerWithConfig("formatter_test", config)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	logger.SetFormatter(logfmtFormatter)
+	logger.Info("checkout", "Order placed")
+	logger.SetFormatter(nil)
+	logger.Info("checkout", "Back to JSON")
+	logger.Close()
+
+	data, err := os.ReadFile(config.FilePath)
+	if err != nil {
+		t.Fatalf("Failed to read log file: %v", err)
+	}
+	first, rest, _ := bytes.Cut(data, []byte("\n"))
+	if string(first) != `level=INFO op=checkout msg="Order placed"` {
+		t.Errorf("Expected a logfmt line, got %q", first)
+	}
+	if !bytes.HasPrefix(rest, []byte("{\n")) {
+		t.Errorf("Expected the built-in encoding after removing the formatter, got %q", rest)
+	}
+
+	// Stdout mode uses the formatter as well
+	config = DefaultConfig()
+	config.Mode = ModeStdout
+	stdoutLogger, err := CreateFileLoggerWithConfig("formatter_stdout_test", config)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	defer stdoutLogger.Close()
+	var buf bytes.Buffer
+	stdoutLogger.stdout = &buf
+	stdoutLogger.SetFormatter(logfmtFormatter)
+	stdoutLogger.Warn("payment", "Retrying")
+	if buf.String() != "level=WARN op=payment msg=\"Retrying\"\n" {
+		t.Errorf("Expected a logfmt line on stdout, got %q", buf.String())
+	}
+}
+
+func TestInvalidOutputFormat(t *testing.T) {
+	config := DefaultConfig()
+	config.OutputFormat = "xml"
+	if err := config.Validate(); err == nil {
+		t.Error("Expected validation to fail for unsupported output format")
+	}
+}
+
+func TestLevelFormats(t *testing.T) {
+	defer os.RemoveAll("test_logs")
+
+	config := DefaultConfig()
+	config.FilePath = "test_logs/level_format_test.log"
+	config.WriteFileMarkers = false
+	config.LevelFormats = "debug=compact, info=compact,ERROR=pretty"
+
+	logger, err := CreateFileLoggerWithConfig("level_format_test", config)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	logger.Info("info_op", "Info entry")
+	logger.Error("error_op", "Error entry")
+	logger.Close()
+
+	data, err := os.ReadFile(config.FilePath)
+	if err != nil {
+		t.Fatalf("Failed to read log file: %v", err)
+	}
+
+	// The INFO entry takes one line, the ERROR entry is indented over several
+	lines := bytes.Split(bytes.TrimSpace(data), []byte("\n"))
+	var first LogEntry
+	if err := json.Unmarshal(lines[0], &first); err != nil || first.Operation != "info_op" {
+		t.Fatalf("Expected the first line to be the compact INFO entry, got %q (%v)", lines[0], err)
+	}
+	if len(lines) < 4 || string(lines[1]) != "{" {
+		t.Errorf("Expected the ERROR entry to be pretty printed, got %q", lines[1:])
+	}
+
+	// Mixed encodings in one file read back normally
+	result, err := ReadLogFile(config.FilePath)
+	if err != nil {
+		t.Fatalf("Failed to read log file: %v", err)
+	}
+	if len(result.Entries) != 2 || len(result.Corrupt) != 0 {
+		t.Errorf("Expected 2 entries and no corrupt records, got %d and %d", len(result.Entries), len(result.Corrupt))
+	}
+}
+
+func TestParseLevelFormats(t *testing.T) {
+	formats, err := ParseLevelFormats("warn=docker")
+	if err != nil || formats[WARN] != FormatDocker {
+		t.Errorf("Expected warn=docker, got %v (%v)", formats, err)
+	}
+
+	for _, invalid := range []string{"info", "trace=compact", "info=xml", "info=compact,INFO=pretty"} {
+		if _, err := ParseLevelFormats(invalid); err == nil {
+			t.Errorf("Expected %q to be rejected", invalid)
+		}
+	}
+
+	config := DefaultConfig()
+	config.LevelFormats = "error=yaml"
+	if err := config.Validate(); err == nil {
+		t.Error("Expected validation to fail for unsupported level format")
+	}
+}
@@ -0,0 +1,98 @@
+package vibelogger
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// GoroutineBinding holds the IDs applied to entries logged on a goroutine
+// without WithCorrelationID or WithSessionID, see BindGoroutine
+type GoroutineBinding struct {
+	CorrelationID string
+	SessionID     string
+}
+
+// goroutineBindings maps goroutine IDs to their *boundGoroutine
+var goroutineBindings sync.Map
+
+// activeBindings counts bound goroutines, so logging skips the goroutine ID
+// lookup while nothing is bound
+var activeBindings atomic.Int64
+
+// boundGoroutine is a binding and the one it shadows
+type boundGoroutine struct {
+	binding GoroutineBinding
+	prev    *boundGoroutine
+}
+
+// BindGoroutine applies the IDs of binding to every entry the calling
+// goroutine logs until unbind is called, so deeply nested code logs with the
+// right IDs even when no context is passed down legacy call chains. Options on
+// the entry take precedence. Bindings nest: unbind restores the binding that
+// was active before. Goroutines started while bound do not inherit the
+// binding; start them with GoBound.
+//
+// Call unbind on the same goroutine, typically deferred; a goroutine that ends
+// while bound keeps its binding in memory.
+func BindGoroutine(binding GoroutineBinding) (unbind func()) {
+	id := currentGoroutineID()
+	var prev *boundGoroutine
+	if current, ok := goroutineBindings.Load(id); ok {
+		prev = current.(*boundGoroutine)
+	} else {
+		activeBindings.Add(1)
+	}
+	goroutineBindings.Store(id, &boundGoroutine{binding: binding, prev: prev})
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			if prev != nil {
+				goroutineBindings.Store(id, prev)
+				return
+			}
+			goroutineBindings.Delete(id)
+			activeBindings.Add(-1)
+		})
+	}
+}
+
+// BindGoroutineFromContext binds the correlation ID stored in ctx, see
+// ContextWithCorrelationID, keeping the session ID of the current binding
+func BindGoroutineFromContext(ctx context.Context) (unbind func()) {
+	binding, _ := CurrentGoroutineBinding()
+	binding.CorrelationID = CorrelationIDFromContext(ctx)
+	return BindGoroutine(binding)
+}
+
+// CurrentGoroutineBinding returns the binding of the calling goroutine, if any
+func CurrentGoroutineBinding() (GoroutineBinding, bool) {
+	if activeBindings.Load() == 0 {
+		return GoroutineBinding{}, false
+	}
+	current, ok := goroutineBindings.Load(currentGoroutineID())
+	if !ok {
+		return GoroutineBinding{}, false
+	}
+	return current.(*boundGoroutine).binding, true
+}
+
+// GoBound runs fn on a new goroutine bound to the caller's binding, if any
+func GoBound(fn func()) {
+	binding, bound := CurrentGoroutineBinding()
+	go func() {
+		if bound {
+			defer BindGoroutine(binding)()
+		}
+		fn()
+	}()
+}
+
+// applyGoroutineBinding sets the bound IDs on an entry before its options run
+func applyGoroutineBinding(entry *LogEntry) {
+	if binding, ok := CurrentGoroutineBinding(); ok {
+		entry.CorrelationID = binding.CorrelationID
+		entry.SessionID = binding.SessionID
+	}
+}
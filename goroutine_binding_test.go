@@ -0,0 +1,68 @@
+package vibelogger
+
+import (
+	"context"
+	"sync"
+	"testing"
+)
+
+// legacyHandler stands for code deep in a call chain without a context
+func legacyHandler(logger *Logger) {
+	logger.Info("legacy", "Handled")
+}
+
+func TestBindGoroutine(t *testing.T) {
+	logger := NewLoggerWithConfig("binding", &LoggerConfig{AutoSave: false, EnableMemoryLog: true})
+	defer logger.Close()
+
+	unbind := BindGoroutine(GoroutineBinding{CorrelationID: "req-1", SessionID: "task-7"})
+	legacyHandler(logger)
+	logger.Info("explicit", "Handled", WithCorrelationID("req-2"))
+
+	inner := BindGoroutineFromContext(ContextWithCorrelationID(context.Background(), "req-3"))
+	legacyHandler(logger)
+	inner()
+	inner() // Calling unbind again has no effect
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		legacyHandler(logger) // Not bound
+	}()
+	GoBound(func() {
+		defer wg.Done()
+		logger.Info("child", "Handled")
+	})
+	wg.Wait()
+
+	unbind()
+	legacyHandler(logger)
+
+	logs := logger.GetMemoryLogs()
+	if len(logs) != 6 {
+		t.Fatalf("expected 6 entries, got %d", len(logs))
+	}
+	expected := map[int][2]string{
+		0: {"req-1", "task-7"},
+		1: {"req-2", "task-7"},
+		2: {"req-3", "task-7"},
+		5: {"", ""},
+	}
+	for i, ids := range expected {
+		if logs[i].CorrelationID != ids[0] || logs[i].SessionID != ids[1] {
+			t.Errorf("entry %d: got %q %q, want %q %q", i, logs[i].CorrelationID, logs[i].SessionID, ids[0], ids[1])
+		}
+	}
+	for _, entry := range logs[3:5] {
+		if entry.Operation == "child" && entry.CorrelationID != "req-1" {
+			t.Errorf("GoBound should carry the binding, got %q", entry.CorrelationID)
+		}
+		if entry.Operation == "legacy" && entry.CorrelationID != "" {
+			t.Errorf("other goroutines should not be bound, got %q", entry.CorrelationID)
+		}
+	}
+	if _, ok := CurrentGoroutineBinding(); ok {
+		t.Error("expected no binding after unbind")
+	}
+}
@@ -0,0 +1,129 @@
+package vibelogger
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrorHandler receives failures the logger cannot return to a caller, such
+// as unwritable rotation state or panicking write callbacks. Handlers may run
+// while the logger's lock is held, so they must not log through the same
+// logger.
+type ErrorHandler func(error)
+
+// HardenedConfig returns the default configuration for libraries embedding the
+// logger: it never panics, never writes to stderr, does not echo entries to
+// stdout and never creates files outside sandboxDir. Log files go to sandboxDir/logs/{project}/, and failures
+// are only reported through OnError handlers and returned errors. The logger
+// never exits the process in any mode.
+func HardenedConfig(sandboxDir string) *LoggerConfig {
+	config := DefaultConfig()
+	config.Hardened = true
+	config.SandboxDir = sandboxDir
+	config.ConsoleOutput = ConsoleOff
+	return config
+}
+
+// OnError registers a handler for failures that cannot be returned, see
+// ErrorHandler. Without handlers such failures are printed to stderr, unless
+// the logger is hardened. In hardened mode errors returned by logging calls
+// are passed to the handlers as well, as embedding code often ignores them.
+// A panicking handler is ignored.
+func (l *Logger) OnError(handler ErrorHandler) {
+	if handler == nil {
+		return
+	}
+	l.handlerMutex.Lock()
+	defer l.handlerMutex.Unlock()
+	l.errorHandlers = append(l.errorHandlers, handler)
+}
+
+// reportError hands a failure to the OnError handlers, or prints it to stderr
+// when there are none and the logger is not hardened. It is safe on a nil
+// logger, e.g. for a RotationManager created without one.
+func (l *Logger) reportError(err error) {
+	if l == nil {
+		fmt.Fprintf(os.Stderr, "vibelogger: %v\n", err)
+		return
+	}
+	l.handlerMutex.RLock()
+	handlers := l.errorHandlers
+	l.handlerMutex.RUnlock()
+
+	for _, handler := range handlers {
+		callSafely("error_handler", func() { handler(err) })
+	}
+	if len(handlers) == 0 && !l.config.Hardened {
+		fmt.Fprintf(os.Stderr, "vibelogger: %v\n", err)
+	}
+}
+
+// recoverHardened turns a panic of a hardened logger into the returned error.
+// It must be deferred directly.
+func (l *Logger) recoverHardened(err *error) {
+	if r := recover(); r != nil {
+		*err = &PanicError{Source: "logger", Value: r, Stack: panicStack()}
+	}
+	if *err != nil {
+		l.reportError(*err)
+	}
+}
+
+// normalizeSandbox makes SandboxDir absolute and rejects hardened mode without it
+func (c *LoggerConfig) normalizeSandbox() error {
+	if c.SandboxDir == "" {
+		if c.Hardened {
+			return fmt.Errorf("hardened mode requires a sandbox directory")
+		}
+		return nil
+	}
+	abs, err := filepath.Abs(c.SandboxDir)
+	if err != nil {
+		return fmt.Errorf("invalid sandbox directory: %w", err)
+	}
+	c.SandboxDir = abs
+	return nil
+}
+
+// sandboxPath resolves a path against the sandbox: relative paths are taken
+// relative to SandboxDir, and paths escaping it are rejected. Without a
+// sandbox the path is returned unchanged.
+func (c *LoggerConfig) sandboxPath(path string) (string, error) {
+	if c.SandboxDir == "" {
+		return path, nil
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(c.SandboxDir, path)
+	}
+	path = filepath.Clean(path)
+	rel, err := filepath.Rel(c.SandboxDir, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("path outside the sandbox %s: %s", c.SandboxDir, path)
+	}
+	return path, nil
+}
+
+// checkSandbox verifies before any file is created that a sandboxed
+// configuration only writes below its sandbox. Validate errors are ignored by
+// NewLoggerWithConfig, so the file path is checked again here.
+func (c *LoggerConfig) checkSandbox() error {
+	if err := c.normalizeSandbox(); err != nil || c.SandboxDir == "" || c.FilePath == "" {
+		return err
+	}
+	path, err := filepath.Abs(c.FilePath)
+	if err != nil {
+		return fmt.Errorf("invalid file path: %w", err)
+	}
+	if _, err := c.sandboxPath(path); err != nil {
+		return err
+	}
+	return nil
+}
+
+// logsRoot returns the directory holding the project directories: logs/ below
+// the sandbox, or below the working directory without one
+func (c *LoggerConfig) logsRoot() string {
+	return filepath.Join(c.SandboxDir, "logs")
+}
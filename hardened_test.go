@@ -0,0 +1,133 @@
+package vibelogger
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// panickingValue panics when it is encoded
+type panickingValue struct{}
+
+func (panickingValue) MarshalJSON() ([]byte, error) {
+	panic("broken marshaler")
+}
+
+// captureStderr returns everything written to os.Stderr while fn runs
+func captureStderr(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stderr := os.Stderr
+	os.Stderr = w
+	defer func() { os.Stderr = stderr }()
+
+	fn()
+	w.Close()
+	data, _ := io.ReadAll(r)
+	return string(data)
+}
+
+func TestHardenedSandbox(t *testing.T) {
+	sandbox := t.TempDir()
+	config := HardenedConfig(sandbox)
+	config.ProjectName = "embedded"
+	logger, err := CreateFileLoggerWithConfig("lib", config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger.Info("startup", "Library initialized")
+	if err := logger.SetProfileTrigger(&ProfileTrigger{ErrorThreshold: 1, Window: time.Second, Dir: "/tmp/outside"}); err == nil {
+		t.Error("expected a profile directory outside the sandbox to be rejected")
+	}
+	logger.Close()
+
+	if dir := filepath.Dir(logger.filePath); dir != filepath.Join(sandbox, "logs", "embedded") {
+		t.Errorf("expected the log file below the sandbox, got %s", logger.filePath)
+	}
+
+	// Paths are resolved against the sandbox and may not leave it
+	config = HardenedConfig(sandbox)
+	config.FilePath = "custom/lib.log"
+	if err := config.Validate(); err != nil || config.FilePath != filepath.Join(sandbox, "custom", "lib.log") {
+		t.Errorf("expected a relative path inside the sandbox, got %s (%v)", config.FilePath, err)
+	}
+	for _, path := range []string{"/tmp/outside.log", "/var/log/app.log"} {
+		config = HardenedConfig(sandbox)
+		config.FilePath = path
+		if _, err := CreateFileLoggerWithConfig("lib", config); err == nil {
+			t.Errorf("expected %s to be rejected", path)
+		}
+	}
+
+	config = DefaultConfig()
+	config.Hardened = true
+	if _, err := CreateFileLoggerWithConfig("lib", config); err == nil {
+		t.Error("expected hardened mode without a sandbox to be rejected")
+	}
+}
+
+func TestHardenedNeverPanics(t *testing.T) {
+	config := HardenedConfig(t.TempDir())
+	logger, err := CreateFileLoggerWithConfig("lib", config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logger.Close()
+
+	var reported []error
+	logger.OnError(func(err error) { reported = append(reported, err) })
+
+	err = logger.Info("encode", "Bad value", WithContext(map[string]interface{}{"value": panickingValue{}}))
+	var p *PanicError
+	if !errors.As(err, &p) {
+		t.Fatalf("expected the panic to be returned as an error, got %v", err)
+	}
+	if len(reported) != 1 || reported[0] != err {
+		t.Errorf("expected the failure to reach the error handler, got %v", reported)
+	}
+
+	// The logger keeps working after recovering
+	if err := logger.Info("after", "Still logging"); err != nil {
+		t.Errorf("expected the logger to stay usable, got %v", err)
+	}
+}
+
+func TestHardenedStderrSilence(t *testing.T) {
+	config := HardenedConfig(t.TempDir())
+	config.AutoSave = false
+	logger := NewLoggerWithConfig("lib", config)
+	defer logger.Close()
+	logger.OnWrite(func(WriteEvent) { panic("callback") })
+
+	output := captureStderr(t, func() {
+		logger.Info("op", "Message")
+	})
+	if output != "" {
+		t.Errorf("hardened logger wrote to stderr: %q", output)
+	}
+
+	var reported []error
+	logger.OnError(func(err error) { reported = append(reported, err) })
+	logger.OnError(func(error) { panic("handler") })
+	output = captureStderr(t, func() {
+		logger.Info("op", "Message")
+	})
+	if output != "" || len(reported) != 1 || !strings.Contains(reported[0].Error(), "write_callback panicked") {
+		t.Errorf("expected the callback panic only at the handler, got %v and stderr %q", reported, output)
+	}
+
+	// Without hardening the failure is still visible on stderr
+	plain := NewLoggerWithConfig("plain", &LoggerConfig{AutoSave: false})
+	defer plain.Close()
+	plain.OnWrite(func(WriteEvent) { panic("callback") })
+	if output := captureStderr(t, func() { plain.Info("op", "Message") }); !strings.Contains(output, "write_callback panicked") {
+		t.Errorf("expected the failure on stderr, got %q", output)
+	}
+}
@@ -0,0 +1,185 @@
+package vibelogger
+
+import (
+	"fmt"
+	"runtime"
+	"time"
+)
+
+// LogSchemaVersion is the version of the on-disk record layout. Version 2
+// records the environment in the header; entries may only carry the fields
+// that differ from it, see LoggerConfig.EnvironmentDiff.
+const LogSchemaVersion = 2
+
+// Record types used to distinguish file markers from regular log entries
+const (
+	RecordTypeHeader = "header"
+	RecordTypeFooter = "footer"
+)
+
+// Footer reasons describing why a log file was finalized
+const (
+	FooterReasonClose    = "close"
+	FooterReasonRotation = "rotation"
+	FooterReasonSnapshot = "snapshot"
+)
+
+// HostInfo describes the host and process that produced a log file
+type HostInfo struct {
+	Hostname  string `json:"hostname,omitempty"`
+	OS        string `json:"os"`
+	Arch      string `json:"arch"`
+	PID       int    `json:"pid"`
+	GoVersion string `json:"go_version"`
+}
+
+// FileHeader is the first record written to a newly created log file
+type FileHeader struct {
+	RecordType    string            `json:"record_type"`
+	SchemaVersion int               `json:"schema_version"`
+	Timestamp     time.Time         `json:"timestamp"`
+	LoggerName    string            `json:"logger_name"`
+	Config        HeaderConfig      `json:"config"`
+	Version       *VersionInfo      `json:"version"`
+	Host          HostInfo          `json:"host"`
+	Environment   map[string]string `json:"environment,omitempty"` // Baseline of the entry environments in this file section
+}
+
+// HeaderConfig is the part of LoggerConfig recorded in the file header: the
+// settings that shape the records of the file. Paths, URLs and other values
+// that may carry credentials are deliberately left out.
+type HeaderConfig struct {
+	Environment      string  `json:"environment"`
+	MaxFileSize      int64   `json:"max_file_size"`
+	RotationEnabled  bool    `json:"rotation_enabled"`
+	MaxRotatedFiles  int     `json:"max_rotated_files"`
+	Mode             string  `json:"mode"`
+	OutputFormat     string  `json:"output_format"`
+	LevelFormats     string  `json:"level_formats,omitempty"`
+	MinLevel         string  `json:"min_level,omitempty"`
+	SampleRate       float64 `json:"sample_rate,omitempty"`
+	WriteFileMarkers bool    `json:"write_file_markers"`
+	WriterShards     int     `json:"writer_shards,omitempty"`
+	EntryValidation  string  `json:"entry_validation,omitempty"`
+	ControlChars     string  `json:"control_chars,omitempty"`
+	EscapeNonASCII   bool    `json:"escape_non_ascii"`
+	FoldMultiline    bool    `json:"fold_multiline"`
+	EnvironmentDiff  bool    `json:"environment_diff"`
+	Hardened         bool    `json:"hardened"`
+}
+
+// newHeaderConfig copies the allowlisted fields of config
+func newHeaderConfig(config *LoggerConfig) HeaderConfig {
+	return HeaderConfig{
+		Environment:      config.Environment,
+		MaxFileSize:      config.MaxFileSize,
+		RotationEnabled:  config.RotationEnabled,
+		MaxRotatedFiles:  config.MaxRotatedFiles,
+		Mode:             config.Mode,
+		OutputFormat:     config.OutputFormat,
+		LevelFormats:     config.LevelFormats,
+		MinLevel:         config.MinLevel,
+		SampleRate:       config.SampleRate,
+		WriteFileMarkers: config.WriteFileMarkers,
+		WriterShards:     config.WriterShards,
+		EntryValidation:  config.EntryValidation,
+		ControlChars:     config.ControlChars,
+		EscapeNonASCII:   config.EscapeNonASCII,
+		FoldMultiline:    config.FoldMultiline,
+		EnvironmentDiff:  config.EnvironmentDiff,
+		Hardened:         config.Hardened,
+	}
+}
+
+// FileFooter is the last record written to a log file that was finalized cleanly.
+// A file with a header but no footer was not closed properly.
+type FileFooter struct {
+	RecordType string    `json:"record_type"`
+	Timestamp  time.Time `json:"timestamp"`
+	Reason     string    `json:"reason"`
+	EntryCount int64     `json:"entry_count"`
+}
+
+// getHostInfo returns information about the current host and process
+func getHostInfo() HostInfo {
+	process := GetProcessInfo()
+	return HostInfo{
+		Hostname:  process.Hostname,
+		OS:        runtime.GOOS,
+		Arch:      runtime.GOARCH,
+		PID:       process.PID,
+		GoVersion: runtime.Version(),
+	}
+}
+
+// fileMarkersEnabled reports whether header and footer records should be written
+func (l *Logger) fileMarkersEnabled() bool {
+	return l.config.WriteFileMarkers && l.config.AutoSave && l.file != nil
+}
+
+// writeHeader writes a header record to the current file and returns the bytes written
+func (l *Logger) writeHeader() (int64, error) {
+	if !l.fileMarkersEnabled() {
+		l.headerEnv.Store(nil)
+		return 0, nil
+	}
+
+	env := getEnvironment()
+	header := FileHeader{
+		RecordType:    RecordTypeHeader,
+		SchemaVersion: LogSchemaVersion,
+		Timestamp:     time.Now().UTC(),
+		LoggerName:    l.name,
+		Config:        newHeaderConfig(l.config),
+		Version:       GetVersionInfo(),
+		Host:          getHostInfo(),
+		Environment:   env,
+	}
+	written, err := l.writeRecord(header)
+	if err != nil {
+		l.headerEnv.Store(nil)
+		return written, err
+	}
+	l.headerEnv.Store(&env)
+	return written, nil
+}
+
+// writeFooter writes a footer record to the current file and returns the bytes written
+func (l *Logger) writeFooter(reason string) (int64, error) {
+	if !l.fileMarkersEnabled() {
+		return 0, nil
+	}
+	return l.writeRecord(l.newFooter(reason))
+}
+
+// newFooter returns the footer finalizing the current file
+func (l *Logger) newFooter(reason string) FileFooter {
+	return FileFooter{
+		RecordType: RecordTypeFooter,
+		Timestamp:  time.Now().UTC(),
+		Reason:     reason,
+		EntryCount: l.fileEntries,
+	}
+}
+
+// writeRecord marshals a non-entry record and appends it to the current file
+func (l *Logger) writeRecord(record interface{}) (int64, error) {
+	jsonData, err := l.encodeFileRecord(record)
+	if err != nil {
+		return 0, err
+	}
+
+	if _, err := l.file.Write(jsonData); err != nil {
+		return 0, fmt.Errorf("failed to write log record: %w", err)
+	}
+	return int64(len(jsonData)), nil
+}
+
+// encodeFileRecord marshals a non-entry record as a line of the current file
+func (l *Logger) encodeFileRecord(record interface{}) ([]byte, error) {
+	jsonData, err := encodeRecord(record, l.config.OutputFormat, time.Now(), "stdout")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal log record: %w", err)
+	}
+	return append(jsonData, '\n'), nil
+}
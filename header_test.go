@@ -0,0 +1,272 @@
+package vibelogger
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// readRawRecords decodes every JSON record in a log file into generic maps
+func readRawRecords(t *testing.T, path string) []map[string]interface{} {
+	t.Helper()
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Failed to open log file: %v", err)
+	}
+	defer file.Close()
+
+	var records []map[string]interface{}
+	decoder := json.NewDecoder(file)
+	for {
+		var record map[string]interface{}
+		if err := decoder.Decode(&record); err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatalf("Failed to decode record: %v", err)
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestFileHeaderAndFooter(t *testing.T) {
+	defer os.RemoveAll("test_logs")
+
+	config := DefaultConfig()
+	config.FilePath = "test_logs/markers_test.log"
+
+	logger, err := CreateFileLoggerWithConfig("markers_test", config)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+
+	logger.Info("test", "First entry")
+	logger.Info("test", "Second entry")
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Failed to close logger: %v", err)
+	}
+
+	records := readRawRecords(t, config.FilePath)
+	if len(records) != 4 {
+		t.Fatalf("Expected header, 2 entries and footer, got %d records", len(records))
+	}
+
+	header := records[0]
+	if header["record_type"] != RecordTypeHeader {
+		t.Errorf("Expected first record to be a header, got %v", header["record_type"])
+	}
+	if header["schema_version"] != float64(LogSchemaVersion) {
+		t.Errorf("Expected schema version %d, got %v", LogSchemaVersion, header["schema_version"])
+	}
+	if header["logger_name"] != "markers_test" {
+		t.Errorf("Expected logger name 'markers_test', got %v", header["logger_name"])
+	}
+	if _, ok := header["config"].(map[string]interface{}); !ok {
+		t.Error("Header should contain a config snapshot")
+	}
+	if _, ok := header["host"].(map[string]interface{}); !ok {
+		t.Error("Header should contain host info")
+	}
+
+	footer := records[3]
+	if footer["record_type"] != RecordTypeFooter {
+		t.Errorf("Expected last record to be a footer, got %v", footer["record_type"])
+	}
+	if footer["reason"] != FooterReasonClose {
+		t.Errorf("Expected footer reason %q, got %v", FooterReasonClose, footer["reason"])
+	}
+	if footer["entry_count"] != float64(2) {
+		t.Errorf("Expected footer entry count 2, got %v", footer["entry_count"])
+	}
+}
+
+func TestFileMarkersOnRotation(t *testing.T) {
+	defer os.RemoveAll("test_logs")
+
+	config := DefaultConfig()
+	config.FilePath = "test_logs/markers_rotation_test.log"
+
+	logger, err := CreateFileLoggerWithConfig("markers_rotation_test", config)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	defer logger.Close()
+
+	logger.Info("test", "Before rotation")
+	if err := logger.ForceRotation(); err != nil {
+		t.Fatalf("Failed to rotate: %v", err)
+	}
+
+	rotated := logger.GetRotatedFiles()
+	if len(rotated) != 1 {
+		t.Fatalf("Expected 1 rotated file, got %d", len(rotated))
+	}
+
+	records := readRawRecords(t, rotated[0])
+	last := records[len(records)-1]
+	if last["record_type"] != RecordTypeFooter || last["reason"] != FooterReasonRotation {
+		t.Errorf("Expected rotated file to end with a rotation footer, got %v", last)
+	}
+
+	records = readRawRecords(t, config.FilePath)
+	if len(records) != 1 || records[0]["record_type"] != RecordTypeHeader {
+		t.Errorf("Expected new file to start with a header, got %v", records)
+	}
+}
+
+func TestHeaderConfigOmitsSecrets(t *testing.T) {
+	defer os.RemoveAll("test_logs")
+
+	config := DefaultConfig()
+	config.FilePath = "test_logs/header_secrets_test.log"
+	config.RunbookURLs = "auth_error=https://wiki.example.com/auth?token=runbook-secret"
+	config.RedactKeys = "session_cookie"
+	config.MaxFileSize = 4096
+
+	logger, err := CreateFileLoggerWithConfig("header_secrets_test", config)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	logger.Close()
+
+	records := readRawRecords(t, config.FilePath)
+	headerConfig, ok := records[0]["config"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("Expected the header to record the config, got %v", records[0])
+	}
+	data, _ := json.Marshal(headerConfig)
+	for _, secret := range []string{"runbook-secret", "session_cookie", config.FilePath} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("Expected %q to stay out of the header config: %s", secret, data)
+		}
+	}
+	if headerConfig["max_file_size"] != float64(4096) {
+		t.Errorf("Expected the file size limit in the header config, got %v", headerConfig["max_file_size"])
+	}
+}
+
+func TestFileMarkersDisabled(t *testing.T) {
+	defer os.RemoveAll("test_logs")
+
+	config := DefaultConfig()
+	config.FilePath = "test_logs/no_markers_test.log"
+	config.WriteFileMarkers = false
+
+	logger, err := CreateFileLoggerWithConfig("no_markers_test", config)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	logger.Info("test", "Only entry")
+	logger.Close()
+
+	records := readRawRecords(t, config.FilePath)
+	if len(records) != 1 {
+		t.Fatalf("Expected only the entry without markers, got %d records", len(records))
+	}
+	if _, exists := records[0]["record_type"]; exists {
+		t.Error("Entry should not carry a record type")
+	}
+}
+
+func TestEnvironmentRecordedInHeader(t *testing.T) {
+	defer os.RemoveAll("test_logs")
+
+	for _, diff := range []bool{true, false} {
+		config := DefaultConfig()
+		config.FilePath = fmt.Sprintf("test_logs/environment_%v.log", diff)
+		config.OutputFormat = FormatCompact
+		config.EnvironmentDiff = diff
+
+		logger, err := CreateFileLoggerWithConfig("environment_test", config)
+		if err != nil {
+			t.Fatalf("Failed to create logger: %v", err)
+		}
+		logger.Info("test", "Static environment")
+		if err := logger.Close(); err != nil {
+			t.Fatalf("Failed to close logger: %v", err)
+		}
+
+		records := readRawRecords(t, config.FilePath)
+		if _, ok := records[0]["environment"].(map[string]interface{}); !ok {
+			t.Errorf("diff=%v: header should record the environment", diff)
+		}
+		if _, ok := records[1]["environment"]; ok == diff {
+			t.Errorf("diff=%v: unexpected entry environment %v", diff, records[1]["environment"])
+		}
+
+		result, err := ReadLogFile(config.FilePath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, entry := range result.Entries {
+			if !reflect.DeepEqual(entry.Environment, getEnvironment()) {
+				t.Errorf("diff=%v: expected the full environment to be restored, got %v", diff, entry.Environment)
+			}
+		}
+	}
+}
+
+func TestEnvironmentFollowsHeader(t *testing.T) {
+	defer os.RemoveAll("test_logs")
+
+	// Without a header there is no baseline, so entries carry the full environment
+	config := DefaultConfig()
+	config.FilePath = "test_logs/environment_no_header.log"
+	config.OutputFormat = FormatCompact
+	config.WriteFileMarkers = false
+	logger, err := CreateFileLoggerWithConfig("environment_test", config)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	logger.Info("test", "No header")
+	logger.Close()
+	records := readRawRecords(t, config.FilePath)
+	if env, ok := records[0]["environment"].(map[string]interface{}); !ok || len(env) != len(getEnvironment()) {
+		t.Errorf("Expected the full environment without a header, got %v", records[0]["environment"])
+	}
+
+	// Entries are diffed against the environment the header recorded
+	config = DefaultConfig()
+	config.FilePath = "test_logs/environment_header.log"
+	logger, err = CreateFileLoggerWithConfig("environment_test", config)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	defer logger.Close()
+	header := map[string]string{"recorded": "header"}
+	logger.headerEnv.Store(&header)
+	entry := &LogEntry{Environment: map[string]string{"recorded": "header", "added": "later"}}
+	data, err := logger.encodeFileEntry(entry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var encoded LogEntry
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]string{"added": "later"}; !reflect.DeepEqual(encoded.Environment, want) {
+		t.Errorf("Expected %v diffed against the header, got %v", want, encoded.Environment)
+	}
+}
+
+func TestEnvironmentDiff(t *testing.T) {
+	base := map[string]string{"os": "linux", "arch": "amd64", "pwd": "/srv"}
+	env := map[string]string{"os": "linux", "pwd": "/tmp", "region": "eu"}
+
+	diff := diffEnvironment(base, env)
+	want := map[string]string{"arch": "", "pwd": "/tmp", "region": "eu"}
+	if !reflect.DeepEqual(diff, want) {
+		t.Errorf("Expected %v, got %v", want, diff)
+	}
+	if restored := applyEnvironment(base, diff); !reflect.DeepEqual(restored, env) {
+		t.Errorf("Expected %v restored, got %v", env, restored)
+	}
+	if diffEnvironment(base, base) != nil {
+		t.Error("Identical environments should not produce a diff")
+	}
+}
@@ -0,0 +1,56 @@
+package vibelogger
+
+import (
+	"runtime"
+	"time"
+)
+
+// StartHeartbeat writes an INFO "heartbeat" entry every interval with uptime,
+// entry counts and memory statistics, so a quiet but healthy service can be told
+// apart from one that stopped logging. Calling it again replaces the previous
+// heartbeat; a non-positive interval only stops it.
+func (l *Logger) StartHeartbeat(interval time.Duration) {
+	l.StopHeartbeat()
+	if interval <= 0 {
+		return
+	}
+
+	task := startPeriodicTask(interval, l.writeHeartbeat)
+	l.bgMutex.Lock()
+	l.heartbeat = task
+	l.bgMutex.Unlock()
+}
+
+// StopHeartbeat stops the heartbeat goroutine if one is running
+func (l *Logger) StopHeartbeat() {
+	l.bgMutex.Lock()
+	task := l.heartbeat
+	l.heartbeat = nil
+	l.bgMutex.Unlock()
+
+	task.Stop()
+}
+
+// writeHeartbeat logs a single alive entry
+func (l *Logger) writeHeartbeat() {
+	stats := l.Stats()
+
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	byLevel := make(map[string]int64, len(stats.EntriesByLevel))
+	for level, count := range stats.EntriesByLevel {
+		byLevel[string(level)] = count
+	}
+
+	l.Info("heartbeat", "alive", WithContext(map[string]interface{}{
+		"uptime_seconds":   int64(stats.Uptime.Seconds()),
+		"uptime_human":     stats.Uptime.Round(time.Second).String(),
+		"total_entries":    stats.TotalEntries,
+		"entries_by_level": byLevel,
+		"heap_alloc_bytes": mem.HeapAlloc,
+		"heap_inuse_bytes": mem.HeapInuse,
+		"num_gc":           mem.NumGC,
+		"goroutines":       runtime.NumGoroutine(),
+	}))
+}
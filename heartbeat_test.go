@@ -0,0 +1,68 @@
+package vibelogger
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestHeartbeat(t *testing.T) {
+	logger := NewLoggerWithConfig("heartbeat_test", &LoggerConfig{EnableMemoryLog: true})
+	logger.Info("test", "Regular entry")
+
+	logger.StartHeartbeat(10 * time.Millisecond)
+	logs := waitForMemoryLogs(t, logger, 2)
+	logger.StopHeartbeat()
+
+	beat := logs[1]
+	if beat.Level != INFO || beat.Operation != "heartbeat" || beat.Message != "alive" {
+		t.Fatalf("Expected heartbeat entry, got %s %s %s", beat.Level, beat.Operation, beat.Message)
+	}
+	for _, key := range []string{"uptime_seconds", "total_entries", "entries_by_level", "heap_alloc_bytes", "num_gc", "goroutines"} {
+		if _, ok := beat.Context[key]; !ok {
+			t.Errorf("Expected heartbeat context to contain %s", key)
+		}
+	}
+	if beat.Context["total_entries"] != int64(1) {
+		t.Errorf("Expected total_entries 1 before the heartbeat, got %v", beat.Context["total_entries"])
+	}
+
+	// No more beats after stopping
+	count := len(logger.GetMemoryLogs())
+	time.Sleep(30 * time.Millisecond)
+	if len(logger.GetMemoryLogs()) != count {
+		t.Error("Heartbeat should stop after StopHeartbeat")
+	}
+	logger.StopHeartbeat() // Stopping twice is safe
+}
+
+func TestHeartbeatFromConfig(t *testing.T) {
+	defer os.RemoveAll("test_logs")
+
+	os.Setenv("VIBE_LOG_HEARTBEAT_INTERVAL", "15ms")
+	defer os.Unsetenv("VIBE_LOG_HEARTBEAT_INTERVAL")
+
+	config, err := NewConfigFromEnvironment()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if config.HeartbeatInterval != 15*time.Millisecond {
+		t.Fatalf("Expected heartbeat interval 15ms, got %v", config.HeartbeatInterval)
+	}
+	config.FilePath = "test_logs/heartbeat_test.log"
+	config.EnableMemoryLog = true
+
+	logger, err := CreateFileLoggerWithConfig("heartbeat_test", config)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	waitForMemoryLogs(t, logger, 1)
+	if err := logger.Close(); err != nil {
+		t.Errorf("Failed to close logger: %v", err)
+	}
+
+	os.Setenv("VIBE_LOG_HEARTBEAT_INTERVAL", "soon")
+	if _, err := NewConfigFromEnvironment(); err == nil {
+		t.Error("Expected error for invalid duration")
+	}
+}
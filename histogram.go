@@ -0,0 +1,233 @@
+package vibelogger
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Limits of the exponential histograms, as in the OpenTelemetry SDK defaults
+const (
+	histogramMaxScale   = 20
+	histogramMaxBuckets = 160
+)
+
+// DurationHistogram is a pre-aggregated distribution of the durations of one
+// operation in the OpenTelemetry exponential histogram layout. Bucket i
+// (counting from Offset) holds values in (base^i, base^(i+1)] milliseconds
+// with base = 2^(2^-Scale); the scale is lowered automatically to keep at most
+// 160 buckets, so relative error stays bounded for any range of durations.
+type DurationHistogram struct {
+	Operation    string    `json:"operation"`
+	Unit         string    `json:"unit"` // Always "ms"
+	Start        time.Time `json:"start"`
+	End          time.Time `json:"end"`
+	Count        uint64    `json:"count"`
+	Sum          float64   `json:"sum"`
+	Min          float64   `json:"min"`
+	Max          float64   `json:"max"`
+	Scale        int32     `json:"scale"`
+	ZeroCount    uint64    `json:"zero_count"` // Durations of zero
+	Offset       int32     `json:"offset"`     // Index of the first bucket
+	BucketCounts []uint64  `json:"bucket_counts"`
+}
+
+// Quantile estimates the duration below which the fraction q of the
+// measurements fall, using the geometric midpoint of the bucket it lands in
+func (h *DurationHistogram) Quantile(q float64) time.Duration {
+	if h.Count == 0 {
+		return 0
+	}
+	rank := uint64(math.Ceil(q * float64(h.Count)))
+	if rank <= h.ZeroCount {
+		return 0
+	}
+	seen := h.ZeroCount
+	base := math.Exp2(math.Exp2(-float64(h.Scale)))
+	for i, count := range h.BucketCounts {
+		seen += count
+		if seen < rank {
+			continue
+		}
+		index := float64(h.Offset + int32(i))
+		lower := math.Pow(base, index)
+		upper := math.Pow(base, index+1)
+		estimate := math.Min(math.Max(math.Sqrt(lower*upper), h.Min), h.Max)
+		return time.Duration(estimate * float64(time.Millisecond))
+	}
+	return time.Duration(h.Max * float64(time.Millisecond))
+}
+
+// record adds a duration in milliseconds
+func (h *DurationHistogram) record(ms float64) {
+	if h.Count == 0 || ms < h.Min {
+		h.Min = ms
+	}
+	if h.Count == 0 || ms > h.Max {
+		h.Max = ms
+	}
+	h.Count++
+	h.Sum += ms
+	if ms <= 0 {
+		h.ZeroCount++
+		return
+	}
+
+	index := histogramIndex(ms, h.Scale)
+	if len(h.BucketCounts) == 0 {
+		h.Offset = index
+		h.BucketCounts = []uint64{1}
+		return
+	}
+
+	// Lower the scale until the new index fits into the bucket limit
+	low, high := min(h.Offset, index), max(h.Offset+int32(len(h.BucketCounts))-1, index)
+	change := int32(0)
+	for (high>>change)-(low>>change)+1 > histogramMaxBuckets {
+		change++
+	}
+	if change > 0 {
+		h.downscale(change)
+		index >>= change
+	}
+
+	if index < h.Offset {
+		grown := make([]uint64, int(h.Offset-index)+len(h.BucketCounts))
+		copy(grown[h.Offset-index:], h.BucketCounts)
+		h.BucketCounts = grown
+		h.Offset = index
+	} else if last := h.Offset + int32(len(h.BucketCounts)) - 1; index > last {
+		h.BucketCounts = append(h.BucketCounts, make([]uint64, index-last)...)
+	}
+	h.BucketCounts[index-h.Offset]++
+}
+
+// downscale merges buckets by lowering the scale by change
+func (h *DurationHistogram) downscale(change int32) {
+	offset := h.Offset >> change
+	counts := make([]uint64, ((h.Offset+int32(len(h.BucketCounts))-1)>>change)-offset+1)
+	for i, count := range h.BucketCounts {
+		counts[((h.Offset+int32(i))>>change)-offset] += count
+	}
+	h.Scale -= change
+	h.Offset = offset
+	h.BucketCounts = counts
+}
+
+// histogramIndex returns the bucket of a positive value at the given scale
+func histogramIndex(value float64, scale int32) int32 {
+	return int32(math.Ceil(math.Log2(value)*math.Exp2(float64(scale)))) - 1
+}
+
+// histogramState collects the histograms of the current interval
+type histogramState struct {
+	mutex      sync.Mutex
+	start      time.Time
+	histograms map[string]*DurationHistogram
+}
+
+// RecordDuration records how long an operation took. While histograms are
+// enabled (HistogramInterval or StartHistograms) the duration is aggregated
+// into the operation's histogram; otherwise it is logged right away as an
+// INFO entry with WithDuration.
+func (l *Logger) RecordDuration(operation string, duration time.Duration) error {
+	l.bgMutex.Lock()
+	state := l.histogramState
+	l.bgMutex.Unlock()
+	if state == nil {
+		return l.Info(operation, fmt.Sprintf("%s took %s", operation, duration), WithDuration(duration))
+	}
+
+	state.mutex.Lock()
+	defer state.mutex.Unlock()
+	h, ok := state.histograms[operation]
+	if !ok {
+		h = &DurationHistogram{Operation: operation, Unit: "ms", Scale: histogramMaxScale}
+		state.histograms[operation] = h
+	}
+	h.record(float64(duration) / float64(time.Millisecond))
+	return nil
+}
+
+// StartHistograms aggregates RecordDuration measurements into one exponential
+// histogram per operation and writes an INFO "duration_histogram" entry per
+// operation every interval, instead of one entry per measurement. Calling it
+// again flushes and replaces the previous aggregation; a non-positive interval
+// only stops it.
+func (l *Logger) StartHistograms(interval time.Duration) {
+	l.StopHistograms()
+	if interval <= 0 {
+		return
+	}
+
+	state := &histogramState{start: time.Now(), histograms: make(map[string]*DurationHistogram)}
+	task := startPeriodicTask(interval, func() { l.writeHistograms(state) })
+	l.bgMutex.Lock()
+	l.histograms, l.histogramState = task, state
+	l.bgMutex.Unlock()
+}
+
+// StopHistograms stops the aggregation and writes the pending histograms.
+// Later RecordDuration calls log one entry per measurement again.
+func (l *Logger) StopHistograms() {
+	l.bgMutex.Lock()
+	task, state := l.histograms, l.histogramState
+	l.histograms, l.histogramState = nil, nil
+	l.bgMutex.Unlock()
+
+	task.Stop()
+	if state != nil {
+		l.writeHistograms(state)
+	}
+}
+
+// FlushHistograms writes the histograms collected since the previous flush
+// and starts a new interval
+func (l *Logger) FlushHistograms() {
+	l.bgMutex.Lock()
+	state := l.histogramState
+	l.bgMutex.Unlock()
+	if state != nil {
+		l.writeHistograms(state)
+	}
+}
+
+// writeHistograms writes one "duration_histogram" entry per operation
+// measured since the previous write, ordered by operation
+func (l *Logger) writeHistograms(state *histogramState) {
+	state.mutex.Lock()
+	histograms := state.histograms
+	start := state.start
+	state.histograms = make(map[string]*DurationHistogram)
+	state.start = time.Now()
+	state.mutex.Unlock()
+
+	operations := make([]string, 0, len(histograms))
+	for operation := range histograms {
+		operations = append(operations, operation)
+	}
+	sort.Strings(operations)
+
+	end := time.Now()
+	for _, operation := range operations {
+		h := histograms[operation]
+		h.Start = start.UTC()
+		h.End = end.UTC()
+		p50, p90, p99 := h.Quantile(0.5), h.Quantile(0.9), h.Quantile(0.99)
+		l.Info("duration_histogram",
+			fmt.Sprintf("%s: %d durations in %s, p50 %s, p99 %s", operation, h.Count, end.Sub(start).Round(time.Second), p50, p99),
+			WithContext(map[string]interface{}{
+				"histogram": h,
+				"p50_ms":    durationMillis(p50),
+				"p90_ms":    durationMillis(p90),
+				"p99_ms":    durationMillis(p99),
+			}))
+	}
+}
+
+// durationMillis converts a duration to fractional milliseconds
+func durationMillis(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
@@ -0,0 +1,116 @@
+package vibelogger
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestDurationHistogramBuckets(t *testing.T) {
+	h := &DurationHistogram{Scale: histogramMaxScale}
+	for i := 1; i <= 1000; i++ {
+		h.record(float64(i))
+	}
+	h.record(0)
+
+	if h.Count != 1001 || h.ZeroCount != 1 || h.Min != 0 || h.Max != 1000 || h.Sum != 500500 {
+		t.Errorf("unexpected totals: %+v", h)
+	}
+	if len(h.BucketCounts) > histogramMaxBuckets || h.Scale >= histogramMaxScale {
+		t.Errorf("expected downscaling to at most %d buckets, got %d at scale %d", histogramMaxBuckets, len(h.BucketCounts), h.Scale)
+	}
+	var total uint64
+	for _, count := range h.BucketCounts {
+		total += count
+	}
+	if total+h.ZeroCount != h.Count {
+		t.Errorf("bucket counts add up to %d, expected %d", total+h.ZeroCount, h.Count)
+	}
+
+	// Every value must fall into the bucket its index describes
+	base := math.Exp2(math.Exp2(-float64(h.Scale)))
+	for _, v := range []float64{1, 2, 3, 500, 1000} {
+		index := histogramIndex(v, h.Scale)
+		if lower, upper := math.Pow(base, float64(index)), math.Pow(base, float64(index+1)); v <= lower*(1-1e-9) || v > upper*(1+1e-9) {
+			t.Errorf("%v is outside bucket %d (%v, %v]", v, index, lower, upper)
+		}
+	}
+
+	// Relative error of the estimates is bounded by the bucket width
+	for q, want := range map[float64]float64{0.5: 500, 0.9: 900, 0.99: 990} {
+		got := durationMillis(h.Quantile(q))
+		if math.Abs(got-want)/want > base-1 {
+			t.Errorf("p%v: expected about %v, got %v", q*100, want, got)
+		}
+	}
+	if h.Quantile(0) != 0 {
+		t.Error("the lowest quantile should be the zero duration")
+	}
+}
+
+func TestRecordDurationHistograms(t *testing.T) {
+	logger := NewLoggerWithConfig("histograms", &LoggerConfig{AutoSave: false, EnableMemoryLog: true, MemoryLogLimit: 10})
+	defer logger.Close()
+
+	// Without histograms every measurement is an entry
+	logger.RecordDuration("query", 5*time.Millisecond)
+	logs := logger.GetMemoryLogs()
+	if len(logs) != 1 || logs[0].Operation != "query" || logs[0].Duration != 5*time.Millisecond {
+		t.Fatalf("expected a single duration entry, got %+v", logs)
+	}
+
+	logger.StartHistograms(time.Hour)
+	for i := 0; i < 100; i++ {
+		logger.RecordDuration("query", time.Duration(i+1)*time.Millisecond)
+	}
+	logger.RecordDuration("checkout", 800*time.Millisecond)
+	if n := len(logger.GetMemoryLogs()); n != 1 {
+		t.Fatalf("measurements should be aggregated, got %d entries", n)
+	}
+
+	logger.FlushHistograms()
+	logs = logger.GetMemoryLogs()
+	if len(logs) != 3 {
+		t.Fatalf("expected one histogram entry per operation, got %d entries", len(logs))
+	}
+	checkout, query := logs[1], logs[2]
+	if checkout.Operation != "duration_histogram" || query.Operation != "duration_histogram" {
+		t.Errorf("unexpected operations %s, %s", checkout.Operation, query.Operation)
+	}
+	h, ok := query.Context["histogram"].(*DurationHistogram)
+	if !ok || h.Operation != "query" || h.Count != 100 || h.Unit != "ms" || h.Max != 100 {
+		t.Fatalf("unexpected histogram: %+v", query.Context["histogram"])
+	}
+	if p99 := query.Context["p99_ms"].(float64); p99 < 95 || p99 > 100 {
+		t.Errorf("unexpected p99: %v", p99)
+	}
+
+	// Flushing starts a new interval; stopping writes what is pending
+	logger.FlushHistograms()
+	logger.RecordDuration("query", time.Millisecond)
+	logger.StopHistograms()
+	logs = logger.GetMemoryLogs()
+	if len(logs) != 4 || logs[3].Context["histogram"].(*DurationHistogram).Count != 1 {
+		t.Errorf("expected only the pending histogram to be written on stop, got %d entries", len(logs))
+	}
+
+	logger.RecordDuration("query", time.Millisecond)
+	if logs = logger.GetMemoryLogs(); logs[len(logs)-1].Operation != "query" {
+		t.Error("measurements should be logged individually after StopHistograms")
+	}
+}
+
+func TestHistogramIntervalConfig(t *testing.T) {
+	logger := NewLoggerWithConfig("histogram_config", &LoggerConfig{AutoSave: false, EnableMemoryLog: true, MemoryLogLimit: 10, HistogramInterval: time.Hour})
+	logger.startBackgroundWorkers()
+	logger.RecordDuration("query", time.Millisecond)
+	if n := len(logger.GetMemoryLogs()); n != 0 {
+		t.Fatalf("HistogramInterval should enable aggregation, got %d entries", n)
+	}
+	logger.Close()
+
+	logs := logger.GetMemoryLogs()
+	if len(logs) != 1 || logs[0].Operation != "duration_histogram" {
+		t.Errorf("Close should write the pending histograms, got %+v", logs)
+	}
+}
@@ -0,0 +1,167 @@
+package vibelogger
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultHTTPSinkTimeout limits a single request of an HTTPSink
+const DefaultHTTPSinkTimeout = 10 * time.Second
+
+// HTTPSink posts entries as NDJSON to a collector such as a Receiver.
+// Write sends one entry per request and WriteBatch several; wrap the sink in an
+// AsyncSink to batch queued entries and keep network latency out of the logging
+// path, and in a SpoolSink to survive outages.
+type HTTPSink struct {
+	url    string
+	token  string
+	client *http.Client
+	gzip   bool // Compress payloads once the collector accepts gzip
+	// Encoding of the entries, their own JSON when nil
+	formatter Formatter
+
+	mutex      sync.Mutex
+	gzipActive bool // The collector advertised gzip support
+}
+
+// HTTPSinkOption customizes an HTTPSink
+type HTTPSinkOption func(*HTTPSink)
+
+// WithGzip compresses request bodies with gzip. Compression starts after the
+// collector advertises "Accept-Encoding: gzip" in a response and stops again if
+// it answers 415 Unsupported Media Type.
+func WithGzip() HTTPSinkOption {
+	return func(s *HTTPSink) {
+		s.gzip = true
+	}
+}
+
+// WithHTTPFormatter encodes each entry with formatter instead of the entry's
+// own JSON, one record per line, e.g. to emit the envelope a legacy collector
+// expects with NewFieldMapping or NewTemplateFormatter
+func WithHTTPFormatter(formatter Formatter) HTTPSinkOption {
+	return func(s *HTTPSink) {
+		s.formatter = formatter
+	}
+}
+
+// NewHTTPSink creates a sink posting to url with token as bearer token (optional)
+func NewHTTPSink(url, token string, options ...HTTPSinkOption) *HTTPSink {
+	s := &HTTPSink{
+		url:    url,
+		token:  token,
+		client: &http.Client{Timeout: DefaultHTTPSinkTimeout},
+	}
+	for _, opt := range options {
+		opt(s)
+	}
+	return s
+}
+
+// Write posts a single entry and fails unless the collector answers with 2xx
+func (s *HTTPSink) Write(entry *LogEntry) error {
+	return s.WriteBatch([]LogEntry{*entry})
+}
+
+// WriteBatch posts entries in a single request
+func (s *HTTPSink) WriteBatch(entries []LogEntry) error {
+	var body bytes.Buffer
+	encoder := json.NewEncoder(&body)
+	for i := range entries {
+		if s.formatter != nil {
+			record, err := s.formatter.Format(&entries[i])
+			if err != nil {
+				return fmt.Errorf("failed to format log entry: %w", err)
+			}
+			body.Write(record)
+			body.WriteByte('\n')
+			continue
+		}
+		if err := encoder.Encode(&entries[i]); err != nil {
+			return fmt.Errorf("failed to marshal log entry: %w", err)
+		}
+	}
+
+	s.mutex.Lock()
+	compress := s.gzip && s.gzipActive
+	s.mutex.Unlock()
+
+	status, err := s.post(body.Bytes(), compress)
+	if err == nil && status == http.StatusUnsupportedMediaType && compress {
+		// The collector no longer accepts gzip; fall back to plain payloads
+		s.mutex.Lock()
+		s.gzipActive = false
+		s.mutex.Unlock()
+		status, err = s.post(body.Bytes(), false)
+	}
+	if err != nil {
+		return err
+	}
+	if status < 200 || status >= 300 {
+		return fmt.Errorf("collector rejected %d log entries: %d %s", len(entries), status, http.StatusText(status))
+	}
+	return nil
+}
+
+// post sends one payload and returns the response status
+func (s *HTTPSink) post(payload []byte, compress bool) (int, error) {
+	if compress {
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		zw.Write(payload)
+		if err := zw.Close(); err != nil {
+			return 0, fmt.Errorf("failed to compress log entries: %w", err)
+		}
+		payload = buf.Bytes()
+	}
+
+	req, err := http.NewRequest(http.MethodPost, s.url, bytes.NewReader(payload))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	if compress {
+		req.Header.Set("Content-Encoding", "gzip")
+	}
+	if s.token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.token)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to send log entries: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	if s.gzip && acceptsGzip(resp.Header.Get("Accept-Encoding")) {
+		s.mutex.Lock()
+		s.gzipActive = true
+		s.mutex.Unlock()
+	}
+	return resp.StatusCode, nil
+}
+
+// acceptsGzip reports whether an Accept-Encoding header value includes gzip
+func acceptsGzip(header string) bool {
+	for _, part := range strings.Split(header, ",") {
+		name, _, _ := strings.Cut(part, ";")
+		if strings.EqualFold(strings.TrimSpace(name), "gzip") {
+			return true
+		}
+	}
+	return false
+}
+
+// Close releases idle connections
+func (s *HTTPSink) Close() error {
+	s.client.CloseIdleConnections()
+	return nil
+}
@@ -0,0 +1,91 @@
+package vibelogger
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHTTPSinkToReceiver(t *testing.T) {
+	receiver := newTestReceiver(t, ReceiverConfig{})
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	sink := NewHTTPSink(server.URL, "token-a")
+	defer sink.Close()
+	entry := &LogEntry{ID: NewEntryID(), Level: WARN, Operation: "sync", Message: "Retrying"}
+	if err := sink.Write(entry); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	receiver.Close()
+
+	entries := readTenantEntries(t, "receiver-tenant-a")
+	if len(entries) != 1 || entries[0].ID != entry.ID {
+		t.Errorf("Expected the entry to arrive with its ID, got %v", entries)
+	}
+}
+
+func TestHTTPSinkErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	sink := NewHTTPSink(server.URL, "wrong")
+	if err := sink.Write(&LogEntry{}); err == nil {
+		t.Error("Expected an error for a rejected entry")
+	}
+
+	server.Close()
+	if err := sink.Write(&LogEntry{}); err == nil {
+		t.Error("Expected an error for an unreachable collector")
+	}
+}
+
+func TestHTTPSinkNegotiatesGzip(t *testing.T) {
+	receiver := newTestReceiver(t, ReceiverConfig{})
+	var encodings []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		encodings = append(encodings, r.Header.Get("Content-Encoding"))
+		receiver.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	sink := NewHTTPSink(server.URL, "token-a", WithGzip())
+	for i := 0; i < 2; i++ {
+		if err := sink.Write(&LogEntry{Operation: "sync", Message: "Entry"}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	if err := sink.WriteBatch([]LogEntry{{Message: "a"}, {Message: "b"}}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	receiver.Close()
+
+	if len(encodings) != 3 || encodings[0] != "" || encodings[1] != "gzip" || encodings[2] != "gzip" {
+		t.Errorf("Expected gzip after the first response, got %q", encodings)
+	}
+	if entries := readTenantEntries(t, "receiver-tenant-a"); len(entries) != 4 {
+		t.Errorf("Expected 4 received entries, got %d", len(entries))
+	}
+}
+
+func TestHTTPSinkFallsBackWithoutGzip(t *testing.T) {
+	var encodings []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		encodings = append(encodings, r.Header.Get("Content-Encoding"))
+		w.Header().Set("Accept-Encoding", "gzip")
+		if r.Header.Get("Content-Encoding") == "gzip" {
+			w.WriteHeader(http.StatusUnsupportedMediaType)
+		}
+	}))
+	defer server.Close()
+
+	sink := NewHTTPSink(server.URL, "", WithGzip())
+	sink.Write(&LogEntry{})
+	// Advertised but rejected gzip is retried uncompressed
+	if err := sink.Write(&LogEntry{}); err != nil {
+		t.Fatalf("Expected the plain retry to succeed, got %v", err)
+	}
+	if len(encodings) != 3 || encodings[1] != "gzip" || encodings[2] != "" {
+		t.Errorf("Expected a plain retry after 415, got %q", encodings)
+	}
+}
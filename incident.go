@@ -0,0 +1,201 @@
+package vibelogger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Incident providers
+const (
+	IncidentPagerDuty = "pagerduty"
+	IncidentOpsgenie  = "opsgenie"
+)
+
+// Default endpoints of the incident providers
+const (
+	PagerDutyEventsURL = "https://events.pagerduty.com/v2/enqueue"
+	OpsgenieAlertsURL  = "https://api.opsgenie.com/v2/alerts"
+)
+
+// maxOpsgenieMessage is the longest alert message Opsgenie accepts
+const maxOpsgenieMessage = 130
+
+// IncidentConfig configures an IncidentSink
+type IncidentConfig struct {
+	Provider string                     // pagerduty or opsgenie
+	Key      string                     // PagerDuty routing key or Opsgenie API key
+	URL      string                     // Endpoint override, e.g. for the Opsgenie EU region
+	Source   string                     // Reported source (default: hostname)
+	Match    func(entry *LogEntry) bool // Entries that open an incident (default: IncidentOnEscalation)
+}
+
+// IncidentSink opens incidents for selected entries through the PagerDuty
+// Events API v2 or the Opsgenie Alert API. Repeated occurrences share a dedup
+// key derived from ErrorFingerprint, so the provider groups them into one
+// incident.
+type IncidentSink struct {
+	config IncidentConfig
+	client *http.Client
+}
+
+// IncidentOnEscalation matches entries raised by an escalation rule, see
+// Logger.SetEscalationRules
+func IncidentOnEscalation(entry *LogEntry) bool {
+	_, ok := entry.Context["escalation"]
+	return ok
+}
+
+// IncidentOnTag returns a Match function selecting ERROR entries that carry
+// tag, e.g. to page the billing team for entries tagged "billing"
+func IncidentOnTag(tag string) func(entry *LogEntry) bool {
+	return func(entry *LogEntry) bool {
+		return entry.Level == ERROR && entry.HasTag(tag)
+	}
+}
+
+// NewIncidentSink creates a sink for the configured provider
+func NewIncidentSink(config IncidentConfig) (*IncidentSink, error) {
+	switch config.Provider {
+	case IncidentPagerDuty:
+		if config.URL == "" {
+			config.URL = PagerDutyEventsURL
+		}
+	case IncidentOpsgenie:
+		if config.URL == "" {
+			config.URL = OpsgenieAlertsURL
+		}
+	default:
+		return nil, fmt.Errorf("unsupported incident provider: %q (must be %s or %s)", config.Provider, IncidentPagerDuty, IncidentOpsgenie)
+	}
+	if config.Key == "" {
+		return nil, fmt.Errorf("incident provider key is required")
+	}
+	if config.Source == "" {
+		config.Source = GetProcessInfo().Hostname
+	}
+	if config.Match == nil {
+		config.Match = IncidentOnEscalation
+	}
+	return &IncidentSink{config: config, client: &http.Client{Timeout: DefaultHTTPSinkTimeout}}, nil
+}
+
+// Write opens or updates an incident when the entry matches
+func (s *IncidentSink) Write(entry *LogEntry) error {
+	if !s.config.Match(entry) {
+		return nil
+	}
+
+	dedupKey := "vibe-" + ErrorFingerprint(entry)
+	var body interface{}
+	if s.config.Provider == IncidentPagerDuty {
+		body = s.pagerDutyEvent(entry, dedupKey)
+	} else {
+		body = s.opsgenieAlert(entry, dedupKey)
+	}
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to marshal incident: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, s.config.URL, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.config.Provider == IncidentOpsgenie {
+		req.Header.Set("Authorization", "GenieKey "+s.config.Key)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send incident: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%s rejected incident: %d %s", s.config.Provider, resp.StatusCode, http.StatusText(resp.StatusCode))
+	}
+	return nil
+}
+
+// incidentSummary describes the entry in one line
+func incidentSummary(entry *LogEntry) string {
+	return fmt.Sprintf("[%s] %s: %s", entry.Level, entry.Operation, entry.Message)
+}
+
+// pagerDutyEvent builds an Events API v2 trigger event
+func (s *IncidentSink) pagerDutyEvent(entry *LogEntry, dedupKey string) map[string]interface{} {
+	severity := "warning"
+	if getSeverityScore(entry.Level) >= getSeverityScore(ERROR) {
+		severity = "critical"
+	}
+	return map[string]interface{}{
+		"routing_key":  s.config.Key,
+		"event_action": "trigger",
+		"dedup_key":    dedupKey,
+		"payload": map[string]interface{}{
+			"summary":        incidentSummary(entry),
+			"source":         s.config.Source,
+			"severity":       severity,
+			"timestamp":      entry.Timestamp.UTC().Format(time.RFC3339),
+			"component":      entry.Operation,
+			"class":          entry.Pattern,
+			"custom_details": incidentDetails(entry),
+		},
+	}
+}
+
+// opsgenieAlert builds an Alert API create request
+func (s *IncidentSink) opsgenieAlert(entry *LogEntry, alias string) map[string]interface{} {
+	message := incidentSummary(entry)
+	if runes := []rune(message); len(runes) > maxOpsgenieMessage {
+		message = string(runes[:maxOpsgenieMessage])
+	}
+	priority := "P3"
+	if getSeverityScore(entry.Level) >= getSeverityScore(ERROR) {
+		priority = "P1"
+	}
+
+	details := make(map[string]string)
+	for key, value := range incidentDetails(entry) {
+		details[key] = fmt.Sprint(value)
+	}
+	return map[string]interface{}{
+		"message":     message,
+		"alias":       alias,
+		"description": entry.FullMessage(),
+		"source":      s.config.Source,
+		"priority":    priority,
+		"details":     details,
+		"tags":        append([]string{entry.Operation, entry.Pattern}, entry.Tags...),
+	}
+}
+
+// incidentDetails collects the fields that help the responder
+func incidentDetails(entry *LogEntry) map[string]interface{} {
+	details := make(map[string]interface{}, len(entry.Context)+4)
+	for key, value := range entry.Context {
+		details[key] = value
+	}
+	for key, value := range map[string]string{
+		"entry_id":       entry.ID,
+		"correlation_id": entry.CorrelationID,
+		"suggestion":     entry.Suggestion,
+		"runbook_url":    entry.RunbookURL,
+	} {
+		if value != "" {
+			details[key] = value
+		}
+	}
+	return details
+}
+
+// Close releases idle connections
+func (s *IncidentSink) Close() error {
+	s.client.CloseIdleConnections()
+	return nil
+}
@@ -0,0 +1,105 @@
+package vibelogger
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// incidentServer records the decoded request bodies and headers it receives
+func incidentServer(t *testing.T) (*httptest.Server, *[]map[string]interface{}, *[]http.Header) {
+	var bodies []map[string]interface{}
+	var headers []http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("invalid request body: %v", err)
+		}
+		bodies = append(bodies, body)
+		headers = append(headers, r.Header.Clone())
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	t.Cleanup(server.Close)
+	return server, &bodies, &headers
+}
+
+func escalatedEntry(message string) *LogEntry {
+	return &LogEntry{
+		Timestamp: time.Now(),
+		Level:     ERROR,
+		Operation: "login",
+		Message:   message,
+		Pattern:   "auth_error",
+		Context:   map[string]interface{}{"escalation": map[string]interface{}{"escalated_from": "WARN"}},
+	}
+}
+
+func TestIncidentSinkPagerDuty(t *testing.T) {
+	server, bodies, _ := incidentServer(t)
+	sink, err := NewIncidentSink(IncidentConfig{Provider: IncidentPagerDuty, Key: "routing", URL: server.URL, Source: "web-1"})
+	if err != nil {
+		t.Fatalf("NewIncidentSink failed: %v", err)
+	}
+	defer sink.Close()
+
+	sink.Write(&LogEntry{Level: ERROR, Operation: "login", Message: "Not escalated"})
+	sink.Write(escalatedEntry("Unauthorized request from 10.0.0.1"))
+	sink.Write(escalatedEntry("Unauthorized request from 10.0.0.2"))
+
+	if len(*bodies) != 2 {
+		t.Fatalf("expected 2 events for escalated entries, got %d", len(*bodies))
+	}
+	first, second := (*bodies)[0], (*bodies)[1]
+	if first["routing_key"] != "routing" || first["event_action"] != "trigger" {
+		t.Errorf("unexpected event: %v", first)
+	}
+	if first["dedup_key"] == "" || first["dedup_key"] != second["dedup_key"] {
+		t.Errorf("repeated errors should share a dedup key: %v vs %v", first["dedup_key"], second["dedup_key"])
+	}
+	payload := first["payload"].(map[string]interface{})
+	if payload["severity"] != "critical" || payload["source"] != "web-1" || payload["class"] != "auth_error" {
+		t.Errorf("unexpected payload: %v", payload)
+	}
+}
+
+func TestIncidentSinkOpsgenie(t *testing.T) {
+	server, bodies, headers := incidentServer(t)
+	sink, err := NewIncidentSink(IncidentConfig{
+		Provider: IncidentOpsgenie,
+		Key:      "genie",
+		URL:      server.URL,
+		Match:    func(entry *LogEntry) bool { return entry.Level == ERROR },
+	})
+	if err != nil {
+		t.Fatalf("NewIncidentSink failed: %v", err)
+	}
+
+	if err := sink.Write(&LogEntry{Level: ERROR, Operation: "payment", Message: strings.Repeat("x", 200)}); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	if len(*bodies) != 1 {
+		t.Fatalf("expected 1 alert, got %d", len(*bodies))
+	}
+	if got := (*headers)[0].Get("Authorization"); got != "GenieKey genie" {
+		t.Errorf("unexpected authorization header: %q", got)
+	}
+	alert := (*bodies)[0]
+	if len(alert["message"].(string)) != maxOpsgenieMessage || alert["priority"] != "P1" {
+		t.Errorf("unexpected alert: %v", alert)
+	}
+	if !strings.HasPrefix(alert["alias"].(string), "vibe-") {
+		t.Errorf("unexpected alias: %v", alert["alias"])
+	}
+}
+
+func TestNewIncidentSinkInvalid(t *testing.T) {
+	if _, err := NewIncidentSink(IncidentConfig{Provider: "slack", Key: "k"}); err == nil {
+		t.Error("expected error for unsupported provider")
+	}
+	if _, err := NewIncidentSink(IncidentConfig{Provider: IncidentPagerDuty}); err == nil {
+		t.Error("expected error for missing key")
+	}
+}
@@ -0,0 +1,58 @@
+package vibelogger
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestVerifyRotatedFiles(t *testing.T) {
+	defer os.RemoveAll("test_logs")
+
+	config := DefaultConfig()
+	config.FilePath = "test_logs/integrity/app.log"
+	logger, err := CreateFileLoggerWithConfig("integrity_test", config)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		logger.Info("test", "Before rotation")
+		if err := logger.ForceRotation(); err != nil {
+			t.Fatalf("Failed to rotate: %v", err)
+		}
+	}
+
+	results, err := logger.VerifyRotatedFiles()
+	if err != nil || len(results) != 3 {
+		t.Fatalf("Expected 3 verified files, got %v (err: %v)", results, err)
+	}
+	for _, result := range results {
+		if result.Status != IntegrityOK || result.Failed() {
+			t.Errorf("Expected untouched files to verify, got %+v", result)
+		}
+	}
+	logger.Close()
+
+	// Tamper with one file and remove another after rotation
+	tampered, removed := results[0].Path, results[1].Path
+	data, _ := os.ReadFile(tampered)
+	data[len(data)/2] ^= 1
+	os.WriteFile(tampered, data, 0644)
+	os.Remove(removed)
+
+	results, err = VerifyLogDir("test_logs")
+	if err != nil || len(results) != 3 {
+		t.Fatalf("Expected 3 results from the directory, got %v (err: %v)", results, err)
+	}
+	statuses := make(map[string]string)
+	for _, result := range results {
+		statuses[result.Path] = result.Status
+	}
+	if statuses[tampered] != IntegrityModified || statuses[removed] != IntegrityMissing || statuses[results[2].Path] == "" {
+		t.Errorf("Expected modified and missing files to be detected, got %v", statuses)
+	}
+
+	if results, err := VerifyRotatedFiles(filepath.Join("test_logs", "unknown.log")); err != nil || results != nil {
+		t.Errorf("Expected no results without state, got %v (err: %v)", results, err)
+	}
+}
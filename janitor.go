@@ -0,0 +1,327 @@
+package vibelogger
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// RetentionPolicy decides which files CleanLogTree removes from every project
+type RetentionPolicy struct {
+	MaxRotatedFiles int           // Rotated files kept per log file, newest first (0 = keep all)
+	MaxAge          time.Duration // Log files not modified for longer are removed (0 = no age limit)
+	Keep            []string      // Files never removed, such as the log files still being written
+}
+
+// JanitorReport summarizes a retention pass over a logs tree
+type JanitorReport struct {
+	Root            string    `json:"root"`
+	StartedAt       time.Time `json:"started_at"`
+	ProjectsScanned int       `json:"projects_scanned"`
+	DeletedFiles    []string  `json:"deleted_files,omitempty"`
+	BytesFreed      int64     `json:"bytes_freed"`
+	RemovedProjects []string  `json:"removed_projects,omitempty"` // Project directories left without log files
+	Errors          []string  `json:"errors,omitempty"`
+}
+
+// janitorLogFile is a log file of a project directory
+type janitorLogFile struct {
+	path    string
+	base    string // Log file a rotated file was rotated from, the file itself otherwise
+	rotated bool
+	size    int64
+	modTime time.Time
+}
+
+// CleanLogTree applies policy to the log files of every project directory
+// below root (normally "logs"), including projects no running logger belongs
+// to. Rotated files beyond MaxRotatedFiles per log file and log files older
+// than MaxAge are removed, rotation state and project metadata are updated,
+// and project directories left without log files are removed. Files listed
+// in Keep and hidden state files are never removed directly.
+//
+// MaxAge also applies to unrotated log files, so it must exceed the longest
+// time a live logger of another process may stay silent; a heartbeat keeps
+// idle loggers' files fresh. Failures to remove single files are collected in
+// the report instead of stopping the pass.
+func CleanLogTree(root string, policy RetentionPolicy) (*JanitorReport, error) {
+	report := &JanitorReport{Root: root, StartedAt: time.Now().UTC()}
+	dirs, err := os.ReadDir(root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read logs directory: %w", err)
+	}
+
+	keep := make(map[string]bool, len(policy.Keep))
+	for _, path := range policy.Keep {
+		if abs, err := filepath.Abs(path); err == nil {
+			keep[abs] = true
+		}
+	}
+
+	for _, d := range dirs {
+		if !d.IsDir() || !isValidProjectName(d.Name()) {
+			continue
+		}
+		report.ProjectsScanned++
+		cleanProjectDir(filepath.Join(root, d.Name()), policy, keep, report)
+	}
+	return report, nil
+}
+
+// cleanProjectDir applies the policy to one project directory
+func cleanProjectDir(dir string, policy RetentionPolicy, keep map[string]bool, report *JanitorReport) {
+	files, err := projectLogFiles(dir)
+	if err != nil {
+		report.Errors = append(report.Errors, err.Error())
+		return
+	}
+
+	// Group rotated files by the log file they came from, newest first
+	rotated := make(map[string][]janitorLogFile)
+	for _, f := range files {
+		if f.rotated {
+			rotated[f.base] = append(rotated[f.base], f)
+		}
+	}
+	doomed := make(map[string]bool)
+	if policy.MaxRotatedFiles > 0 {
+		for _, group := range rotated {
+			sort.SliceStable(group, func(i, j int) bool { return group[i].modTime.After(group[j].modTime) })
+			for _, f := range group[min(policy.MaxRotatedFiles, len(group)):] {
+				doomed[f.path] = true
+			}
+		}
+	}
+	if policy.MaxAge > 0 {
+		cutoff := time.Now().Add(-policy.MaxAge)
+		for _, f := range files {
+			if f.modTime.Before(cutoff) {
+				doomed[f.path] = true
+			}
+		}
+	}
+
+	remaining := 0
+	removedByBase := make(map[string]map[string]bool)
+	for _, f := range files {
+		abs, _ := filepath.Abs(f.path)
+		if !doomed[f.path] || keep[abs] {
+			remaining++
+			continue
+		}
+		if err := os.Remove(f.path); err != nil && !os.IsNotExist(err) {
+			report.Errors = append(report.Errors, fmt.Sprintf("failed to remove %s: %v", f.path, err))
+			remaining++
+			continue
+		}
+		report.DeletedFiles = append(report.DeletedFiles, f.path)
+		report.BytesFreed += f.size
+		if removedByBase[f.base] == nil {
+			removedByBase[f.base] = make(map[string]bool)
+		}
+		removedByBase[f.base][filepath.Base(f.path)] = true
+	}
+	if len(removedByBase) == 0 {
+		return
+	}
+
+	if remaining == 0 && removeEmptyProjectDir(dir, report) {
+		return
+	}
+	for base, removed := range removedByBase {
+		if err := pruneRotationState(base, removed); err != nil {
+			report.Errors = append(report.Errors, err.Error())
+		}
+	}
+	refreshProjectActivity(dir, report)
+}
+
+// projectLogFiles lists the log files of a project directory. Rotated files
+// are named "<log file>.<timestamp>", see RotationManager.
+func projectLogFiles(dir string) ([]janitorLogFile, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read project directory: %w", err)
+	}
+
+	var files []janitorLogFile
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || strings.HasPrefix(name, ".") {
+			continue // Rotation state, project metadata and temporary files
+		}
+		f := janitorLogFile{path: filepath.Join(dir, name)}
+		if i := strings.Index(name, ".log."); i >= 0 {
+			f.base = filepath.Join(dir, name[:i+len(".log")])
+			f.rotated = true
+		} else if strings.HasSuffix(name, ".log") {
+			f.base = f.path
+		} else {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue // Removed by a logger's own retention meanwhile
+		}
+		f.size = info.Size()
+		f.modTime = info.ModTime()
+		files = append(files, f)
+	}
+	return files, nil
+}
+
+// pruneRotationState drops removed rotated files from the rotation state of
+// base, or deletes the state once the log file and its rotated files are gone
+func pruneRotationState(base string, removed map[string]bool) error {
+	state, err := loadRotationState(base)
+	if err != nil || state == nil {
+		return err
+	}
+	if _, err := os.Stat(base); os.IsNotExist(err) {
+		remaining := 0
+		for _, file := range state.Files {
+			if _, err := os.Stat(filepath.Join(filepath.Dir(base), filepath.Base(file.Path))); err == nil {
+				remaining++
+			}
+		}
+		if remaining == 0 {
+			if err := os.Remove(rotationStatePath(base)); err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("failed to remove rotation state: %w", err)
+			}
+			return nil
+		}
+	}
+
+	files := state.Files[:0]
+	for _, file := range state.Files {
+		if !removed[filepath.Base(file.Path)] {
+			files = append(files, file)
+		}
+	}
+	if len(files) == len(state.Files) {
+		return nil
+	}
+	state.Files = files
+	return saveRotationState(base, state)
+}
+
+// removeEmptyProjectDir removes a project directory holding nothing but hidden
+// state files, reporting whether it was removed
+func removeEmptyProjectDir(dir string, report *JanitorReport) bool {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		report.Errors = append(report.Errors, fmt.Sprintf("failed to read project directory: %v", err))
+		return false
+	}
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasPrefix(e.Name(), ".") {
+			return false // A logger created a file meanwhile, or foreign content
+		}
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		report.Errors = append(report.Errors, fmt.Sprintf("failed to remove project directory %s: %v", dir, err))
+		return false
+	}
+	report.RemovedProjects = append(report.RemovedProjects, filepath.Base(dir))
+	return true
+}
+
+// refreshProjectActivity updates the file counts of a project's metadata,
+// keeping its last activity since removing files is not activity
+func refreshProjectActivity(dir string, report *JanitorReport) {
+	activity, err := loadProjectActivity(dir)
+	if err != nil {
+		return // No metadata; GetProjectActivity scans the directory
+	}
+	scanned, err := scanProjectActivity(dir)
+	if err != nil {
+		report.Errors = append(report.Errors, err.Error())
+		return
+	}
+	activity.FileCount = scanned.FileCount
+	activity.TotalBytes = scanned.TotalBytes
+	if err := saveProjectActivity(dir, activity); err != nil {
+		report.Errors = append(report.Errors, err.Error())
+	}
+}
+
+// StartJanitor applies the logger's retention policy to every project below
+// logs/ each interval: MaxRotatedFiles (when rotation is enabled) and
+// RetentionMaxAge. The logger's own file is never removed. Passes that delete
+// files or fail write a "log_janitor" entry with the report. Calling it again
+// replaces the previous janitor; a non-positive interval only stops it.
+func (l *Logger) StartJanitor(interval time.Duration) {
+	l.StopJanitor()
+	if interval <= 0 {
+		return
+	}
+
+	task := startPeriodicTask(interval, func() { l.RunJanitor() })
+	l.bgMutex.Lock()
+	l.janitor = task
+	l.bgMutex.Unlock()
+}
+
+// StopJanitor stops the janitor goroutine if one is running
+func (l *Logger) StopJanitor() {
+	l.bgMutex.Lock()
+	task := l.janitor
+	l.janitor = nil
+	l.bgMutex.Unlock()
+
+	task.Stop()
+}
+
+// RunJanitor performs a single janitor pass immediately, see StartJanitor
+func (l *Logger) RunJanitor() (*JanitorReport, error) {
+	l.mutex.Lock()
+	policy := RetentionPolicy{MaxAge: l.config.RetentionMaxAge}
+	if l.config.RotationEnabled {
+		policy.MaxRotatedFiles = l.config.MaxRotatedFiles
+	}
+	if l.filePath != "" {
+		policy.Keep = []string{l.filePath}
+	}
+	l.mutex.Unlock()
+
+	report, err := CleanLogTree(l.config.logsRoot(), policy)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) { // No project has written logs yet
+			l.Warn("log_janitor", "Log retention pass failed", WithError(err))
+		}
+		return nil, err
+	}
+	l.writeJanitorReport(report)
+	return report, nil
+}
+
+// writeJanitorReport logs a report that deleted files or hit errors
+func (l *Logger) writeJanitorReport(report *JanitorReport) {
+	if len(report.DeletedFiles) == 0 && len(report.Errors) == 0 {
+		return
+	}
+
+	level := INFO
+	message := fmt.Sprintf("Removed %d old log files (%d bytes)", len(report.DeletedFiles), report.BytesFreed)
+	if len(report.Errors) > 0 {
+		level = WARN
+		message += fmt.Sprintf(", %d errors", len(report.Errors))
+	}
+	context := map[string]interface{}{
+		"projects_scanned": report.ProjectsScanned,
+		"deleted_count":    len(report.DeletedFiles),
+		"bytes_freed":      report.BytesFreed,
+		"deleted_files":    report.DeletedFiles,
+	}
+	if len(report.RemovedProjects) > 0 {
+		context["removed_projects"] = report.RemovedProjects
+	}
+	if len(report.Errors) > 0 {
+		context["errors"] = report.Errors
+	}
+	l.Log(level, "log_janitor", message, WithContext(context))
+}
@@ -0,0 +1,127 @@
+package vibelogger
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeAgedFile creates a file with the given modification time
+func writeAgedFile(t *testing.T, path string, modTime time.Time) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("{}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCleanLogTree(t *testing.T) {
+	root := t.TempDir()
+	now := time.Now()
+	old := now.Add(-48 * time.Hour)
+
+	// Project with a current file and three rotated files
+	api := filepath.Join(root, "api")
+	base := filepath.Join(api, "server_20240101_000000.log")
+	writeAgedFile(t, base, now)
+	for i, age := range []time.Duration{time.Hour, 2 * time.Hour, 3 * time.Hour} {
+		writeAgedFile(t, fmt.Sprintf("%s.%d", base, i), now.Add(-age))
+	}
+	if err := saveRotationState(base, &RotationState{Version: rotationStateVersion, Files: []RotatedFileState{
+		{Path: base + ".0"}, {Path: base + ".1"}, {Path: base + ".2"},
+	}}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Abandoned project with only old files, and a kept old file elsewhere
+	writeAgedFile(t, filepath.Join(root, "stale", "job_20230101_000000.log"), old)
+	kept := filepath.Join(root, "worker", "worker_20230101_000000.log")
+	writeAgedFile(t, kept, old)
+
+	report, err := CleanLogTree(root, RetentionPolicy{MaxRotatedFiles: 2, MaxAge: 24 * time.Hour, Keep: []string{kept}})
+	if err != nil {
+		t.Fatalf("CleanLogTree: %v", err)
+	}
+
+	if report.ProjectsScanned != 3 || len(report.Errors) != 0 {
+		t.Errorf("Unexpected report: %+v", report)
+	}
+	if len(report.DeletedFiles) != 2 || report.BytesFreed != 6 {
+		t.Errorf("Expected the oldest rotated file and the stale log removed, got %v (%d bytes)", report.DeletedFiles, report.BytesFreed)
+	}
+	if _, err := os.Stat(base + ".2"); !os.IsNotExist(err) {
+		t.Error("Rotated file beyond MaxRotatedFiles should be removed")
+	}
+	for _, path := range []string{base, base + ".0", base + ".1", kept} {
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("%s should be kept: %v", path, err)
+		}
+	}
+	if len(report.RemovedProjects) != 1 || report.RemovedProjects[0] != "stale" {
+		t.Errorf("Expected the stale project to be removed, got %v", report.RemovedProjects)
+	}
+	if _, err := os.Stat(filepath.Join(root, "stale")); !os.IsNotExist(err) {
+		t.Error("Empty project directory should be removed")
+	}
+
+	state, err := loadRotationState(base)
+	if err != nil || state == nil || len(state.Files) != 2 {
+		t.Fatalf("Expected the removed file dropped from the rotation state, got %+v (%v)", state, err)
+	}
+
+	// A second pass has nothing left to do
+	report, err = CleanLogTree(root, RetentionPolicy{MaxRotatedFiles: 2, MaxAge: 24 * time.Hour, Keep: []string{kept}})
+	if err != nil || len(report.DeletedFiles) != 0 {
+		t.Errorf("Expected an idempotent second pass, got %v (%v)", report.DeletedFiles, err)
+	}
+}
+
+func TestLoggerJanitor(t *testing.T) {
+	// Far older than anything else below logs/, so only this file qualifies
+	ancient := time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC)
+	staleDir := filepath.Join("logs", "janitor_stale_test")
+	defer os.RemoveAll(staleDir)
+	writeAgedFile(t, filepath.Join(staleDir, "old_19900101_000000.log"), ancient)
+
+	config := DefaultConfig()
+	config.ProjectName = "janitor_test"
+	config.EnableMemoryLog = true
+	config.RetentionMaxAge = time.Since(ancient) - 24*time.Hour
+	logger, err := CreateFileLoggerWithConfig("janitor", config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(filepath.Join("logs", "janitor_test"))
+	defer logger.Close()
+
+	report, err := logger.RunJanitor()
+	if err != nil {
+		t.Fatalf("RunJanitor: %v", err)
+	}
+	if len(report.RemovedProjects) != 1 || report.RemovedProjects[0] != "janitor_stale_test" {
+		t.Fatalf("Expected the stale project removed, got %+v", report)
+	}
+
+	var entry *LogEntry
+	for _, e := range logger.GetMemoryLogs() {
+		if e.Operation == "log_janitor" {
+			entry = &e
+		}
+	}
+	if entry == nil || entry.Level != INFO || entry.Context["deleted_count"] != 1 {
+		t.Fatalf("Expected a log_janitor report entry, got %+v", entry)
+	}
+
+	// Periodic passes run until stopped
+	logger.StartJanitor(time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+	logger.StopJanitor()
+	logger.StopJanitor() // Stopping twice is safe
+}
@@ -0,0 +1,120 @@
+package vibelogger
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultJournaldSocket is the native protocol socket of systemd-journald
+const DefaultJournaldSocket = "/run/systemd/journal/socket"
+
+// JournaldSink sends entries to systemd-journald using the native journal protocol,
+// so they can be filtered with journalctl (e.g. journalctl CORRELATION_ID=abc).
+type JournaldSink struct {
+	identifier string
+	conn       *net.UnixConn
+}
+
+// NewJournaldSink connects to the local journal. identifier becomes SYSLOG_IDENTIFIER.
+func NewJournaldSink(identifier string) (*JournaldSink, error) {
+	return newJournaldSink(identifier, DefaultJournaldSocket)
+}
+
+// newJournaldSink connects to a journal socket at the given path
+func newJournaldSink(identifier, socketPath string) (*JournaldSink, error) {
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: socketPath, Net: "unixgram"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to journald socket %s: %w", socketPath, err)
+	}
+	return &JournaldSink{identifier: identifier, conn: conn}, nil
+}
+
+// Write sends a single entry as one journal record
+func (s *JournaldSink) Write(entry *LogEntry) error {
+	if _, err := s.conn.Write(encodeJournalEntry(s.identifier, entry)); err != nil {
+		return fmt.Errorf("failed to write to journald: %w", err)
+	}
+	return nil
+}
+
+// Close closes the connection to the journal
+func (s *JournaldSink) Close() error {
+	return s.conn.Close()
+}
+
+// journalPriority maps a log level to a syslog priority
+func journalPriority(level LogLevel) int {
+	switch level {
+	case ERROR:
+		return 3 // err
+	case WARN:
+		return 4 // warning
+	case DEBUG:
+		return 7 // debug
+	default:
+		return 6 // info
+	}
+}
+
+// encodeJournalEntry serializes an entry into a native journal protocol datagram
+func encodeJournalEntry(identifier string, entry *LogEntry) []byte {
+	var buf bytes.Buffer
+
+	appendJournalField(&buf, "MESSAGE", entry.FullMessage())
+	appendJournalField(&buf, "PRIORITY", strconv.Itoa(journalPriority(entry.Level)))
+	appendJournalField(&buf, "SYSLOG_IDENTIFIER", identifier)
+	appendJournalField(&buf, "VIBE_LEVEL", string(entry.Level))
+	appendJournalField(&buf, "VIBE_OPERATION", entry.Operation)
+	appendJournalField(&buf, "VIBE_TIMESTAMP", entry.Timestamp.Format(time.RFC3339Nano))
+	appendJournalField(&buf, "VIBE_SEVERITY", strconv.Itoa(entry.Severity))
+
+	optional := []struct{ key, value string }{
+		{"CORRELATION_ID", entry.CorrelationID},
+		{"VIBE_SESSION_ID", entry.SessionID},
+		{"VIBE_ENTRY_ID", entry.ID},
+		{"CATEGORY", entry.Category},
+		{"VIBE_PATTERN", entry.Pattern},
+		{"VIBE_SUGGESTION", entry.Suggestion},
+		{"VIBE_RUNBOOK_URL", entry.RunbookURL},
+		{"VIBE_HUMAN_NOTE", entry.HumanNote},
+		{"VIBE_AI_TODO", entry.AITodo},
+		{"VIBE_STACK_TRACE", strings.Join(entry.StackTrace, "\n")},
+	}
+	for _, field := range optional {
+		if field.value != "" {
+			appendJournalField(&buf, field.key, field.value)
+		}
+	}
+
+	if len(entry.Context) > 0 {
+		if contextJSON, err := json.Marshal(entry.Context); err == nil {
+			appendJournalField(&buf, "VIBE_CONTEXT", string(contextJSON))
+		}
+	}
+
+	return buf.Bytes()
+}
+
+// appendJournalField appends a KEY=VALUE pair, switching to the binary-safe
+// length-prefixed form when the value contains a newline
+func appendJournalField(buf *bytes.Buffer, key, value string) {
+	if !strings.Contains(value, "\n") {
+		buf.WriteString(key)
+		buf.WriteByte('=')
+		buf.WriteString(value)
+		buf.WriteByte('\n')
+		return
+	}
+
+	buf.WriteString(key)
+	buf.WriteByte('\n')
+	binary.Write(buf, binary.LittleEndian, uint64(len(value)))
+	buf.WriteString(value)
+	buf.WriteByte('\n')
+}
@@ -0,0 +1,96 @@
+package vibelogger
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// parseJournalDatagram decodes a native journal protocol datagram into fields
+func parseJournalDatagram(t *testing.T, data []byte) map[string]string {
+	t.Helper()
+
+	fields := make(map[string]string)
+	for len(data) > 0 {
+		nl := bytes.IndexByte(data, '\n')
+		if nl < 0 {
+			t.Fatalf("Unterminated journal field: %q", data)
+		}
+		line := data[:nl]
+		data = data[nl+1:]
+
+		if eq := bytes.IndexByte(line, '='); eq >= 0 {
+			fields[string(line[:eq])] = string(line[eq+1:])
+			continue
+		}
+
+		// Binary-safe form: KEY\n<uint64 length><value>\n
+		size := binary.LittleEndian.Uint64(data[:8])
+		fields[string(line)] = string(data[8 : 8+size])
+		data = data[8+size+1:]
+	}
+	return fields
+}
+
+func TestJournaldSink(t *testing.T) {
+	socketPath := filepath.Join(t.TempDir(), "journal.sock")
+	listener, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: socketPath, Net: "unixgram"})
+	if err != nil {
+		t.Skipf("Unix datagram sockets not available: %v", err)
+	}
+	defer listener.Close()
+
+	sink, err := newJournaldSink("vibe-test", socketPath)
+	if err != nil {
+		t.Fatalf("Failed to create journald sink: %v", err)
+	}
+
+	config := &LoggerConfig{AutoSave: false}
+	logger := NewLoggerWithConfig("journald_test", config)
+	logger.AddSink(sink)
+	defer logger.Close()
+
+	if err := logger.Error("db_query", "connection refused", WithCorrelationID("req-42"),
+		WithContext(map[string]interface{}{"table": "users"})); err != nil {
+		t.Fatalf("Failed to log: %v", err)
+	}
+
+	buf := make([]byte, 65536)
+	listener.SetReadDeadline(time.Now().Add(2 * time.Second))
+	n, err := listener.Read(buf)
+	if err != nil {
+		t.Fatalf("Failed to read datagram: %v", err)
+	}
+
+	fields := parseJournalDatagram(t, buf[:n])
+	expected := map[string]string{
+		"MESSAGE":           "connection refused",
+		"PRIORITY":          "3",
+		"SYSLOG_IDENTIFIER": "vibe-test",
+		"CORRELATION_ID":    "req-42",
+		"CATEGORY":          "database",
+		"VIBE_OPERATION":    "db_query",
+		"VIBE_PATTERN":      "database_error",
+		"VIBE_CONTEXT":      `{"table":"users"}`,
+	}
+	for key, value := range expected {
+		if fields[key] != value {
+			t.Errorf("Expected %s=%q, got %q", key, value, fields[key])
+		}
+	}
+	if fields["VIBE_STACK_TRACE"] == "" {
+		t.Error("Expected multi-line stack trace to be sent in binary-safe form")
+	}
+}
+
+func TestJournalPriority(t *testing.T) {
+	tests := map[LogLevel]int{ERROR: 3, WARN: 4, INFO: 6, DEBUG: 7}
+	for level, priority := range tests {
+		if got := journalPriority(level); got != priority {
+			t.Errorf("Expected priority %d for %s, got %d", priority, level, got)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package vibelogger
+
+import (
+	"fmt"
+	"path"
+	"sync"
+	"time"
+)
+
+// SLAViolationTag is added to entries whose duration exceeds the latency
+// budget of their operation, e.g. to page with IncidentOnTag
+const SLAViolationTag = "sla_violation"
+
+// LatencyBudget is the expected latency of an operation, e.g. "checkout must
+// finish within 800ms". Entries carrying a duration (WithDuration, transports,
+// watchdogs) longer than the budget are flagged as SLA violations.
+type LatencyBudget struct {
+	Operation  string        // Operation name or path.Match pattern; empty matches every operation
+	Budget     time.Duration // Longest acceptable duration
+	EscalateTo LogLevel      // Level violating entries are raised to; empty keeps the level
+}
+
+// latencyState holds the budgets and the budget resolved per operation
+type latencyState struct {
+	mutex   sync.Mutex
+	budgets []LatencyBudget
+	byOp    map[string]*LatencyBudget // nil for operations without a budget
+}
+
+// SetLatencyBudgets replaces the latency budgets of the logger. Each operation
+// uses the first budget matching it. Violating entries get the sla_violation
+// tag and context fields with the budget and the overrun, and are raised to
+// EscalateTo when that is above their level, before escalation rules and SLO
+// tracking see them. Calling it without budgets disables the check.
+func (l *Logger) SetLatencyBudgets(budgets ...LatencyBudget) error {
+	for i, budget := range budgets {
+		if budget.Budget <= 0 {
+			return fmt.Errorf("latency budget %d: budget must be positive", i)
+		}
+		if budget.EscalateTo != "" {
+			if _, err := ParseLevel(string(budget.EscalateTo)); err != nil {
+				return fmt.Errorf("latency budget %d: %w", i, err)
+			}
+		}
+		if _, err := path.Match(budget.Operation, ""); err != nil {
+			return fmt.Errorf("latency budget %d: invalid operation pattern %q: %w", i, budget.Operation, err)
+		}
+	}
+
+	var state *latencyState
+	if len(budgets) > 0 {
+		state = &latencyState{
+			budgets: make([]LatencyBudget, len(budgets)),
+			byOp:    make(map[string]*LatencyBudget),
+		}
+		for i, budget := range budgets {
+			budget.EscalateTo, _ = ParseLevel(string(budget.EscalateTo))
+			state.budgets[i] = budget
+		}
+	}
+	l.mutex.Lock()
+	l.latency = state
+	l.mutex.Unlock()
+	return nil
+}
+
+// budgetFor returns the budget of an operation, nil if it has none
+func (s *latencyState) budgetFor(operation string) *LatencyBudget {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	budget, ok := s.byOp[operation]
+	if !ok {
+		for i := range s.budgets {
+			candidate := &s.budgets[i]
+			if matched, _ := path.Match(candidate.Operation, operation); candidate.Operation == "" || matched {
+				budget = candidate
+				break
+			}
+		}
+		// Operations without a budget are remembered as nil to skip the patterns next time
+		s.byOp[operation] = budget
+	}
+	return budget
+}
+
+// checkLatencyBudget flags an entry whose duration exceeds its operation's
+// budget and reports whether its level was raised
+func (l *Logger) checkLatencyBudget(entry *LogEntry) bool {
+	if entry.Duration <= 0 {
+		return false
+	}
+	l.mutex.Lock()
+	state := l.latency
+	l.mutex.Unlock()
+	if state == nil {
+		return false
+	}
+
+	budget := state.budgetFor(entry.Operation)
+	if budget == nil || entry.Duration <= budget.Budget {
+		return false
+	}
+
+	if entry.Context == nil {
+		entry.Context = make(map[string]interface{})
+	}
+	entry.Context["sla_violation"] = true
+	entry.Context["sla_budget_ms"] = budget.Budget.Milliseconds()
+	entry.Context["sla_overrun_ms"] = (entry.Duration - budget.Budget).Milliseconds()
+	if !entry.HasTag(SLAViolationTag) {
+		entry.Tags = append(entry.Tags, SLAViolationTag)
+	}
+
+	if budget.EscalateTo == "" || getSeverityScore(budget.EscalateTo) <= getSeverityScore(entry.Level) {
+		return false
+	}
+	entry.Context["sla_escalated_from"] = string(entry.Level)
+	entry.Level = budget.EscalateTo
+	return true
+}
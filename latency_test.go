@@ -0,0 +1,98 @@
+package vibelogger
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLatencyBudgets(t *testing.T) {
+	logger := NewLoggerWithConfig("latency", &LoggerConfig{AutoSave: false, EnableMemoryLog: true, MemoryLogLimit: 10})
+	defer logger.Close()
+
+	err := logger.SetLatencyBudgets(
+		LatencyBudget{Operation: "checkout", Budget: 800 * time.Millisecond, EscalateTo: WARN},
+		LatencyBudget{Operation: "db_*", Budget: 50 * time.Millisecond},
+	)
+	if err != nil {
+		t.Fatalf("SetLatencyBudgets failed: %v", err)
+	}
+
+	logger.Info("checkout", "Order placed", WithDuration(500*time.Millisecond))
+	logger.Info("checkout", "Order placed", WithDuration(1200*time.Millisecond))
+	logger.Info("db_query", "Query done", WithDuration(80*time.Millisecond), WithTags(SLAViolationTag))
+	logger.Error("checkout", "Payment failed", WithDuration(2*time.Second))
+	logger.Info("search", "Results", WithDuration(time.Minute))
+	logger.Info("checkout", "No duration")
+
+	logs := logger.GetMemoryLogs()
+	if len(logs) != 6 {
+		t.Fatalf("expected 6 entries, got %d", len(logs))
+	}
+
+	if _, ok := logs[0].Context["sla_violation"]; ok {
+		t.Error("entry within budget should not be flagged")
+	}
+
+	slow := logs[1]
+	if slow.Level != WARN || slow.Severity != getSeverityScore(WARN) {
+		t.Errorf("slow checkout should be escalated to WARN, got %s", slow.Level)
+	}
+	if slow.Context["sla_violation"] != true || slow.Context["sla_budget_ms"] != int64(800) || slow.Context["sla_overrun_ms"] != int64(400) {
+		t.Errorf("unexpected SLA context: %v", slow.Context)
+	}
+	if slow.Context["sla_escalated_from"] != "INFO" || !slow.HasTag(SLAViolationTag) {
+		t.Errorf("expected escalation source and tag, got %v %v", slow.Context["sla_escalated_from"], slow.Tags)
+	}
+
+	query := logs[2]
+	if query.Level != INFO || query.Context["sla_violation"] != true || len(query.Tags) != 1 {
+		t.Errorf("slow query should be flagged without escalation or duplicate tags, got %s %v", query.Level, query.Tags)
+	}
+
+	failed := logs[3]
+	if failed.Level != ERROR || failed.Context["sla_escalated_from"] != nil {
+		t.Errorf("budgets must never lower the level, got %s", failed.Level)
+	}
+	for _, entry := range logs[4:] {
+		if _, ok := entry.Context["sla_violation"]; ok {
+			t.Errorf("%s entry should not be flagged", entry.Operation)
+		}
+	}
+
+	stats := logger.Stats()
+	if stats.SLAViolations["checkout"] != 2 || stats.SLAViolations["db_query"] != 1 {
+		t.Errorf("unexpected SLA violation counts: %v", stats.SLAViolations)
+	}
+
+	logger.writeSummary()
+	logs = logger.GetMemoryLogs()
+	summary := logs[len(logs)-1]
+	if violations, ok := summary.Context["sla_violations"].(map[string]int64); !ok || violations["checkout"] != 2 {
+		t.Errorf("summary should report SLA violations, got %v", summary.Context["sla_violations"])
+	}
+
+	if err := logger.SetLatencyBudgets(); err != nil {
+		t.Fatal(err)
+	}
+	logger.Info("checkout", "Order placed", WithDuration(time.Hour))
+	logs = logger.GetMemoryLogs()
+	if entry := logs[len(logs)-1]; entry.Context["sla_violation"] != nil {
+		t.Error("budgets should be disabled without arguments")
+	}
+}
+
+func TestLatencyBudgetValidation(t *testing.T) {
+	logger := NewLoggerWithConfig("latency_validation", &LoggerConfig{AutoSave: false})
+	defer logger.Close()
+
+	invalid := []LatencyBudget{
+		{Operation: "checkout"},
+		{Operation: "checkout", Budget: time.Second, EscalateTo: "FATAL"},
+		{Operation: "[", Budget: time.Second},
+	}
+	for i, budget := range invalid {
+		if err := logger.SetLatencyBudgets(budget); err == nil {
+			t.Errorf("budget %d should be rejected", i)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package vibelogger
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Limits and wording used by FormatForLLM
+const (
+	llmStackFrames   = 5
+	llmContextLength = 300
+	llmOmittedNote   = "\n_%d less important entries omitted to fit the token budget._\n"
+)
+
+// llmBlock is a group of identical entries rendered as one list item
+type llmBlock struct {
+	entry    LogEntry
+	count    int
+	last     LogEntry
+	position int
+	text     string
+}
+
+// EstimateTokens roughly estimates the number of LLM tokens in s, assuming
+// about four characters per token
+func EstimateTokens(s string) int {
+	return (len(s) + 3) / 4
+}
+
+// FormatForLLM renders entries as compact markdown for pasting into an LLM
+// prompt. Entries with the same level, operation and message are merged with
+// a count, environment blocks are dropped, context is compacted and stack
+// traces are cut to the frames around the first application frame. When the
+// result exceeds tokenBudget (estimated with EstimateTokens), the least severe
+// and then the oldest groups are omitted. A budget of 0 disables the limit.
+func FormatForLLM(entries []LogEntry, tokenBudget int) string {
+	var blocks []*llmBlock
+	groups := make(map[string]*llmBlock)
+	for i := range entries {
+		entry := entries[i]
+		key := string(entry.Level) + "\x00" + entry.Operation + "\x00" + entry.FullMessage()
+		if block, ok := groups[key]; ok {
+			block.count++
+			block.last = entry
+			continue
+		}
+		block := &llmBlock{entry: entry, count: 1, last: entry, position: len(blocks)}
+		groups[key] = block
+		blocks = append(blocks, block)
+	}
+	for _, block := range blocks {
+		block.text = renderLLMBlock(block)
+	}
+
+	header := fmt.Sprintf("## Log excerpt (%d entries, %d distinct)\n\n", len(entries), len(blocks))
+	kept := blocks
+	omitted := 0
+	if tokenBudget > 0 {
+		// Drop the least severe, then the oldest groups until the excerpt fits
+		order := append([]*llmBlock(nil), blocks...)
+		sort.SliceStable(order, func(i, j int) bool {
+			si, sj := getSeverityScore(order[i].entry.Level), getSeverityScore(order[j].entry.Level)
+			if si != sj {
+				return si < sj
+			}
+			return order[i].position < order[j].position
+		})
+		dropped := make(map[*llmBlock]bool)
+		size := EstimateTokens(header)
+		for _, block := range blocks {
+			size += EstimateTokens(block.text)
+		}
+		target := tokenBudget
+		if size > tokenBudget {
+			// Leave room for the omission note
+			target -= EstimateTokens(fmt.Sprintf(llmOmittedNote, len(entries)))
+		}
+		for _, block := range order {
+			if size <= target {
+				break
+			}
+			dropped[block] = true
+			size -= EstimateTokens(block.text)
+			omitted += block.count
+		}
+		kept = nil
+		for _, block := range blocks {
+			if !dropped[block] {
+				kept = append(kept, block)
+			}
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(header)
+	for _, block := range kept {
+		b.WriteString(block.text)
+	}
+	if omitted > 0 {
+		fmt.Fprintf(&b, llmOmittedNote, omitted)
+	}
+	return b.String()
+}
+
+// renderLLMBlock renders a group of identical entries
+func renderLLMBlock(block *llmBlock) string {
+	entry := &block.entry
+	var b strings.Builder
+	fmt.Fprintf(&b, "- %s **%s** `%s`: %s", entry.Timestamp.UTC().Format("15:04:05"), entry.Level, entry.Operation, entry.Message)
+	if block.count > 1 {
+		fmt.Fprintf(&b, " (×%d, last %s)", block.count, block.last.Timestamp.UTC().Format("15:04:05"))
+	}
+	b.WriteString("\n")
+
+	for _, line := range entry.MessageLines {
+		fmt.Fprintf(&b, "  > %s\n", line)
+	}
+	pattern := entry.Pattern
+	if pattern == "unknown_pattern" {
+		pattern = ""
+	}
+	details := []struct{ name, value string }{
+		{"correlation", entry.CorrelationID},
+		{"pattern", pattern},
+		{"suggestion", entry.Suggestion},
+		{"runbook", entry.RunbookURL},
+		{"note", entry.HumanNote},
+		{"todo", entry.AITodo},
+	}
+	for _, detail := range details {
+		if detail.value != "" {
+			fmt.Fprintf(&b, "  - %s: %s\n", detail.name, detail.value)
+		}
+	}
+	if context := compactContext(entry.Context); context != "" {
+		fmt.Fprintf(&b, "  - context: `%s`\n", context)
+	}
+	if frames := trimStack(entry.StackTrace, llmStackFrames); len(frames) > 0 {
+		b.WriteString("  - stack:\n")
+		for _, frame := range frames {
+			fmt.Fprintf(&b, "    - %s\n", frame)
+		}
+	}
+	return b.String()
+}
+
+// compactContext encodes the context as one JSON line of bounded length
+func compactContext(context map[string]interface{}) string {
+	if len(context) == 0 {
+		return ""
+	}
+	data, err := json.Marshal(context)
+	if err != nil {
+		return ""
+	}
+	if runes := []rune(string(data)); len(runes) > llmContextLength {
+		return string(runes[:llmContextLength]) + "…"
+	}
+	return string(data)
+}
+
+// trimStack keeps up to n frames starting at the first application frame
+func trimStack(stack []string, n int) []string {
+	if len(stack) == 0 {
+		return nil
+	}
+	start := 0
+	for i, frame := range stack {
+		if strings.HasPrefix(frame, AppFrameMarker) {
+			start = i
+			break
+		}
+	}
+	end := start + n
+	if end > len(stack) {
+		end = len(stack)
+	}
+	frames := append([]string(nil), stack[start:end]...)
+	if rest := len(stack) - end + start; rest > 0 {
+		frames = append(frames, fmt.Sprintf("… %d more frames", rest))
+	}
+	return frames
+}
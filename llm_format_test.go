@@ -0,0 +1,60 @@
+package vibelogger
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFormatForLLM(t *testing.T) {
+	base := time.Date(2026, 1, 2, 10, 30, 0, 0, time.UTC)
+	entries := []LogEntry{
+		{Timestamp: base, Level: INFO, Operation: "startup", Message: "Service ready", Environment: map[string]string{"go_version": "go1.21"}},
+		{Timestamp: base.Add(time.Second), Level: ERROR, Operation: "db_query", Message: "connection refused",
+			Pattern: "database_error", Suggestion: "Check database connectivity",
+			Context: map[string]interface{}{"table": "orders"},
+			StackTrace: []string{
+				"/src/vibe-logger-go/logger.go:10 github.com/sumee-139/vibe-logger-go.(*Logger).Error",
+				AppFrameMarker + "/src/app/db.go:42 main.query",
+				"/src/app/main.go:1 main.a", "/src/app/main.go:2 main.b", "/src/app/main.go:3 main.c",
+				"/src/app/main.go:4 main.d", "/src/app/main.go:5 main.e",
+			}},
+		{Timestamp: base.Add(2 * time.Second), Level: ERROR, Operation: "db_query", Message: "connection refused"},
+	}
+
+	out := FormatForLLM(entries, 0)
+	if !strings.Contains(out, "3 entries, 2 distinct") {
+		t.Errorf("missing header: %s", out)
+	}
+	if !strings.Contains(out, "(×2, last 10:30:02)") {
+		t.Errorf("duplicates should be merged: %s", out)
+	}
+	if strings.Contains(out, "go_version") {
+		t.Errorf("environment should be dropped: %s", out)
+	}
+	if strings.Contains(out, "vibe-logger-go.(*Logger).Error") || !strings.Contains(out, "… 2 more frames") {
+		t.Errorf("stack should start at the app frame and be truncated: %s", out)
+	}
+	if !strings.Contains(out, `context: `+"`"+`{"table":"orders"}`) {
+		t.Errorf("context should be compact: %s", out)
+	}
+}
+
+func TestFormatForLLMBudget(t *testing.T) {
+	var entries []LogEntry
+	for i := 0; i < 20; i++ {
+		entries = append(entries, LogEntry{Level: INFO, Operation: "tick", Message: strings.Repeat("x", 40) + string(rune('a'+i))})
+	}
+	entries = append(entries, LogEntry{Level: ERROR, Operation: "crash", Message: "fatal failure"})
+
+	out := FormatForLLM(entries, 100)
+	if EstimateTokens(out) > 100 {
+		t.Errorf("excerpt exceeds the budget: %d tokens", EstimateTokens(out))
+	}
+	if !strings.Contains(out, "fatal failure") {
+		t.Errorf("errors should be kept over info entries: %s", out)
+	}
+	if !strings.Contains(out, "entries omitted") {
+		t.Errorf("omission should be noted: %s", out)
+	}
+}
@@ -0,0 +1,291 @@
+package vibelogger
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// EntryIterator streams log entries one at a time:
+//
+//	for it.Next() {
+//		entry := it.Entry()
+//		...
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+//
+// The entry returned by Entry is only valid until the next call of Next; copy
+// it to keep it. Close releases the underlying files and may be called at any
+// time, also before the iteration ends.
+type EntryIterator interface {
+	Next() bool
+	Entry() *LogEntry
+	Err() error
+	Close() error
+}
+
+// LogIterator streams the entries of a single log. Memory use is bounded by
+// the largest record (see WithMaxRecordSize) instead of growing with the log,
+// so multi-gigabyte files can be scanned in constant memory. Corrupt records
+// are skipped like with ReadLog and reported through Corrupt.
+type LogIterator struct {
+	scanner *recordScanner
+	options readOptions
+	closer  io.Closer
+	entry   LogEntry
+	header  *FileHeader
+	footer  *FileFooter
+	corrupt []CorruptRecord
+	err     error
+}
+
+// NewLogIterator returns an iterator over the entries read from r
+func NewLogIterator(r io.Reader, options ...ReadOption) (*LogIterator, error) {
+	opts, err := newReadOptions(options)
+	if err != nil {
+		return nil, err
+	}
+	scanner := newRecordScanner(r)
+	scanner.maxRecord = opts.maxRecordSize
+	scanner.maxNesting = opts.maxNesting
+	return &LogIterator{scanner: scanner, options: opts}, nil
+}
+
+// OpenLogIterator returns an iterator over the entries of a log file. The
+// file stays open until Close is called.
+func OpenLogIterator(path string, options ...ReadOption) (*LogIterator, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open log file: %w", err)
+	}
+	it, err := NewLogIterator(file, options...)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	it.closer = file
+	return it, nil
+}
+
+// Next advances to the next entry, skipping header, footer and corrupt records
+func (it *LogIterator) Next() bool {
+	it.corrupt = nil
+	if it.err != nil {
+		return false
+	}
+	for it.scanner.Next() {
+		rec := it.scanner.Record()
+		switch {
+		case rec.reason != "":
+			it.corrupt = append(it.corrupt, newCorruptRecord(rec, rec.reason))
+			continue
+		case !rec.complete:
+			it.corrupt = append(it.corrupt, newCorruptRecord(rec, "incomplete record"))
+			continue
+		}
+
+		it.entry = LogEntry{}
+		header, footer, err := decodeRecord(rec.data, &it.options, &it.entry)
+		switch {
+		case err != nil:
+			it.corrupt = append(it.corrupt, newCorruptRecord(rec, err.Error()))
+		case header != nil:
+			it.header = header
+			it.footer = nil // A new header starts a new file section
+		case footer != nil:
+			it.footer = footer
+		default:
+			if it.header != nil && it.header.Environment != nil {
+				it.entry.Environment = applyEnvironment(it.header.Environment, it.entry.Environment)
+			}
+			return true
+		}
+	}
+	if err := it.scanner.Err(); err != nil {
+		it.err = fmt.Errorf("failed to read log data: %w", err)
+	}
+	return false
+}
+
+// Entry returns the current entry
+func (it *LogIterator) Entry() *LogEntry {
+	return &it.entry
+}
+
+// Corrupt returns the records skipped by the last call of Next, so corrupt
+// records do not accumulate while streaming
+func (it *LogIterator) Corrupt() []CorruptRecord {
+	return it.corrupt
+}
+
+// Header returns the most recent file header seen so far, nil if none
+func (it *LogIterator) Header() *FileHeader {
+	return it.header
+}
+
+// Footer returns the footer ending the current file section, nil if none was
+// seen yet. Once Next returned false, a nil footer means the file was not
+// closed cleanly, see ReadResult.CleanShutdown.
+func (it *LogIterator) Footer() *FileFooter {
+	return it.footer
+}
+
+// Err returns the read error that ended the iteration, if any
+func (it *LogIterator) Err() error {
+	return it.err
+}
+
+// Close closes the file opened by OpenLogIterator
+func (it *LogIterator) Close() error {
+	if it.closer == nil {
+		return nil
+	}
+	closer := it.closer
+	it.closer = nil
+	return closer.Close()
+}
+
+// MergeEntries merges iterators into one iterator ordered by timestamp.
+// Each iterator is expected to yield its entries in time order, as log files
+// are written; only one entry per iterator is held at a time. Entries with
+// equal timestamps keep the order of the iterators. Closing the merged
+// iterator closes all of them.
+func MergeEntries(iterators ...EntryIterator) EntryIterator {
+	m := &mergeIterator{sources: iterators}
+	for i, it := range iterators {
+		if it.Next() {
+			m.heap = append(m.heap, mergeSource{it: it, index: i})
+		} else if err := it.Err(); err != nil {
+			m.err = err
+		}
+	}
+	heap.Init(&m.heap)
+	return m
+}
+
+// mergeSource is an iterator positioned on its next entry
+type mergeSource struct {
+	it    EntryIterator
+	index int // Position among the merged iterators, to order equal timestamps
+}
+
+// mergeHeap orders sources by the timestamp of their current entry
+type mergeHeap []mergeSource
+
+func (h mergeHeap) Len() int { return len(h) }
+func (h mergeHeap) Less(i, j int) bool {
+	ti, tj := h[i].it.Entry().Timestamp, h[j].it.Entry().Timestamp
+	if ti.Equal(tj) {
+		return h[i].index < h[j].index
+	}
+	return ti.Before(tj)
+}
+func (h mergeHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *mergeHeap) Push(x interface{}) { *h = append(*h, x.(mergeSource)) }
+func (h *mergeHeap) Pop() interface{} {
+	old := *h
+	source := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return source
+}
+
+// mergeIterator implements MergeEntries
+type mergeIterator struct {
+	sources []EntryIterator
+	heap    mergeHeap
+	current EntryIterator // Source of the returned entry, advanced on the next call
+	err     error
+}
+
+// Next advances to the oldest pending entry of all sources
+func (m *mergeIterator) Next() bool {
+	// The returned entry belongs to its source, so the source only moves on now
+	if m.current != nil {
+		if m.current.Next() {
+			heap.Fix(&m.heap, 0)
+		} else {
+			if err := m.current.Err(); err != nil && m.err == nil {
+				m.err = err
+			}
+			heap.Pop(&m.heap)
+		}
+		m.current = nil
+	}
+	if m.err != nil || len(m.heap) == 0 {
+		return false
+	}
+	m.current = m.heap[0].it
+	return true
+}
+
+// Entry returns the current entry
+func (m *mergeIterator) Entry() *LogEntry {
+	if m.current == nil {
+		return nil
+	}
+	return m.current.Entry()
+}
+
+// Err returns the first error of any source
+func (m *mergeIterator) Err() error {
+	return m.err
+}
+
+// Close closes all sources
+func (m *mergeIterator) Close() error {
+	var errs []error
+	for _, it := range m.sources {
+		if err := it.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	m.heap = nil
+	m.current = nil
+	return errors.Join(errs...)
+}
+
+// OpenLogDirIterator streams the entries of every log file below dir merged by
+// timestamp, the streaming counterpart of ReadLogDir. Companion metrics files
+// are skipped. All files stay open until Close is called.
+func OpenLogDirIterator(dir string, options ...ReadOption) (EntryIterator, error) {
+	var iterators []EntryIterator
+	closeAll := func() {
+		for _, it := range iterators {
+			it.Close()
+		}
+	}
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !isLogFileName(d) {
+			return nil
+		}
+		it, err := OpenLogIterator(path, options...)
+		if err != nil {
+			return err
+		}
+		iterators = append(iterators, it)
+		return nil
+	})
+	if err != nil {
+		closeAll()
+		return nil, fmt.Errorf("failed to read log directory: %w", err)
+	}
+	return MergeEntries(iterators...), nil
+}
+
+// isLogFileName reports whether a directory entry is a log file read by
+// ReadLogDir. Rotated files keep ".log" in the middle of their name; hidden
+// files hold rotation state.
+func isLogFileName(d fs.DirEntry) bool {
+	name := d.Name()
+	return !d.IsDir() && !strings.HasPrefix(name, ".") && !strings.HasSuffix(name, "_metrics.log") && strings.Contains(name, ".log")
+}
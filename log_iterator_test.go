@@ -0,0 +1,131 @@
+package vibelogger
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLogIteratorMatchesReadLog(t *testing.T) {
+	data := `{"record_type": "header", "schema_version": 1}
+{"timestamp": "2024-01-01T00:00:00Z", "level": "INFO", "operation": "a", "message": "first", "severity": 2}
+{"timestamp": "2024-01-01T00:00:01Z", "level": "ERROR", "operation": "b", "message": "broken"
+{"timestamp": "2024-01-01T00:00:02Z", "level": "INFO", "operation": "c", "message": "second", "severity": 2}
+not json
+{"record_type": "footer", "reason": "close"}
+`
+	want, err := ReadLog(strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	it, err := NewLogIterator(strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var operations []string
+	var corrupt []CorruptRecord
+	for it.Next() {
+		corrupt = append(corrupt, it.Corrupt()...)
+		operations = append(operations, it.Entry().Operation)
+	}
+	corrupt = append(corrupt, it.Corrupt()...)
+	if err := it.Err(); err != nil {
+		t.Fatalf("Err: %v", err)
+	}
+
+	if strings.Join(operations, ",") != "a,c" || len(want.Entries) != 2 {
+		t.Errorf("Expected entries a,c, got %v", operations)
+	}
+	if len(corrupt) != len(want.Corrupt) || len(corrupt) != 2 || corrupt[0].Line != 3 {
+		t.Errorf("Expected the corrupt records of ReadLog, got %+v", corrupt)
+	}
+	if it.Header() == nil || it.Footer() == nil || !want.CleanShutdown() {
+		t.Error("Expected header and footer to be reported")
+	}
+	if it.Next() {
+		t.Error("Next should keep returning false at the end")
+	}
+}
+
+func TestLogIteratorEntriesAreFresh(t *testing.T) {
+	data := `{"timestamp": "2024-01-01T00:00:00Z", "level": "INFO", "operation": "a", "message": "m", "context": {"x": 1}, "severity": 2}
+{"timestamp": "2024-01-01T00:00:01Z", "level": "INFO", "operation": "b", "message": "m", "severity": 2}
+`
+	it, _ := NewLogIterator(strings.NewReader(data))
+	it.Next()
+	it.Next()
+	if it.Entry().Operation != "b" || it.Entry().Context != nil {
+		t.Errorf("Fields of the previous entry leaked into the next one: %+v", it.Entry())
+	}
+}
+
+func TestLogIteratorOptions(t *testing.T) {
+	if _, err := NewLogIterator(strings.NewReader(""), WithNumberMode("bogus")); err == nil {
+		t.Error("Expected invalid options to be rejected")
+	}
+	if _, err := OpenLogIterator(filepath.Join(t.TempDir(), "missing.log")); err == nil {
+		t.Error("Expected an error for a missing file")
+	}
+}
+
+// writeTimedLog writes compact entries with the given second offsets
+func writeTimedLog(t *testing.T, path string, seconds ...int) {
+	t.Helper()
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var data strings.Builder
+	for _, s := range seconds {
+		fmt.Fprintf(&data, `{"timestamp": %q, "level": "INFO", "operation": "op_%d", "message": "m", "severity": 2}`+"\n",
+			base.Add(time.Duration(s)*time.Second).Format(time.RFC3339), s)
+	}
+	if err := os.WriteFile(path, []byte(data.String()), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestOpenLogDirIterator(t *testing.T) {
+	dir := t.TempDir()
+	writeTimedLog(t, filepath.Join(dir, "app.log"), 1, 4, 6)
+	writeTimedLog(t, filepath.Join(dir, "app.log.20240101_000000"), 0, 2)
+	writeTimedLog(t, filepath.Join(dir, "worker.log"), 3, 5)
+	writeTimedLog(t, filepath.Join(dir, "app_metrics.log"), 7)
+	writeTimedLog(t, filepath.Join(dir, ".app.log.rotation"), 8)
+
+	it, err := OpenLogDirIterator(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var operations []string
+	for it.Next() {
+		operations = append(operations, it.Entry().Operation)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if err := it.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	got := strings.Join(operations, ",")
+	if got != "op_0,op_1,op_2,op_3,op_4,op_5,op_6" {
+		t.Errorf("Expected entries merged by time, got %s", got)
+	}
+
+	entries, err := ReadLogDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != len(operations) {
+		t.Errorf("Expected the entries of ReadLogDir, got %d and %d", len(operations), len(entries))
+	}
+}
+
+func TestMergeEntriesEmpty(t *testing.T) {
+	it := MergeEntries()
+	if it.Next() || it.Entry() != nil || it.Err() != nil || it.Close() != nil {
+		t.Error("Expected an empty merge to yield nothing")
+	}
+}
@@ -1,13 +1,14 @@
 package vibelogger
 
 import (
-	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"runtime"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -23,43 +24,90 @@ const (
 
 // LogEntry represents a single log entry with AI-optimized structure
 type LogEntry struct {
+	ID            string                 `json:"id,omitempty"` // ULID for deduplication and references
 	Timestamp     time.Time              `json:"timestamp"`
+	EventTime     *time.Time             `json:"event_time,omitempty"`  // When the described event happened, if not when it was logged
+	Duration      time.Duration          `json:"duration_ns,omitempty"` // Elapsed time of the operation
 	Level         LogLevel               `json:"level"`
 	Operation     string                 `json:"operation"`
 	Message       string                 `json:"message"`
+	MessageLines  []string               `json:"message_lines,omitempty"` // Continuation lines of a folded multi-line message
 	Context       map[string]interface{} `json:"context,omitempty"`
 	HumanNote     string                 `json:"human_note,omitempty"`
 	AITodo        string                 `json:"ai_todo,omitempty"`
 	StackTrace    []string               `json:"stack_trace,omitempty"`
 	Environment   map[string]string      `json:"environment,omitempty"`
 	CorrelationID string                 `json:"correlation_id,omitempty"`
+	SessionID     string                 `json:"session_id,omitempty"` // AI-agent task or user session the entry belongs to
+	Tags          []string               `json:"tags,omitempty"`       // Cross-cutting groups such as "billing", see WithTags
 	// AI-optimized fields
-	Severity   int    `json:"severity"`             // 1-5 scale for AI prioritization
-	Category   string `json:"category,omitempty"`   // business_logic, system, user_action, etc.
-	Searchable string `json:"searchable,omitempty"` // AI-friendly search terms
-	Pattern    string `json:"pattern,omitempty"`    // Known error patterns
-	Suggestion string `json:"suggestion,omitempty"` // AI debugging suggestions
+	Severity   int    `json:"severity"`              // 1-5 scale for AI prioritization
+	Category   string `json:"category,omitempty"`    // business_logic, system, user_action, etc.
+	Searchable string `json:"searchable,omitempty"`  // AI-friendly search terms
+	Pattern    string `json:"pattern,omitempty"`     // Known error patterns
+	Suggestion string `json:"suggestion,omitempty"`  // AI debugging suggestions
+	RunbookURL string `json:"runbook_url,omitempty"` // Remediation document for the pattern or error code
 }
 
 // Logger is the main vibe logger instance
 type Logger struct {
 	name        string
 	filePath    string
+	projectDir  string // logs/<project> when the file is placed by project, see ProjectActivity
 	file        *os.File
 	mutex       sync.Mutex
 	config      *LoggerConfig
 	currentSize int64
-	memoryLogs  []LogEntry
-	memoryMutex sync.Mutex
+	fileEntries int64 // Entries written to the current file, reported in its footer
+	memoryLogs  memoryRing
 	rotationMgr *RotationManager
+	// Fields attached to the context of every entry
+	globalFields   map[string]interface{}
+	globalMutex    sync.RWMutex
+	stdout         io.Writer       // Destination for stdout mode; os.Stdout when nil
+	sinks          []Sink          // Additional destinations registered with AddSink
+	writeCallbacks []WriteCallback // Observers registered with OnWrite
+	errorHandlers  []ErrorHandler  // Handlers registered with OnError
+	subscriptions  []*Subscription // In-process subscribers, see Subscribe
+	closed         bool            // Close was called
+	handlerMutex   sync.RWMutex    // Guards errorHandlers, which are called with the mutex held
+	stats          *loggerStats
+	bgMutex        sync.Mutex // Guards the background worker handles below
+	heartbeat      *periodicTask
+	summarizer     *periodicTask
+	runtimeMonitor *periodicTask
+	queueReporter  *periodicTask
+	janitor        *periodicTask
+	histograms     *periodicTask
+	histogramState *histogramState      // Durations aggregated by RecordDuration, see StartHistograms
+	operations     *OperationRegistry   // Allowed operation names, see SetOperationRegistry
+	schema         ContextSchema        // Expected context types, see SetContextSchema
+	escalation     *escalationState     // Level escalation rules, see SetEscalationRules
+	slo            *sloState            // Error budget tracking, see SetSLORules
+	latency        *latencyState        // Latency budgets per operation, see SetLatencyBudgets
+	usage          *usageState          // Deprecations and flag exposures already logged, see LogDeprecation
+	suggestions    []SuggestionProvider // Suggestion sources, see SetSuggestionProviders
+	runbooks       runbookIndex         // Runbook URLs from LoggerConfig.RunbookURLs
+	levelFormats   levelFormats         // Output format overrides from LoggerConfig.LevelFormats
+	consoleOut     io.Writer            // Destination of the console echo, nil when off, see LoggerConfig.ConsoleOutput
+	console        *ConsoleFormatter    // Text encoding of console output, nil for JSON, see LoggerConfig.ConsoleFormat
+	consoleLevel   LogLevel             // Parsed LoggerConfig.ConsoleMinLevel, empty when every entry is echoed
+	minLevel       LogLevel             // Parsed LoggerConfig.MinLevel, empty when every level is kept
+	redactKeys     []string             // Parsed LoggerConfig.RedactKeys
+	learner        *PatternLearner      // Learned patterns, see SetPatternLearner
+	profiler       *profiler
+	shards         *shardedWriter                    // Parallel encoders feeding the file, see LoggerConfig.WriterShards
+	journal        *writeAheadJournal                // Entries queued on the shards, see LoggerConfig.WriteAheadJournal
+	faults         *faultInjector                    // Failures injected into file writes, see LoggerConfig.FaultInjection
+	formatter      atomic.Pointer[Formatter]         // Custom record encoding, see SetFormatter
+	headerEnv      atomic.Pointer[map[string]string] // Environment of the current file header, nil without one
+	shared         *sharedResources                  // Writer pool and rotation scheduler of a LoggerManager
+	windows        debugWindows                      // Temporary filter overrides, see OpenDebugWindow
 }
 
 // NewLogger creates a new Logger instance with default configuration
 func NewLogger(name string) *Logger {
-	return &Logger{
-		name:   name,
-		config: DefaultConfig(),
-	}
+	return newLogger(name, DefaultConfig())
 }
 
 // NewLoggerWithConfig creates a new Logger instance with custom configuration
@@ -69,10 +117,70 @@ func NewLoggerWithConfig(name string, config *LoggerConfig) *Logger {
 	}
 	config.Validate()
 
-	return &Logger{
+	return newLogger(name, config)
+}
+
+// newLogger initializes the in-memory state shared by all constructors
+func newLogger(name string, config *LoggerConfig) *Logger {
+	logger := &Logger{
 		name:   name,
 		config: config,
+		stats:  newLoggerStats(projectName(config)),
+	}
+	logger.runbooks = newRunbookIndex(config.RunbookURLs)
+	logger.levelFormats = newLevelFormats(config.LevelFormats)
+	logger.setConsole(config)
+	logger.minLevel, _ = ParseLevel(config.MinLevel)
+	logger.consoleLevel, _ = ParseLevel(config.ConsoleMinLevel)
+	logger.redactKeys = parseRedactKeys(config.RedactKeys)
+	logger.usage = newUsageState(config)
+	logger.initGlobalFields()
+	return logger
+}
+
+// initGlobalFields seeds the global fields from the configuration
+func (l *Logger) initGlobalFields() {
+	l.globalFields = make(map[string]interface{})
+	if l.config.IncludeBuildInfo {
+		for k, v := range GetBuildInfo().Fields() {
+			l.globalFields[k] = v
+		}
+	}
+	if l.config.IncludeProcessInfo {
+		for k, v := range GetProcessInfo().Fields() {
+			l.globalFields[k] = v
+		}
+	}
+}
+
+// SetGlobalField attaches a field to the context of every subsequent entry.
+// Fields set on an individual entry take precedence over global fields.
+func (l *Logger) SetGlobalField(key string, value interface{}) {
+	l.globalMutex.Lock()
+	defer l.globalMutex.Unlock()
+	if l.globalFields == nil {
+		l.globalFields = make(map[string]interface{})
 	}
+	l.globalFields[key] = value
+}
+
+// RemoveGlobalField stops attaching the given field to new entries
+func (l *Logger) RemoveGlobalField(key string) {
+	l.globalMutex.Lock()
+	defer l.globalMutex.Unlock()
+	delete(l.globalFields, key)
+}
+
+// GlobalFields returns a copy of the fields attached to every entry
+func (l *Logger) GlobalFields() map[string]interface{} {
+	l.globalMutex.RLock()
+	defer l.globalMutex.RUnlock()
+
+	fields := make(map[string]interface{}, len(l.globalFields))
+	for k, v := range l.globalFields {
+		fields[k] = v
+	}
+	return fields
 }
 
 // CreateFileLogger creates a new file-based logger with default configuration
@@ -82,7 +190,28 @@ func CreateFileLogger(name string) (*Logger, error) {
 
 // CreateFileLoggerWithConfig creates a new file-based logger with custom configuration
 func CreateFileLoggerWithConfig(name string, config *LoggerConfig) (*Logger, error) {
+	return createFileLogger(name, config, nil)
+}
+
+// createFileLogger creates a file logger, using the resources of a LoggerManager when shared is set
+func createFileLogger(name string, config *LoggerConfig, shared *sharedResources) (*Logger, error) {
 	logger := NewLoggerWithConfig(name, config)
+	logger.shared = shared
+
+	// Registered policies must hold before any file is created
+	if err := config.runCustomValidators(); err != nil {
+		return nil, fmt.Errorf("config policy violation: %w", err)
+	}
+	if err := config.checkSandbox(); err != nil {
+		return nil, fmt.Errorf("sandbox violation: %w", err)
+	}
+
+	// In stdout mode no files or directories are created at all
+	if config.Mode == ModeStdout {
+		logger.warnIgnoredStdoutOptions()
+		logger.startBackgroundWorkers()
+		return logger, nil
+	}
 
 	// Use custom file path or generate default with project organization
 	var logDir, filename string
@@ -94,16 +223,8 @@ func CreateFileLoggerWithConfig(name string, config *LoggerConfig) (*Logger, err
 			return nil, fmt.Errorf("failed to create directory for custom file path: %w", err)
 		}
 	} else {
-		// Determine project directory
-		var projectDir string
-		if config.ProjectName != "" {
-			projectDir = config.ProjectName
-		} else {
-			projectDir = "default"
-		}
-
 		// Create project-specific logs directory
-		logDir = filepath.Join("logs", projectDir)
+		logDir = filepath.Join(config.logsRoot(), projectName(config))
 		if err := os.MkdirAll(logDir, 0755); err != nil {
 			return nil, fmt.Errorf("failed to create project logs directory: %w", err)
 		}
@@ -112,6 +233,13 @@ func CreateFileLoggerWithConfig(name string, config *LoggerConfig) (*Logger, err
 		timestamp := time.Now().Format("20060102_150405")
 		filename = fmt.Sprintf("%s_%s.log", name, timestamp)
 		logger.filePath = filepath.Join(logDir, filename)
+		logger.projectDir = logDir
+	}
+
+	// Remove a trailing partial record left behind by a crash before appending
+	repairedBytes, err := repairLogFile(logger.filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to repair log file: %w", err)
 	}
 
 	// Open or create the log file
@@ -127,32 +255,179 @@ func CreateFileLoggerWithConfig(name string, config *LoggerConfig) (*Logger, err
 
 	logger.file = file
 
-	// Initialize rotation manager if rotation is enabled
+	// Write a header record when the file has just been created
+	if logger.currentSize == 0 {
+		n, err := logger.writeHeader()
+		if err != nil {
+			file.Close()
+			return nil, fmt.Errorf("failed to write log file header: %w", err)
+		}
+		logger.currentSize += n
+	}
+
+	// Copy errors to their own file when requested
+	if config.SplitErrorFile {
+		sink, err := newErrorFileSink(name, logger.filePath, config)
+		if err != nil {
+			logger.Close()
+			return nil, err
+		}
+		logger.sinks = append(logger.sinks, sink)
+	}
+
+	// Mirror metrics to their own file
+	if config.MetricsFile {
+		sink, err := newMetricsFileSink(name, logger.filePath, config)
+		if err != nil {
+			logger.Close()
+			return nil, err
+		}
+		logger.sinks = append(logger.sinks, sink)
+	}
+
+	// Copy routed categories to their own files
+	if routes, _ := ParseCategoryRoutes(config.CategoryRoutes); len(routes) > 0 {
+		sinks, err := newRouteSinks(name, logger.filePath, routes, config)
+		if err != nil {
+			logger.Close()
+			return nil, err
+		}
+		logger.sinks = append(logger.sinks, sinks...)
+	}
+
+	// Encode entries on several goroutines under heavy parallel logging
+	if shared != nil && shared.shards != nil {
+		logger.shards = shared.shards
+	} else if config.WriterShards > 0 {
+		logger.shards = newShardedWriter(config.WriterShards)
+	}
+
+	// Keep queued entries on disk until they are written
+	var recoveredEntries int
+	if logger.shards != nil && config.WriteAheadJournal {
+		if recoveredEntries, err = logger.openJournal(); err != nil {
+			logger.Close()
+			return nil, err
+		}
+	}
+
+	// Initialize rotation manager if rotation is enabled, once recovered
+	// entries are back in the current file
 	if config.RotationEnabled {
-		logger.rotationMgr = NewRotationManager(logger, config, logger.filePath)
+		logger.rotationMgr = newRotationManager(logger, config, logger.filePath, shared.rotationScheduler())
 	}
 
+	logger.startBackgroundWorkers()
+
+	logger.reportRotationWarnings(logger.rotationMgr)
+	if repairedBytes > 0 {
+		logger.Warn("log_recovery", "Truncated partial record left by an unclean shutdown",
+			WithContext(map[string]interface{}{
+				"file":            logger.filePath,
+				"truncated_bytes": repairedBytes,
+			}))
+	}
+	if recoveredEntries > 0 {
+		logger.Warn("log_recovery", "Wrote entries left in the write-ahead journal by an unclean shutdown",
+			WithContext(map[string]interface{}{
+				"file":              logger.filePath,
+				"recovered_entries": recoveredEntries,
+			}))
+	}
+	logger.enableFaultInjection()
+
 	return logger, nil
 }
 
 // Log writes a log entry with the specified level
 func (l *Logger) Log(level LogLevel, operation, message string, options ...LogOption) error {
+	return l.log(nil, level, operation, message, options...)
+}
+
+// log writes an entry of scope, or of the logger itself when scope is nil
+func (l *Logger) log(scope *ScopedLogger, level LogLevel, operation, message string, options ...LogOption) (err error) {
+	// UpdateConfig replaces the configuration; build the whole entry from one
+	l.mutex.Lock()
+	config := l.config
+	l.mutex.Unlock()
+
+	if config.Hardened {
+		defer l.recoverHardened(&err)
+	}
+
 	entry := LogEntry{
 		Timestamp: time.Now().UTC(),
 		Level:     level,
 		Operation: operation,
 		Message:   message,
-		Context:   make(map[string]interface{}),
+		Context:   l.GlobalFields(),
 	}
+	applyGoroutineBinding(&entry)
 
-	// Apply options
+	// Apply options; a panicking option is skipped and reported after the entry
+	var panics []*PanicError
 	for _, opt := range options {
-		opt(&entry)
+		if err := callSafely("log_option", func() { opt(&entry) }); err != nil {
+			panics = append(panics, err.(*PanicError))
+		}
+	}
+	// Options may escalate the level, e.g. WithDeadline after a missed deadline
+	level = entry.Level
+
+	// Drop entries below the effective level or sampled out
+	settings := l.resolveSettings(scope)
+	if !shouldLog(level, settings) {
+		for _, p := range panics {
+			l.logPanic(p)
+		}
+		return nil
 	}
 
-	// Add stack trace for ERROR level
-	if level == ERROR {
-		entry.StackTrace = getStackTrace()
+	// Check the entry before anything is derived from it
+	if mode := config.EntryValidation; mode == ValidationFix || mode == ValidationReject {
+		problems := validateEntry(&entry, mode == ValidationFix)
+		if len(problems) > 0 && mode == ValidationReject {
+			return &EntryValidationError{Operation: operation, Problems: problems}
+		}
+	}
+
+	// Enforce the types of context fields
+	if problems := l.applySchema(&entry, config.ContextSchemaMode != SchemaReject); len(problems) > 0 &&
+		config.ContextSchemaMode == SchemaReject {
+		return &EntryValidationError{Operation: operation, Problems: problems}
+	}
+
+	// Keep the operation vocabulary consistent
+	unknownOperation := l.checkOperation(&entry)
+
+	// Store multi-line messages as an array of lines
+	if config.FoldMultiline {
+		foldMessage(&entry)
+	}
+
+	// Hide sensitive context values
+	redactEntry(&entry, settings.RedactKeys)
+
+	// Make free text safe for line-oriented tools
+	if sanitizer := newStringSanitizer(config); sanitizer != nil {
+		sanitizer.sanitizeEntry(&entry)
+	}
+
+	// Add stack trace for ERROR level unless an option already provided one
+	if level == ERROR && len(entry.StackTrace) == 0 {
+		entry.StackTrace = newStackFilter(config).apply(getStackTrace())
+	}
+
+	// Attach runtime statistics to errors unless an option already did
+	if config.RuntimeStatsOnError && getSeverityScore(level) >= getSeverityScore(ERROR) {
+		if _, ok := entry.Context["runtime"]; !ok {
+			WithRuntimeStats()(&entry)
+		}
+	}
+
+	// Identify the entry unless it was given an ID, e.g. when replayed
+	if entry.ID == "" {
+		entry.ID = entryIDs.next(entry.Timestamp)
 	}
 
 	// Add environment information
@@ -160,12 +435,52 @@ func (l *Logger) Log(level LogLevel, operation, message string, options ...LogOp
 
 	// Set AI-optimized fields
 	entry.Severity = getSeverityScore(level)
-	entry.Category = inferCategory(operation, message)
+	if entry.Category == "" {
+		entry.Category = inferCategory(operation, message)
+	}
 	entry.Searchable = generateSearchableTerms(operation, message)
 	entry.Pattern = detectKnownPattern(operation, message)
-	entry.Suggestion = generateAISuggestion(level, operation, message)
+	learned, hasLearned := l.applyLearnedPattern(&entry)
+
+	// Flag operations slower than their budget, then raise repeated entries
+	// to the level they deserve
+	overBudget := l.checkLatencyBudget(&entry)
+	if escalated := l.escalate(&entry); escalated || overBudget {
+		level = entry.Level
+		entry.Severity = getSeverityScore(level)
+		if level == ERROR && len(entry.StackTrace) == 0 {
+			entry.StackTrace = newStackFilter(config).apply(getStackTrace())
+		}
+	}
+	// Show the code that logged the error when its source is available
+	if config.SourceSnippets && level == ERROR {
+		if _, ok := entry.Context["source"]; !ok {
+			attachSourceSnippet(&entry)
+		}
+	}
 
-	return l.writeEntry(entry)
+	suggestion, suggestionPanics := l.suggest(&entry)
+	entry.Suggestion = suggestion
+	if hasLearned && learned.Suggestion != "" {
+		entry.Suggestion = learned.Suggestion
+	}
+	panics = append(panics, suggestionPanics...)
+	if entry.RunbookURL == "" {
+		entry.RunbookURL = l.runbookURL(&entry)
+	}
+
+	err = l.writeEntry(entry)
+	l.recordSLO(&entry)
+	if level == ERROR {
+		l.observeError()
+	}
+	for _, p := range panics {
+		l.logPanic(p)
+	}
+	if unknownOperation != "" {
+		l.reportUnknownOperation(unknownOperation)
+	}
+	return err
 }
 
 // Info logs an info level message
@@ -190,8 +505,15 @@ func (l *Logger) Debug(operation, message string, options ...LogOption) error {
 
 // Close closes the logger and its file handle
 func (l *Logger) Close() error {
+	// Stop background writers before taking the lock they need
+	l.stopBackgroundWorkers()
+	shardErr := l.closeShards()
+	l.journal.close()
+
 	l.mutex.Lock()
 	defer l.mutex.Unlock()
+	l.closed = true
+	l.closeSubscriptions()
 
 	// Close rotation manager first
 	if l.rotationMgr != nil {
@@ -199,99 +521,235 @@ func (l *Logger) Close() error {
 		l.rotationMgr = nil
 	}
 
+	sinkErr := l.closeSinks()
+
 	if l.file != nil {
+		// Mark the file as cleanly closed; a failure here must not prevent closing
+		l.writeFooter(FooterReasonClose)
+
 		err := l.file.Close()
 		l.file = nil // Set to nil to prevent double-close
-		return err
+		l.recordProjectActivity()
+		if err != nil {
+			return err
+		}
 	}
-	return nil
+	if sinkErr != nil {
+		return sinkErr
+	}
+	return shardErr
 }
 
-// writeEntry writes a log entry to the file
-func (l *Logger) writeEntry(entry LogEntry) error {
+// isClosed reports whether Close was called
+func (l *Logger) isClosed() bool {
 	l.mutex.Lock()
 	defer l.mutex.Unlock()
+	return l.closed
+}
 
-	jsonData, err := json.MarshalIndent(entry, "", "  ")
+// writeEntry writes a log entry to the file, or queues it when WriterShards is
+// set, journaling it first when WriteAheadJournal is set
+func (l *Logger) writeEntry(entry LogEntry) error {
+	seq, err := l.journal.append(&entry)
 	if err != nil {
-		return fmt.Errorf("failed to marshal log entry: %w", err)
+		l.reportError(err)
 	}
+	if l.shards.submit(l, entry, seq) {
+		return nil
+	}
+
+	rm, err := l.writeEntryUnlocked(entry)
+	l.journal.commit(seq)
+	l.reportRotationWarnings(rm)
+	return err
+}
+
+// writeEntryUnlocked writes an entry under the mutex and returns the rotation
+// manager to report warnings of. The mutex is released even if encoding
+// panics, so a hardened logger stays usable after recovering.
+func (l *Logger) writeEntryUnlocked(entry LogEntry) (*RotationManager, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	err := l.writeEntryLocked(entry, nil)
+	return l.rotationMgr, err
+}
+
+// writeEntryLocked writes an entry already encoded as jsonData, encoding it
+// first when jsonData is nil, and reports the outcome to the OnWrite callbacks
+// and subscriptions.
+// The caller must hold the mutex.
+func (l *Logger) writeEntryLocked(entry LogEntry, jsonData []byte) error {
+	jsonData, err := l.writeOutputs(&entry, jsonData)
+	l.notifyWrite(&entry, jsonData, err)
+	l.publish(&entry)
+	return err
+}
+
+// writeOutputs writes an entry to all outputs and returns the serialized
+// record of the primary output. The caller must hold the mutex.
+func (l *Logger) writeOutputs(entry *LogEntry, jsonData []byte) ([]byte, error) {
+	if l.config.Mode == ModeStdout {
+		jsonData, err := l.writeStdout(*entry)
+		l.stats.recordEntry(entry, recordSize(jsonData))
+		if err != nil {
+			return jsonData, err
+		}
+		return jsonData, l.writeSinks(entry)
+	}
+
+	if jsonData == nil {
+		var err error
+		jsonData, err = l.encodeFileEntry(entry)
+		if err != nil {
+			l.stats.recordEntry(entry, 0)
+			return nil, fmt.Errorf("failed to marshal log entry: %w", err)
+		}
+	}
+	l.stats.recordEntry(entry, recordSize(jsonData))
 
 	// Add to memory log if enabled
 	if l.config.EnableMemoryLog {
-		l.addToMemoryLog(entry)
+		l.addToMemoryLog(*entry)
 	}
 
 	// Write to file if AutoSave is enabled and file exists
 	if l.config.AutoSave && l.file != nil {
-		entrySize := int64(len(jsonData) + 1) // +1 for newline
-
-		// Check if rotation is needed and perform it
-		if l.rotationMgr != nil && l.rotationMgr.ShouldRotate(entrySize) {
-			if err := l.rotationMgr.PerformRotation(); err != nil {
-				return fmt.Errorf("failed to rotate log file: %w", err)
-			}
+		if err := l.writeFile(jsonData); err != nil {
+			return jsonData, err
 		}
+	}
 
-		if _, err := l.file.Write(jsonData); err != nil {
-			return fmt.Errorf("failed to write to log file: %w", err)
-		}
-		if _, err := l.file.WriteString("\n"); err != nil {
-			return fmt.Errorf("failed to write newline to log file: %w", err)
-		}
+	// Echo to the console for debugging
+	l.writeConsole(entry, jsonData)
+
+	return jsonData, l.writeSinks(entry)
+}
+
+// writeFile appends an encoded entry to the current file, rotating it first when
+// the entry would exceed the size limit. The caller must hold the mutex.
+func (l *Logger) writeFile(jsonData []byte) error {
+	entrySize := int64(len(jsonData) + 1) // +1 for newline
+	if err := l.faults.beforeWrite(l.filePath, entrySize); err != nil {
+		return fmt.Errorf("failed to write to log file: %w", err)
+	}
 
-		// Update current file size and rotation manager cache
-		l.currentSize += entrySize
-		if l.rotationMgr != nil {
-			l.rotationMgr.updateCachedSize(entrySize)
+	// Check if rotation is needed and perform it
+	if l.rotationMgr != nil && l.rotationMgr.ShouldRotate(entrySize) {
+		if err := l.rotationMgr.rotateLocked(); err != nil {
+			return fmt.Errorf("failed to rotate log file: %w", err)
 		}
 	}
 
-	// Always output to console for debugging
-	fmt.Printf("%s\n", string(jsonData))
+	if _, err := l.file.Write(jsonData); err != nil {
+		return fmt.Errorf("failed to write to log file: %w", err)
+	}
+	if _, err := l.file.WriteString("\n"); err != nil {
+		return fmt.Errorf("failed to write newline to log file: %w", err)
+	}
 
+	// Update current file size and rotation manager cache
+	l.currentSize += entrySize
+	l.fileEntries++
+	if l.rotationMgr != nil {
+		l.rotationMgr.updateCachedSize(entrySize)
+	}
 	return nil
 }
 
-// addToMemoryLog adds an entry to the in-memory log
-func (l *Logger) addToMemoryLog(entry LogEntry) {
-	l.memoryMutex.Lock()
-	defer l.memoryMutex.Unlock()
+// writeStdout writes an entry as a single compact JSON line, or as encoded by
+// the custom or console formatter, to stdout and returns the line without the
+// newline
+func (l *Logger) writeStdout(entry LogEntry) ([]byte, error) {
+	var jsonData []byte
+	var err error
+	if formatter := l.customFormatter(); formatter != nil {
+		jsonData, err = formatter.Format(&entry)
+	} else if l.console != nil && l.stdout == nil {
+		jsonData, err = l.console.Format(&entry)
+	} else if jsonData, err = marshalEntry(&entry); err == nil && l.config.EscapeNonASCII {
+		jsonData = escapeNonASCII(jsonData)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal log entry: %w", err)
+	}
 
-	l.memoryLogs = append(l.memoryLogs, entry)
+	out := l.stdout
+	if out == nil {
+		out = os.Stdout
+	}
+	if _, err := out.Write(append(jsonData, '\n')); err != nil {
+		return jsonData, fmt.Errorf("failed to write to stdout: %w", err)
+	}
+	return jsonData, nil
+}
 
-	// Enforce memory log limit
-	if l.config.MemoryLogLimit > 0 && len(l.memoryLogs) > l.config.MemoryLogLimit {
-		// Remove oldest entries
-		excess := len(l.memoryLogs) - l.config.MemoryLogLimit
-		l.memoryLogs = l.memoryLogs[excess:]
+// writeConsole echoes the file record of an entry to the console, as text
+// when the console formatter is in use
+func (l *Logger) writeConsole(entry *LogEntry, jsonData []byte) {
+	if l.consoleOut == nil || !atLeastLevel(entry.Level, l.consoleLevel) {
+		return
+	}
+	if l.console != nil {
+		if text, err := l.console.Format(entry); err == nil {
+			jsonData = text
+		}
 	}
+	fmt.Fprintf(l.consoleOut, "%s\n", jsonData)
+}
+
+// warnIgnoredStdoutOptions logs a warning for file-related options that have no effect in stdout mode
+func (l *Logger) warnIgnoredStdoutOptions() {
+	// Only options the caller changed from the defaults are reported, so a
+	// stdout logger built from DefaultConfig starts without a warning
+	defaults := DefaultConfig()
+	var ignored []string
+	if l.config.EnableMemoryLog && !defaults.EnableMemoryLog {
+		ignored = append(ignored, "enable_memory_log")
+	}
+	if l.config.FilePath != defaults.FilePath {
+		ignored = append(ignored, "file_path")
+	}
+	if l.config.MaxFileSize != 0 && l.config.MaxFileSize != defaults.MaxFileSize {
+		ignored = append(ignored, "max_file_size")
+	}
+	if l.config.MaxRotatedFiles != 0 && l.config.MaxRotatedFiles != defaults.MaxRotatedFiles {
+		ignored = append(ignored, "max_rotated_files")
+	}
+	if len(ignored) == 0 {
+		return
+	}
+
+	l.Warn("config_warning", "Options are ignored in stdout mode",
+		WithContext(map[string]interface{}{"ignored_options": ignored}))
+}
+
+// addToMemoryLog adds an entry to the in-memory log
+func (l *Logger) addToMemoryLog(entry LogEntry) {
+	l.memoryLogs.add(&entry, l.config.MemoryLogLimit)
 }
 
-// GetMemoryLogs returns a copy of the current memory logs
+// GetMemoryLogs returns a copy of the current memory logs.
+// It does not take the logger mutex, so polling it does not stall logging.
 func (l *Logger) GetMemoryLogs() []LogEntry {
-	l.memoryMutex.Lock()
-	defer l.memoryMutex.Unlock()
+	return l.memoryLogs.entries()
+}
 
-	// Return a copy to prevent external modification
-	logs := make([]LogEntry, len(l.memoryLogs))
-	copy(logs, l.memoryLogs)
-	return logs
+// MemoryLogCount returns the number of entries in the memory log without copying them
+func (l *Logger) MemoryLogCount() int {
+	return l.memoryLogs.len()
 }
 
 // ClearMemoryLogs clears all entries from the memory log
 func (l *Logger) ClearMemoryLogs() {
-	l.memoryMutex.Lock()
-	defer l.memoryMutex.Unlock()
-	l.memoryLogs = nil
+	l.memoryLogs.clear()
 }
 
 // getStackTrace returns the current stack trace
 func getStackTrace() []string {
 	var stack []string
 
-	// Skip the first 3 frames (getStackTrace, writeEntry, Log)
-	for i := 3; ; i++ {
+	// Skip getStackTrace and the logger frames (log, Log, Error, ...) above the caller
+	for i := 1; ; i++ {
 		pc, file, line, ok := runtime.Caller(i)
 		if !ok {
 			break
@@ -301,6 +759,9 @@ func getStackTrace() []string {
 		if fn == nil {
 			break
 		}
+		if len(stack) == 0 && isLoggerFrame(stackFrame{File: file, Function: fn.Name()}) {
+			continue
+		}
 
 		stack = append(stack, fmt.Sprintf("%s:%d %s", file, line, fn.Name()))
 	}
@@ -308,27 +769,48 @@ func getStackTrace() []string {
 	return stack
 }
 
-// getEnvironment returns current environment information
+// getEnvironment returns the runtime environment attached to every entry.
+// Process identity (pid, hostname, ...) is attached once as global fields instead.
 func getEnvironment() map[string]string {
-	return map[string]string{
-		"go_version": runtime.Version(),
-		"os":         runtime.GOOS,
-		"arch":       runtime.GOARCH,
-		"pid":        fmt.Sprintf("%d", os.Getpid()),
-		"pwd":        func() string { pwd, _ := os.Getwd(); return pwd }(),
+	loadProcessInfo()
+	env := make(map[string]string, len(environmentInfo))
+	for k, v := range environmentInfo {
+		env[k] = v
 	}
+	return env
 }
 
 // ForceRotation manually triggers log file rotation
 func (l *Logger) ForceRotation() error {
 	l.mutex.Lock()
-	defer l.mutex.Unlock()
-
-	if l.rotationMgr == nil {
+	rm := l.rotationMgr
+	if rm == nil {
+		l.mutex.Unlock()
 		return fmt.Errorf("rotation is not enabled")
 	}
+	err := rm.rotateLocked()
+	l.mutex.Unlock()
 
-	return l.rotationMgr.PerformRotation()
+	l.reportRotationWarnings(rm)
+	return err
+}
+
+// reportRotationWarnings logs problems rm found while the mutex was held.
+// It must be called without holding the mutex; rm may be nil.
+func (l *Logger) reportRotationWarnings(rm *RotationManager) {
+	if rm == nil {
+		return
+	}
+	for _, w := range rm.takeWarnings() {
+		var options []LogOption
+		if w.err != nil {
+			options = append(options, WithError(w.err))
+		}
+		if w.context != nil {
+			options = append(options, WithContext(w.context))
+		}
+		l.Warn(w.operation, w.message, options...)
+	}
 }
 
 // ForceRotationAsync manually triggers log file rotation asynchronously
@@ -349,6 +831,14 @@ func (l *Logger) SetAsyncRotation(enabled bool) {
 	}
 }
 
+// GetFilePath returns the path of the current log file, empty in stdout mode
+// and for loggers without a file
+func (l *Logger) GetFilePath() string {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.filePath
+}
+
 // GetRotatedFiles returns the list of current rotated files
 func (l *Logger) GetRotatedFiles() []string {
 	if l.rotationMgr == nil {
@@ -361,19 +851,34 @@ func (l *Logger) GetRotatedFiles() []string {
 // UpdateConfig updates the logger configuration including rotation settings
 func (l *Logger) UpdateConfig(config *LoggerConfig) error {
 	l.mutex.Lock()
-	defer l.mutex.Unlock()
+	rm, err := l.updateConfigLocked(config)
+	l.mutex.Unlock()
 
+	l.reportRotationWarnings(rm)
+	return err
+}
+
+// updateConfigLocked applies the configuration and returns the active rotation
+// manager. The caller must hold the mutex.
+func (l *Logger) updateConfigLocked(config *LoggerConfig) (*RotationManager, error) {
 	// Validate new configuration
 	if err := config.Validate(); err != nil {
-		return fmt.Errorf("invalid configuration: %w", err)
+		return l.rotationMgr, fmt.Errorf("invalid configuration: %w", err)
 	}
 
 	l.config = config
+	l.runbooks = newRunbookIndex(config.RunbookURLs)
+	l.levelFormats = newLevelFormats(config.LevelFormats)
+	l.setConsole(config)
+	l.minLevel, _ = ParseLevel(config.MinLevel)
+	l.consoleLevel, _ = ParseLevel(config.ConsoleMinLevel)
+	l.redactKeys = parseRedactKeys(config.RedactKeys)
+	l.usage = newUsageState(config)
 
 	// Initialize or update rotation manager
 	if config.RotationEnabled && l.rotationMgr == nil {
 		// Enable rotation
-		l.rotationMgr = NewRotationManager(l, config, l.filePath)
+		l.rotationMgr = newRotationManager(l, config, l.filePath, l.shared.rotationScheduler())
 	} else if !config.RotationEnabled && l.rotationMgr != nil {
 		// Disable rotation
 		l.rotationMgr = nil
@@ -382,7 +887,7 @@ func (l *Logger) UpdateConfig(config *LoggerConfig) error {
 		l.rotationMgr.UpdateConfig(config)
 	}
 
-	return nil
+	return l.rotationMgr, nil
 }
 
 // getSeverityScore converts log level to numerical severity for AI prioritization
@@ -482,7 +987,7 @@ func detectKnownPattern(operation, message string) string {
 	}
 
 	// Performance patterns
-	if containsAny(combined, []string{"slow query", "high memory", "cpu usage", "memory leak"}) {
+	if containsAny(combined, []string{"slow query", "high memory", "cpu usage", "memory leak", "gc pause", "scheduling latency"}) {
 		return "performance_issue"
 	}
 
@@ -536,6 +1041,12 @@ func generateAISuggestion(level LogLevel, operation, message string) string {
 	}
 
 	// Performance suggestions
+	if containsAny(combined, []string{"gc pause"}) {
+		return "Reduce allocation rate or tune GOGC/GOMEMLIMIT"
+	}
+	if containsAny(combined, []string{"scheduling latency"}) {
+		return "Check for CPU saturation, GOMAXPROCS limits or long-running goroutines without preemption points"
+	}
 	if containsAny(combined, []string{"slow", "timeout", "performance"}) {
 		return "Consider optimizing query/operation or adding timeout handling"
 	}
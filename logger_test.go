@@ -1,8 +1,12 @@
 package vibelogger
 
 import (
+	"bytes"
+	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -174,12 +178,55 @@ func TestLoggerClose(t *testing.T) {
 func TestGetEnvironment(t *testing.T) {
 	env := getEnvironment()
 
-	expectedKeys := []string{"go_version", "os", "arch", "pid", "pwd"}
+	expectedKeys := []string{"go_version", "os", "arch"}
 	for _, key := range expectedKeys {
 		if _, exists := env[key]; !exists {
 			t.Errorf("Expected environment key '%s' to exist", key)
 		}
 	}
+
+	// Process identity moved to global fields
+	for _, key := range []string{"pid", "pwd"} {
+		if _, exists := env[key]; exists {
+			t.Errorf("Expected environment key '%s' to be absent", key)
+		}
+	}
+
+	// Each entry gets its own map
+	env["go_version"] = "modified"
+	if getEnvironment()["go_version"] == "modified" {
+		t.Error("Expected getEnvironment to return a copy")
+	}
+}
+
+func TestProcessInfoEnrichment(t *testing.T) {
+	config := DefaultConfig()
+	config.IncludeBuildInfo = false
+	logger := NewLoggerWithConfig("test_process_info", config)
+
+	info := GetProcessInfo()
+	if info.PID != os.Getpid() || info.StartTime.IsZero() {
+		t.Errorf("Unexpected process info: %+v", info)
+	}
+	if GetProcessInfo().StartTime != info.StartTime {
+		t.Error("Expected process info to be computed once")
+	}
+
+	fields := logger.GlobalFields()
+	if fields["pid"] != os.Getpid() {
+		t.Errorf("Expected pid global field, got %v", fields["pid"])
+	}
+	if _, ok := fields["process_start_time"]; !ok {
+		t.Error("Expected process_start_time global field")
+	}
+
+	config = DefaultConfig()
+	config.IncludeBuildInfo = false
+	config.IncludeProcessInfo = false
+	logger = NewLoggerWithConfig("test_no_process_info", config)
+	if len(logger.GlobalFields()) != 0 {
+		t.Errorf("Expected no global fields, got %v", logger.GlobalFields())
+	}
 }
 
 func TestGetStackTrace(t *testing.T) {
@@ -254,3 +301,195 @@ func TestLogEntryStructure(t *testing.T) {
 		t.Errorf("Expected correlation ID 'test-123', got '%s'", entry.CorrelationID)
 	}
 }
+
+func TestGlobalFields(t *testing.T) {
+	config := &LoggerConfig{
+		AutoSave:        false,
+		EnableMemoryLog: true,
+		MemoryLogLimit:  10,
+	}
+	logger := NewLoggerWithConfig("test_global", config)
+
+	logger.SetGlobalField("service", "billing")
+	logger.SetGlobalField("region", "eu-west-1")
+
+	if err := logger.Info("test", "Global fields", WithContext(map[string]interface{}{"region": "us-east-1"})); err != nil {
+		t.Fatalf("Failed to log: %v", err)
+	}
+
+	logger.RemoveGlobalField("service")
+	if err := logger.Info("test", "After removal"); err != nil {
+		t.Fatalf("Failed to log: %v", err)
+	}
+
+	logs := logger.GetMemoryLogs()
+	if len(logs) != 2 {
+		t.Fatalf("Expected 2 log entries, got %d", len(logs))
+	}
+
+	if logs[0].Context["service"] != "billing" {
+		t.Errorf("Expected global field 'service' to be attached, got %v", logs[0].Context["service"])
+	}
+	// Entry-level context must take precedence over global fields
+	if logs[0].Context["region"] != "us-east-1" {
+		t.Errorf("Expected entry context to override global field, got %v", logs[0].Context["region"])
+	}
+	if _, exists := logs[1].Context["service"]; exists {
+		t.Error("Removed global field should not be attached")
+	}
+
+	// Modifying the returned map must not affect the logger
+	fields := logger.GlobalFields()
+	fields["injected"] = true
+	if _, exists := logger.GlobalFields()["injected"]; exists {
+		t.Error("GlobalFields should return a copy")
+	}
+}
+
+func TestBuildInfoEnrichment(t *testing.T) {
+	config := DefaultConfig()
+	config.AutoSave = false
+	config.EnableMemoryLog = true
+
+	logger := NewLoggerWithConfig("test_build_info", config)
+	expected := GetBuildInfo().Fields()
+
+	fields := logger.GlobalFields()
+	for k, v := range expected {
+		if fields[k] != v {
+			t.Errorf("Expected global field %s=%v, got %v", k, v, fields[k])
+		}
+	}
+
+	config = DefaultConfig()
+	config.IncludeBuildInfo = false
+	config.IncludeProcessInfo = false
+	logger = NewLoggerWithConfig("test_no_build_info", config)
+	if len(logger.GlobalFields()) != 0 {
+		t.Errorf("Expected no global fields with IncludeBuildInfo disabled, got %v", logger.GlobalFields())
+	}
+}
+
+func TestStdoutMode(t *testing.T) {
+	defer os.RemoveAll("logs")
+
+	config := DefaultConfig()
+	config.Mode = ModeStdout
+	config.ProjectName = "stdout_mode_project"
+
+	logger, err := CreateFileLoggerWithConfig("stdout_test", config)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	defer logger.Close()
+
+	var buf bytes.Buffer
+	logger.stdout = &buf
+
+	logger.Info("first", "First entry")
+	logger.Warn("second", "Second entry")
+
+	if logger.file != nil || logger.rotationMgr != nil {
+		t.Error("Stdout mode should not open files or start rotation")
+	}
+	if _, err := os.Stat(filepath.Join("logs", "stdout_mode_project")); !os.IsNotExist(err) {
+		t.Error("Stdout mode should not create log directories")
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 NDJSON lines, got %d: %q", len(lines), buf.String())
+	}
+	var entry LogEntry
+	if err := json.Unmarshal([]byte(lines[1]), &entry); err != nil {
+		t.Fatalf("Failed to parse NDJSON line: %v", err)
+	}
+	if entry.Operation != "second" || entry.Level != WARN {
+		t.Errorf("Unexpected entry: %+v", entry)
+	}
+}
+
+func TestStdoutModeIgnoredOptionsWarning(t *testing.T) {
+	config := DefaultConfig()
+	config.Mode = ModeStdout
+	config.EnableMemoryLog = true
+	config.MaxRotatedFiles = 10
+
+	logger := NewLoggerWithConfig("stdout_warning_test", config)
+	var buf bytes.Buffer
+	logger.stdout = &buf
+
+	// Defaults are not reported
+	quiet := NewLoggerWithConfig("stdout_default_test", &LoggerConfig{Mode: ModeStdout, RotationEnabled: true, MaxFileSize: DefaultConfig().MaxFileSize})
+	var quietBuf bytes.Buffer
+	quiet.stdout = &quietBuf
+	quiet.warnIgnoredStdoutOptions()
+	if quietBuf.Len() != 0 {
+		t.Errorf("Expected no warning for default options, got %q", quietBuf.String())
+	}
+
+	logger.warnIgnoredStdoutOptions()
+	logger.Info("test", "Not kept in memory")
+
+	if len(logger.GetMemoryLogs()) != 0 {
+		t.Error("Memory log should be ignored in stdout mode")
+	}
+	output := buf.String()
+	if !strings.Contains(output, "config_warning") || !strings.Contains(output, "enable_memory_log") ||
+		!strings.Contains(output, "max_rotated_files") || strings.Contains(output, "rotation_enabled") {
+		t.Errorf("Expected warning listing ignored options, got %q", output)
+	}
+}
+
+func TestInvalidMode(t *testing.T) {
+	config := DefaultConfig()
+	config.Mode = "syslog"
+	if err := config.Validate(); err == nil {
+		t.Error("Expected validation to fail for unknown mode")
+	}
+
+	os.Setenv("VIBE_LOG_MODE", "stdout")
+	defer os.Unsetenv("VIBE_LOG_MODE")
+	config, err := NewConfigFromEnvironment()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if config.Mode != ModeStdout {
+		t.Errorf("Expected mode from VIBE_LOG_MODE, got %q", config.Mode)
+	}
+}
+
+func TestLogDuringUpdateConfig(t *testing.T) {
+	config := DefaultConfig()
+	config.FilePath = filepath.Join(t.TempDir(), "update.log")
+	config.ConsoleOutput = ConsoleOff
+	logger, err := CreateFileLoggerWithConfig("update_test", config)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	defer logger.Close()
+
+	// Run with -race: every entry must see one consistent configuration
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				logger.Error("update_test", "line one\nline two", WithContext(map[string]interface{}{"password": "x"}))
+			}
+		}()
+	}
+	for i := 0; i < 20; i++ {
+		update := *config
+		update.FoldMultiline = i%2 == 0
+		update.ControlChars = ControlCharsEscape
+		update.EntryValidation = ValidationFix
+		update.RedactKeys = "password"
+		update.SourceSnippets = i%2 == 1
+		if err := logger.UpdateConfig(&update); err != nil {
+			t.Fatalf("UpdateConfig failed: %v", err)
+		}
+	}
+	wg.Wait()
+}
@@ -0,0 +1,152 @@
+package vibelogger
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// sharedResources are the workers a LoggerManager shares between its loggers
+type sharedResources struct {
+	shards    *shardedWriter     // nil unless LoggerConfig.WriterShards is set
+	rotations *rotationScheduler // Runs async rotations of all loggers
+}
+
+// rotationScheduler returns the shared scheduler; it is safe on nil resources
+func (r *sharedResources) rotationScheduler() *rotationScheduler {
+	if r == nil {
+		return nil
+	}
+	return r.rotations
+}
+
+// LoggerManager owns a set of named file loggers created from one configuration.
+// The loggers share a single sharded writer pool (when WriterShards is set) and
+// a single goroutine for asynchronous rotations, instead of starting their own
+// per logger. Services with dozens of per-component loggers create them through
+// the manager and release everything with CloseAll.
+type LoggerManager struct {
+	mutex     sync.Mutex
+	config    *LoggerConfig
+	loggers   map[string]*Logger
+	resources *sharedResources
+	closed    bool
+}
+
+// NewLoggerManager creates a manager whose loggers use copies of config. A nil
+// config loads the configuration from the environment. Loggers are placed by
+// name in the project directory, so a fixed FilePath is rejected.
+func NewLoggerManager(config *LoggerConfig) (*LoggerManager, error) {
+	if config == nil {
+		var err error
+		if config, err = NewConfigFromEnvironment(); err != nil {
+			return nil, err
+		}
+	}
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+	if config.FilePath != "" {
+		return nil, fmt.Errorf("managed loggers cannot share FilePath %s; use ProjectName", config.FilePath)
+	}
+
+	resources := &sharedResources{rotations: newRotationScheduler()}
+	if config.WriterShards > 0 {
+		resources.shards = newShardedWriter(config.WriterShards)
+	}
+	return &LoggerManager{
+		config:    config,
+		loggers:   make(map[string]*Logger),
+		resources: resources,
+	}, nil
+}
+
+// Logger returns the logger with the given name, creating it on first use.
+// A logger closed by its caller is replaced by a new one.
+func (m *LoggerManager) Logger(name string) (*Logger, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.closed {
+		return nil, fmt.Errorf("logger manager is closed")
+	}
+	if logger, ok := m.loggers[name]; ok && !logger.isClosed() {
+		return logger, nil
+	}
+
+	config := *m.config
+	logger, err := createFileLogger(name, &config, m.resources)
+	if err != nil {
+		return nil, err
+	}
+	m.loggers[name] = logger
+	return logger, nil
+}
+
+// Names returns the names of the managed loggers in alphabetical order
+func (m *LoggerManager) Names() []string {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	names := make([]string, 0, len(m.loggers))
+	for name := range m.loggers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// UpdateConfig applies config to every managed logger and to loggers created
+// later. Settings of the shared workers, such as WriterShards, keep the values
+// the manager was created with.
+func (m *LoggerManager) UpdateConfig(config *LoggerConfig) error {
+	if err := config.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+	if config.FilePath != "" {
+		return fmt.Errorf("managed loggers cannot share FilePath %s; use ProjectName", config.FilePath)
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.config = config
+	var errs []error
+	for name, logger := range m.loggers {
+		loggerConfig := *config
+		if err := logger.UpdateConfig(&loggerConfig); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// FlushAll waits until every entry logged before the call has been written
+func (m *LoggerManager) FlushAll() {
+	m.resources.shards.flush()
+}
+
+// CloseAll closes every managed logger and stops the shared workers. The
+// manager cannot create loggers afterwards; calling it again is a no-op.
+func (m *LoggerManager) CloseAll() error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.closed {
+		return nil
+	}
+	m.closed = true
+
+	var errs []error
+	for name, logger := range m.loggers {
+		if err := logger.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+		}
+	}
+	if err := m.resources.shards.close(); err != nil {
+		errs = append(errs, err)
+	}
+	m.resources.rotations.close()
+	return errors.Join(errs...)
+}
@@ -0,0 +1,107 @@
+package vibelogger
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestLoggerManager(t *testing.T) {
+	dir := filepath.Join("logs", "manager-test")
+	defer os.RemoveAll(dir)
+
+	config := DefaultConfig()
+	config.ProjectName = "manager-test"
+	config.WriterShards = 2
+	config.EnableMemoryLog = true
+
+	manager, err := NewLoggerManager(config)
+	if err != nil {
+		t.Fatalf("Failed to create manager: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			logger, err := manager.Logger(fmt.Sprintf("component%d", i%2))
+			if err != nil {
+				t.Errorf("Failed to get logger: %v", err)
+				return
+			}
+			for j := 0; j < 25; j++ {
+				logger.Info("managed_op", fmt.Sprintf("%d-%d", i, j))
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	names := manager.Names()
+	if len(names) != 2 || names[0] != "component0" || names[1] != "component1" {
+		t.Fatalf("Expected two managed loggers, got %v", names)
+	}
+	first, _ := manager.Logger("component0")
+	second, _ := manager.Logger("component1")
+	if first.shards == nil || first.shards != second.shards {
+		t.Error("Expected the loggers to share one writer pool")
+	}
+	if first.rotationMgr.asyncRotationChan != second.rotationMgr.asyncRotationChan {
+		t.Error("Expected the loggers to share one rotation scheduler")
+	}
+
+	// Shared async rotations and FlushAll
+	if err := <-first.ForceRotationAsync(); err != nil {
+		t.Errorf("Async rotation failed: %v", err)
+	}
+	manager.FlushAll()
+	if first.MemoryLogCount() != 50 || second.MemoryLogCount() != 50 {
+		t.Errorf("Expected 50 entries per logger after FlushAll, got %d and %d",
+			first.MemoryLogCount(), second.MemoryLogCount())
+	}
+
+	// Configuration updates reach every logger
+	updated := *config
+	updated.MemoryLogLimit = 10
+	if err := manager.UpdateConfig(&updated); err != nil {
+		t.Fatalf("Failed to update config: %v", err)
+	}
+	if first.config.MemoryLogLimit != 10 || second.config.MemoryLogLimit != 10 {
+		t.Error("Expected the update to reach all loggers")
+	}
+
+	// A logger closed on its own is recreated on the next lookup
+	if err := first.Close(); err != nil {
+		t.Fatalf("Failed to close logger: %v", err)
+	}
+	reopened, err := manager.Logger("component0")
+	if err != nil || reopened == first {
+		t.Fatalf("Expected a new logger after Close, got %p (%v)", reopened, err)
+	}
+	reopened.Info("managed_op", "after reopen")
+	manager.FlushAll()
+	if reopened.MemoryLogCount() != 1 {
+		t.Errorf("Expected the new logger to write, got %d entries", reopened.MemoryLogCount())
+	}
+
+	if err := manager.CloseAll(); err != nil {
+		t.Fatalf("CloseAll failed: %v", err)
+	}
+	if _, err := manager.Logger("component2"); err == nil {
+		t.Error("Expected a closed manager to refuse new loggers")
+	}
+	result, err := ReadLogFile(second.filePath)
+	if err != nil || len(result.Entries) != 50 || !result.CleanShutdown() {
+		t.Errorf("Expected 50 entries and a clean shutdown, got %+v (%v)", result, err)
+	}
+}
+
+func TestLoggerManagerRejectsFilePath(t *testing.T) {
+	config := DefaultConfig()
+	config.FilePath = "test_logs/shared.log"
+	if _, err := NewLoggerManager(config); err == nil {
+		t.Error("Expected a shared FilePath to be rejected")
+	}
+}
@@ -0,0 +1,104 @@
+package vibelogger
+
+import "sync"
+
+// memoryRing holds the in-memory log as a ring of immutable entries.
+// Entries are never modified after insertion, so readers only copy pointers
+// under the read lock and dereference them after releasing it. Writers are
+// blocked at most for the pointer copy, not for copying whole entries.
+type memoryRing struct {
+	mutex sync.RWMutex
+	buf   []*LogEntry // Circular with len(buf) == limit when limited, linear otherwise
+	head  int         // Index of the oldest entry
+	size  int
+	limit int // Capacity the buffer was laid out for, 0 = unlimited
+}
+
+// add stores the entry, evicting the oldest ones beyond limit (0 = unlimited)
+func (r *memoryRing) add(entry *LogEntry, limit int) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	// Relayout when the limit changed through UpdateConfig
+	if limit != r.limit {
+		r.resize(limit)
+	}
+
+	if limit <= 0 {
+		r.buf = append(r.buf, entry)
+		r.size++
+		return
+	}
+	if r.size < limit {
+		r.buf[(r.head+r.size)%limit] = entry
+		r.size++
+		return
+	}
+	r.buf[r.head] = entry
+	r.head = (r.head + 1) % limit
+}
+
+// resize rearranges the entries for a new limit, keeping the newest ones.
+// Must be called with the write lock held.
+func (r *memoryRing) resize(limit int) {
+	entries := r.ordered()
+	if limit > 0 && len(entries) > limit {
+		entries = entries[len(entries)-limit:]
+	}
+	if limit > 0 {
+		r.buf = make([]*LogEntry, limit)
+		copy(r.buf, entries)
+	} else {
+		r.buf = entries
+	}
+	r.head = 0
+	r.size = len(entries)
+	r.limit = limit
+}
+
+// ordered returns the entry pointers from oldest to newest.
+// Must be called with the lock held.
+func (r *memoryRing) ordered() []*LogEntry {
+	entries := make([]*LogEntry, r.size)
+	if r.limit <= 0 {
+		copy(entries, r.buf[:r.size])
+		return entries
+	}
+	n := copy(entries, r.buf[r.head:min(r.head+r.size, len(r.buf))])
+	copy(entries[n:], r.buf[:r.size-n])
+	return entries
+}
+
+// snapshot returns the entry pointers from oldest to newest
+func (r *memoryRing) snapshot() []*LogEntry {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	return r.ordered()
+}
+
+// entries returns copies of the stored entries from oldest to newest
+func (r *memoryRing) entries() []LogEntry {
+	pointers := r.snapshot()
+	logs := make([]LogEntry, len(pointers))
+	for i, entry := range pointers {
+		logs[i] = *entry
+	}
+	return logs
+}
+
+// len returns the number of stored entries
+func (r *memoryRing) len() int {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	return r.size
+}
+
+// clear removes all entries
+func (r *memoryRing) clear() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.buf = nil
+	r.head = 0
+	r.size = 0
+	r.limit = 0
+}
@@ -1,7 +1,9 @@
 package vibelogger
 
 import (
+	"fmt"
 	"os"
+	"sync"
 	"testing"
 )
 
@@ -170,4 +172,70 @@ func TestRotationManagerUpdateConfigDirect(t *testing.T) {
 
 	// Test passes if UpdateConfig method runs without error
 	// (we can't easily verify internal state without exposing fields)
-}
\ No newline at end of file
+}
+func TestMemoryRingLimit(t *testing.T) {
+	var ring memoryRing
+	for i := 0; i < 5; i++ {
+		ring.add(&LogEntry{Operation: fmt.Sprintf("op%d", i)}, 3)
+	}
+
+	// Only the newest entries survive, oldest first
+	entries := ring.entries()
+	if len(entries) != 3 || entries[0].Operation != "op2" || entries[2].Operation != "op4" {
+		t.Fatalf("Expected op2..op4, got %v", entries)
+	}
+
+	// Shrinking keeps the newest, growing keeps everything
+	ring.add(&LogEntry{Operation: "op5"}, 2)
+	entries = ring.entries()
+	if len(entries) != 2 || entries[0].Operation != "op4" || entries[1].Operation != "op5" {
+		t.Fatalf("Expected op4 and op5 after shrinking, got %v", entries)
+	}
+	ring.add(&LogEntry{Operation: "op6"}, 0)
+	if ring.len() != 3 || ring.entries()[2].Operation != "op6" {
+		t.Fatalf("Expected 3 entries ending with op6 when unlimited, got %v", ring.entries())
+	}
+}
+
+func TestMemoryLogConcurrentReads(t *testing.T) {
+	config := &LoggerConfig{
+		AutoSave:        false,
+		EnableMemoryLog: true,
+		MemoryLogLimit:  50,
+	}
+	logger := NewLoggerWithConfig("test", config)
+
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				logs := logger.GetMemoryLogs()
+				if len(logs) > 50 {
+					t.Errorf("Memory log exceeded its limit: %d", len(logs))
+					return
+				}
+			}
+		}()
+	}
+	for i := 0; i < 200; i++ {
+		logger.Debug("concurrent_op", fmt.Sprintf("message %d", i))
+	}
+	close(done)
+	wg.Wait()
+
+	logs := logger.GetMemoryLogs()
+	if logger.MemoryLogCount() != 50 || len(logs) != 50 {
+		t.Fatalf("Expected 50 entries, got count %d and %d logs", logger.MemoryLogCount(), len(logs))
+	}
+	if logs[49].Message != "message 199" {
+		t.Errorf("Expected the newest entry last, got %q", logs[49].Message)
+	}
+}
@@ -0,0 +1,153 @@
+package vibelogger
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// MetricRecord is one line of the companion metrics file
+type MetricRecord struct {
+	Timestamp time.Time `json:"timestamp"`
+	Operation string    `json:"operation"`
+	Metric    string    `json:"metric"`
+	Value     float64   `json:"value"`
+}
+
+// WithMetrics adds numeric measurements to the context. When MetricsFile is
+// enabled they are also written to the companion metrics file.
+func WithMetrics(metrics map[string]float64) LogOption {
+	return func(entry *LogEntry) {
+		if entry.Context == nil {
+			entry.Context = make(map[string]interface{})
+		}
+		copied := make(map[string]float64, len(metrics))
+		for name, value := range metrics {
+			copied[name] = value
+		}
+		entry.Context["metrics"] = copied
+	}
+}
+
+// metricsFilePath derives the metrics file path from the main log file path,
+// e.g. logs/app.log becomes logs/app_metrics.log
+func metricsFilePath(path string) string {
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + "_metrics" + ext
+}
+
+// metricsSink mirrors WithMetrics and WithDuration values into a compact
+// metrics file, one MetricRecord per line
+type metricsSink struct {
+	logger *Logger
+}
+
+// newMetricsFileSink opens the metrics file next to mainPath
+func newMetricsFileSink(name, mainPath string, config *LoggerConfig) (*metricsSink, error) {
+	metricsConfig := childFileConfig(config, metricsFilePath(mainPath))
+	metricsConfig.WriteFileMarkers = false
+
+	logger, err := CreateFileLoggerWithConfig(name+"_metrics", metricsConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create metrics file: %w", err)
+	}
+	return &metricsSink{logger: logger}, nil
+}
+
+// Write appends one record per metric found in the entry
+func (s *metricsSink) Write(entry *LogEntry) error {
+	records := extractMetrics(entry)
+	if len(records) == 0 {
+		return nil
+	}
+
+	l := s.logger
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if l.file == nil {
+		return nil
+	}
+	for _, record := range records {
+		data, err := json.Marshal(record)
+		if err != nil {
+			return fmt.Errorf("failed to marshal metric: %w", err)
+		}
+		if err := l.writeFile(data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close finalizes the metrics file
+func (s *metricsSink) Close() error {
+	return s.logger.Close()
+}
+
+// extractMetrics collects duration_ms and the WithMetrics values of an entry,
+// sorted by metric name
+func extractMetrics(entry *LogEntry) []MetricRecord {
+	if len(entry.Context) == 0 {
+		return nil
+	}
+
+	values := make(map[string]float64)
+	if duration, ok := numericValue(entry.Context["duration_ms"]); ok {
+		values["duration_ms"] = duration
+	}
+	switch metrics := entry.Context["metrics"].(type) {
+	case map[string]float64:
+		for name, value := range metrics {
+			values[name] = value
+		}
+	case map[string]interface{}:
+		// Entries read back from JSON
+		for name, raw := range metrics {
+			if value, ok := numericValue(raw); ok {
+				values[name] = value
+			}
+		}
+	}
+	if len(values) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	records := make([]MetricRecord, 0, len(names))
+	for _, name := range names {
+		records = append(records, MetricRecord{
+			Timestamp: entry.Timestamp,
+			Operation: entry.Operation,
+			Metric:    name,
+			Value:     values[name],
+		})
+	}
+	return records
+}
+
+// numericValue converts integer and floating point values to float64
+func numericValue(value interface{}) (float64, bool) {
+	if n, ok := value.(json.Number); ok {
+		f, err := n.Float64()
+		return f, err == nil
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	}
+	return 0, false
+}
@@ -0,0 +1,85 @@
+package vibelogger
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestMetricsFilePath(t *testing.T) {
+	if got := metricsFilePath("logs/app.log"); got != "logs/app_metrics.log" {
+		t.Errorf("metricsFilePath = %q, expected logs/app_metrics.log", got)
+	}
+}
+
+func TestExtractMetrics(t *testing.T) {
+	entry := LogEntry{Operation: "query", Context: map[string]interface{}{
+		"duration_ms": int64(12),
+		"metrics":     map[string]interface{}{"rows": 3.0, "label": "x"},
+	}}
+	records := extractMetrics(&entry)
+	if len(records) != 2 {
+		t.Fatalf("expected 2 records, got %v", records)
+	}
+	if records[0].Metric != "duration_ms" || records[0].Value != 12 {
+		t.Errorf("unexpected first record: %+v", records[0])
+	}
+	if records[1].Metric != "rows" || records[1].Value != 3 || records[1].Operation != "query" {
+		t.Errorf("unexpected second record: %+v", records[1])
+	}
+
+	if records := extractMetrics(&LogEntry{Context: map[string]interface{}{"user": "a"}}); len(records) != 0 {
+		t.Errorf("expected no records, got %v", records)
+	}
+}
+
+func TestMetricsFile(t *testing.T) {
+	defer os.RemoveAll("test_logs")
+
+	config := DefaultConfig()
+	config.FilePath = "test_logs/metrics_test.log"
+	config.MetricsFile = true
+
+	logger, err := CreateFileLoggerWithConfig("metrics_test", config)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	logger.Info("checkout", "Order placed",
+		WithMetrics(map[string]float64{"amount": 19.5, "items": 2}),
+		WithDuration(40*time.Millisecond))
+	logger.Info("checkout", "No metrics here")
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Failed to close logger: %v", err)
+	}
+
+	file, err := os.Open("test_logs/metrics_test_metrics.log")
+	if err != nil {
+		t.Fatalf("Failed to open metrics file: %v", err)
+	}
+	defer file.Close()
+
+	var records []MetricRecord
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var record MetricRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatalf("Invalid metrics line %q: %v", scanner.Text(), err)
+		}
+		records = append(records, record)
+	}
+
+	expected := []string{"amount", "duration_ms", "items"}
+	if len(records) != len(expected) {
+		t.Fatalf("Expected %d records, got %v", len(expected), records)
+	}
+	for i, name := range expected {
+		if records[i].Metric != name || records[i].Operation != "checkout" {
+			t.Errorf("record %d = %+v, expected metric %s", i, records[i], name)
+		}
+	}
+	if records[1].Value != 40 {
+		t.Errorf("Expected duration_ms 40, got %v", records[1].Value)
+	}
+}
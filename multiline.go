@@ -0,0 +1,34 @@
+package vibelogger
+
+import "strings"
+
+// foldMessage moves every line of a multi-line message after the first into
+// MessageLines, so that the message stays readable in one-line listings and
+// multi-line payloads such as stack dumps are stored as a JSON array
+func foldMessage(entry *LogEntry) {
+	if !strings.ContainsAny(entry.Message, "\r\n") {
+		return
+	}
+
+	lines := strings.Split(strings.ReplaceAll(entry.Message, "\r\n", "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSuffix(line, "\r")
+	}
+	// A trailing newline does not start another line
+	if len(lines) > 1 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	entry.Message = lines[0]
+	if len(lines) > 1 {
+		entry.MessageLines = append(entry.MessageLines, lines[1:]...)
+	}
+}
+
+// FullMessage returns the message with folded continuation lines restored
+func (e *LogEntry) FullMessage() string {
+	if len(e.MessageLines) == 0 {
+		return e.Message
+	}
+	return e.Message + "\n" + strings.Join(e.MessageLines, "\n")
+}
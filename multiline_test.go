@@ -0,0 +1,72 @@
+package vibelogger
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestFoldMessage(t *testing.T) {
+	tests := []struct {
+		message      string
+		expected     string
+		expectedRest []string
+	}{
+		{"single line", "single line", nil},
+		{"panic: boom\ngoroutine 1:\n\tmain.go:10", "panic: boom", []string{"goroutine 1:", "\tmain.go:10"}},
+		{"windows\r\nline\r\n", "windows", []string{"line"}},
+		{"trailing newline\n", "trailing newline", nil},
+	}
+
+	for _, tt := range tests {
+		entry := &LogEntry{Message: tt.message}
+		foldMessage(entry)
+		if entry.Message != tt.expected || !reflect.DeepEqual(entry.MessageLines, tt.expectedRest) {
+			t.Errorf("foldMessage(%q) = %q %q, expected %q %q",
+				tt.message, entry.Message, entry.MessageLines, tt.expected, tt.expectedRest)
+		}
+	}
+}
+
+func TestMultilineRoundTrip(t *testing.T) {
+	defer os.RemoveAll("test_logs")
+
+	config := DefaultConfig()
+	config.FilePath = "test_logs/multiline_test.log"
+	config.FoldMultiline = true
+	logger, err := CreateFileLoggerWithConfig("multiline_test", config)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	dump := "external tool failed\nline 2\nline 3"
+	logger.Error("tool", dump)
+	logger.Close()
+
+	result, err := ReadLogFile(config.FilePath)
+	if err != nil {
+		t.Fatalf("Failed to read log file: %v", err)
+	}
+	entry := result.Entries[0]
+	if entry.Message != "external tool failed" || len(entry.MessageLines) != 2 {
+		t.Errorf("Expected folded message, got %q %q", entry.Message, entry.MessageLines)
+	}
+	if entry.FullMessage() != dump {
+		t.Errorf("Expected FullMessage to restore %q, got %q", dump, entry.FullMessage())
+	}
+
+	query := Query{Text: "line 3"}
+	if !query.Match(&entry) {
+		t.Error("Expected full-text search to match continuation lines")
+	}
+
+	// Messages are kept as written unless folding is enabled
+	defaults := DefaultConfig()
+	defaults.AutoSave = false
+	defaults.ConsoleOutput = ConsoleOff
+	defaults.EnableMemoryLog = true
+	plain := NewLoggerWithConfig("multiline_default", defaults)
+	plain.Error("tool", dump)
+	if entries := plain.GetMemoryLogs(); entries[0].Message != dump || entries[0].MessageLines != nil {
+		t.Errorf("Expected the message unfolded by default, got %q %q", entries[0].Message, entries[0].MessageLines)
+	}
+}
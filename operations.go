@@ -0,0 +1,218 @@
+package vibelogger
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// Handling of operations missing from an OperationRegistry
+const (
+	UnknownOperationsAllow     = "allow"     // Entries are written as given (default)
+	UnknownOperationsWarn      = "warn"      // A WARN entry reports each unknown operation once
+	UnknownOperationsNormalize = "normalize" // Names are converted to snake_case; names still unknown become UnregisteredOperation
+)
+
+// UnregisteredOperation replaces unknown operation names in normalize mode.
+// The original name is kept in the "original_operation" context field.
+const UnregisteredOperation = "unregistered_operation"
+
+// builtinOperations are written by the logger itself and always registered
+var builtinOperations = map[string]string{
+	InternalOperation:       "Failures inside the logger, such as panicking options or sinks",
+	"heartbeat":             "Periodic alive entry",
+	"log_summary":           "Periodic entry counts by level and pattern",
+	"queue_stats":           "Periodic telemetry of asynchronous sinks",
+	"duration_histogram":    "Periodic duration histogram of an operation measured with RecordDuration",
+	runtimeMonitorEvent:     "Slow garbage collection or scheduling",
+	"profile_capture":       "CPU or heap profile written after an error burst",
+	"http_client":           "Summary of an outgoing HTTP request",
+	"log_recovery":          "Partial record removed after an unclean shutdown",
+	"rotation_cleanup":      "Failure to remove old rotated files",
+	"config_update_cleanup": "Failure to apply retention after a configuration change",
+	"log_janitor":           "Old log files removed by the janitor across all projects",
+	"config_warning":        "Configuration options ignored in the current mode",
+	"deprecation":           "Use of a deprecated feature, see LogDeprecation",
+	"feature_flag":          "Feature flag evaluation, see LogFeatureFlag",
+	"rotation_recovery":     "Recovery of an interrupted or failed rotation",
+	"debug_window":          "Debug window opened or closed, see EnableDebugFor",
+	"fault_injection":       "Fault injection enabled for resilience testing",
+	"receiver_quota":        "Receiver project exceeding its ingest quota",
+	UnregisteredOperation:   "Operation name that is not registered",
+}
+
+// OperationDefinition describes an allowed operation name or glob pattern
+type OperationDefinition struct {
+	Name        string `json:"name"` // Exact name or pattern such as "http_*"
+	Description string `json:"description"`
+}
+
+// OperationRegistry declares the operation names a team uses, so the operation
+// vocabulary stays consistent for querying and AI analysis. Attach it to a
+// logger with SetOperationRegistry; UnknownOperations decides what happens to
+// names that are not registered.
+type OperationRegistry struct {
+	mutex    sync.RWMutex
+	exact    map[string]OperationDefinition
+	patterns []OperationDefinition
+	reported map[string]bool // Unknown operations already reported in warn mode
+}
+
+// NewOperationRegistry creates a registry containing the logger's own operations
+func NewOperationRegistry() *OperationRegistry {
+	r := &OperationRegistry{
+		exact:    make(map[string]OperationDefinition),
+		reported: make(map[string]bool),
+	}
+	for name, description := range builtinOperations {
+		r.exact[name] = OperationDefinition{Name: name, Description: description}
+	}
+	return r
+}
+
+// Register declares an operation name, or a pattern using path.Match syntax
+// (e.g. "http_*" or "job_?")
+func (r *OperationRegistry) Register(name, description string) error {
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("operation name is empty")
+	}
+	def := OperationDefinition{Name: name, Description: description}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if !strings.ContainsAny(name, "*?[") {
+		r.exact[name] = def
+		return nil
+	}
+	if _, err := path.Match(name, ""); err != nil {
+		return fmt.Errorf("invalid operation pattern %q: %w", name, err)
+	}
+	r.patterns = append(r.patterns, def)
+	return nil
+}
+
+// Lookup returns the definition matching an operation name
+func (r *OperationRegistry) Lookup(operation string) (OperationDefinition, bool) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	if def, ok := r.exact[operation]; ok {
+		return def, true
+	}
+	for _, def := range r.patterns {
+		if ok, _ := path.Match(def.Name, operation); ok {
+			return def, true
+		}
+	}
+	return OperationDefinition{}, false
+}
+
+// Operations returns all registered definitions sorted by name
+func (r *OperationRegistry) Operations() []OperationDefinition {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	defs := make([]OperationDefinition, 0, len(r.exact)+len(r.patterns))
+	for _, def := range r.exact {
+		defs = append(defs, def)
+	}
+	defs = append(defs, r.patterns...)
+	sort.Slice(defs, func(i, j int) bool { return defs[i].Name < defs[j].Name })
+	return defs
+}
+
+// firstReport records an unknown operation and reports whether it is new
+func (r *OperationRegistry) firstReport(operation string) bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.reported[operation] {
+		return false
+	}
+	r.reported[operation] = true
+	return true
+}
+
+// isValidUnknownOperations checks if the unknown operation mode is supported
+func isValidUnknownOperations(mode string) bool {
+	return mode == "" || mode == UnknownOperationsAllow || mode == UnknownOperationsWarn || mode == UnknownOperationsNormalize
+}
+
+// NormalizeOperationName converts an operation name to snake_case, e.g.
+// "User Login", "user-login" and "userLogin" all become "user_login"
+func NormalizeOperationName(name string) string {
+	runes := []rune(strings.TrimSpace(name))
+	var b strings.Builder
+	separate := false
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			separate = true
+			continue
+		}
+		// Word boundaries inside camelCase and after acronyms as in HTTPRequest
+		if i > 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				separate = true
+			}
+		}
+		if separate && b.Len() > 0 {
+			b.WriteByte('_')
+		}
+		separate = false
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
+
+// SetOperationRegistry attaches a registry checked for every entry according
+// to LoggerConfig.UnknownOperations. A nil registry disables the check.
+func (l *Logger) SetOperationRegistry(registry *OperationRegistry) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.operations = registry
+}
+
+// checkOperation applies the registry to an entry. It returns the unknown
+// operation to report in warn mode, or "".
+func (l *Logger) checkOperation(entry *LogEntry) string {
+	l.mutex.Lock()
+	registry := l.operations
+	mode := l.config.UnknownOperations
+	l.mutex.Unlock()
+	if registry == nil {
+		return ""
+	}
+	if _, ok := registry.Lookup(entry.Operation); ok {
+		return ""
+	}
+
+	switch mode {
+	case UnknownOperationsWarn:
+		if registry.firstReport(entry.Operation) {
+			return entry.Operation
+		}
+	case UnknownOperationsNormalize:
+		original := entry.Operation
+		entry.Operation = NormalizeOperationName(original)
+		if _, ok := registry.Lookup(entry.Operation); !ok {
+			entry.Operation = UnregisteredOperation
+		}
+		if entry.Context == nil {
+			entry.Context = make(map[string]interface{})
+		}
+		entry.Context["original_operation"] = original
+	}
+	return ""
+}
+
+// reportUnknownOperation writes the warning for an unregistered operation
+func (l *Logger) reportUnknownOperation(operation string) {
+	l.Warn(InternalOperation, fmt.Sprintf("Operation %q is not registered", operation),
+		WithContext(map[string]interface{}{
+			"unknown_operation": operation,
+		}))
+}
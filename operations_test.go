@@ -0,0 +1,111 @@
+package vibelogger
+
+import "testing"
+
+func TestNormalizeOperationName(t *testing.T) {
+	tests := map[string]string{
+		"user_login":    "user_login",
+		"User Login":    "user_login",
+		"user-login":    "user_login",
+		"userLogin":     "user_login",
+		"HTTPRequest":   "http_request",
+		"db.query  v2":  "db_query_v2",
+		"  payment--3 ": "payment_3",
+	}
+	for input, expected := range tests {
+		if got := NormalizeOperationName(input); got != expected {
+			t.Errorf("NormalizeOperationName(%q) = %q, expected %q", input, got, expected)
+		}
+	}
+}
+
+func TestOperationRegistryLookup(t *testing.T) {
+	registry := NewOperationRegistry()
+	registry.Register("user_login", "User signed in")
+	registry.Register("http_*", "Incoming HTTP requests")
+	if err := registry.Register("job_[", "Broken pattern"); err == nil {
+		t.Error("Expected an invalid pattern to be rejected")
+	}
+	if err := registry.Register(" ", ""); err == nil {
+		t.Error("Expected an empty name to be rejected")
+	}
+
+	if def, ok := registry.Lookup("http_get"); !ok || def.Description != "Incoming HTTP requests" {
+		t.Errorf("Expected http_get to match the pattern, got %+v %v", def, ok)
+	}
+	for _, name := range []string{"user_login", "heartbeat", "rotation_recovery", InternalOperation} {
+		if _, ok := registry.Lookup(name); !ok {
+			t.Errorf("Expected %s to be registered", name)
+		}
+	}
+	if _, ok := registry.Lookup("userLogin"); ok {
+		t.Error("Expected lookups to be exact")
+	}
+
+	defs := registry.Operations()
+	for i := 1; i < len(defs); i++ {
+		if defs[i-1].Name > defs[i].Name {
+			t.Fatal("Expected operations sorted by name")
+		}
+	}
+}
+
+func newRegistryTestLogger(mode string) *Logger {
+	logger := NewLoggerWithConfig("operations_test", &LoggerConfig{
+		AutoSave:          false,
+		EnableMemoryLog:   true,
+		MemoryLogLimit:    20,
+		UnknownOperations: mode,
+	})
+	registry := NewOperationRegistry()
+	registry.Register("user_login", "User signed in")
+	logger.SetOperationRegistry(registry)
+	return logger
+}
+
+func TestUnknownOperationsWarn(t *testing.T) {
+	logger := newRegistryTestLogger(UnknownOperationsWarn)
+
+	logger.Info("user_login", "Known")
+	logger.Info("usr_login", "Typo")
+	logger.Info("usr_login", "Typo again")
+
+	logs := logger.GetMemoryLogs()
+	if len(logs) != 4 {
+		t.Fatalf("Expected 3 entries and one warning, got %d", len(logs))
+	}
+	warning := logs[2]
+	if warning.Operation != InternalOperation || warning.Level != WARN || warning.Context["unknown_operation"] != "usr_login" {
+		t.Errorf("Expected a warning for the unknown operation, got %+v", warning)
+	}
+	if logs[1].Operation != "usr_login" || logs[3].Operation != "usr_login" {
+		t.Error("Expected unknown operations to be written as given in warn mode")
+	}
+}
+
+func TestUnknownOperationsNormalize(t *testing.T) {
+	logger := newRegistryTestLogger(UnknownOperationsNormalize)
+
+	logger.Info("User Login", "Known after normalization")
+	logger.Info("Password Reset", "Unknown")
+	logger.Info("heartbeat", "Built-in")
+
+	logs := logger.GetMemoryLogs()
+	if logs[0].Operation != "user_login" || logs[0].Context["original_operation"] != "User Login" {
+		t.Errorf("Expected the normalized registered name, got %+v", logs[0])
+	}
+	if logs[1].Operation != UnregisteredOperation || logs[1].Context["original_operation"] != "Password Reset" {
+		t.Errorf("Expected an unregistered operation, got %+v", logs[1])
+	}
+	if logs[2].Operation != "heartbeat" || logs[2].Context["original_operation"] != nil {
+		t.Errorf("Expected built-in operations to be accepted, got %+v", logs[2])
+	}
+}
+
+func TestUnknownOperationsAllowByDefault(t *testing.T) {
+	logger := newRegistryTestLogger("")
+	logger.Info("anything goes", "Written as given")
+	if logs := logger.GetMemoryLogs(); len(logs) != 1 || logs[0].Operation != "anything goes" {
+		t.Errorf("Expected the entry to be written as given, got %v", logs)
+	}
+}
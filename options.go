@@ -1,7 +1,10 @@
 package vibelogger
 
 import (
+	"context"
 	"fmt"
+	"runtime"
+	"strings"
 	"time"
 )
 
@@ -41,6 +44,35 @@ func WithCorrelationID(id string) LogOption {
 	}
 }
 
+// WithSessionID assigns the entry to a session, e.g. one AI-agent task or user
+// session that spans many requests, see Logger.GetSession
+func WithSessionID(id string) LogOption {
+	return func(entry *LogEntry) {
+		entry.SessionID = id
+	}
+}
+
+// WithTags adds tags that group entries across operations, such as "billing"
+// or "migration". Empty and repeated tags are skipped, so scoped loggers can
+// add tags on top of bound ones.
+func WithTags(tags ...string) LogOption {
+	return func(entry *LogEntry) {
+		for _, tag := range tags {
+			if tag = strings.TrimSpace(tag); tag != "" && !entry.HasTag(tag) {
+				entry.Tags = append(entry.Tags, tag)
+			}
+		}
+	}
+}
+
+// WithCategory sets the entry category instead of inferring it from the
+// operation and message, e.g. to route audit entries with CategoryRoutes
+func WithCategory(category string) LogOption {
+	return func(entry *LogEntry) {
+		entry.Category = category
+	}
+}
+
 // WithFields is a convenience function for adding multiple context fields
 func WithFields(fields map[string]interface{}) LogOption {
 	return WithContext(fields)
@@ -77,9 +109,11 @@ func WithRequestID(requestID string) LogOption {
 	}
 }
 
-// WithDuration adds duration information to the context
+// WithDuration sets the typed Duration field. The duration_ms and
+// duration_human context keys are kept for readers of older entries.
 func WithDuration(duration time.Duration) LogOption {
 	return func(entry *LogEntry) {
+		entry.Duration = duration
 		if entry.Context == nil {
 			entry.Context = make(map[string]interface{})
 		}
@@ -87,3 +121,76 @@ func WithDuration(duration time.Duration) LogOption {
 		entry.Context["duration_human"] = duration.String()
 	}
 }
+
+// WithEventTime records when the described event happened, e.g. for entries
+// about jobs finished earlier or events received from other systems
+func WithEventTime(t time.Time) LogOption {
+	return func(entry *LogEntry) {
+		eventTime := t.UTC()
+		entry.EventTime = &eventTime
+	}
+}
+
+// WithDeadline records the deadline of ctx and the time remaining when the entry
+// is written under the "deadline" context key. Entries written after the deadline
+// has passed are escalated to WARN so that work finishing too late is not silently
+// logged at INFO or DEBUG. Contexts without a deadline leave the entry unchanged.
+func WithDeadline(ctx context.Context) LogOption {
+	return func(entry *LogEntry) {
+		deadline, ok := ctx.Deadline()
+		if !ok {
+			return
+		}
+		if entry.Context == nil {
+			entry.Context = make(map[string]interface{})
+		}
+		now := entry.Timestamp
+		if now.IsZero() {
+			now = time.Now()
+		}
+		remaining := deadline.Sub(now)
+		exceeded := remaining <= 0
+		info := map[string]interface{}{
+			"deadline":        deadline.UTC().Format(time.RFC3339Nano),
+			"remaining_ms":    remaining.Milliseconds(),
+			"remaining_human": remaining.String(),
+			"exceeded":        exceeded,
+		}
+		if exceeded && getSeverityScore(entry.Level) < getSeverityScore(WARN) {
+			info["escalated_from"] = string(entry.Level)
+			entry.Level = WARN
+		}
+		entry.Context["deadline"] = info
+	}
+}
+
+// WithRuntimeStats attaches a snapshot of runtime memory and scheduler statistics
+// to the context, to help correlate an entry with resource pressure.
+// Reading the statistics briefly stops the world, so avoid it on hot paths.
+func WithRuntimeStats() LogOption {
+	return func(entry *LogEntry) {
+		if entry.Context == nil {
+			entry.Context = make(map[string]interface{})
+		}
+		entry.Context["runtime"] = runtimeStats()
+	}
+}
+
+// runtimeStats returns the runtime statistics attached by WithRuntimeStats
+func runtimeStats() map[string]interface{} {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	var lastPause time.Duration
+	if mem.NumGC > 0 {
+		lastPause = time.Duration(mem.PauseNs[(mem.NumGC+255)%256])
+	}
+	return map[string]interface{}{
+		"heap_inuse_bytes":    mem.HeapInuse,
+		"heap_alloc_bytes":    mem.HeapAlloc,
+		"num_gc":              mem.NumGC,
+		"last_gc_pause_ns":    lastPause.Nanoseconds(),
+		"last_gc_pause_human": lastPause.String(),
+		"goroutines":          runtime.NumGoroutine(),
+	}
+}
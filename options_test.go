@@ -1,7 +1,9 @@
 package vibelogger
 
 import (
+	"context"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 )
@@ -307,4 +309,128 @@ func TestWithRequestIDNilContext(t *testing.T) {
 	} else if actualRequestID != requestID {
 		t.Errorf("Expected request_id to be '%s', got '%v'", requestID, actualRequestID)
 	}
-}
\ No newline at end of file
+}
+func TestWithRuntimeStats(t *testing.T) {
+	entry := &LogEntry{}
+	WithRuntimeStats()(entry)
+
+	stats, ok := entry.Context["runtime"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("Expected runtime stats in context, got %v", entry.Context["runtime"])
+	}
+	for _, key := range []string{"heap_inuse_bytes", "num_gc", "last_gc_pause_ns", "goroutines"} {
+		if _, ok := stats[key]; !ok {
+			t.Errorf("Expected runtime stats to contain %s", key)
+		}
+	}
+	if stats["goroutines"].(int) < 1 {
+		t.Errorf("Expected at least one goroutine, got %v", stats["goroutines"])
+	}
+}
+
+func TestRuntimeStatsOnError(t *testing.T) {
+	config := &LoggerConfig{
+		AutoSave:            false,
+		EnableMemoryLog:     true,
+		MemoryLogLimit:      10,
+		RuntimeStatsOnError: true,
+	}
+	logger := NewLoggerWithConfig("runtime_stats_test", config)
+
+	logger.Info("test", "Info entry")
+	logger.Error("test", "Error entry")
+
+	logs := logger.GetMemoryLogs()
+	if _, ok := logs[0].Context["runtime"]; ok {
+		t.Error("Expected no runtime stats on INFO entries")
+	}
+	if _, ok := logs[1].Context["runtime"]; !ok {
+		t.Error("Expected runtime stats on ERROR entries")
+	}
+}
+
+func TestWithDeadline(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+	defer cancel()
+
+	entry := &LogEntry{Level: INFO, Timestamp: time.Now()}
+	WithDeadline(ctx)(entry)
+
+	info, ok := entry.Context["deadline"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("Expected deadline info in context, got %v", entry.Context["deadline"])
+	}
+	if info["exceeded"] != false || info["remaining_ms"].(int64) <= 0 {
+		t.Errorf("Expected remaining time before the deadline, got %v", info)
+	}
+	if entry.Level != INFO {
+		t.Errorf("Expected level to stay INFO, got %s", entry.Level)
+	}
+
+	entry = &LogEntry{Level: INFO}
+	WithDeadline(context.Background())(entry)
+	if entry.Context != nil {
+		t.Errorf("Expected no deadline info without a deadline, got %v", entry.Context)
+	}
+}
+
+func TestWithDeadlineEscalatesAfterDeadline(t *testing.T) {
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+
+	config := &LoggerConfig{
+		AutoSave:        false,
+		EnableMemoryLog: true,
+		MemoryLogLimit:  10,
+	}
+	logger := NewLoggerWithConfig("deadline_test", config)
+
+	logger.Info("fetch", "Response sent", WithDeadline(ctx))
+	logger.Error("fetch", "Request failed", WithDeadline(ctx))
+
+	logs := logger.GetMemoryLogs()
+	if logs[0].Level != WARN || logs[0].Severity != getSeverityScore(WARN) {
+		t.Errorf("Expected late INFO entry to be escalated to WARN, got %s (severity %d)", logs[0].Level, logs[0].Severity)
+	}
+	info := logs[0].Context["deadline"].(map[string]interface{})
+	if info["exceeded"] != true || info["escalated_from"] != "INFO" {
+		t.Errorf("Expected exceeded deadline info, got %v", info)
+	}
+	if logs[1].Level != ERROR {
+		t.Errorf("Expected ERROR entry to keep its level, got %s", logs[1].Level)
+	}
+	if _, ok := logs[1].Context["deadline"].(map[string]interface{})["escalated_from"]; ok {
+		t.Error("Expected no escalation for ERROR entries")
+	}
+}
+
+func TestWithEventTime(t *testing.T) {
+	entry := &LogEntry{}
+	happened := time.Date(2025, 3, 1, 9, 30, 0, 0, time.FixedZone("JST", 9*3600))
+	WithEventTime(happened)(entry)
+	WithDuration(1500 * time.Millisecond)(entry)
+
+	if entry.EventTime == nil || !entry.EventTime.Equal(happened) || entry.EventTime.Location() != time.UTC {
+		t.Errorf("Expected the event time in UTC, got %v", entry.EventTime)
+	}
+	if entry.Duration != 1500*time.Millisecond || entry.Context["duration_ms"] != int64(1500) {
+		t.Errorf("Expected the typed duration and the context keys, got %v / %v", entry.Duration, entry.Context)
+	}
+	if !entry.OccurredAt().Equal(happened) {
+		t.Errorf("Expected OccurredAt to return the event time, got %v", entry.OccurredAt())
+	}
+}
+
+func TestWithTags(t *testing.T) {
+	logger := NewLoggerWithConfig("tags_test", &LoggerConfig{AutoSave: false, EnableMemoryLog: true})
+	billing := logger.With(WithTags("billing"))
+
+	billing.Info("charge", "Charged", WithTags(" migration ", "billing", ""))
+	logs := logger.GetMemoryLogs()
+	if len(logs) != 1 || strings.Join(logs[0].Tags, ",") != "billing,migration" {
+		t.Fatalf("Expected trimmed, deduplicated tags, got %v", logs[0].Tags)
+	}
+	if !logs[0].HasTag("migration") || logs[0].HasTag("search") {
+		t.Error("Unexpected HasTag result")
+	}
+}
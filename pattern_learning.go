@@ -0,0 +1,162 @@
+package vibelogger
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LearnedPattern is feedback that entries with a fingerprint, or containing a
+// phrase, belong to a pattern and should carry a suggestion
+type LearnedPattern struct {
+	Fingerprint string    `json:"fingerprint,omitempty"` // ErrorFingerprint of matching entries
+	Phrase      string    `json:"phrase,omitempty"`      // Case-insensitive substring of operation or message
+	Pattern     string    `json:"pattern"`
+	Suggestion  string    `json:"suggestion,omitempty"`
+	LearnedAt   time.Time `json:"learned_at"`
+}
+
+// PatternLearner keeps learned patterns in a JSON rules file so pattern
+// detection improves as incidents are resolved. Learned patterns take
+// precedence over the built-in detection.
+type PatternLearner struct {
+	path     string
+	mutex    sync.RWMutex
+	patterns []LearnedPattern
+}
+
+// LoadPatternLearner reads the rules file at path; a missing file starts empty
+func LoadPatternLearner(path string) (*PatternLearner, error) {
+	learner := &PatternLearner{path: path}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return learner, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pattern rules: %w", err)
+	}
+	if err := json.Unmarshal(data, &learner.patterns); err != nil {
+		return nil, fmt.Errorf("failed to parse pattern rules: %w", err)
+	}
+	return learner, nil
+}
+
+// Learn records feedback and persists the rules file. Feedback for a
+// fingerprint or phrase that is already known replaces the earlier rule.
+func (p *PatternLearner) Learn(feedback LearnedPattern) error {
+	if feedback.Pattern == "" {
+		return fmt.Errorf("pattern is required")
+	}
+	if feedback.Fingerprint == "" && feedback.Phrase == "" {
+		return fmt.Errorf("fingerprint or phrase is required")
+	}
+	if feedback.LearnedAt.IsZero() {
+		feedback.LearnedAt = time.Now().UTC()
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	replaced := false
+	for i, known := range p.patterns {
+		if known.Fingerprint == feedback.Fingerprint && strings.EqualFold(known.Phrase, feedback.Phrase) {
+			p.patterns[i] = feedback
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		p.patterns = append(p.patterns, feedback)
+	}
+	return p.save()
+}
+
+// LearnFromEntry records that entries like entry belong to pattern. The
+// entry's fingerprint identifies similar entries.
+func (p *PatternLearner) LearnFromEntry(entry *LogEntry, pattern, suggestion string) error {
+	return p.Learn(LearnedPattern{Fingerprint: ErrorFingerprint(entry), Pattern: pattern, Suggestion: suggestion})
+}
+
+// Patterns returns a copy of the learned patterns
+func (p *PatternLearner) Patterns() []LearnedPattern {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	return append([]LearnedPattern(nil), p.patterns...)
+}
+
+// Match returns the learned pattern for an entry. Fingerprint rules win over
+// phrase rules, and later rules over earlier ones.
+func (p *PatternLearner) Match(entry *LogEntry) (LearnedPattern, bool) {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	if len(p.patterns) == 0 {
+		return LearnedPattern{}, false
+	}
+
+	fingerprint := ErrorFingerprint(entry)
+	for i := len(p.patterns) - 1; i >= 0; i-- {
+		if p.patterns[i].Fingerprint != "" && p.patterns[i].Fingerprint == fingerprint {
+			return p.patterns[i], true
+		}
+	}
+	combined := strings.ToLower(entry.Operation + " " + entry.FullMessage())
+	for i := len(p.patterns) - 1; i >= 0; i-- {
+		phrase := p.patterns[i].Phrase
+		if p.patterns[i].Fingerprint == "" && phrase != "" && strings.Contains(combined, strings.ToLower(phrase)) {
+			return p.patterns[i], true
+		}
+	}
+	return LearnedPattern{}, false
+}
+
+// Suggest implements SuggestionProvider with the learned suggestions
+func (p *PatternLearner) Suggest(entry *LogEntry) string {
+	if learned, ok := p.Match(entry); ok {
+		return learned.Suggestion
+	}
+	return ""
+}
+
+// save writes the rules file atomically; the caller holds the write lock
+func (p *PatternLearner) save() error {
+	data, err := json.MarshalIndent(p.patterns, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal pattern rules: %w", err)
+	}
+	tmpPath := p.path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write pattern rules: %w", err)
+	}
+	if err := os.Rename(tmpPath, p.path); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to write pattern rules: %w", err)
+	}
+	return nil
+}
+
+// SetPatternLearner applies learned patterns to new entries. A learned
+// suggestion replaces the one from the suggestion providers. nil disables it.
+func (l *Logger) SetPatternLearner(learner *PatternLearner) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.learner = learner
+}
+
+// applyLearnedPattern overrides the detected pattern with learned feedback
+func (l *Logger) applyLearnedPattern(entry *LogEntry) (LearnedPattern, bool) {
+	l.mutex.Lock()
+	learner := l.learner
+	l.mutex.Unlock()
+	if learner == nil {
+		return LearnedPattern{}, false
+	}
+	learned, ok := learner.Match(entry)
+	if ok {
+		entry.Pattern = learned.Pattern
+	}
+	return learned, ok
+}
@@ -0,0 +1,60 @@
+package vibelogger
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestPatternLearnerPersists(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "patterns.json")
+	learner, err := LoadPatternLearner(path)
+	if err != nil {
+		t.Fatalf("LoadPatternLearner failed: %v", err)
+	}
+
+	if err := learner.Learn(LearnedPattern{Phrase: "quota exceeded", Pattern: "quota_error", Suggestion: "Raise the API quota"}); err != nil {
+		t.Fatalf("Learn failed: %v", err)
+	}
+	if err := learner.Learn(LearnedPattern{Phrase: "Quota Exceeded", Pattern: "quota_error", Suggestion: "Request a quota increase"}); err != nil {
+		t.Fatalf("Learn failed: %v", err)
+	}
+	if err := learner.Learn(LearnedPattern{Pattern: "x"}); err == nil {
+		t.Error("expected error without fingerprint or phrase")
+	}
+
+	reloaded, err := LoadPatternLearner(path)
+	if err != nil {
+		t.Fatalf("reload failed: %v", err)
+	}
+	patterns := reloaded.Patterns()
+	if len(patterns) != 1 || patterns[0].Suggestion != "Request a quota increase" {
+		t.Errorf("feedback for the same phrase should replace the rule: %+v", patterns)
+	}
+}
+
+func TestPatternLearnerAppliedToEntries(t *testing.T) {
+	learner, _ := LoadPatternLearner(filepath.Join(t.TempDir(), "patterns.json"))
+	logger := NewLoggerWithConfig("learning", &LoggerConfig{AutoSave: false, EnableMemoryLog: true, MemoryLogLimit: 10})
+	defer logger.Close()
+
+	logger.Warn("billing_sync", "Upstream returned quota exceeded")
+	first := logger.GetMemoryLogs()[0]
+	if first.Pattern != "unknown_pattern" {
+		t.Fatalf("expected unknown pattern before learning, got %q", first.Pattern)
+	}
+
+	if err := learner.LearnFromEntry(&first, "quota_error", "Raise the billing API quota"); err != nil {
+		t.Fatalf("LearnFromEntry failed: %v", err)
+	}
+	logger.SetPatternLearner(learner)
+
+	logger.Warn("billing_sync", "Upstream returned quota exceeded")
+	logger.Warn("other_sync", "Upstream returned quota exceeded")
+	logs := logger.GetMemoryLogs()
+	if logs[1].Pattern != "quota_error" || logs[1].Suggestion != "Raise the billing API quota" {
+		t.Errorf("learned pattern not applied: pattern=%q suggestion=%q", logs[1].Pattern, logs[1].Suggestion)
+	}
+	if logs[2].Pattern != "unknown_pattern" {
+		t.Errorf("different fingerprint should not match, got %q", logs[2].Pattern)
+	}
+}
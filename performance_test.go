@@ -72,6 +72,27 @@ func BenchmarkLogger_Concurrent(b *testing.B) {
 	})
 }
 
+func BenchmarkLogger_Sharded(b *testing.B) {
+	config := DefaultConfig()
+	config.WriterShards = 8
+	logger, err := CreateFileLoggerWithConfig("bench_sharded", config)
+	if err != nil {
+		b.Fatalf("Failed to create logger: %v", err)
+	}
+	defer logger.Close()
+	defer cleanup()
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			logger.Info("concurrent_operation", fmt.Sprintf("concurrent message %d", i),
+				WithCorrelationID(fmt.Sprintf("req-%d", i%64)))
+			i++
+		}
+	})
+}
+
 func BenchmarkAIOptimization(b *testing.B) {
 	logger, err := CreateFileLogger("bench_ai")
 	if err != nil {
@@ -221,3 +242,23 @@ func cleanup() {
 	// Note: In a real implementation, you might want to clean up test log files
 	// For now, we'll let them persist for inspection
 }
+
+func BenchmarkMarshalEntry(b *testing.B) {
+	entry := LogEntry{
+		Timestamp: time.Now(),
+		Level:     INFO,
+		Operation: "http_request",
+		Message:   "request served",
+		Context:   map[string]interface{}{"hostname": "web-1", "version": "v1.4.2", "status": 200},
+		Tags:      []string{"http"},
+		Severity:  2,
+		Category:  "network",
+	}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := marshalEntry(&entry); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
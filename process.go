@@ -0,0 +1,70 @@
+package vibelogger
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// ProcessInfo identifies the host and process producing log entries.
+// It is computed once per process.
+type ProcessInfo struct {
+	Hostname   string    `json:"hostname,omitempty"`
+	Executable string    `json:"executable,omitempty"`
+	PID        int       `json:"pid"`
+	StartTime  time.Time `json:"start_time"`
+	WorkingDir string    `json:"working_dir,omitempty"`
+}
+
+var (
+	processInfoOnce sync.Once
+	processInfo     ProcessInfo
+	environmentInfo map[string]string
+)
+
+// loadProcessInfo computes the process and environment information once
+func loadProcessInfo() {
+	processInfoOnce.Do(func() {
+		hostname, _ := os.Hostname()
+		executable, _ := os.Executable()
+		workingDir, _ := os.Getwd()
+		processInfo = ProcessInfo{
+			Hostname:   hostname,
+			Executable: filepath.Base(executable),
+			PID:        os.Getpid(),
+			StartTime:  time.Now().UTC(),
+			WorkingDir: workingDir,
+		}
+		environmentInfo = map[string]string{
+			"go_version": runtime.Version(),
+			"os":         runtime.GOOS,
+			"arch":       runtime.GOARCH,
+		}
+	})
+}
+
+// GetProcessInfo returns information about the current host and process.
+// StartTime is the time the information was first requested, which is close
+// to process start for loggers created during initialization.
+func GetProcessInfo() ProcessInfo {
+	loadProcessInfo()
+	return processInfo
+}
+
+// Fields returns the process information as global fields:
+// hostname, executable, pid and process_start_time
+func (p ProcessInfo) Fields() map[string]interface{} {
+	fields := map[string]interface{}{
+		"pid":                p.PID,
+		"process_start_time": p.StartTime.Format(time.RFC3339),
+	}
+	if p.Hostname != "" {
+		fields["hostname"] = p.Hostname
+	}
+	if p.Executable != "" {
+		fields["executable"] = p.Executable
+	}
+	return fields
+}
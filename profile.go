@@ -0,0 +1,201 @@
+package vibelogger
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"sync"
+	"time"
+)
+
+// ProfileKind selects the profile captured by a ProfileTrigger
+type ProfileKind string
+
+// Supported profile kinds
+const (
+	ProfileCPU  ProfileKind = "cpu"
+	ProfileHeap ProfileKind = "heap"
+)
+
+// ProfileTrigger captures a pprof profile when ERROR entries arrive faster than
+// ErrorThreshold per Window, so that evidence is collected while things go wrong
+type ProfileTrigger struct {
+	ErrorThreshold int           // ERROR entries within Window that trigger a capture
+	Window         time.Duration // Sliding window used to measure the error rate
+	Kind           ProfileKind   // cpu (default) or heap
+	Duration       time.Duration // Length of a CPU profile (default 10s)
+	Dir            string        // Artifacts directory (default: "artifacts" next to the log file)
+	Cooldown       time.Duration // Minimum time between captures (default 5m)
+}
+
+// profiler tracks the error rate and runs at most one capture at a time
+type profiler struct {
+	trigger   ProfileTrigger
+	mutex     sync.Mutex
+	errors    []time.Time
+	capturing bool
+	lastStart time.Time
+	stop      chan struct{}
+	wg        sync.WaitGroup
+}
+
+// SetProfileTrigger enables profile capture on error bursts; nil disables it.
+// A capture in progress is stopped when the trigger is replaced or the logger is closed.
+func (l *Logger) SetProfileTrigger(trigger *ProfileTrigger) error {
+	var p *profiler
+	if trigger != nil {
+		t := *trigger
+		if t.ErrorThreshold <= 0 || t.Window <= 0 {
+			return fmt.Errorf("profile trigger requires a positive error threshold and window")
+		}
+		if t.Kind == "" {
+			t.Kind = ProfileCPU
+		}
+		if t.Kind != ProfileCPU && t.Kind != ProfileHeap {
+			return fmt.Errorf("invalid profile kind: %s (must be %s or %s)", t.Kind, ProfileCPU, ProfileHeap)
+		}
+		if t.Duration <= 0 {
+			t.Duration = 10 * time.Second
+		}
+		if t.Cooldown <= 0 {
+			t.Cooldown = 5 * time.Minute
+		}
+		if t.Dir == "" {
+			t.Dir = l.defaultArtifactsDir()
+		}
+		dir, err := l.config.sandboxPath(t.Dir)
+		if err != nil {
+			return fmt.Errorf("invalid profile directory: %w", err)
+		}
+		t.Dir = dir
+		p = &profiler{trigger: t, stop: make(chan struct{})}
+	}
+
+	l.bgMutex.Lock()
+	previous := l.profiler
+	l.profiler = p
+	l.bgMutex.Unlock()
+
+	previous.shutdown()
+	return nil
+}
+
+// defaultArtifactsDir returns the artifacts directory next to the log file
+func (l *Logger) defaultArtifactsDir() string {
+	if l.filePath != "" {
+		return filepath.Join(filepath.Dir(l.filePath), "artifacts")
+	}
+	return filepath.Join(l.config.logsRoot(), "artifacts")
+}
+
+// observeError records an ERROR entry and starts a capture when the threshold is crossed
+func (l *Logger) observeError() {
+	l.bgMutex.Lock()
+	p := l.profiler
+	l.bgMutex.Unlock()
+	if p == nil {
+		return
+	}
+
+	now := time.Now()
+	p.mutex.Lock()
+	cutoff := now.Add(-p.trigger.Window)
+	kept := p.errors[:0]
+	for _, ts := range p.errors {
+		if ts.After(cutoff) {
+			kept = append(kept, ts)
+		}
+	}
+	p.errors = append(kept, now)
+	count := len(p.errors)
+
+	start := count >= p.trigger.ErrorThreshold && !p.capturing &&
+		(p.lastStart.IsZero() || now.Sub(p.lastStart) >= p.trigger.Cooldown)
+	if start {
+		p.capturing = true
+		p.lastStart = now
+		p.errors = p.errors[:0]
+		p.wg.Add(1)
+	}
+	p.mutex.Unlock()
+
+	if start {
+		go l.captureProfile(p, count)
+	}
+}
+
+// captureProfile writes a profile to the artifacts directory and logs its location
+func (l *Logger) captureProfile(p *profiler, errorCount int) {
+	defer p.wg.Done()
+	defer func() {
+		p.mutex.Lock()
+		p.capturing = false
+		p.mutex.Unlock()
+	}()
+
+	started := time.Now()
+	path, err := p.capture(started)
+	if err != nil {
+		l.Warn("profile_capture", "Failed to capture profile after error burst",
+			WithError(err),
+			WithContext(map[string]interface{}{"profile_kind": string(p.trigger.Kind)}))
+		return
+	}
+
+	l.Info("profile_capture", fmt.Sprintf("Captured %s profile after %d errors within %s", p.trigger.Kind, errorCount, p.trigger.Window),
+		WithDuration(time.Since(started)),
+		WithContext(map[string]interface{}{
+			"profile_kind": string(p.trigger.Kind),
+			"profile_path": path,
+			"error_count":  errorCount,
+		}))
+}
+
+// capture writes a single profile and returns its path
+func (p *profiler) capture(started time.Time) (string, error) {
+	if err := os.MkdirAll(p.trigger.Dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create artifacts directory: %w", err)
+	}
+
+	name := fmt.Sprintf("%s_%s.pprof", p.trigger.Kind, started.Format("20060102_150405"))
+	path := filepath.Join(p.trigger.Dir, name)
+	file, err := os.Create(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to create profile file: %w", err)
+	}
+	defer file.Close()
+
+	switch p.trigger.Kind {
+	case ProfileHeap:
+		runtime.GC() // Report up-to-date allocation data
+		err = pprof.WriteHeapProfile(file)
+	default:
+		if err = pprof.StartCPUProfile(file); err != nil {
+			break
+		}
+		timer := time.NewTimer(p.trigger.Duration)
+		select {
+		case <-timer.C:
+		case <-p.stop:
+			timer.Stop()
+		}
+		pprof.StopCPUProfile()
+	}
+	if err != nil {
+		file.Close()
+		os.Remove(path)
+		return "", fmt.Errorf("failed to write %s profile: %w", p.trigger.Kind, err)
+	}
+	return path, nil
+}
+
+// shutdown stops a running capture and waits for it to finish. It is safe on a nil profiler.
+func (p *profiler) shutdown() {
+	if p == nil {
+		return
+	}
+	close(p.stop)
+	p.wg.Wait()
+}
@@ -0,0 +1,63 @@
+package vibelogger
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestProfileTriggerOnErrorBurst(t *testing.T) {
+	for _, kind := range []ProfileKind{ProfileHeap, ProfileCPU} {
+		t.Run(string(kind), func(t *testing.T) {
+			logger := NewLoggerWithConfig("profile_test", &LoggerConfig{EnableMemoryLog: true, MemoryLogLimit: 100})
+			defer logger.Close()
+
+			err := logger.SetProfileTrigger(&ProfileTrigger{
+				ErrorThreshold: 3,
+				Window:         time.Minute,
+				Kind:           kind,
+				Duration:       20 * time.Millisecond,
+				Dir:            t.TempDir(),
+			})
+			if err != nil {
+				t.Fatalf("Failed to set profile trigger: %v", err)
+			}
+
+			logger.Error("test", "First error")
+			logger.Error("test", "Second error")
+			logger.Error("test", "Third error")
+			logs := waitForMemoryLogs(t, logger, 4)
+
+			capture := logs[3]
+			if capture.Operation != "profile_capture" || capture.Level != INFO {
+				t.Fatalf("Expected profile_capture INFO entry, got %s %s: %s", capture.Level, capture.Operation, capture.Message)
+			}
+			path, _ := capture.Context["profile_path"].(string)
+			if info, err := os.Stat(path); err != nil || info.Size() == 0 {
+				t.Errorf("Expected non-empty profile at %q: %v", path, err)
+			}
+
+			// The cooldown prevents another capture for the next burst
+			for i := 0; i < 3; i++ {
+				logger.Error("test", "Another error")
+			}
+			time.Sleep(50 * time.Millisecond)
+			if n := len(logger.GetMemoryLogs()); n != 7 {
+				t.Errorf("Expected no capture during cooldown, got %d entries", n)
+			}
+		})
+	}
+}
+
+func TestProfileTriggerValidation(t *testing.T) {
+	logger := NewLoggerWithConfig("profile_test", &LoggerConfig{})
+	if err := logger.SetProfileTrigger(&ProfileTrigger{ErrorThreshold: 1}); err == nil {
+		t.Error("Expected error for missing window")
+	}
+	if err := logger.SetProfileTrigger(&ProfileTrigger{ErrorThreshold: 1, Window: time.Second, Kind: "block"}); err == nil {
+		t.Error("Expected error for unsupported profile kind")
+	}
+	if err := logger.SetProfileTrigger(nil); err != nil {
+		t.Errorf("Disabling the trigger should not fail: %v", err)
+	}
+}
@@ -0,0 +1,155 @@
+package vibelogger
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// projectActivityFile holds the metadata of a project directory. The leading
+// dot keeps it out of log file listings such as ReadLogDir.
+const projectActivityFile = ".project.json"
+
+// ProjectActivity is the lightweight bookkeeping of a project directory under
+// logs/, refreshed whenever a logger of the project rotates or closes its file
+type ProjectActivity struct {
+	Project      string    `json:"project"`
+	LastActivity time.Time `json:"last_activity"` // Last rotation or close, or newest file change without metadata
+	FileCount    int       `json:"file_count"`    // Log files, including rotated ones
+	TotalBytes   int64     `json:"total_bytes"`
+}
+
+// GetProjectActivity returns the activity of logs/<project>. An empty project
+// means "default". Directories without metadata, e.g. written by older
+// versions, are scanned instead.
+func GetProjectActivity(project string) (*ProjectActivity, error) {
+	if project == "" {
+		project = "default"
+	}
+	if !isValidProjectName(project) {
+		return nil, fmt.Errorf("invalid project name: %s", project)
+	}
+	dir := filepath.Join("logs", project)
+	if _, err := os.Stat(dir); err != nil {
+		return nil, fmt.Errorf("failed to read project directory: %w", err)
+	}
+
+	if activity, err := loadProjectActivity(dir); err == nil {
+		return activity, nil
+	}
+	return scanProjectActivity(dir)
+}
+
+// ListProjectActivity returns the activity of every project directory under
+// logs/, most recently active first
+func ListProjectActivity() ([]ProjectActivity, error) {
+	dirs, err := os.ReadDir("logs")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read logs directory: %w", err)
+	}
+
+	var activities []ProjectActivity
+	for _, d := range dirs {
+		if !d.IsDir() || !isValidProjectName(d.Name()) {
+			continue
+		}
+		activity, err := GetProjectActivity(d.Name())
+		if err != nil {
+			return nil, err
+		}
+		activities = append(activities, *activity)
+	}
+	sort.SliceStable(activities, func(i, j int) bool {
+		return activities[i].LastActivity.After(activities[j].LastActivity)
+	})
+	return activities, nil
+}
+
+// loadProjectActivity reads the metadata file of a project directory
+func loadProjectActivity(dir string) (*ProjectActivity, error) {
+	data, err := os.ReadFile(filepath.Join(dir, projectActivityFile))
+	if err != nil {
+		return nil, err
+	}
+	var activity ProjectActivity
+	if err := json.Unmarshal(data, &activity); err != nil {
+		return nil, err
+	}
+	return &activity, nil
+}
+
+// scanProjectActivity computes the activity of a project directory from its files
+func scanProjectActivity(dir string) (*ProjectActivity, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read project directory: %w", err)
+	}
+
+	activity := &ProjectActivity{Project: filepath.Base(dir)}
+	for _, e := range entries {
+		// Hidden files hold rotation state and this metadata
+		if e.IsDir() || strings.HasPrefix(e.Name(), ".") || !strings.Contains(e.Name(), ".log") {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue // Removed by retention meanwhile
+		}
+		activity.FileCount++
+		activity.TotalBytes += info.Size()
+		if info.ModTime().After(activity.LastActivity) {
+			activity.LastActivity = info.ModTime()
+		}
+	}
+	return activity, nil
+}
+
+// recordProjectActivity refreshes the metadata of the logger's project
+// directory. Loggers with a custom FilePath have no project directory. The
+// callers hold the mutex, so failures go to reportError.
+func (l *Logger) recordProjectActivity() {
+	if l.projectDir == "" {
+		return
+	}
+	activity, err := scanProjectActivity(l.projectDir)
+	if err != nil {
+		l.reportError(err)
+		return
+	}
+	activity.LastActivity = time.Now().UTC()
+	if err := saveProjectActivity(l.projectDir, activity); err != nil {
+		l.reportError(err)
+	}
+}
+
+// saveProjectActivity writes the metadata atomically. Several loggers may share
+// a project, so each writes through its own temporary file.
+func saveProjectActivity(dir string, activity *ProjectActivity) error {
+	data, err := json.MarshalIndent(activity, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal project activity: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(dir, projectActivityFile+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write project activity: %w", err)
+	}
+	if err = tmp.Chmod(0644); err == nil {
+		_, err = tmp.Write(data)
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), filepath.Join(dir, projectActivityFile))
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write project activity: %w", err)
+	}
+	return nil
+}
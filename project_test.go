@@ -0,0 +1,66 @@
+package vibelogger
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestProjectActivity(t *testing.T) {
+	dir := filepath.Join("logs", "activity-test")
+	defer os.RemoveAll(dir)
+
+	config := DefaultConfig()
+	config.ProjectName = "activity-test"
+	logger, err := CreateFileLoggerWithConfig("activity_test", config)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	start := time.Now().Add(-time.Second)
+
+	logger.Info("test", "Before rotation")
+	if err := logger.ForceRotation(); err != nil {
+		t.Fatalf("Failed to rotate: %v", err)
+	}
+	activity, err := GetProjectActivity("activity-test")
+	if err != nil {
+		t.Fatalf("Failed to get activity: %v", err)
+	}
+	if activity.FileCount != 2 || activity.TotalBytes == 0 || activity.LastActivity.Before(start) {
+		t.Errorf("Expected 2 files after rotation, got %+v", activity)
+	}
+
+	logger.Info("test", "After rotation")
+	logger.Close()
+	closed, err := GetProjectActivity("activity-test")
+	if err != nil {
+		t.Fatalf("Failed to get activity: %v", err)
+	}
+	if closed.TotalBytes <= activity.TotalBytes || closed.LastActivity.Before(activity.LastActivity) {
+		t.Errorf("Expected the close to update the activity, got %+v after %+v", closed, activity)
+	}
+
+	// Directories without metadata are scanned
+	os.Remove(filepath.Join(dir, projectActivityFile))
+	scanned, err := GetProjectActivity("activity-test")
+	if err != nil || scanned.FileCount != 2 || scanned.TotalBytes != closed.TotalBytes {
+		t.Errorf("Expected the scan to match the metadata, got %+v (%v)", scanned, err)
+	}
+
+	all, err := ListProjectActivity()
+	if err != nil {
+		t.Fatalf("Failed to list activity: %v", err)
+	}
+	found := false
+	for _, a := range all {
+		found = found || a.Project == "activity-test"
+	}
+	if !found {
+		t.Errorf("Expected activity-test in %+v", all)
+	}
+
+	if _, err := GetProjectActivity("../etc"); err == nil {
+		t.Error("Expected an invalid project name to be rejected")
+	}
+}
@@ -0,0 +1,206 @@
+package vibelogger
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Query selects log entries. Zero-valued fields match everything and all
+// non-zero fields must match.
+type Query struct {
+	ID            string        // Exact entry ID
+	Levels        []LogLevel    // Any of these levels
+	Operation     string        // Exact operation name
+	CorrelationID string        // Exact correlation ID
+	SessionID     string        // Exact session ID
+	Tags          []string      // Entries carrying every one of these tags
+	Text          string        // Case-insensitive substring of the operation, message, tags or context
+	Since         time.Time     // Entries at or after this time
+	Until         time.Time     // Entries before this time
+	EventSince    time.Time     // Entries whose event happened at or after this time, see LogEntry.OccurredAt
+	EventUntil    time.Time     // Entries whose event happened before this time
+	MinDuration   time.Duration // Entries with a Duration of at least this
+	MaxDuration   time.Duration // Entries with a non-zero Duration of at most this
+	Redacted      bool          // Entries with at least one redacted context value, see LogEntry.RedactedFields
+}
+
+// Match reports whether the entry satisfies the query
+func (q *Query) Match(entry *LogEntry) bool {
+	if q.ID != "" && entry.ID != q.ID {
+		return false
+	}
+	if len(q.Levels) > 0 && !containsLevel(q.Levels, entry.Level) {
+		return false
+	}
+	if q.Operation != "" && entry.Operation != q.Operation {
+		return false
+	}
+	if q.CorrelationID != "" && entry.CorrelationID != q.CorrelationID {
+		return false
+	}
+	if q.SessionID != "" && entry.SessionID != q.SessionID {
+		return false
+	}
+	for _, tag := range q.Tags {
+		if !entry.HasTag(tag) {
+			return false
+		}
+	}
+	if !q.Since.IsZero() && entry.Timestamp.Before(q.Since) {
+		return false
+	}
+	if !q.Until.IsZero() && !entry.Timestamp.Before(q.Until) {
+		return false
+	}
+	if !q.EventSince.IsZero() && entry.OccurredAt().Before(q.EventSince) {
+		return false
+	}
+	if !q.EventUntil.IsZero() && !entry.OccurredAt().Before(q.EventUntil) {
+		return false
+	}
+	if q.MinDuration > 0 && entry.Duration < q.MinDuration {
+		return false
+	}
+	if q.MaxDuration > 0 && (entry.Duration == 0 || entry.Duration > q.MaxDuration) {
+		return false
+	}
+	if q.Redacted && len(entry.RedactedFields()) == 0 {
+		return false
+	}
+	if q.Text != "" && !entryContainsText(entry, q.Text) {
+		return false
+	}
+	return true
+}
+
+// Filter returns the entries matching the query, preserving their order
+func (q *Query) Filter(entries []LogEntry) []LogEntry {
+	var matched []LogEntry
+	for i := range entries {
+		if q.Match(&entries[i]) {
+			matched = append(matched, entries[i])
+		}
+	}
+	return matched
+}
+
+// ParseLevels parses a comma-separated list of level names such as "warn,error"
+func ParseLevels(s string) []LogLevel {
+	var levels []LogLevel
+	for _, name := range strings.Split(s, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			levels = append(levels, LogLevel(strings.ToUpper(name)))
+		}
+	}
+	return levels
+}
+
+// ParseLevel parses a single level name such as "warn"
+func ParseLevel(s string) (LogLevel, error) {
+	level := LogLevel(strings.ToUpper(strings.TrimSpace(s)))
+	if !containsLevel([]LogLevel{DEBUG, INFO, WARN, ERROR}, level) {
+		return "", fmt.Errorf("unknown level: %s", s)
+	}
+	return level, nil
+}
+
+// ParseTags parses a comma-separated list of tags such as "billing,migration"
+func ParseTags(s string) []string {
+	var tags []string
+	for _, tag := range strings.Split(s, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// HasTag reports whether the entry carries the tag
+func (e *LogEntry) HasTag(tag string) bool {
+	for _, t := range e.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// containsLevel reports whether level is in levels
+func containsLevel(levels []LogLevel, level LogLevel) bool {
+	for _, l := range levels {
+		if l == level {
+			return true
+		}
+	}
+	return false
+}
+
+// entryContainsText performs the full-text match of Query.Text
+func entryContainsText(entry *LogEntry, text string) bool {
+	text = strings.ToLower(text)
+	if strings.Contains(strings.ToLower(entry.Operation), text) ||
+		strings.Contains(strings.ToLower(entry.FullMessage()), text) {
+		return true
+	}
+	for _, tag := range entry.Tags {
+		if strings.Contains(strings.ToLower(tag), text) {
+			return true
+		}
+	}
+	if len(entry.Context) == 0 {
+		return false
+	}
+	context, err := json.Marshal(entry.Context)
+	return err == nil && strings.Contains(strings.ToLower(string(context)), text)
+}
+
+// OccurredAt returns the EventTime of the entry, or its Timestamp without one
+func (e *LogEntry) OccurredAt() time.Time {
+	if e.EventTime != nil {
+		return *e.EventTime
+	}
+	return e.Timestamp
+}
+
+// DurationStats aggregates the Duration field of entries
+type DurationStats struct {
+	Count int           `json:"count"` // Entries with a non-zero Duration
+	Total time.Duration `json:"total"`
+	Min   time.Duration `json:"min"`
+	Max   time.Duration `json:"max"`
+	Mean  time.Duration `json:"mean"`
+	P50   time.Duration `json:"p50"`
+	P95   time.Duration `json:"p95"`
+	P99   time.Duration `json:"p99"`
+}
+
+// SummarizeDurations aggregates the durations of the entries, ignoring entries
+// without one. Combine it with Query.Filter to aggregate one operation.
+func SummarizeDurations(entries []LogEntry) DurationStats {
+	var durations []time.Duration
+	for i := range entries {
+		if entries[i].Duration > 0 {
+			durations = append(durations, entries[i].Duration)
+		}
+	}
+	if len(durations) == 0 {
+		return DurationStats{}
+	}
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+
+	stats := DurationStats{Count: len(durations), Min: durations[0], Max: durations[len(durations)-1]}
+	for _, d := range durations {
+		stats.Total += d
+	}
+	stats.Mean = stats.Total / time.Duration(len(durations))
+	// Nearest-rank percentiles
+	percentile := func(p int) time.Duration {
+		rank := (p*len(durations) + 99) / 100
+		return durations[max(rank, 1)-1]
+	}
+	stats.P50, stats.P95, stats.P99 = percentile(50), percentile(95), percentile(99)
+	return stats
+}
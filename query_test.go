@@ -0,0 +1,78 @@
+package vibelogger
+
+import (
+	"testing"
+	"time"
+)
+
+func TestQueryFilter(t *testing.T) {
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	entries := []LogEntry{
+		{Timestamp: base, Level: INFO, Operation: "startup", Message: "Service started"},
+		{Timestamp: base.Add(time.Minute), Level: ERROR, Operation: "db_query", Message: "Connection refused", CorrelationID: "req-1"},
+		{Timestamp: base.Add(2 * time.Minute), Level: WARN, Operation: "api_call", Message: "Slow response",
+			CorrelationID: "req-1", Context: map[string]interface{}{"endpoint": "/users"}, Duration: 3 * time.Second,
+			Tags: []string{"billing", "migration"}},
+	}
+	occurred := base.Add(-time.Hour)
+	entries[1].EventTime = &occurred
+	entries[1].Duration = 200 * time.Millisecond
+
+	tests := []struct {
+		name     string
+		query    Query
+		expected []string
+	}{
+		{"empty query", Query{}, []string{"startup", "db_query", "api_call"}},
+		{"levels", Query{Levels: ParseLevels("warn, error")}, []string{"db_query", "api_call"}},
+		{"operation", Query{Operation: "startup"}, []string{"startup"}},
+		{"correlation", Query{CorrelationID: "req-1"}, []string{"db_query", "api_call"}},
+		{"text in message", Query{Text: "REFUSED"}, []string{"db_query"}},
+		{"text in context", Query{Text: "/users"}, []string{"api_call"}},
+		{"time range", Query{Since: base.Add(time.Minute), Until: base.Add(2 * time.Minute)}, []string{"db_query"}},
+		{"combined", Query{Levels: []LogLevel{ERROR}, CorrelationID: "req-2"}, nil},
+		{"tags", Query{Tags: ParseTags("migration, billing")}, []string{"api_call"}},
+		{"missing tag", Query{Tags: []string{"billing", "search"}}, nil},
+		{"text in tags", Query{Text: "MIGRAT"}, []string{"api_call"}},
+		{"event time", Query{EventUntil: base}, []string{"db_query"}},
+		{"event time falls back to timestamp", Query{EventSince: base}, []string{"startup", "api_call"}},
+		{"min duration", Query{MinDuration: time.Second}, []string{"api_call"}},
+		{"max duration", Query{MaxDuration: time.Second}, []string{"db_query"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matched := tt.query.Filter(entries)
+			if len(matched) != len(tt.expected) {
+				t.Fatalf("Expected %d entries, got %d", len(tt.expected), len(matched))
+			}
+			for i, entry := range matched {
+				if entry.Operation != tt.expected[i] {
+					t.Errorf("Expected entry %d to be %s, got %s", i, tt.expected[i], entry.Operation)
+				}
+			}
+		})
+	}
+}
+
+func TestSummarizeDurations(t *testing.T) {
+	var entries []LogEntry
+	for i := 1; i <= 100; i++ {
+		entries = append(entries, LogEntry{Operation: "op", Duration: time.Duration(i) * time.Millisecond})
+	}
+	entries = append(entries, LogEntry{Operation: "op"}) // No duration
+
+	stats := SummarizeDurations(entries)
+	if stats.Count != 100 || stats.Min != time.Millisecond || stats.Max != 100*time.Millisecond {
+		t.Errorf("Unexpected count or range: %+v", stats)
+	}
+	if stats.Total != 5050*time.Millisecond || stats.Mean != 50500*time.Microsecond {
+		t.Errorf("Unexpected total or mean: %+v", stats)
+	}
+	if stats.P50 != 50*time.Millisecond || stats.P95 != 95*time.Millisecond || stats.P99 != 99*time.Millisecond {
+		t.Errorf("Unexpected percentiles: %+v", stats)
+	}
+	if empty := SummarizeDurations(nil); empty != (DurationStats{}) {
+		t.Errorf("Expected zero stats without durations, got %+v", empty)
+	}
+}
@@ -0,0 +1,113 @@
+package vibelogger
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sync"
+	"time"
+)
+
+// Quota limits what one receiver project may ingest. Zero fields are unlimited.
+type Quota struct {
+	// EntriesPerSecond is the sustained entry rate. A batch is admitted while
+	// the project is within its rate and may exceed it; later batches are
+	// rejected until the average is back under the rate, so batching clients
+	// are not penalized for the size of their batches.
+	EntriesPerSecond float64
+	// BytesPerDay limits the decoded request bodies accepted per UTC day
+	BytesPerDay int64
+}
+
+// validate rejects negative limits
+func (q Quota) validate() error {
+	if q.EntriesPerSecond < 0 || math.IsNaN(q.EntriesPerSecond) || math.IsInf(q.EntriesPerSecond, 0) {
+		return fmt.Errorf("invalid entries per second: %v", q.EntriesPerSecond)
+	}
+	if q.BytesPerDay < 0 {
+		return fmt.Errorf("invalid bytes per day: %d", q.BytesPerDay)
+	}
+	return nil
+}
+
+// Names of the quota exceeded, as reported in responses and audit entries
+const (
+	quotaEntriesPerSecond = "entries_per_second"
+	quotaBytesPerDay      = "bytes_per_day"
+)
+
+// quotaUsage tracks the consumption of one project's quota
+type quotaUsage struct {
+	quota Quota
+
+	mutex    sync.Mutex
+	credit   float64   // Entries that may still be admitted, negative after a large batch
+	refilled time.Time // When credit was last refilled
+	day      string    // UTC day bytes are counted for
+	bytes    int64     // Bytes accepted on day
+	exceeded string    // Quota rejected last, reported once until a batch is admitted again
+}
+
+// newQuotaUsage starts tracking quota with a full second of entries available
+func newQuotaUsage(quota Quota) *quotaUsage {
+	return &quotaUsage{quota: quota, credit: quota.EntriesPerSecond}
+}
+
+// admit charges a batch of entries and bytes received at now. When a quota
+// is exceeded nothing is charged, and it returns the quota's name, how long
+// the client should wait and whether this is the first rejection since the
+// last admitted batch.
+func (u *quotaUsage) admit(entries int, bytes int64, now time.Time) (exceeded string, retryAfter time.Duration, first bool) {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	if rate := u.quota.EntriesPerSecond; rate > 0 {
+		if !u.refilled.IsZero() {
+			u.credit = math.Min(rate, u.credit+now.Sub(u.refilled).Seconds()*rate)
+		}
+		u.refilled = now
+		if u.credit <= 0 {
+			wait := time.Duration((1 - u.credit) / rate * float64(time.Second))
+			return u.reject(quotaEntriesPerSecond, wait)
+		}
+	}
+
+	if limit := u.quota.BytesPerDay; limit > 0 {
+		day := now.UTC().Format("2006-01-02")
+		if day != u.day {
+			u.day, u.bytes = day, 0
+		}
+		if u.bytes+bytes > limit {
+			year, month, date := now.UTC().Date()
+			midnight := time.Date(year, month, date+1, 0, 0, 0, 0, time.UTC)
+			return u.reject(quotaBytesPerDay, midnight.Sub(now))
+		}
+		u.bytes += bytes
+	}
+
+	if u.quota.EntriesPerSecond > 0 {
+		u.credit -= float64(entries)
+	}
+	u.exceeded = ""
+	return "", 0, false
+}
+
+// reject records a rejection by quota
+func (u *quotaUsage) reject(quota string, retryAfter time.Duration) (string, time.Duration, bool) {
+	first := u.exceeded != quota
+	u.exceeded = quota
+	return quota, retryAfter, first
+}
+
+// countingReader counts the bytes read through it
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+// Read implements io.Reader
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,74 @@
+package vibelogger
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestQuotaUsage(t *testing.T) {
+	start := time.Date(2024, 1, 2, 23, 59, 0, 0, time.UTC)
+	usage := newQuotaUsage(Quota{EntriesPerSecond: 10, BytesPerDay: 1000})
+
+	// A large batch is admitted within the rate, then clients must wait
+	if exceeded, _, _ := usage.admit(30, 100, start); exceeded != "" {
+		t.Fatalf("expected the first batch to be admitted, got %s", exceeded)
+	}
+	exceeded, retryAfter, first := usage.admit(1, 10, start.Add(time.Second))
+	if exceeded != quotaEntriesPerSecond || !first || retryAfter != 1100*time.Millisecond {
+		t.Errorf("expected the rate to be exceeded for 1.1s, got %s %v %v", exceeded, retryAfter, first)
+	}
+	if _, _, first := usage.admit(1, 10, start.Add(time.Second)); first {
+		t.Error("expected a repeated rejection not to be reported again")
+	}
+	if exceeded, _, _ := usage.admit(1, 10, start.Add(4*time.Second)); exceeded != "" {
+		t.Errorf("expected the rate to recover, got %s", exceeded)
+	}
+
+	exceeded, retryAfter, _ = usage.admit(1, 900, start.Add(10*time.Second))
+	if exceeded != quotaBytesPerDay || retryAfter != 50*time.Second {
+		t.Errorf("expected the daily bytes to be exceeded until midnight, got %s %v", exceeded, retryAfter)
+	}
+	if exceeded, _, _ := usage.admit(1, 900, start.Add(time.Minute)); exceeded != "" {
+		t.Errorf("expected the daily bytes to reset, got %s", exceeded)
+	}
+}
+
+func TestReceiverQuotas(t *testing.T) {
+	diagnostics := NewLoggerWithConfig("receiver_diag", &LoggerConfig{AutoSave: false, EnableMemoryLog: true})
+	receiver := newTestReceiver(t, ReceiverConfig{
+		Quotas:       map[string]Quota{"receiver-tenant-a": {EntriesPerSecond: 0.001}},
+		DefaultQuota: Quota{BytesPerDay: 100},
+		Logger:       diagnostics,
+	})
+	body := `{"operation":"sync","message":"Synced"}` + "\n"
+
+	if rec, resp := postEntries(receiver, "token-a", strings.Repeat(body, 3)); rec.Code != http.StatusAccepted || resp.Accepted != 3 {
+		t.Fatalf("expected the first batch to be accepted, got %d %+v", rec.Code, resp)
+	}
+	for i := 0; i < 2; i++ {
+		rec, resp := postEntries(receiver, "token-a", body)
+		if rec.Code != http.StatusTooManyRequests || resp.Rejected != 1 || rec.Header().Get("Retry-After") == "" {
+			t.Errorf("expected 429 with Retry-After, got %d %+v", rec.Code, resp)
+		}
+	}
+
+	// Other projects are limited by the default quota only
+	if rec, _ := postEntries(receiver, "token-b", body); rec.Code != http.StatusAccepted {
+		t.Errorf("expected tenant b to be unaffected, got %d", rec.Code)
+	}
+	if rec, resp := postEntries(receiver, "token-b", body+body); rec.Code != http.StatusTooManyRequests || !strings.Contains(resp.Error, quotaBytesPerDay) {
+		t.Errorf("expected the daily bytes to be exceeded, got %d %+v", rec.Code, resp)
+	}
+
+	logs := diagnostics.GetMemoryLogs()
+	if len(logs) != 2 || logs[0].Operation != "receiver_quota" || logs[0].Category != "audit" ||
+		logs[0].Context["project"] != "receiver-tenant-a" || logs[1].Context["quota"] != quotaBytesPerDay {
+		t.Errorf("expected one audit entry per exceeded quota, got %+v", logs)
+	}
+
+	if _, err := NewReceiver(ReceiverConfig{Tokens: map[string]string{"t": "p"}, DefaultQuota: Quota{BytesPerDay: -1}}); err == nil {
+		t.Error("expected a negative quota to be rejected")
+	}
+}
@@ -0,0 +1,153 @@
+package vibelogger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// Decoding of numbers in the context of entries read back from log files
+const (
+	NumbersFloat = "float" // float64 as with encoding/json (default); integers beyond 2^53 lose precision
+	NumbersExact = "exact" // json.Number, keeping the literal as written
+	NumbersInt64 = "int64" // int64 for integers that fit, float64 for everything else
+)
+
+// ReadOption customizes how ReadLog, ReadLogFile, ReadLogDir and the log
+// iterators decode entries
+type ReadOption func(*readOptions)
+
+// readOptions holds the decoding settings of a read
+type readOptions struct {
+	numbers       string
+	schema        ContextSchema
+	maxRecordSize int
+	maxNesting    int
+}
+
+// WithNumberMode selects how context numbers are decoded, see NumbersFloat,
+// NumbersExact and NumbersInt64. Use NumbersInt64 or NumbersExact when context
+// values carry int64 IDs or counters that must survive intact for querying.
+func WithNumberMode(mode string) ReadOption {
+	return func(o *readOptions) {
+		o.numbers = mode
+	}
+}
+
+// WithReadSchema decodes the listed top-level context keys as their schema
+// type regardless of the number mode: FieldInt as int64, FieldFloat as float64
+// and FieldString as the literal text of a number. Values that cannot be
+// converted are decoded according to the number mode.
+func WithReadSchema(schema ContextSchema) ReadOption {
+	return func(o *readOptions) {
+		o.schema = schema
+	}
+}
+
+// WithMaxRecordSize limits the bytes of a single line or pretty-printed record,
+// DefaultMaxRecordSize when not set. Longer records are reported as corrupt
+// instead of being buffered.
+func WithMaxRecordSize(size int) ReadOption {
+	return func(o *readOptions) {
+		o.maxRecordSize = size
+	}
+}
+
+// WithMaxNesting limits how deeply objects and arrays may nest within a record,
+// DefaultMaxNesting when not set. Deeper records are reported as corrupt.
+func WithMaxNesting(depth int) ReadOption {
+	return func(o *readOptions) {
+		o.maxNesting = depth
+	}
+}
+
+// newReadOptions applies options over the defaults
+func newReadOptions(options []ReadOption) (readOptions, error) {
+	opts := readOptions{numbers: NumbersFloat, maxRecordSize: DefaultMaxRecordSize, maxNesting: DefaultMaxNesting}
+	for _, option := range options {
+		option(&opts)
+	}
+	switch opts.numbers {
+	case NumbersFloat, NumbersExact, NumbersInt64:
+	default:
+		return opts, fmt.Errorf("unsupported number mode: %s", opts.numbers)
+	}
+	if opts.maxRecordSize <= 0 || opts.maxNesting <= 0 {
+		return opts, fmt.Errorf("read limits must be positive")
+	}
+	return opts, nil
+}
+
+// decodeEntry decodes an entry record according to the options
+func (o *readOptions) decodeEntry(data []byte, entry *LogEntry) error {
+	if (o.numbers == "" || o.numbers == NumbersFloat) && len(o.schema) == 0 {
+		return json.Unmarshal(data, entry)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(entry); err != nil {
+		return err
+	}
+	for key, value := range entry.Context {
+		if typ, ok := o.schema[key]; ok {
+			if n, isNumber := value.(json.Number); isNumber {
+				if converted, ok := convertNumber(n, typ); ok {
+					entry.Context[key] = converted
+					continue
+				}
+			}
+		}
+		entry.Context[key] = o.numbersIn(value)
+	}
+	return nil
+}
+
+// numbersIn converts the json.Number values in value according to the number mode
+func (o *readOptions) numbersIn(value interface{}) interface{} {
+	switch v := value.(type) {
+	case json.Number:
+		return o.number(v)
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = o.numbersIn(item)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = o.numbersIn(item)
+		}
+	}
+	return value
+}
+
+// number converts a single number according to the number mode
+func (o *readOptions) number(n json.Number) interface{} {
+	switch o.numbers {
+	case NumbersExact:
+		return n
+	case NumbersInt64:
+		if i, err := strconv.ParseInt(n.String(), 10, 64); err == nil {
+			return i
+		}
+	}
+	f, _ := n.Float64()
+	return f
+}
+
+// convertNumber converts a number to a schema type
+func convertNumber(n json.Number, typ FieldType) (interface{}, bool) {
+	switch typ {
+	case FieldInt:
+		if i, err := strconv.ParseInt(n.String(), 10, 64); err == nil {
+			return i, true
+		}
+	case FieldFloat:
+		if f, err := n.Float64(); err == nil {
+			return f, true
+		}
+	case FieldString:
+		return n.String(), true
+	}
+	return nil, false
+}
@@ -0,0 +1,415 @@
+package vibelogger
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// maxCorruptSnippet limits how much of a corrupt record is kept for diagnostics
+const maxCorruptSnippet = 256
+
+// Defensive limits applied while reading, so malformed or adversarial files
+// cannot exhaust memory or the stack of tools ingesting them
+const (
+	DefaultMaxRecordSize = 4 << 20 // Bytes of a single line or pretty-printed record
+	DefaultMaxNesting    = 128     // Nested objects and arrays within a record
+)
+
+// CorruptRecord describes data in a log file that could not be parsed as a record
+type CorruptRecord struct {
+	Line    int    `json:"line"`    // 1-based line where the record starts
+	Offset  int64  `json:"offset"`  // Byte offset where the record starts
+	Partial bool   `json:"partial"` // Record was cut off before it was complete
+	Snippet string `json:"snippet"` // Beginning of the raw data
+	Error   string `json:"error"`
+}
+
+// ReadResult holds everything recovered from a log file
+type ReadResult struct {
+	Header  *FileHeader
+	Footer  *FileFooter
+	Entries []LogEntry
+	Corrupt []CorruptRecord
+}
+
+// CleanShutdown reports whether the file was finalized by a clean close, rotation or snapshot.
+// Files written without file markers never report a clean shutdown.
+func (r *ReadResult) CleanShutdown() bool {
+	return r.Header != nil && r.Footer != nil
+}
+
+// ReadLogFile reads all entries from a log file, skipping corrupt records
+func ReadLogFile(path string, options ...ReadOption) (*ReadResult, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open log file: %w", err)
+	}
+	defer file.Close()
+
+	return ReadLog(file, options...)
+}
+
+// ReadLogDir reads the entries of every log file below dir, ordered by time.
+// Companion metrics files are skipped. OpenLogDirIterator streams them instead.
+func ReadLogDir(dir string, options ...ReadOption) ([]LogEntry, error) {
+	var entries []LogEntry
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !isLogFileName(d) {
+			return nil
+		}
+		result, err := ReadLogFile(path, options...)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, result.Entries...)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read log directory: %w", err)
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Timestamp.Before(entries[j].Timestamp)
+	})
+	return entries, nil
+}
+
+// ReadLog reads all entries from r. Corrupt or truncated records are reported
+// in ReadResult.Corrupt and reading continues with the next valid record.
+// Context numbers are decoded as float64 unless WithNumberMode or
+// WithReadSchema select otherwise. Use NewLogIterator to process large logs
+// without holding all entries in memory.
+func ReadLog(r io.Reader, options ...ReadOption) (*ReadResult, error) {
+	it, err := NewLogIterator(r, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ReadResult{}
+	for it.Next() {
+		result.Corrupt = append(result.Corrupt, it.Corrupt()...)
+		result.Entries = append(result.Entries, *it.Entry())
+	}
+	result.Corrupt = append(result.Corrupt, it.Corrupt()...)
+	result.Header = it.Header()
+	result.Footer = it.Footer()
+	if err := it.Err(); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// recordMarker holds the fields telling record types apart
+type recordMarker struct {
+	RecordType string  `json:"record_type"`
+	Log        *string `json:"log"`
+	Stream     string  `json:"stream"`
+}
+
+// decodeRecord decodes a complete record. Entries are decoded into entry;
+// for header and footer records the decoded record is returned instead.
+func decodeRecord(data []byte, options *readOptions, entry *LogEntry) (*FileHeader, *FileFooter, error) {
+	var marker recordMarker
+	if err := json.Unmarshal(data, &marker); err != nil {
+		return nil, nil, err
+	}
+
+	// Docker json-file lines wrap the actual record in the log field; only one
+	// level is unwrapped so crafted input cannot recurse
+	if marker.Log != nil && marker.Stream != "" && marker.RecordType == "" {
+		data = bytes.TrimSpace([]byte(*marker.Log))
+		marker = recordMarker{}
+		if err := json.Unmarshal(data, &marker); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	switch marker.RecordType {
+	case RecordTypeHeader:
+		header := &FileHeader{}
+		if err := json.Unmarshal(data, header); err != nil {
+			return nil, nil, err
+		}
+		return header, nil, nil
+	case RecordTypeFooter:
+		footer := &FileFooter{}
+		if err := json.Unmarshal(data, footer); err != nil {
+			return nil, nil, err
+		}
+		return nil, footer, nil
+	default:
+		return nil, nil, options.decodeEntry(data, entry)
+	}
+}
+
+// newCorruptRecord builds a CorruptRecord from raw scanner output
+func newCorruptRecord(rec rawRecord, reason string) CorruptRecord {
+	snippet := rec.data
+	if len(snippet) > maxCorruptSnippet {
+		snippet = snippet[:maxCorruptSnippet]
+	}
+	return CorruptRecord{
+		Line:    rec.line,
+		Offset:  rec.offset,
+		Partial: !rec.complete,
+		Snippet: string(snippet),
+		Error:   reason,
+	}
+}
+
+// rawRecord is a single top-level JSON value as found in a log file
+type rawRecord struct {
+	data     []byte
+	line     int
+	offset   int64
+	complete bool
+	reason   string // Set when the record was rejected by a limit
+}
+
+// recordScanner splits a log stream into top-level JSON records.
+// It understands both pretty-printed (multi-line) and one-per-line records.
+// A line starting with '{' while a record is still open means the previous
+// record was cut off, so scanning resynchronizes at that line.
+type recordScanner struct {
+	reader     *bufio.Reader
+	pending    []rawRecord
+	buf        []byte
+	depth      int
+	inStr      bool
+	escaped    bool
+	tooDeep    bool
+	start      int64
+	startLn    int
+	offset     int64
+	line       int
+	current    rawRecord
+	err        error
+	eof        bool
+	maxRecord  int // Longest line or record; longer ones are reported and skipped
+	maxNesting int // Deepest nesting; deeper records are reported
+}
+
+// newRecordScanner creates a recordScanner reading from r with the default limits
+func newRecordScanner(r io.Reader) *recordScanner {
+	return &recordScanner{
+		reader:     bufio.NewReader(r),
+		maxRecord:  DefaultMaxRecordSize,
+		maxNesting: DefaultMaxNesting,
+	}
+}
+
+// Next advances to the next record, returning false at the end of input
+func (s *recordScanner) Next() bool {
+	for len(s.pending) == 0 && !s.eof {
+		s.readLine()
+	}
+	if len(s.pending) == 0 {
+		return false
+	}
+	s.current = s.pending[0]
+	s.pending = s.pending[1:]
+	return true
+}
+
+// Record returns the record found by the last call to Next
+func (s *recordScanner) Record() rawRecord {
+	return s.current
+}
+
+// Err returns the first non-EOF error encountered while reading
+func (s *recordScanner) Err() error {
+	return s.err
+}
+
+// readLine consumes one line of input and queues any records it completes
+func (s *recordScanner) readLine() {
+	line, size, err := s.readLimited()
+	if err != nil {
+		s.eof = true
+		if err != io.EOF {
+			s.err = err
+		}
+	}
+
+	if size > len(line) {
+		// Only the beginning of an overlong line is kept; it also breaks an open record
+		s.line++
+		if len(s.buf) > 0 {
+			s.flush(false)
+		}
+		s.pending = append(s.pending, rawRecord{
+			data:     line,
+			line:     s.line,
+			offset:   s.offset,
+			complete: err == nil,
+			reason:   fmt.Sprintf("line exceeds %d bytes", s.maxRecord),
+		})
+		s.offset += int64(size)
+	} else if len(line) > 0 {
+		s.line++
+		s.consume(line)
+		s.offset += int64(len(line))
+	}
+
+	if s.eof && len(s.buf) > 0 {
+		s.flush(false)
+	}
+}
+
+// readLimited reads the next line, keeping at most maxRecord bytes of it.
+// size is the full length of the line as read from the input.
+func (s *recordScanner) readLimited() (line []byte, size int, err error) {
+	for {
+		var chunk []byte
+		chunk, err = s.reader.ReadSlice('\n')
+		size += len(chunk)
+		if keep := s.maxRecord - len(line); keep > 0 {
+			line = append(line, chunk[:min(keep, len(chunk))]...)
+		}
+		if err != bufio.ErrBufferFull {
+			return line, size, err
+		}
+	}
+}
+
+// consume feeds one line into the record state machine
+func (s *recordScanner) consume(line []byte) {
+	trimmed := bytes.TrimSpace(line)
+
+	if len(s.buf) == 0 {
+		if len(trimmed) == 0 {
+			return // Blank lines between records are fine
+		}
+		if line[0] != '{' {
+			// Garbage outside of any record
+			s.pending = append(s.pending, rawRecord{
+				data:     trimmed,
+				line:     s.line,
+				offset:   s.offset,
+				complete: true,
+			})
+			return
+		}
+	} else if line[0] == '{' {
+		// A new record begins before the previous one was closed
+		s.flush(false)
+	}
+
+	if len(s.buf) == 0 {
+		s.start = s.offset
+		s.startLn = s.line
+		s.depth = 0
+		s.inStr = false
+		s.escaped = false
+		s.tooDeep = false
+	}
+	s.buf = append(s.buf, line...)
+
+	for _, c := range line {
+		if s.inStr {
+			switch {
+			case s.escaped:
+				s.escaped = false
+			case c == '\\':
+				s.escaped = true
+			case c == '"':
+				s.inStr = false
+			}
+			continue
+		}
+		switch c {
+		case '"':
+			s.inStr = true
+		case '{', '[':
+			s.depth++
+			if s.depth > s.maxNesting {
+				s.tooDeep = true
+			}
+		case '}', ']':
+			s.depth--
+		}
+	}
+
+	if s.depth <= 0 {
+		s.flush(true)
+	} else if len(s.buf) > s.maxRecord {
+		// A record that never closes must not grow without bound
+		s.flush(false)
+		s.pending[len(s.pending)-1].reason = fmt.Sprintf("record exceeds %d bytes", s.maxRecord)
+	}
+}
+
+// flush queues the buffered record and resets the state machine
+func (s *recordScanner) flush(complete bool) {
+	rec := rawRecord{
+		data:     bytes.TrimSpace(s.buf),
+		line:     s.startLn,
+		offset:   s.start,
+		complete: complete,
+	}
+	if s.tooDeep {
+		rec.reason = fmt.Sprintf("nesting exceeds %d levels", s.maxNesting)
+	}
+	s.pending = append(s.pending, rec)
+	s.buf = nil
+	s.depth = 0
+	s.inStr = false
+	s.escaped = false
+	s.tooDeep = false
+}
+
+// repairLogFile truncates a trailing partial record left behind by a crash
+// so that new entries are not appended to a broken record. It returns the
+// number of bytes removed.
+func repairLogFile(path string) (int64, error) {
+	file, err := os.OpenFile(path, os.O_RDWR, 0644)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	defer file.Close()
+
+	stat, err := file.Stat()
+	if err != nil || stat.Size() == 0 {
+		return 0, err
+	}
+
+	var last rawRecord
+	scanner := newRecordScanner(file)
+	for scanner.Next() {
+		last = scanner.Record()
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, err
+	}
+
+	if last.data != nil && !last.complete {
+		if err := file.Truncate(last.offset); err != nil {
+			return 0, err
+		}
+		return stat.Size() - last.offset, nil
+	}
+
+	// Make sure the next record starts on its own line
+	tail := make([]byte, 1)
+	if _, err := file.ReadAt(tail, stat.Size()-1); err != nil {
+		return 0, err
+	}
+	if tail[0] != '\n' {
+		if _, err := file.WriteAt([]byte("\n"), stat.Size()); err != nil {
+			return 0, err
+		}
+	}
+	return 0, nil
+}
@@ -0,0 +1,249 @@
+package vibelogger
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestReadLogFileRoundTrip(t *testing.T) {
+	defer os.RemoveAll("test_logs")
+
+	config := DefaultConfig()
+	config.FilePath = "test_logs/reader_test.log"
+
+	logger, err := CreateFileLoggerWithConfig("reader_test", config)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	logger.Info("first_op", "First entry")
+	logger.Error("second_op", "Second entry")
+	logger.Close()
+
+	result, err := ReadLogFile(config.FilePath)
+	if err != nil {
+		t.Fatalf("Failed to read log file: %v", err)
+	}
+
+	if len(result.Entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(result.Entries))
+	}
+	if result.Entries[0].Operation != "first_op" || result.Entries[1].Level != ERROR {
+		t.Errorf("Unexpected entries: %+v", result.Entries)
+	}
+	if len(result.Corrupt) != 0 {
+		t.Errorf("Expected no corrupt records, got %+v", result.Corrupt)
+	}
+	if !result.CleanShutdown() {
+		t.Error("Expected clean shutdown to be detected")
+	}
+}
+
+func TestReadLogRecoversFromCorruption(t *testing.T) {
+	data := strings.Join([]string{
+		`{"timestamp":"2025-01-01T00:00:00Z","level":"INFO","operation":"one","message":"ok","severity":2}`,
+		`{`,
+		`  "timestamp": "2025-01-01T00:00:01Z",`,
+		`  "level": "INFO",`,
+		`  "operation": "truncat`,
+		`{`,
+		`  "timestamp": "2025-01-01T00:00:02Z",`,
+		`  "level": "WARN",`,
+		`  "operation": "two",`,
+		`  "message": "brace } inside string {",`,
+		`  "severity": 3`,
+		`}`,
+		`not json at all`,
+		`{"timestamp":"2025-01-01T00:00:03Z","level":"ERROR","operation":"three","message":"ok","severity":4}`,
+		`{"timestamp":"2025-01-01T00:00:04Z","level":"INFO","oper`,
+	}, "\n")
+
+	result, err := ReadLog(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("Failed to read log: %v", err)
+	}
+
+	if len(result.Entries) != 3 {
+		t.Fatalf("Expected 3 valid entries, got %d", len(result.Entries))
+	}
+	expected := []string{"one", "two", "three"}
+	for i, op := range expected {
+		if result.Entries[i].Operation != op {
+			t.Errorf("Entry %d: expected operation %q, got %q", i, op, result.Entries[i].Operation)
+		}
+	}
+
+	if len(result.Corrupt) != 3 {
+		t.Fatalf("Expected 3 corrupt records, got %d: %+v", len(result.Corrupt), result.Corrupt)
+	}
+	if result.Corrupt[0].Line != 2 || !result.Corrupt[0].Partial {
+		t.Errorf("Expected partial record at line 2, got %+v", result.Corrupt[0])
+	}
+	if result.Corrupt[1].Line != 13 || result.Corrupt[1].Partial {
+		t.Errorf("Expected garbage at line 13, got %+v", result.Corrupt[1])
+	}
+	if result.Corrupt[2].Line != 15 || !result.Corrupt[2].Partial {
+		t.Errorf("Expected trailing partial record at line 15, got %+v", result.Corrupt[2])
+	}
+	if result.CleanShutdown() {
+		t.Error("Files without markers should not report a clean shutdown")
+	}
+}
+
+func TestStartupRepairTruncatesPartialRecord(t *testing.T) {
+	defer os.RemoveAll("test_logs")
+
+	config := DefaultConfig()
+	config.FilePath = "test_logs/repair_test.log"
+	config.WriteFileMarkers = false
+
+	logger, err := CreateFileLoggerWithConfig("repair_test", config)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	logger.Info("before_crash", "Written before the crash")
+	logger.Close()
+
+	// Simulate a crash in the middle of writing an entry
+	file, err := os.OpenFile(config.FilePath, os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		t.Fatalf("Failed to open log file: %v", err)
+	}
+	file.WriteString("{\n  \"timestamp\": \"2025-01-01T00:00:00Z\",\n  \"level\": \"IN")
+	file.Close()
+
+	logger, err = CreateFileLoggerWithConfig("repair_test", config)
+	if err != nil {
+		t.Fatalf("Failed to reopen logger: %v", err)
+	}
+	logger.Info("after_crash", "Written after the restart")
+	logger.Close()
+
+	result, err := ReadLogFile(config.FilePath)
+	if err != nil {
+		t.Fatalf("Failed to read log file: %v", err)
+	}
+	if len(result.Corrupt) != 0 {
+		t.Errorf("Expected partial record to be repaired, got %+v", result.Corrupt)
+	}
+
+	var operations []string
+	for _, entry := range result.Entries {
+		operations = append(operations, entry.Operation)
+	}
+	if strings.Join(operations, ",") != "before_crash,log_recovery,after_crash" {
+		t.Errorf("Unexpected entries after repair: %v", operations)
+	}
+}
+
+func TestReadLogNumberModes(t *testing.T) {
+	record := `{"timestamp":"2025-01-01T00:00:00Z","level":"INFO","operation":"order","message":"Placed",` +
+		`"context":{"order_id":9007199254740993,"ratio":0.5,"items":[{"sku":1234567890123456789}],"amount":"12"}}`
+
+	read := func(options ...ReadOption) map[string]interface{} {
+		t.Helper()
+		result, err := ReadLog(strings.NewReader(record), options...)
+		if err != nil || len(result.Entries) != 1 {
+			t.Fatalf("Failed to read entry: %v (%+v)", err, result)
+		}
+		return result.Entries[0].Context
+	}
+
+	// The default keeps the encoding/json behavior, rounding large integers
+	if got := read()["order_id"]; got != float64(9007199254740992) {
+		t.Errorf("Expected a float64 by default, got %T %v", got, got)
+	}
+
+	int64s := read(WithNumberMode(NumbersInt64))
+	if int64s["order_id"] != int64(9007199254740993) || int64s["ratio"] != 0.5 {
+		t.Errorf("Expected int64 IDs and float64 fractions, got %v / %v", int64s["order_id"], int64s["ratio"])
+	}
+	item := int64s["items"].([]interface{})[0].(map[string]interface{})
+	if item["sku"] != int64(1234567890123456789) {
+		t.Errorf("Expected nested integers to survive, got %v", item["sku"])
+	}
+
+	exact := read(WithNumberMode(NumbersExact))
+	if exact["order_id"] != json.Number("9007199254740993") {
+		t.Errorf("Expected the literal number, got %T %v", exact["order_id"], exact["order_id"])
+	}
+
+	typed := read(WithReadSchema(ContextSchema{"order_id": FieldInt, "ratio": FieldString, "amount": FieldInt}))
+	if typed["order_id"] != int64(9007199254740993) || typed["ratio"] != "0.5" {
+		t.Errorf("Expected schema types, got %T %v / %T %v", typed["order_id"], typed["order_id"], typed["ratio"], typed["ratio"])
+	}
+	if typed["amount"] != "12" {
+		t.Errorf("Expected strings to be left alone, got %v", typed["amount"])
+	}
+
+	if _, err := ReadLog(strings.NewReader(record), WithNumberMode("decimal")); err == nil {
+		t.Error("Expected an error for an unknown number mode")
+	}
+}
+
+func TestReadLogLimits(t *testing.T) {
+	valid := `{"timestamp":"2025-01-01T00:00:00Z","level":"INFO","operation":"ok","message":"Fine"}`
+	long := `{"timestamp":"2025-01-01T00:00:00Z","level":"INFO","operation":"long","message":"` + strings.Repeat("x", 500) + `"}`
+	deep := `{"level":"INFO","operation":"deep","context":{"a":` + strings.Repeat("[", 20) + strings.Repeat("]", 20) + `}}`
+	unclosed := "{\n" + strings.Repeat("  \"k\": \"v\",\n", 50)
+
+	input := strings.Join([]string{valid, long, deep, valid}, "\n") + "\n" + unclosed
+	result, err := ReadLog(strings.NewReader(input), WithMaxRecordSize(200), WithMaxNesting(10))
+	if err != nil {
+		t.Fatalf("ReadLog failed: %v", err)
+	}
+	if len(result.Entries) != 2 {
+		t.Errorf("Expected the 2 valid entries around the rejected ones, got %d", len(result.Entries))
+	}
+
+	reasons := make(map[string]bool)
+	for _, c := range result.Corrupt {
+		reasons[c.Error] = true
+		if len(c.Snippet) > maxCorruptSnippet {
+			t.Errorf("Expected snippets to be capped, got %d bytes", len(c.Snippet))
+		}
+	}
+	for _, want := range []string{"line exceeds 200 bytes", "nesting exceeds 10 levels", "record exceeds 200 bytes"} {
+		if !reasons[want] {
+			t.Errorf("Expected a corrupt record for %q, got %v", want, reasons)
+		}
+	}
+	if result.Corrupt[0].Line != 2 || result.Corrupt[0].Offset != int64(len(valid)+1) {
+		t.Errorf("Expected the overlong line to keep its position, got line %d offset %d", result.Corrupt[0].Line, result.Corrupt[0].Offset)
+	}
+
+	if _, err := ReadLog(strings.NewReader(valid), WithMaxNesting(0)); err == nil {
+		t.Error("Expected an error for a non-positive limit")
+	}
+}
+
+func FuzzReadLog(f *testing.F) {
+	f.Add([]byte(`{"timestamp":"2025-01-01T00:00:00Z","level":"INFO","operation":"op","message":"m","context":{"id":1}}` + "\n"))
+	f.Add([]byte("{\n  \"level\": \"WARN\",\n  \"message\": \"pretty\"\n}\n{\"level\":"))
+	f.Add([]byte(`{"log":"{\"level\":\"ERROR\",\"message\":\"docker\"}\n","stream":"stderr","time":"2025-01-01T00:00:00Z"}`))
+	f.Add([]byte(`{"record_type":"header","version":"1"}` + "\n" + `{"record_type":"footer"}`))
+	f.Add([]byte(`{"context":{"a":[[[[{"b":"\"}\\"}]]]]}}` + "\n garbage \x00\xff\n"))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		for _, mode := range []string{NumbersFloat, NumbersExact, NumbersInt64} {
+			result, err := ReadLog(bytes.NewReader(data), WithNumberMode(mode), WithMaxRecordSize(1024), WithMaxNesting(16))
+			if err != nil {
+				t.Fatalf("ReadLog failed on in-memory data: %v", err)
+			}
+			for _, c := range result.Corrupt {
+				if c.Offset < 0 || c.Offset > int64(len(data)) || len(c.Snippet) > maxCorruptSnippet {
+					t.Fatalf("Invalid corrupt record %+v", c)
+				}
+			}
+			for i := range result.Entries {
+				// Tools summarize, format and fingerprint whatever was read
+				entry := &result.Entries[i]
+				entry.FullMessage()
+				ErrorFingerprint(entry)
+			}
+			FormatForLLM(result.Entries, 100)
+		}
+	})
+}
@@ -0,0 +1,449 @@
+package vibelogger
+
+import (
+	"compress/gzip"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultReceiverBodyLimit is the request body limit used when ReceiverConfig.MaxBodySize is not set
+const DefaultReceiverBodyLimit = 10 * 1024 * 1024
+
+// receiverDedupSize is the number of client entry IDs remembered per project
+// to drop entries that are sent again, e.g. replayed by a SpoolSink
+const receiverDedupSize = 10000
+
+// errUnsupportedEncoding is answered with 415 so clients fall back to plain payloads
+var errUnsupportedEncoding = errors.New("unsupported content encoding")
+
+// ReceiverConfig configures a Receiver
+type ReceiverConfig struct {
+	// Tokens maps bearer tokens to the tenant project their entries are written to.
+	// Each tenant gets its own logs/<project>/ directory.
+	Tokens map[string]string
+	// Authenticator identifies callers not matching Tokens (optional). They need
+	// ScopeWrite and write to the project named by the ProjectHeader header, or
+	// to their only project when they are restricted to one.
+	Authenticator Authenticator
+	// LoggerName names the per-tenant log files (default "received")
+	LoggerName string
+	// Config is the template for the per-tenant loggers; ProjectName and FilePath
+	// are set per tenant (default DefaultConfig())
+	Config *LoggerConfig
+	// MaxBodySize limits the size of a request body in bytes (default 10MB)
+	MaxBodySize int64
+	// Quotas limits what each project may ingest, keyed by project (optional).
+	// Requests over quota are answered with 429 Too Many Requests.
+	Quotas map[string]Quota
+	// DefaultQuota applies to the projects missing from Quotas (default unlimited)
+	DefaultQuota Quota
+	// Logger receives the receiver's own diagnostics such as rejected tokens
+	// and the audit entries of exceeded quotas (optional)
+	Logger *Logger
+}
+
+// Receiver is an http.Handler that accepts entries from remote processes and
+// writes them into per-tenant project directories. Clients POST entries in any
+// format ReadLog understands (NDJSON, pretty JSON or Docker json-file) with an
+// "Authorization: Bearer <token>" header; the token selects the tenant. Callers
+// identified by ReceiverConfig.Authenticator select it with ProjectHeader.
+// Entries whose ID was among the last receiverDedupSize IDs of the project are
+// acknowledged as duplicates and not written again.
+type Receiver struct {
+	config  ReceiverConfig
+	mutex   sync.Mutex
+	tenants map[string]*Logger
+	quotas  map[string]*quotaUsage
+	seen    map[string]*recentIDs // Client entry IDs per project
+	closed  bool
+}
+
+// receiverResponse is the JSON body returned by the receiver
+type receiverResponse struct {
+	Accepted   int    `json:"accepted"`
+	Duplicates int    `json:"duplicates,omitempty"`
+	Rejected   int    `json:"rejected,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// NewReceiver validates the configuration and creates a Receiver. Tenant log
+// files are created on the first request of each tenant.
+func NewReceiver(config ReceiverConfig) (*Receiver, error) {
+	if len(config.Tokens) == 0 && config.Authenticator == nil {
+		return nil, fmt.Errorf("receiver requires at least one token or an authenticator")
+	}
+	for token, project := range config.Tokens {
+		if token == "" {
+			return nil, fmt.Errorf("receiver token for project %s is empty", project)
+		}
+		if project == "" || len(project) > 50 || !isValidProjectName(project) {
+			return nil, fmt.Errorf("invalid receiver project name: %q", project)
+		}
+	}
+	for project, quota := range config.Quotas {
+		if project == "" || len(project) > 50 || !isValidProjectName(project) {
+			return nil, fmt.Errorf("invalid quota project name: %q", project)
+		}
+		if err := quota.validate(); err != nil {
+			return nil, fmt.Errorf("invalid quota for project %s: %w", project, err)
+		}
+	}
+	if err := config.DefaultQuota.validate(); err != nil {
+		return nil, fmt.Errorf("invalid default quota: %w", err)
+	}
+	if config.LoggerName == "" {
+		config.LoggerName = "received"
+	}
+	if config.Config == nil {
+		config.Config = DefaultConfig()
+	}
+	if config.MaxBodySize <= 0 {
+		config.MaxBodySize = DefaultReceiverBodyLimit
+	}
+	return &Receiver{
+		config:  config,
+		tenants: make(map[string]*Logger),
+		quotas:  make(map[string]*quotaUsage),
+		seen:    make(map[string]*recentIDs),
+	}, nil
+}
+
+// ServeHTTP handles a batch of entries
+func (r *Receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	// Tell clients they may compress their payloads
+	w.Header().Set("Accept-Encoding", "gzip")
+
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeReceiverResponse(w, http.StatusMethodNotAllowed, receiverResponse{Error: "method not allowed"})
+		return
+	}
+
+	project, status, reason := r.authorize(req)
+	switch status {
+	case http.StatusUnauthorized:
+		r.diagnose("receiver_auth", "Rejected request with missing or unknown token", req)
+		w.Header().Set("WWW-Authenticate", `Bearer realm="vibe-logger"`)
+		writeReceiverResponse(w, status, receiverResponse{Error: reason})
+		return
+	case http.StatusForbidden:
+		r.diagnose("receiver_auth", "Rejected request without write access to the project", req)
+		writeReceiverResponse(w, status, receiverResponse{Error: reason})
+		return
+	case http.StatusBadRequest:
+		writeReceiverResponse(w, status, receiverResponse{Error: reason})
+		return
+	}
+
+	body, err := r.requestBody(w, req)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, errUnsupportedEncoding) {
+			status = http.StatusUnsupportedMediaType
+		}
+		writeReceiverResponse(w, status, receiverResponse{Error: err.Error()})
+		return
+	}
+	defer body.Close()
+	// Keep int64 IDs intact, the entries are encoded again when forwarded
+	counter := &countingReader{r: body}
+	result, err := ReadLog(counter, WithNumberMode(NumbersInt64))
+	if err != nil {
+		status := http.StatusBadRequest
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			status = http.StatusRequestEntityTooLarge
+		}
+		writeReceiverResponse(w, status, receiverResponse{Error: err.Error()})
+		return
+	}
+
+	if usage := r.quotaUsage(project); usage != nil {
+		exceeded, retryAfter, first := usage.admit(len(result.Entries), counter.n, time.Now())
+		if exceeded != "" {
+			if first {
+				r.auditQuota(project, exceeded, req)
+			}
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			writeReceiverResponse(w, http.StatusTooManyRequests, receiverResponse{
+				Rejected: len(result.Entries) + len(result.Corrupt),
+				Error:    "quota exceeded: " + exceeded,
+			})
+			return
+		}
+	}
+
+	logger, err := r.tenant(project)
+	if err != nil {
+		writeReceiverResponse(w, http.StatusServiceUnavailable, receiverResponse{Error: err.Error()})
+		return
+	}
+
+	resp := receiverResponse{Rejected: len(result.Corrupt)}
+	entries := r.dropDuplicates(project, result.Entries)
+	resp.Duplicates = len(result.Entries) - len(entries)
+	for i := range entries {
+		entry := entries[i]
+		normalizeReceivedEntry(&entry)
+		if err := logger.writeEntry(entry); err != nil {
+			// Let a resend of the entry through
+			r.forgetID(project, entries[i].ID)
+			resp.Rejected++
+			continue
+		}
+		resp.Accepted++
+	}
+
+	status = http.StatusAccepted
+	if resp.Accepted == 0 && resp.Duplicates == 0 && resp.Rejected > 0 {
+		status = http.StatusBadRequest
+	}
+	writeReceiverResponse(w, status, resp)
+}
+
+// requestBody returns the decoded request body. MaxBodySize limits both the
+// transferred and the decompressed size.
+func (r *Receiver) requestBody(w http.ResponseWriter, req *http.Request) (io.ReadCloser, error) {
+	body := http.MaxBytesReader(w, req.Body, r.config.MaxBodySize)
+	switch encoding := strings.ToLower(req.Header.Get("Content-Encoding")); encoding {
+	case "", "identity":
+		return body, nil
+	case "gzip":
+		zr, err := gzip.NewReader(body)
+		if err != nil {
+			return nil, fmt.Errorf("invalid gzip body: %w", err)
+		}
+		return http.MaxBytesReader(w, zr, r.config.MaxBodySize), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", errUnsupportedEncoding, encoding)
+	}
+}
+
+// authorize returns the tenant project of a request, or the status and
+// reason to reject it with
+func (r *Receiver) authorize(req *http.Request) (string, int, string) {
+	if project, ok := r.authenticate(req); ok {
+		return project, http.StatusOK, ""
+	}
+	if r.config.Authenticator == nil {
+		return "", http.StatusUnauthorized, "invalid token"
+	}
+	principal, ok := r.config.Authenticator.Authenticate(req)
+	if !ok {
+		return "", http.StatusUnauthorized, "invalid token"
+	}
+
+	project := req.Header.Get(ProjectHeader)
+	if project == "" && len(principal.Projects) == 1 {
+		project = principal.Projects[0]
+	}
+	if project == "" {
+		return "", http.StatusBadRequest, "missing " + ProjectHeader + " header"
+	}
+	if len(project) > 50 || !isValidProjectName(project) {
+		return "", http.StatusBadRequest, fmt.Sprintf("invalid project name: %q", project)
+	}
+	if !principal.HasScope(ScopeWrite) || !principal.CanAccess(project) {
+		return "", http.StatusForbidden, "access denied"
+	}
+	return project, http.StatusOK, ""
+}
+
+// authenticate returns the tenant project of the request's bearer token
+func (r *Receiver) authenticate(req *http.Request) (string, bool) {
+	presented, ok := bearerToken(req)
+	if !ok {
+		return "", false
+	}
+
+	// Compare against every token so timing does not reveal which one matched
+	project, found := "", false
+	for token, p := range r.config.Tokens {
+		if subtle.ConstantTimeCompare([]byte(presented), []byte(token)) == 1 {
+			project, found = p, true
+		}
+	}
+	return project, found
+}
+
+// tenant returns the logger of a tenant project, creating it on first use
+func (r *Receiver) tenant(project string) (*Logger, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.closed {
+		return nil, fmt.Errorf("receiver is closed")
+	}
+	if logger, ok := r.tenants[project]; ok {
+		return logger, nil
+	}
+
+	config := *r.config.Config
+	config.ProjectName = project
+	config.FilePath = ""
+	logger, err := CreateFileLoggerWithConfig(r.config.LoggerName, &config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open log for project %s: %w", project, err)
+	}
+	r.tenants[project] = logger
+	return logger, nil
+}
+
+// quotaUsage returns the quota tracking of a project, nil when it is unlimited
+func (r *Receiver) quotaUsage(project string) *quotaUsage {
+	quota, ok := r.config.Quotas[project]
+	if !ok {
+		quota = r.config.DefaultQuota
+	}
+	if quota == (Quota{}) {
+		return nil
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	usage, ok := r.quotas[project]
+	if !ok {
+		usage = newQuotaUsage(quota)
+		r.quotas[project] = usage
+	}
+	return usage
+}
+
+// dropDuplicates returns the entries whose client ID was not seen recently for
+// the project and remembers their IDs. Entries without an ID are kept.
+func (r *Receiver) dropDuplicates(project string, entries []LogEntry) []LogEntry {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	seen, ok := r.seen[project]
+	if !ok {
+		seen = newRecentIDs(receiverDedupSize)
+		r.seen[project] = seen
+	}
+
+	fresh := entries[:0]
+	for _, entry := range entries {
+		if entry.ID == "" || seen.add(entry.ID) {
+			fresh = append(fresh, entry)
+		}
+	}
+	return fresh
+}
+
+// forgetID removes an ID remembered by dropDuplicates
+func (r *Receiver) forgetID(project, id string) {
+	if id == "" {
+		return
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.seen[project].remove(id)
+}
+
+// recentIDs remembers the most recent IDs up to a fixed number
+type recentIDs struct {
+	ids   map[string]struct{}
+	order []string // Ring buffer of the remembered IDs, oldest at next
+	next  int
+}
+
+// newRecentIDs creates a set remembering up to size IDs
+func newRecentIDs(size int) *recentIDs {
+	return &recentIDs{ids: make(map[string]struct{}), order: make([]string, 0, size)}
+}
+
+// add remembers id, forgetting the oldest ID when full, and reports whether
+// it was new
+func (s *recentIDs) add(id string) bool {
+	if _, ok := s.ids[id]; ok {
+		return false
+	}
+	if len(s.order) < cap(s.order) {
+		s.order = append(s.order, id)
+	} else {
+		delete(s.ids, s.order[s.next])
+		s.order[s.next] = id
+		s.next = (s.next + 1) % len(s.order)
+	}
+	s.ids[id] = struct{}{}
+	return true
+}
+
+// remove forgets id; its slot in the ring buffer is reused in turn
+func (s *recentIDs) remove(id string) {
+	delete(s.ids, id)
+}
+
+// normalizeReceivedEntry fills fields a remote client may have left out,
+// keeping the client's ID so resent entries can be deduplicated
+func normalizeReceivedEntry(entry *LogEntry) {
+	if entry.ID == "" {
+		entry.ID = NewEntryID()
+	}
+	if entry.Timestamp.IsZero() {
+		entry.Timestamp = time.Now().UTC()
+	}
+	if entry.Level == "" {
+		entry.Level = INFO
+	}
+	if entry.Severity == 0 {
+		entry.Severity = getSeverityScore(entry.Level)
+	}
+}
+
+// diagnose reports a receiver event to the configured diagnostics logger
+func (r *Receiver) diagnose(operation, message string, req *http.Request) {
+	if r.config.Logger == nil {
+		return
+	}
+	r.config.Logger.Warn(operation, message, WithContext(map[string]interface{}{
+		"remote_addr": req.RemoteAddr,
+		"path":        req.URL.Path,
+	}))
+}
+
+// auditQuota records a project exceeding its quota as an audit entry of the
+// diagnostics logger. It is called once until the project is admitted again,
+// so a client retrying in a loop does not flood the log.
+func (r *Receiver) auditQuota(project, quota string, req *http.Request) {
+	if r.config.Logger == nil {
+		return
+	}
+	r.config.Logger.Warn("receiver_quota", "Project exceeded its ingest quota", WithCategory("audit"),
+		WithContext(map[string]interface{}{
+			"project":     project,
+			"quota":       quota,
+			"remote_addr": req.RemoteAddr,
+		}))
+}
+
+// Close closes all tenant loggers. Later requests are answered with 503.
+func (r *Receiver) Close() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.closed = true
+	var firstErr error
+	for project, logger := range r.tenants {
+		if err := logger.Close(); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("failed to close log for project %s: %w", project, err)
+		}
+	}
+	r.tenants = nil
+	return firstErr
+}
+
+// writeReceiverResponse writes resp as JSON with the given status
+func writeReceiverResponse(w http.ResponseWriter, status int, resp receiverResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,262 @@
+package vibelogger
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func newTestReceiver(t *testing.T, config ReceiverConfig) *Receiver {
+	t.Helper()
+	if config.Tokens == nil {
+		config.Tokens = map[string]string{
+			"token-a": "receiver-tenant-a",
+			"token-b": "receiver-tenant-b",
+		}
+	}
+	receiver, err := NewReceiver(config)
+	if err != nil {
+		t.Fatalf("Failed to create receiver: %v", err)
+	}
+	t.Cleanup(func() {
+		receiver.Close()
+		os.RemoveAll(filepath.Join("logs", "receiver-tenant-a"))
+		os.RemoveAll(filepath.Join("logs", "receiver-tenant-b"))
+	})
+	return receiver
+}
+
+func postEntries(receiver http.Handler, token, body string) (*httptest.ResponseRecorder, receiverResponse) {
+	req := httptest.NewRequest(http.MethodPost, "/ingest", strings.NewReader(body))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	receiver.ServeHTTP(rec, req)
+
+	var resp receiverResponse
+	json.Unmarshal(rec.Body.Bytes(), &resp)
+	return rec, resp
+}
+
+// readTenantEntries reads the entries received for a tenant project
+func readTenantEntries(t *testing.T, project string) []LogEntry {
+	t.Helper()
+	files, _ := filepath.Glob(filepath.Join("logs", project, "received_*.log"))
+	if len(files) != 1 {
+		t.Fatalf("Expected one log file for %s, got %v", project, files)
+	}
+	result, err := ReadLogFile(files[0])
+	if err != nil {
+		t.Fatalf("Failed to read tenant log: %v", err)
+	}
+	return result.Entries
+}
+
+func TestNewReceiverValidation(t *testing.T) {
+	invalid := []map[string]string{
+		nil,
+		{"": "project"},
+		{"token": ""},
+		{"token": "../etc"},
+		{"token": "with space"},
+	}
+	for _, tokens := range invalid {
+		if _, err := NewReceiver(ReceiverConfig{Tokens: tokens}); err == nil {
+			t.Errorf("Expected error for tokens %v", tokens)
+		}
+	}
+}
+
+func TestReceiverRoutesTenants(t *testing.T) {
+	receiver := newTestReceiver(t, ReceiverConfig{})
+
+	body := `{"id":"01ARYZ6S41TSV4RRFFQ69G5FAV","timestamp":"2025-07-11T22:19:04Z","level":"ERROR","operation":"payment","message":"Card declined"}
+{"level":"INFO","operation":"checkout","message":"Order placed"}
+`
+	rec, resp := postEntries(receiver, "token-a", body)
+	if rec.Code != http.StatusAccepted || resp.Accepted != 2 {
+		t.Fatalf("Expected 2 accepted entries, got %d %+v", rec.Code, resp)
+	}
+	rec, resp = postEntries(receiver, "token-b", `{"level":"WARN","operation":"sync","message":"Retrying"}`)
+	if rec.Code != http.StatusAccepted || resp.Accepted != 1 {
+		t.Fatalf("Expected 1 accepted entry, got %d %+v", rec.Code, resp)
+	}
+	receiver.Close()
+
+	entries := readTenantEntries(t, "receiver-tenant-a")
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries for tenant a, got %d", len(entries))
+	}
+	if entries[0].ID != "01ARYZ6S41TSV4RRFFQ69G5FAV" || entries[0].Severity != 4 {
+		t.Errorf("Expected the client ID and a severity, got %q %d", entries[0].ID, entries[0].Severity)
+	}
+	if entries[1].ID == "" || entries[1].Timestamp.IsZero() {
+		t.Error("Expected missing ID and timestamp to be filled in")
+	}
+
+	entries = readTenantEntries(t, "receiver-tenant-b")
+	if len(entries) != 1 || entries[0].Operation != "sync" {
+		t.Errorf("Expected only tenant b's entry, got %v", entries)
+	}
+}
+
+func TestReceiverDropsDuplicates(t *testing.T) {
+	receiver := newTestReceiver(t, ReceiverConfig{})
+
+	// A spool replaying entries after a lost response sends them again
+	first := `{"id":"01ARYZ6S41TSV4RRFFQ69G5FAV","level":"INFO","operation":"checkout","message":"Order placed"}
+{"level":"INFO","operation":"checkout","message":"Without ID"}
+`
+	if rec, resp := postEntries(receiver, "token-a", first); rec.Code != http.StatusAccepted || resp.Accepted != 2 {
+		t.Fatalf("Expected 2 accepted entries, got %d %+v", rec.Code, resp)
+	}
+	resent := first + `{"id":"01ARYZ6S41TSV4RRFFQ69G5FAW","level":"INFO","operation":"checkout","message":"Order shipped"}
+`
+	rec, resp := postEntries(receiver, "token-a", resent)
+	if rec.Code != http.StatusAccepted || resp.Accepted != 2 || resp.Duplicates != 1 {
+		t.Fatalf("Expected the resent entry to be acknowledged as a duplicate, got %d %+v", rec.Code, resp)
+	}
+	// IDs are remembered per project
+	if _, resp := postEntries(receiver, "token-b", first); resp.Accepted != 2 {
+		t.Errorf("Expected another project to accept the same ID, got %+v", resp)
+	}
+	receiver.Close()
+
+	if entries := readTenantEntries(t, "receiver-tenant-a"); len(entries) != 4 {
+		t.Errorf("Expected the duplicate not to be written, got %d entries", len(entries))
+	}
+
+	// Only the most recent IDs are remembered
+	seen := newRecentIDs(2)
+	for _, id := range []string{"a", "b", "c"} {
+		seen.add(id)
+	}
+	if !seen.add("a") || seen.add("c") {
+		t.Error("Expected the oldest ID to be forgotten")
+	}
+}
+
+func TestReceiverRejectsRequests(t *testing.T) {
+	diagnostics := NewLoggerWithConfig("receiver_diag", &LoggerConfig{
+		AutoSave:        false,
+		EnableMemoryLog: true,
+		MemoryLogLimit:  10,
+	})
+	receiver := newTestReceiver(t, ReceiverConfig{MaxBodySize: 64, Logger: diagnostics})
+
+	if rec, _ := postEntries(receiver, "", `{}`); rec.Code != http.StatusUnauthorized {
+		t.Errorf("Expected 401 without a token, got %d", rec.Code)
+	}
+	if rec, _ := postEntries(receiver, "wrong", `{}`); rec.Code != http.StatusUnauthorized {
+		t.Errorf("Expected 401 for an unknown token, got %d", rec.Code)
+	}
+	if logs := diagnostics.GetMemoryLogs(); len(logs) != 2 || logs[0].Operation != "receiver_auth" {
+		t.Errorf("Expected rejected tokens to be reported, got %v", logs)
+	}
+
+	rec, resp := postEntries(receiver, "token-a", "not json\n")
+	if rec.Code != http.StatusBadRequest || resp.Rejected != 1 {
+		t.Errorf("Expected 400 for a corrupt body, got %d %+v", rec.Code, resp)
+	}
+	if rec, _ := postEntries(receiver, "token-a", strings.Repeat(" ", 100)); rec.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("Expected 413 for a large body, got %d", rec.Code)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/ingest", nil)
+	get := httptest.NewRecorder()
+	receiver.ServeHTTP(get, req)
+	if get.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected 405 for GET, got %d", get.Code)
+	}
+}
+
+func TestReceiverAuthenticator(t *testing.T) {
+	receiver := newTestReceiver(t, ReceiverConfig{Authenticator: NewTokenAuthenticator(map[string]Principal{
+		"agent":  {Scopes: []Scope{ScopeWrite}, Projects: []string{"receiver-tenant-a", "receiver-tenant-b"}},
+		"single": {Scopes: []Scope{ScopeWrite}, Projects: []string{"receiver-tenant-b"}},
+		"reader": {Scopes: []Scope{ScopeRead}},
+	})})
+	post := func(token, project string) int {
+		req := httptest.NewRequest(http.MethodPost, "/ingest", strings.NewReader(`{"operation":"sync","message":"Synced"}`))
+		req.Header.Set("Authorization", "Bearer "+token)
+		if project != "" {
+			req.Header.Set(ProjectHeader, project)
+		}
+		rec := httptest.NewRecorder()
+		receiver.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	if code := post("token-a", ""); code != http.StatusAccepted {
+		t.Errorf("Expected tenant tokens to keep working, got %d", code)
+	}
+	if code := post("agent", "receiver-tenant-b"); code != http.StatusAccepted {
+		t.Errorf("Expected a write to an allowed project, got %d", code)
+	}
+	if code := post("single", ""); code != http.StatusAccepted {
+		t.Errorf("Expected the only project to be the default, got %d", code)
+	}
+	if code := post("agent", ""); code != http.StatusBadRequest {
+		t.Errorf("Expected 400 without a project, got %d", code)
+	}
+	if code := post("single", "receiver-tenant-a"); code != http.StatusForbidden {
+		t.Errorf("Expected 403 for another project, got %d", code)
+	}
+	if code := post("reader", "receiver-tenant-a"); code != http.StatusForbidden {
+		t.Errorf("Expected 403 without the write scope, got %d", code)
+	}
+	receiver.Close()
+
+	if entries := readTenantEntries(t, "receiver-tenant-b"); len(entries) != 2 {
+		t.Errorf("Expected 2 entries for tenant b, got %d", len(entries))
+	}
+}
+
+func gzipBody(t *testing.T, s string) string {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	zw.Write([]byte(s))
+	zw.Close()
+	return buf.String()
+}
+
+func TestReceiverContentEncoding(t *testing.T) {
+	receiver := newTestReceiver(t, ReceiverConfig{MaxBodySize: 1024})
+
+	post := func(encoding, body string) (*httptest.ResponseRecorder, receiverResponse) {
+		req := httptest.NewRequest(http.MethodPost, "/ingest", strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer token-a")
+		req.Header.Set("Content-Encoding", encoding)
+		rec := httptest.NewRecorder()
+		receiver.ServeHTTP(rec, req)
+		var resp receiverResponse
+		json.Unmarshal(rec.Body.Bytes(), &resp)
+		return rec, resp
+	}
+
+	rec, resp := post("gzip", gzipBody(t, `{"level":"INFO","operation":"sync","message":"Compressed"}`))
+	if rec.Code != http.StatusAccepted || resp.Accepted != 1 {
+		t.Errorf("Expected a gzip body to be accepted, got %d %+v", rec.Code, resp)
+	}
+	if rec.Header().Get("Accept-Encoding") != "gzip" {
+		t.Error("Expected the receiver to advertise gzip support")
+	}
+	if rec, _ := post("br", "{}"); rec.Code != http.StatusUnsupportedMediaType {
+		t.Errorf("Expected 415 for an unknown encoding, got %d", rec.Code)
+	}
+	if rec, _ := post("gzip", "not gzip"); rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for an invalid gzip body, got %d", rec.Code)
+	}
+	// Small on the wire, but larger than MaxBodySize once decompressed
+	if rec, _ := post("gzip", gzipBody(t, strings.Repeat(" ", 4096))); rec.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("Expected 413 for a large decompressed body, got %d", rec.Code)
+	}
+}
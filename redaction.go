@@ -0,0 +1,41 @@
+package vibelogger
+
+import "sort"
+
+// RedactedValue replaces context values hidden from the log file, e.g. the
+// values of LoggerConfig.RedactKeys. Readers see which values were hidden with
+// LogEntry.RedactedFields.
+const RedactedValue = "[REDACTED]"
+
+// RedactedFields returns the context keys whose values were redacted when the
+// entry was written, sorted, with nested keys joined by dots (e.g.
+// "payment.card_number"). It lets readers tell a hidden value from a missing
+// one without knowing the settings of the writer.
+func (e *LogEntry) RedactedFields() []string {
+	return contextFields(e.Context, func(value string) bool {
+		return value == RedactedValue
+	})
+}
+
+// contextFields returns the keys of the string context values, including
+// values of nested maps, for which match returns true, sorted
+func contextFields(context map[string]interface{}, match func(string) bool) []string {
+	var fields []string
+	collectContextFields(context, "", match, &fields)
+	sort.Strings(fields)
+	return fields
+}
+
+// collectContextFields appends the matching keys of context to fields
+func collectContextFields(context map[string]interface{}, prefix string, match func(string) bool, fields *[]string) {
+	for key, value := range context {
+		switch v := value.(type) {
+		case string:
+			if match(v) {
+				*fields = append(*fields, prefix+key)
+			}
+		case map[string]interface{}:
+			collectContextFields(v, prefix+key+".", match, fields)
+		}
+	}
+}
@@ -0,0 +1,23 @@
+package vibelogger
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRedactedFields(t *testing.T) {
+	entry := LogEntry{Context: map[string]interface{}{
+		"password": RedactedValue,
+		"payment":  map[string]interface{}{"card_number": RedactedValue, "amount": 10},
+		"user":     "jane",
+	}}
+	if fields := entry.RedactedFields(); !reflect.DeepEqual(fields, []string{"password", "payment.card_number"}) {
+		t.Errorf("unexpected redacted fields %v", fields)
+	}
+
+	query := Query{Redacted: true}
+	entries := []LogEntry{entry, {Context: map[string]interface{}{"user": "joe"}}, {}}
+	if matched := query.Filter(entries); len(matched) != 1 || matched[0].Context["user"] != "jane" {
+		t.Errorf("expected only the redacted entry, got %v", matched)
+	}
+}
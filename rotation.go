@@ -1,7 +1,9 @@
 package vibelogger
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"sort"
@@ -12,11 +14,27 @@ import (
 
 // rotationRequest は非同期ローテーション要求を表す
 type rotationRequest struct {
-	force    bool       // 強制ローテーションかどうか
-	response chan error // 結果を返すチャネル
+	rm       *RotationManager // 対象のローテーションマネージャー
+	force    bool             // 強制ローテーションかどうか
+	response chan error       // 結果を返すチャネル
 }
 
-// RotationManager handles log file rotation and cleanup
+// rotationWarning is a problem found while the logger mutex was held. It is
+// logged once the mutex is released, since logging needs the mutex itself.
+type rotationWarning struct {
+	operation string
+	message   string
+	err       error
+	context   map[string]interface{}
+}
+
+// RotationManager handles log file rotation and cleanup.
+//
+// Ordering guarantee: a rotation only ever runs while the logger mutex is held,
+// the same mutex every file write takes. A write therefore either completes in
+// the old file before its footer, or starts in the new file after its header;
+// no entry is written to a file that has already been renamed. Lock order is
+// logger mutex, then rotation mutex.
 type RotationManager struct {
 	logger            *Logger
 	config            *LoggerConfig
@@ -29,10 +47,23 @@ type RotationManager struct {
 	pendingRotation   bool                 // Flag to prevent duplicate rotations
 	asyncRotationChan chan rotationRequest // Channel for async rotation requests
 	asyncEnabled      bool                 // Whether async rotation is enabled
+	sharedScheduler   bool                 // asyncRotationChan belongs to a LoggerManager
+	warnings          []rotationWarning    // Reported by the logger after releasing its mutex
+	// Persisted rotation state
+	lastRotation  time.Time
+	rotationCount int64
+	fileStates    map[string]RotatedFileState
+	checksums     sync.WaitGroup // Pending checksumRotatedFile goroutines
 }
 
 // NewRotationManager creates a new rotation manager for the given logger
 func NewRotationManager(logger *Logger, config *LoggerConfig, basePath string) *RotationManager {
+	return newRotationManager(logger, config, basePath, nil)
+}
+
+// newRotationManager creates a rotation manager whose async rotations run on
+// scheduler, or on a worker of its own when scheduler is nil
+func newRotationManager(logger *Logger, config *LoggerConfig, basePath string, scheduler *rotationScheduler) *RotationManager {
 	rm := &RotationManager{
 		logger:            logger,
 		config:            config,
@@ -41,22 +72,40 @@ func NewRotationManager(logger *Logger, config *LoggerConfig, basePath string) *
 		lastSizeSync:      time.Now(),
 		asyncRotationChan: make(chan rotationRequest, 1), // Buffer of 1 to prevent blocking
 		asyncEnabled:      true,                          // Enable async rotation by default
+		fileStates:        make(map[string]RotatedFileState),
 	}
 
 	// Initialize cached file size
 	rm.syncFileSize()
 
-	// Initialize list of existing rotated files
-	rm.scanExistingRotatedFiles()
+	// Initialize list of existing rotated files, from the persisted state when available
+	restored, unregistered := rm.restoreState()
+	if !restored {
+		rm.scanExistingRotatedFiles()
+	}
+
+	// Repair a rotation the previous process did not finish; the lock guards
+	// against the checksum goroutines started by recordRotation
+	rm.mutex.Lock()
+	rm.recoverInterruptedRotation(unregistered)
+	rm.mutex.Unlock()
 
 	// Start async rotation worker
-	go rm.asyncRotationWorker()
+	if scheduler != nil {
+		rm.asyncRotationChan = scheduler.requests
+		rm.sharedScheduler = true
+	} else {
+		go rm.asyncRotationWorker()
+	}
 
 	return rm
 }
 
 // ShouldRotate checks if rotation is needed for the given entry size
 func (rm *RotationManager) ShouldRotate(newEntrySize int64) bool {
+	rm.mutex.Lock()
+	defer rm.mutex.Unlock()
+
 	if !rm.config.RotationEnabled || rm.pendingRotation {
 		return false
 	}
@@ -82,11 +131,28 @@ func (rm *RotationManager) ShouldRotate(newEntrySize int64) bool {
 	return wouldExceed
 }
 
-// PerformRotation rotates the current log file and creates a new one
+// PerformRotation rotates the current log file and creates a new one. It waits
+// for the write in progress, if any, and blocks further writes until the new
+// file is ready.
 func (rm *RotationManager) PerformRotation() error {
+	rm.logger.mutex.Lock()
+	err := rm.rotateLocked()
+	rm.logger.mutex.Unlock()
+
+	rm.logger.reportRotationWarnings(rm)
+	return err
+}
+
+// rotateLocked performs the rotation. The caller must hold the logger mutex.
+func (rm *RotationManager) rotateLocked() error {
 	rm.mutex.Lock()
 	defer rm.mutex.Unlock()
 
+	// A closed logger has no file to rotate; do not reopen one behind its back
+	if rm.logger.file == nil {
+		return fmt.Errorf("log file is closed")
+	}
+
 	// Prevent duplicate rotations
 	if rm.pendingRotation {
 		return nil
@@ -94,31 +160,57 @@ func (rm *RotationManager) PerformRotation() error {
 	rm.pendingRotation = true
 	defer func() { rm.pendingRotation = false }()
 
-	// Close current file
+	// Finalize and close current file
 	if rm.logger.file != nil {
+		rm.logger.writeFooter(FooterReasonRotation)
 		if err := rm.logger.file.Close(); err != nil {
 			return fmt.Errorf("failed to close current log file: %w", err)
 		}
 	}
 
+	rm.logger.faults.delayRotation()
+	if _, err := rm.archiveCurrentFile(time.Now()); err != nil {
+		// A file deleted behind the logger's back cannot be archived; start a new one
+		if !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		rm.addWarning("rotation_recovery", "Log file was deleted before rotation; started a new file", err)
+	}
+	if err := rm.openNewFile(); err != nil {
+		return err
+	}
+	rm.logger.recordProjectActivity()
+	return nil
+}
+
+// archiveCurrentFile renames the closed current file to a rotated name and
+// applies the retention policy. The caller must hold rm.mutex.
+func (rm *RotationManager) archiveCurrentFile(rotatedAt time.Time) (string, error) {
 	// Generate rotated file name with timestamp
-	timestamp := time.Now().Format("20060102_150405")
-	rotatedPath := fmt.Sprintf("%s.%s", rm.basePath, timestamp)
+	timestamp := rotatedAt.Format("20060102_150405")
+	rotatedPath := uniqueRotatedPath(fmt.Sprintf("%s.%s", rm.basePath, timestamp))
 
 	// Rename current file to rotated name
 	if err := os.Rename(rm.basePath, rotatedPath); err != nil {
-		return fmt.Errorf("failed to rotate log file: %w", err)
+		return "", fmt.Errorf("failed to rotate log file: %w", err)
 	}
 
 	// Add to rotated files list
 	rm.rotatedFiles = append(rm.rotatedFiles, rotatedPath)
+	rm.recordRotation(rotatedPath, rotatedAt)
 
 	// Clean up old files if needed
 	if err := rm.cleanupOldFiles(); err != nil {
 		// Log warning but don't fail rotation
-		rm.logger.Warn("rotation_cleanup", "Failed to cleanup old files", WithError(err))
+		rm.addWarning("rotation_cleanup", "Failed to cleanup old files", err)
 	}
+	rm.persistState()
+	return rotatedPath, nil
+}
 
+// openNewFile creates the next current file and writes its header. The caller
+// must hold rm.mutex and the logger mutex.
+func (rm *RotationManager) openNewFile() error {
 	// Create new log file
 	newFile, err := os.OpenFile(rm.basePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
 	if err != nil {
@@ -127,13 +219,30 @@ func (rm *RotationManager) PerformRotation() error {
 
 	// Update logger with new file and reset cached sizes
 	rm.logger.file = newFile
-	rm.logger.currentSize = 0
-	rm.cachedFileSize = 0
+	rm.logger.fileEntries = 0
+	headerSize, err := rm.logger.writeHeader()
+	if err != nil {
+		return fmt.Errorf("failed to write header to new log file: %w", err)
+	}
+	rm.logger.currentSize = headerSize
+	rm.cachedFileSize = headerSize
 	rm.lastSizeSync = time.Now()
 
 	return nil
 }
 
+// uniqueRotatedPath appends a counter when several rotations happen within the
+// same second, so a rename never replaces an earlier rotated file
+func uniqueRotatedPath(path string) string {
+	candidate := path
+	for i := 1; ; i++ {
+		if _, err := os.Lstat(candidate); os.IsNotExist(err) {
+			return candidate
+		}
+		candidate = fmt.Sprintf("%s.%d", path, i)
+	}
+}
+
 // scanExistingRotatedFiles scans for existing rotated files matching the pattern
 func (rm *RotationManager) scanExistingRotatedFiles() {
 	baseDir := filepath.Dir(rm.basePath)
@@ -191,7 +300,7 @@ func (rm *RotationManager) cleanupOldFiles() error {
 		filesToDelete := rm.rotatedFiles[rm.config.MaxRotatedFiles:]
 
 		for _, file := range filesToDelete {
-			if err := os.Remove(file); err != nil {
+			if err := os.Remove(file); err != nil && !os.IsNotExist(err) {
 				return fmt.Errorf("failed to remove old rotated file %s: %w", file, err)
 			}
 		}
@@ -223,8 +332,36 @@ func (rm *RotationManager) UpdateConfig(config *LoggerConfig) {
 
 	// Clean up files if retention policy changed
 	if err := rm.cleanupOldFiles(); err != nil {
-		rm.logger.Warn("config_update_cleanup", "Failed to cleanup files after config update", WithError(err))
+		rm.addWarning("config_update_cleanup", "Failed to cleanup files after config update", err)
+	}
+	if rm.rotationCount > 0 {
+		rm.persistState()
+	}
+}
+
+// addWarning records a problem to log later. The caller must hold rm.mutex.
+func (rm *RotationManager) addWarning(operation, message string, err error) {
+	rm.warnings = append(rm.warnings, rotationWarning{operation: operation, message: message, err: err})
+}
+
+// hasWarnings reports whether warnings are waiting to be reported; false for
+// a nil manager
+func (rm *RotationManager) hasWarnings() bool {
+	if rm == nil {
+		return false
 	}
+	rm.mutex.Lock()
+	defer rm.mutex.Unlock()
+	return len(rm.warnings) > 0
+}
+
+// takeWarnings returns and clears the recorded warnings
+func (rm *RotationManager) takeWarnings() []rotationWarning {
+	rm.mutex.Lock()
+	defer rm.mutex.Unlock()
+	warnings := rm.warnings
+	rm.warnings = nil
+	return warnings
 }
 
 // syncFileSize synchronizes the cached file size with the actual file size on disk
@@ -245,7 +382,10 @@ func (rm *RotationManager) updateCachedSize(deltaSize int64) {
 func (rm *RotationManager) PerformRotationAsync() <-chan error {
 	response := make(chan error, 1)
 
-	if !rm.asyncEnabled {
+	rm.mutex.Lock()
+	asyncEnabled := rm.asyncEnabled
+	rm.mutex.Unlock()
+	if !asyncEnabled {
 		// Fall back to synchronous rotation
 		go func() {
 			response <- rm.PerformRotation()
@@ -254,6 +394,7 @@ func (rm *RotationManager) PerformRotationAsync() <-chan error {
 	}
 
 	request := rotationRequest{
+		rm:       rm,
 		force:    false,
 		response: response,
 	}
@@ -277,6 +418,7 @@ func (rm *RotationManager) ForceRotationAsync() <-chan error {
 	response := make(chan error, 1)
 
 	request := rotationRequest{
+		rm:       rm,
 		force:    true,
 		response: response,
 	}
@@ -316,7 +458,47 @@ func (rm *RotationManager) SetAsyncRotation(enabled bool) {
 	rm.asyncEnabled = enabled
 }
 
-// Close shuts down the rotation manager and its background worker
+// Close shuts down the rotation manager and its background worker. A shared
+// scheduler keeps running; its owner stops it.
 func (rm *RotationManager) Close() {
+	rm.checksums.Wait()
+	if rm.sharedScheduler {
+		return
+	}
 	close(rm.asyncRotationChan)
 }
+
+// rotationSchedulerQueue is the number of pending async rotations a shared scheduler accepts
+const rotationSchedulerQueue = 16
+
+// rotationScheduler runs the async rotations of several rotation managers on a
+// single goroutine, see LoggerManager
+type rotationScheduler struct {
+	requests chan rotationRequest
+	done     chan struct{}
+}
+
+// newRotationScheduler starts the scheduler goroutine
+func newRotationScheduler() *rotationScheduler {
+	s := &rotationScheduler{
+		requests: make(chan rotationRequest, rotationSchedulerQueue),
+		done:     make(chan struct{}),
+	}
+	go func() {
+		defer close(s.done)
+		for request := range s.requests {
+			err := request.rm.PerformRotation()
+			select {
+			case request.response <- err:
+			default:
+			}
+		}
+	}()
+	return s
+}
+
+// close runs the pending rotations and stops the goroutine
+func (s *rotationScheduler) close() {
+	close(s.requests)
+	<-s.done
+}
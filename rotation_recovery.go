@@ -0,0 +1,89 @@
+package vibelogger
+
+import (
+	"os"
+	"time"
+)
+
+// recoverInterruptedRotation repairs what a process that died during rotation
+// left behind and queues a "rotation_recovery" warning describing the repairs.
+// unregistered lists rotated files missing from the persisted state. It runs
+// before the manager is shared, with rm.mutex held.
+func (rm *RotationManager) recoverInterruptedRotation(unregistered []string) {
+	var actions []map[string]interface{}
+
+	// The state was being written when the process died; the previous state is intact
+	tmpPath := rotationStatePath(rm.basePath) + ".tmp"
+	if _, err := os.Lstat(tmpPath); err == nil && os.Remove(tmpPath) == nil {
+		actions = append(actions, map[string]interface{}{"action": "removed_state_temp", "path": tmpPath})
+	}
+
+	// The file was renamed but the process died before persisting the state
+	for _, path := range unregistered {
+		rm.recordRotation(path, rm.fileStates[path].RotatedAt)
+		actions = append(actions, map[string]interface{}{"action": "registered_rotated_file", "path": path})
+	}
+
+	// The footer was written but the file was never renamed; appending to it
+	// would put entries after the footer without a header
+	if footer := rotationFooter(rm.basePath); footer != nil {
+		reopen := rm.logger.file != nil
+		if reopen {
+			rm.logger.file.Close()
+			rm.logger.file = nil
+		}
+		path, err := rm.archiveCurrentFile(footer.Timestamp)
+		// Even if the rename failed, a header after the footer starts a valid new section
+		if reopen {
+			if openErr := rm.openNewFile(); err == nil {
+				err = openErr
+			}
+		}
+		if err != nil {
+			rm.addWarning("rotation_recovery", "Failed to finish an interrupted log rotation", err)
+		} else {
+			actions = append(actions, map[string]interface{}{"action": "completed_rotation", "path": path})
+		}
+	}
+
+	if len(actions) == 0 {
+		return
+	}
+	if len(unregistered) > 0 {
+		rm.persistState()
+	}
+	rm.warnings = append(rm.warnings, rotationWarning{
+		operation: "rotation_recovery",
+		message:   "Repaired a log rotation interrupted by an unclean shutdown",
+		context:   map[string]interface{}{"file": rm.basePath, "actions": actions},
+	})
+}
+
+// rotationFooter returns the footer ending the file if it was written by a
+// rotation, or nil when the file is missing or continues after it
+func rotationFooter(path string) *FileFooter {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	var last rawRecord
+	scanner := newRecordScanner(file)
+	for scanner.Next() {
+		last = scanner.Record()
+	}
+	if scanner.Err() != nil || last.data == nil || !last.complete {
+		return nil
+	}
+
+	// Decode like the reader so Docker-wrapped footers are recognized too
+	_, footer, err := decodeRecord(last.data, &readOptions{}, &LogEntry{})
+	if err != nil || footer == nil || footer.Reason != FooterReasonRotation {
+		return nil
+	}
+	if footer.Timestamp.IsZero() {
+		footer.Timestamp = time.Now()
+	}
+	return footer
+}
@@ -0,0 +1,235 @@
+package vibelogger
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// rotationStateVersion is the version of the persisted rotation state layout
+const rotationStateVersion = 1
+
+// RotationState is the rotation bookkeeping persisted next to the log file,
+// so that a restarted process continues with the same counters and retention
+// list instead of rescanning the directory heuristically
+type RotationState struct {
+	Version       int                `json:"version"`
+	LastRotation  time.Time          `json:"last_rotation,omitempty"`
+	RotationCount int64              `json:"rotation_count"`
+	Files         []RotatedFileState `json:"files"`
+}
+
+// RotatedFileState describes a rotated file known to the rotation manager
+type RotatedFileState struct {
+	Path      string    `json:"path"`
+	RotatedAt time.Time `json:"rotated_at"`
+	Size      int64     `json:"size"`
+	SHA256    string    `json:"sha256,omitempty"`
+}
+
+// rotationStatePath returns the state file of a log file. The leading dot keeps
+// it out of the "<name>.<timestamp>" pattern used for rotated files.
+func rotationStatePath(basePath string) string {
+	return filepath.Join(filepath.Dir(basePath), "."+filepath.Base(basePath)+".rotation")
+}
+
+// loadRotationState reads the persisted state, returning nil if there is none
+func loadRotationState(basePath string) (*RotationState, error) {
+	data, err := os.ReadFile(rotationStatePath(basePath))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rotation state: %w", err)
+	}
+
+	var state RotationState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse rotation state: %w", err)
+	}
+	if state.Version != rotationStateVersion {
+		return nil, fmt.Errorf("unsupported rotation state version: %d", state.Version)
+	}
+	return &state, nil
+}
+
+// saveRotationState writes the state atomically
+func saveRotationState(basePath string, state *RotationState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal rotation state: %w", err)
+	}
+
+	path := rotationStatePath(basePath)
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write rotation state: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to write rotation state: %w", err)
+	}
+	return nil
+}
+
+// describeRotatedFile returns the size and checksum of a rotated file
+func describeRotatedFile(path string, rotatedAt time.Time) (RotatedFileState, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return RotatedFileState{}, err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	size, err := io.Copy(hash, file)
+	if err != nil {
+		return RotatedFileState{}, err
+	}
+	return RotatedFileState{
+		Path:      path,
+		RotatedAt: rotatedAt,
+		Size:      size,
+		SHA256:    hex.EncodeToString(hash.Sum(nil)),
+	}, nil
+}
+
+// restoreState initializes the rotated file list from the persisted state.
+// Files that disappeared are dropped and rotated files unknown to the state are
+// added from a directory scan. It reports whether a usable state file was found,
+// and returns the unknown files: every rotation persists the state, so these
+// were renamed by a process that died before it could.
+func (rm *RotationManager) restoreState() (bool, []string) {
+	state, err := loadRotationState(rm.basePath)
+	if err != nil {
+		rm.logger.reportError(err)
+		return false, nil
+	}
+	if state == nil {
+		return false, nil
+	}
+
+	rm.lastRotation = state.LastRotation
+	rm.rotationCount = state.RotationCount
+
+	known := make(map[string]bool)
+	for _, file := range state.Files {
+		if _, err := os.Stat(file.Path); err != nil {
+			continue
+		}
+		known[file.Path] = true
+		rm.fileStates[file.Path] = file
+	}
+
+	// Keep the scan for files rotated by processes that did not persist state
+	rm.scanExistingRotatedFiles()
+	var unregistered []string
+	for _, path := range rm.rotatedFiles {
+		if !known[path] {
+			if info, err := os.Stat(path); err == nil {
+				rm.fileStates[path] = RotatedFileState{Path: path, RotatedAt: info.ModTime(), Size: info.Size()}
+				unregistered = append(unregistered, path)
+			}
+		}
+	}
+
+	// Newest first, by rotation time rather than modification time
+	sort.SliceStable(rm.rotatedFiles, func(i, j int) bool {
+		return rm.fileStates[rm.rotatedFiles[i]].RotatedAt.After(rm.fileStates[rm.rotatedFiles[j]].RotatedAt)
+	})
+	return true, unregistered
+}
+
+// recordRotation registers a newly rotated file in the state. Rotation runs
+// under the logger mutex, so the checksum is computed by checksumRotatedFile
+// instead of reading the whole file here.
+func (rm *RotationManager) recordRotation(path string, rotatedAt time.Time) {
+	rm.lastRotation = rotatedAt
+	rm.rotationCount++
+
+	file := RotatedFileState{Path: path, RotatedAt: rotatedAt}
+	if info, err := os.Stat(path); err == nil {
+		file.Size = info.Size()
+	}
+	rm.fileStates[path] = file
+
+	rm.checksums.Add(1)
+	go rm.checksumRotatedFile(file)
+}
+
+// checksumRotatedFile hashes a rotated file without holding any lock and
+// persists the result, unless retention dropped the file in the meantime
+func (rm *RotationManager) checksumRotatedFile(file RotatedFileState) {
+	defer rm.checksums.Done()
+
+	described, err := describeRotatedFile(file.Path, file.RotatedAt)
+	if err != nil {
+		return
+	}
+
+	rm.mutex.Lock()
+	defer rm.mutex.Unlock()
+	if current, ok := rm.fileStates[file.Path]; !ok || !current.RotatedAt.Equal(file.RotatedAt) {
+		return
+	}
+	rm.fileStates[file.Path] = described
+	// Reported by the logger like other background rotation failures
+	if err := rm.saveState(); err != nil {
+		rm.addWarning("rotation_state", "Failed to persist the rotated file checksum", err)
+	}
+}
+
+// persistState writes the current state, dropping files no longer retained.
+// Failing to persist does not fail the rotation itself.
+func (rm *RotationManager) persistState() {
+	if err := rm.saveState(); err != nil {
+		rm.logger.reportError(err)
+	}
+}
+
+// saveState is persistState returning the error
+func (rm *RotationManager) saveState() error {
+	state := rm.snapshotState()
+
+	retained := make(map[string]bool, len(rm.rotatedFiles))
+	for _, path := range rm.rotatedFiles {
+		retained[path] = true
+	}
+	for path := range rm.fileStates {
+		if !retained[path] {
+			delete(rm.fileStates, path)
+		}
+	}
+	return saveRotationState(rm.basePath, &state)
+}
+
+// snapshotState builds a RotationState from the manager's bookkeeping
+func (rm *RotationManager) snapshotState() RotationState {
+	state := RotationState{
+		Version:       rotationStateVersion,
+		LastRotation:  rm.lastRotation,
+		RotationCount: rm.rotationCount,
+		Files:         make([]RotatedFileState, 0, len(rm.rotatedFiles)),
+	}
+	for _, path := range rm.rotatedFiles {
+		file, ok := rm.fileStates[path]
+		if !ok {
+			file = RotatedFileState{Path: path}
+		}
+		state.Files = append(state.Files, file)
+	}
+	return state
+}
+
+// State returns the rotation counters and the rotated files currently retained
+func (rm *RotationManager) State() RotationState {
+	rm.checksums.Wait()
+	rm.mutex.Lock()
+	defer rm.mutex.Unlock()
+	return rm.snapshotState()
+}
@@ -0,0 +1,216 @@
+package vibelogger
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestRotationStatePersistence(t *testing.T) {
+	defer os.RemoveAll("test_logs")
+
+	config := DefaultConfig()
+	config.FilePath = "test_logs/rotation_state_test.log"
+
+	logger, err := CreateFileLoggerWithConfig("rotation_state_test", config)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	logger.Info("test", "Before rotation")
+	if err := logger.ForceRotation(); err != nil {
+		t.Fatalf("Failed to rotate: %v", err)
+	}
+	logger.Close()
+
+	state, err := loadRotationState(config.FilePath)
+	if err != nil || state == nil {
+		t.Fatalf("Expected persisted rotation state, got %v (err: %v)", state, err)
+	}
+	if state.RotationCount != 1 || len(state.Files) != 1 {
+		t.Fatalf("Expected 1 rotation and 1 file, got %d and %d", state.RotationCount, len(state.Files))
+	}
+	rotated := state.Files[0]
+	if rotated.SHA256 == "" || rotated.Size == 0 || rotated.RotatedAt.IsZero() {
+		t.Errorf("Expected checksum, size and rotation time, got %+v", rotated)
+	}
+
+	// A new logger on the same file continues from the persisted state
+	logger, err = CreateFileLoggerWithConfig("rotation_state_test", config)
+	if err != nil {
+		t.Fatalf("Failed to reopen logger: %v", err)
+	}
+	restored := logger.rotationMgr.State()
+	if restored.RotationCount != 1 || !restored.LastRotation.Equal(state.LastRotation) {
+		t.Errorf("Expected restored counters, got %+v", restored)
+	}
+	if files := logger.GetRotatedFiles(); len(files) != 1 || files[0] != rotated.Path {
+		t.Errorf("Expected rotated file %s to be known, got %v", rotated.Path, files)
+	}
+	logger.Close()
+
+	// Files removed while the process was down are dropped
+	os.Remove(rotated.Path)
+	logger, err = CreateFileLoggerWithConfig("rotation_state_test", config)
+	if err != nil {
+		t.Fatalf("Failed to reopen logger: %v", err)
+	}
+	defer logger.Close()
+	if files := logger.GetRotatedFiles(); len(files) != 0 {
+		t.Errorf("Expected missing rotated file to be dropped, got %v", files)
+	}
+	if logger.rotationMgr.State().RotationCount != 1 {
+		t.Error("Expected rotation count to survive file removal")
+	}
+}
+
+// recoveryActions returns the actions of the rotation_recovery entry in the memory log
+func recoveryActions(t *testing.T, logger *Logger) []string {
+	t.Helper()
+	for _, entry := range logger.GetMemoryLogs() {
+		if entry.Operation != "rotation_recovery" {
+			continue
+		}
+		var actions []string
+		for _, action := range entry.Context["actions"].([]map[string]interface{}) {
+			actions = append(actions, action["action"].(string))
+		}
+		return actions
+	}
+	t.Fatal("Expected a rotation_recovery entry")
+	return nil
+}
+
+func TestRecoverUnrenamedRotation(t *testing.T) {
+	defer os.RemoveAll("test_logs")
+
+	config := DefaultConfig()
+	config.FilePath = "test_logs/recovery_footer_test.log"
+	config.EnableMemoryLog = true
+
+	logger, err := CreateFileLoggerWithConfig("recovery_footer_test", config)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	logger.Info("test", "Before crash")
+
+	// Simulate a crash after the rotation footer but before the rename
+	logger.mutex.Lock()
+	logger.writeFooter(FooterReasonRotation)
+	logger.file.Close()
+	logger.file = nil
+	logger.mutex.Unlock()
+	logger.Close()
+
+	logger, err = CreateFileLoggerWithConfig("recovery_footer_test", config)
+	if err != nil {
+		t.Fatalf("Failed to reopen logger: %v", err)
+	}
+	logger.Info("test", "After restart")
+	actions := recoveryActions(t, logger)
+	files := logger.GetRotatedFiles()
+	logger.Close()
+
+	if len(actions) != 1 || actions[0] != "completed_rotation" {
+		t.Errorf("Expected the rotation to be completed, got %v", actions)
+	}
+	if len(files) != 1 {
+		t.Fatalf("Expected 1 rotated file, got %v", files)
+	}
+	rotated, err := ReadLogFile(files[0])
+	if err != nil || len(rotated.Entries) != 1 || rotated.Footer == nil || rotated.Footer.Reason != FooterReasonRotation {
+		t.Errorf("Expected the old entry and rotation footer in %s, got %+v (%v)", files[0], rotated, err)
+	}
+
+	// The new file starts with a header and holds only entries after the restart
+	current, err := ReadLogFile(config.FilePath)
+	if err != nil || current.Header == nil || !current.CleanShutdown() {
+		t.Fatalf("Expected a complete new file, got %+v (%v)", current, err)
+	}
+	for _, entry := range current.Entries {
+		if entry.Message == "Before crash" {
+			t.Error("Entry before the crash must not be in the new file")
+		}
+	}
+}
+
+func TestRecoverUnregisteredRotatedFile(t *testing.T) {
+	defer os.RemoveAll("test_logs")
+
+	config := DefaultConfig()
+	config.FilePath = "test_logs/recovery_state_test.log"
+	config.EnableMemoryLog = true
+
+	logger, err := CreateFileLoggerWithConfig("recovery_state_test", config)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	logger.ForceRotation()
+	logger.Info("test", "Second file")
+	logger.Close()
+
+	// Simulate a crash after the rename, while the state was being written
+	orphan := config.FilePath + ".20990101_000000"
+	if err := os.Rename(config.FilePath, orphan); err != nil {
+		t.Fatalf("Failed to rename: %v", err)
+	}
+	tmpPath := rotationStatePath(config.FilePath) + ".tmp"
+	os.WriteFile(tmpPath, []byte("{"), 0644)
+
+	logger, err = CreateFileLoggerWithConfig("recovery_state_test", config)
+	if err != nil {
+		t.Fatalf("Failed to reopen logger: %v", err)
+	}
+	defer logger.Close()
+
+	actions := recoveryActions(t, logger)
+	if len(actions) != 2 || actions[0] != "removed_state_temp" || actions[1] != "registered_rotated_file" {
+		t.Errorf("Expected the temp file removal and orphan registration, got %v", actions)
+	}
+	if _, err := os.Stat(tmpPath); !os.IsNotExist(err) {
+		t.Error("Expected the state temp file to be removed")
+	}
+	state := logger.rotationMgr.State()
+	if state.RotationCount != 2 || len(state.Files) != 2 {
+		t.Errorf("Expected 2 rotations and 2 files, got %d and %d", state.RotationCount, len(state.Files))
+	}
+	persisted, _ := loadRotationState(config.FilePath)
+	if persisted == nil || persisted.RotationCount != 2 {
+		t.Errorf("Expected the repaired state to be persisted, got %+v", persisted)
+	}
+}
+
+func TestChecksumRotatedFile(t *testing.T) {
+	defer os.RemoveAll("test_logs")
+
+	config := DefaultConfig()
+	config.FilePath = "test_logs/rotation_checksum_test.log"
+
+	logger, err := CreateFileLoggerWithConfig("rotation_checksum_test", config)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	defer logger.Close()
+	logger.Info("test", "Before rotation")
+	if err := logger.ForceRotation(); err != nil {
+		t.Fatalf("Failed to rotate: %v", err)
+	}
+
+	// State waits for the checksum computed after the rotation
+	rm := logger.rotationMgr
+	state := rm.State()
+	if len(state.Files) != 1 || state.Files[0].SHA256 == "" {
+		t.Fatalf("Expected the rotated file checksum, got %+v", state.Files)
+	}
+
+	// A checksum finishing after retention dropped the file does not register it again
+	dropped := RotatedFileState{Path: state.Files[0].Path + ".dropped", RotatedAt: time.Now()}
+	os.WriteFile(dropped.Path, []byte("{}\n"), 0644)
+	rm.checksums.Add(1)
+	rm.checksumRotatedFile(dropped)
+	rm.mutex.Lock()
+	_, registered := rm.fileStates[dropped.Path]
+	rm.mutex.Unlock()
+	if registered {
+		t.Error("Expected a dropped file to stay out of the state")
+	}
+}
@@ -1,7 +1,9 @@
 package vibelogger
 
 import (
+	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 	"sync"
 	"testing"
@@ -105,6 +107,9 @@ func TestLogRotationRetentionPolicy(t *testing.T) {
 
 	logFileCount := 0
 	for _, file := range files {
+		if strings.HasPrefix(file.Name(), ".") {
+			continue // Rotation state
+		}
 		if strings.HasSuffix(file.Name(), ".log") || strings.Contains(file.Name(), "retention_test.log") {
 			logFileCount++
 		}
@@ -611,3 +616,81 @@ func TestPerformRotationAsyncChannelBehavior(t *testing.T) {
 		t.Error("Expected rotated files after multiple async rotations")
 	}
 }
+
+// TestRotationWriteOrdering stresses concurrent writes against size based and
+// asynchronous forced rotations. Every entry must end up in exactly one file,
+// between that file's header and footer.
+func TestRotationWriteOrdering(t *testing.T) {
+	defer os.RemoveAll("test_logs")
+
+	config := DefaultConfig()
+	config.FilePath = "test_logs/ordering_test.log"
+	config.MaxFileSize = 4096
+	config.RotationEnabled = true
+	config.MaxRotatedFiles = 0 // Keep every file so all entries can be counted
+
+	logger, err := CreateFileLoggerWithConfig("ordering_test", config)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+
+	const workers, perWorker = 8, 100
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < perWorker; i++ {
+				if err := logger.Info("ordering_op", fmt.Sprintf("w%d-%d", w, i)); err != nil {
+					t.Errorf("Write failed: %v", err)
+				}
+			}
+		}(w)
+	}
+	stop := make(chan struct{})
+	rotations := make(chan struct{})
+	go func() {
+		defer close(rotations)
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			<-logger.ForceRotationAsync()
+		}
+	}()
+	wg.Wait()
+	close(stop)
+	<-rotations
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	files, _ := filepath.Glob(config.FilePath + "*")
+	seen := make(map[string]int)
+	for _, file := range files {
+		result, err := ReadLogFile(file)
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", file, err)
+		}
+		if len(result.Corrupt) > 0 || result.Header == nil || !result.CleanShutdown() {
+			t.Errorf("%s: expected header, footer and no corrupt records, got %d corrupt, header=%v, clean=%v",
+				file, len(result.Corrupt), result.Header != nil, result.CleanShutdown())
+		}
+		for _, entry := range result.Entries {
+			if entry.Operation == "ordering_op" {
+				seen[entry.Message]++
+			}
+		}
+	}
+
+	if len(seen) != workers*perWorker {
+		t.Errorf("Expected %d distinct entries across %d files, found %d", workers*perWorker, len(files), len(seen))
+	}
+	for message, count := range seen {
+		if count != 1 {
+			t.Errorf("Entry %s written %d times", message, count)
+		}
+	}
+}
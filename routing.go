@@ -0,0 +1,125 @@
+package vibelogger
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// CategoryRoute sends entries of one category to a file in the main log directory
+type CategoryRoute struct {
+	Category string // Entry category, e.g. audit or database
+	File     string // File name next to the main log file, e.g. audit.log
+}
+
+// ParseCategoryRoutes parses a route list such as
+// "audit=audit.log,database=diagnostics.log". Several categories may share a file.
+func ParseCategoryRoutes(s string) ([]CategoryRoute, error) {
+	var routes []CategoryRoute
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		category, file, ok := strings.Cut(part, "=")
+		category, file = strings.TrimSpace(category), strings.TrimSpace(file)
+		if !ok || category == "" || file == "" {
+			return nil, fmt.Errorf("invalid route %q (must be category=file)", part)
+		}
+		if !isValidProjectName(category) {
+			return nil, fmt.Errorf("invalid route category: %s", category)
+		}
+		if !isValidRouteFile(file) {
+			return nil, fmt.Errorf("invalid route file for %s: %s (must be a file name without directories)", category, file)
+		}
+		if seen[category] {
+			return nil, fmt.Errorf("duplicate route for category %s", category)
+		}
+		seen[category] = true
+		routes = append(routes, CategoryRoute{Category: category, File: file})
+	}
+	return routes, nil
+}
+
+// isValidRouteFile accepts plain file names so routes cannot leave the log directory
+func isValidRouteFile(file string) bool {
+	if strings.HasPrefix(file, ".") || strings.ContainsAny(file, `/\`) {
+		return false
+	}
+	return isValidProjectName(strings.ReplaceAll(file, ".", ""))
+}
+
+// categorySink forwards entries of selected categories to another sink
+type categorySink struct {
+	categories map[string]bool
+	sink       Sink
+}
+
+// Write forwards the entry if its category is routed
+func (s *categorySink) Write(entry *LogEntry) error {
+	if !s.categories[entry.Category] {
+		return nil
+	}
+	return s.sink.Write(entry)
+}
+
+// Close closes the wrapped sink
+func (s *categorySink) Close() error {
+	return s.sink.Close()
+}
+
+// RouteCategories forwards entries of the given categories to sink in addition
+// to the primary output. The sink is closed with the logger.
+func (l *Logger) RouteCategories(sink Sink, categories ...string) {
+	if sink == nil || len(categories) == 0 {
+		return
+	}
+	set := make(map[string]bool, len(categories))
+	for _, category := range categories {
+		set[category] = true
+	}
+	l.AddSink(&categorySink{categories: set, sink: sink})
+}
+
+// newRouteSinks opens one file per distinct route target next to mainPath
+func newRouteSinks(name, mainPath string, routes []CategoryRoute, config *LoggerConfig) ([]Sink, error) {
+	var files []string
+	categories := make(map[string]map[string]bool)
+	for _, route := range routes {
+		if categories[route.File] == nil {
+			categories[route.File] = make(map[string]bool)
+			files = append(files, route.File)
+		}
+		categories[route.File][route.Category] = true
+	}
+
+	var sinks []Sink
+	dir := filepath.Dir(mainPath)
+	for _, file := range files {
+		path := filepath.Join(dir, file)
+		clean := filepath.Clean(mainPath)
+		if path == clean || path == errorFilePath(clean) || path == metricsFilePath(clean) {
+			closeAll(sinks)
+			return nil, fmt.Errorf("route file %s conflicts with the main log files", file)
+		}
+		routeName := name + "_" + strings.TrimSuffix(file, filepath.Ext(file))
+		logger, err := CreateFileLoggerWithConfig(routeName, childFileConfig(config, path))
+		if err != nil {
+			closeAll(sinks)
+			return nil, fmt.Errorf("failed to create route file %s: %w", file, err)
+		}
+		set := categories[file]
+		sinks = append(sinks, &fileSink{logger: logger, match: func(entry *LogEntry) bool {
+			return set[entry.Category]
+		}})
+	}
+	return sinks, nil
+}
+
+// closeAll closes sinks opened before a failure
+func closeAll(sinks []Sink) {
+	for _, sink := range sinks {
+		sink.Close()
+	}
+}
@@ -0,0 +1,120 @@
+package vibelogger
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestParseCategoryRoutes(t *testing.T) {
+	routes, err := ParseCategoryRoutes(" audit=audit.log, database=diagnostics.log,security=audit.log ")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := []CategoryRoute{
+		{Category: "audit", File: "audit.log"},
+		{Category: "database", File: "diagnostics.log"},
+		{Category: "security", File: "audit.log"},
+	}
+	if !reflect.DeepEqual(routes, expected) {
+		t.Errorf("Expected %v, got %v", expected, routes)
+	}
+
+	if routes, err := ParseCategoryRoutes(""); err != nil || routes != nil {
+		t.Errorf("Expected no routes for an empty value, got %v %v", routes, err)
+	}
+
+	invalid := []string{
+		"audit",
+		"audit=",
+		"=audit.log",
+		"audit=../audit.log",
+		"audit=logs/audit.log",
+		"audit=.hidden",
+		"au dit=audit.log",
+		"audit=a.log,audit=b.log",
+	}
+	for _, value := range invalid {
+		if _, err := ParseCategoryRoutes(value); err == nil {
+			t.Errorf("Expected error for %q", value)
+		}
+	}
+}
+
+func TestCategoryRoutesFromEnvironment(t *testing.T) {
+	t.Setenv("VIBE_LOG_CATEGORY_ROUTES", "audit=../../etc/passwd")
+	if _, err := NewConfigFromEnvironment(); err == nil {
+		t.Error("Expected invalid route file to be rejected")
+	}
+}
+
+func TestCategoryRouting(t *testing.T) {
+	defer os.RemoveAll("test_logs")
+
+	config := DefaultConfig()
+	config.FilePath = "test_logs/routing_test.log"
+	config.CategoryRoutes = "audit=audit.log,database=diagnostics.log"
+
+	logger, err := CreateFileLoggerWithConfig("routing_test", config)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	logger.Info("permission_change", "Granted admin role", WithCategory("audit"))
+	logger.Warn("db_query", "Slow query detected")
+	logger.Info("startup", "Service started")
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Failed to close logger: %v", err)
+	}
+
+	main, err := ReadLogFile(config.FilePath)
+	if err != nil {
+		t.Fatalf("Failed to read main file: %v", err)
+	}
+	if len(main.Entries) != 3 {
+		t.Errorf("Expected all 3 entries in the main file, got %d", len(main.Entries))
+	}
+
+	audit, err := ReadLogFile("test_logs/audit.log")
+	if err != nil {
+		t.Fatalf("Failed to read audit file: %v", err)
+	}
+	if len(audit.Entries) != 1 || audit.Entries[0].Category != "audit" {
+		t.Errorf("Expected only the audit entry in the audit file, got %v", audit.Entries)
+	}
+	if !audit.CleanShutdown() {
+		t.Error("Expected the audit file to be closed cleanly")
+	}
+
+	diagnostics, err := ReadLogFile("test_logs/diagnostics.log")
+	if err != nil {
+		t.Fatalf("Failed to read diagnostics file: %v", err)
+	}
+	if len(diagnostics.Entries) != 1 || diagnostics.Entries[0].Operation != "db_query" {
+		t.Errorf("Expected only the database entry in the diagnostics file, got %v", diagnostics.Entries)
+	}
+}
+
+func TestCategoryRouteConflictsWithMainFile(t *testing.T) {
+	defer os.RemoveAll("test_logs")
+
+	config := DefaultConfig()
+	config.FilePath = "test_logs/conflict.log"
+	config.CategoryRoutes = "audit=conflict.log"
+	if _, err := CreateFileLoggerWithConfig("conflict", config); err == nil {
+		t.Error("Expected a route to the main log file to be rejected")
+	}
+}
+
+func TestRouteCategories(t *testing.T) {
+	config := &LoggerConfig{AutoSave: false}
+	logger := NewLoggerWithConfig("route_sink_test", config)
+	sink := &recordingSink{}
+	logger.RouteCategories(sink, "audit")
+
+	logger.Info("login", "User signed in")
+	logger.Info("export", "Exported report", WithCategory("audit"))
+
+	if len(sink.entries) != 1 || sink.entries[0].Operation != "export" {
+		t.Errorf("Expected only the audit entry to be routed, got %v", sink.entries)
+	}
+}
@@ -0,0 +1,77 @@
+package vibelogger
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// RunbookLink points entries with a detected pattern or error code to the
+// document describing their remediation
+type RunbookLink struct {
+	Key string // Pattern such as auth_error, or the error_code context value
+	URL string
+}
+
+// ParseRunbookURLs parses a list such as
+// "auth_error=https://wiki.example.com/auth,E1042=https://wiki.example.com/e1042"
+func ParseRunbookURLs(s string) ([]RunbookLink, error) {
+	var links []RunbookLink
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		key, link, ok := strings.Cut(part, "=")
+		key, link = strings.TrimSpace(key), strings.TrimSpace(link)
+		if !ok || key == "" || link == "" {
+			return nil, fmt.Errorf("invalid runbook link %q (must be key=url)", part)
+		}
+		u, err := url.Parse(link)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("invalid runbook URL for %s: %s (must be an http or https URL)", key, link)
+		}
+		if seen[key] {
+			return nil, fmt.Errorf("duplicate runbook link for %s", key)
+		}
+		seen[key] = true
+		links = append(links, RunbookLink{Key: key, URL: link})
+	}
+	return links, nil
+}
+
+// runbookIndex maps keys to runbook URLs
+type runbookIndex map[string]string
+
+// newRunbookIndex builds the index from the configured links; invalid lists
+// are rejected by Validate and yield an empty index here
+func newRunbookIndex(s string) runbookIndex {
+	links, _ := ParseRunbookURLs(s)
+	if len(links) == 0 {
+		return nil
+	}
+	index := make(runbookIndex, len(links))
+	for _, link := range links {
+		index[link.Key] = link.URL
+	}
+	return index
+}
+
+// lookup returns the runbook for the entry's error code, falling back to its pattern
+func (idx runbookIndex) lookup(entry *LogEntry) string {
+	if code, ok := entry.Context["error_code"]; ok {
+		if link, ok := idx[fmt.Sprint(code)]; ok {
+			return link
+		}
+	}
+	return idx[entry.Pattern]
+}
+
+// runbookURL returns the configured runbook for the entry, if any
+func (l *Logger) runbookURL(entry *LogEntry) string {
+	l.mutex.Lock()
+	runbooks := l.runbooks
+	l.mutex.Unlock()
+	return runbooks.lookup(entry)
+}
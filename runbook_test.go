@@ -0,0 +1,55 @@
+package vibelogger
+
+import "testing"
+
+func TestParseRunbookURLs(t *testing.T) {
+	links, err := ParseRunbookURLs("auth_error=https://wiki.example.com/auth, E1042=https://wiki.example.com/page?id=1042")
+	if err != nil {
+		t.Fatalf("ParseRunbookURLs failed: %v", err)
+	}
+	if len(links) != 2 || links[1].Key != "E1042" || links[1].URL != "https://wiki.example.com/page?id=1042" {
+		t.Errorf("unexpected links: %v", links)
+	}
+
+	invalid := []string{
+		"auth_error",
+		"auth_error=wiki/auth",
+		"auth_error=ftp://wiki.example.com/auth",
+		"a=https://x.example.com,a=https://y.example.com",
+	}
+	for _, s := range invalid {
+		if _, err := ParseRunbookURLs(s); err == nil {
+			t.Errorf("expected error for %q", s)
+		}
+	}
+}
+
+func TestRunbookURLAttached(t *testing.T) {
+	logger := NewLoggerWithConfig("runbook", &LoggerConfig{
+		AutoSave:        false,
+		EnableMemoryLog: true,
+		MemoryLogLimit:  10,
+		RunbookURLs:     "auth_error=https://wiki.example.com/auth,E1042=https://wiki.example.com/e1042",
+	})
+	defer logger.Close()
+
+	logger.Warn("login", "Invalid token presented")
+	logger.Error("payment", "Card declined", WithContext(map[string]interface{}{"error_code": "E1042"}))
+	logger.Info("startup", "Service ready")
+
+	logs := logger.GetMemoryLogs()
+	expected := []string{"https://wiki.example.com/auth", "https://wiki.example.com/e1042", ""}
+	for i, url := range expected {
+		if logs[i].RunbookURL != url {
+			t.Errorf("entry %d: runbook_url = %q, expected %q", i, logs[i].RunbookURL, url)
+		}
+	}
+}
+
+func TestRunbookURLsValidation(t *testing.T) {
+	config := DefaultConfig()
+	config.RunbookURLs = "auth_error=not a url"
+	if err := config.Validate(); err == nil {
+		t.Error("expected error for invalid runbook URL")
+	}
+}
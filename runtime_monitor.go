@@ -0,0 +1,141 @@
+package vibelogger
+
+import (
+	"fmt"
+	"math"
+	"runtime/metrics"
+	"time"
+)
+
+// runtime/metrics histograms sampled by the runtime monitor
+const (
+	metricGCPauses      = "/sched/pauses/total/gc:seconds" // Go 1.22+
+	metricGCPausesOld   = "/gc/pauses:seconds"
+	metricSchedLatency  = "/sched/latencies:seconds"
+	runtimeMonitorEvent = "runtime_monitor"
+)
+
+// RuntimeThresholds are the latencies above which the runtime monitor logs a warning.
+// A zero threshold disables the corresponding check.
+type RuntimeThresholds struct {
+	GCPause      time.Duration
+	SchedLatency time.Duration
+}
+
+// runtimeMonitor remembers the previous histogram samples so that only
+// pauses observed since the last check are reported
+type runtimeMonitor struct {
+	logger     *Logger
+	thresholds RuntimeThresholds
+	samples    []metrics.Sample
+	previous   map[string][]uint64
+}
+
+// StartRuntimeMonitor samples runtime/metrics every interval and logs a WARN entry
+// when a GC pause or goroutine scheduling latency above the thresholds was observed
+// since the previous sample. The entries use the performance_issue pattern.
+// Calling it again replaces the previous monitor; a non-positive interval only stops it.
+func (l *Logger) StartRuntimeMonitor(interval time.Duration, thresholds RuntimeThresholds) {
+	l.StopRuntimeMonitor()
+	if interval <= 0 {
+		return
+	}
+
+	monitor := newRuntimeMonitor(l, thresholds)
+	monitor.check() // Establish the baseline
+
+	task := startPeriodicTask(interval, monitor.check)
+	l.bgMutex.Lock()
+	l.runtimeMonitor = task
+	l.bgMutex.Unlock()
+}
+
+// StopRuntimeMonitor stops the runtime monitor if one is running
+func (l *Logger) StopRuntimeMonitor() {
+	l.bgMutex.Lock()
+	task := l.runtimeMonitor
+	l.runtimeMonitor = nil
+	l.bgMutex.Unlock()
+
+	task.Stop()
+}
+
+// newRuntimeMonitor creates a monitor for the metrics supported by this Go version
+func newRuntimeMonitor(l *Logger, thresholds RuntimeThresholds) *runtimeMonitor {
+	supported := make(map[string]bool)
+	for _, desc := range metrics.All() {
+		supported[desc.Name] = desc.Kind == metrics.KindFloat64Histogram
+	}
+
+	m := &runtimeMonitor{
+		logger:     l,
+		thresholds: thresholds,
+		previous:   make(map[string][]uint64),
+	}
+	switch {
+	case supported[metricGCPauses]:
+		m.samples = append(m.samples, metrics.Sample{Name: metricGCPauses})
+	case supported[metricGCPausesOld]:
+		m.samples = append(m.samples, metrics.Sample{Name: metricGCPausesOld})
+	}
+	if supported[metricSchedLatency] {
+		m.samples = append(m.samples, metrics.Sample{Name: metricSchedLatency})
+	}
+	return m
+}
+
+// check reads the histograms and reports latencies above the thresholds
+func (m *runtimeMonitor) check() {
+	metrics.Read(m.samples)
+
+	for _, sample := range m.samples {
+		hist := sample.Value.Float64Histogram()
+		previous := m.previous[sample.Name]
+		m.previous[sample.Name] = append([]uint64(nil), hist.Counts...)
+		if previous == nil {
+			continue
+		}
+
+		threshold, kind := m.thresholds.GCPause, "GC pause"
+		if sample.Name == metricSchedLatency {
+			threshold, kind = m.thresholds.SchedLatency, "scheduling latency"
+		}
+		if threshold <= 0 {
+			continue
+		}
+
+		count, worst := countAbove(hist, previous, threshold.Seconds())
+		if count == 0 {
+			continue
+		}
+		worstDuration := time.Duration(worst * float64(time.Second))
+		m.logger.Warn(runtimeMonitorEvent,
+			fmt.Sprintf("High %s: %d observed above %s (up to %s)", kind, count, threshold, worstDuration),
+			WithContext(map[string]interface{}{
+				"metric":       sample.Name,
+				"threshold_ms": threshold.Milliseconds(),
+				"count":        count,
+				"max_ms":       worstDuration.Milliseconds(),
+			}))
+	}
+}
+
+// countAbove returns the number of new observations in buckets entirely above
+// threshold seconds and the lower bound of the highest such bucket
+func countAbove(hist *metrics.Float64Histogram, previous []uint64, threshold float64) (uint64, float64) {
+	var count uint64
+	worst := 0.0
+	for i, c := range hist.Counts {
+		if i >= len(previous) || c <= previous[i] {
+			continue
+		}
+		// Bucket i covers [Buckets[i], Buckets[i+1])
+		lower := hist.Buckets[i]
+		if lower < threshold || math.IsInf(lower, -1) {
+			continue
+		}
+		count += c - previous[i]
+		worst = lower
+	}
+	return count, worst
+}
@@ -0,0 +1,57 @@
+package vibelogger
+
+import (
+	"math"
+	"runtime"
+	"runtime/metrics"
+	"testing"
+	"time"
+)
+
+func TestCountAbove(t *testing.T) {
+	hist := &metrics.Float64Histogram{
+		Counts:  []uint64{5, 3, 4, 2},
+		Buckets: []float64{math.Inf(-1), 0.001, 0.01, 0.1, math.Inf(1)},
+	}
+	previous := []uint64{1, 3, 1, 1}
+
+	count, worst := countAbove(hist, previous, 0.01)
+	if count != 4 {
+		t.Errorf("Expected 4 new observations above threshold, got %d", count)
+	}
+	if worst != 0.1 {
+		t.Errorf("Expected worst bucket 0.1, got %v", worst)
+	}
+
+	if count, _ := countAbove(hist, hist.Counts, 0); count != 0 {
+		t.Errorf("Expected no new observations, got %d", count)
+	}
+}
+
+func TestRuntimeMonitorReportsGCPause(t *testing.T) {
+	logger := NewLoggerWithConfig("runtime_monitor_test", &LoggerConfig{EnableMemoryLog: true})
+
+	monitor := newRuntimeMonitor(logger, RuntimeThresholds{GCPause: time.Nanosecond})
+	monitor.check()
+	if len(logger.GetMemoryLogs()) != 0 {
+		t.Fatal("Expected no entries for the baseline sample")
+	}
+
+	runtime.GC()
+	monitor.check()
+
+	logs := logger.GetMemoryLogs()
+	if len(logs) != 1 {
+		t.Fatalf("Expected 1 GC pause warning, got %d entries", len(logs))
+	}
+	entry := logs[0]
+	if entry.Level != WARN || entry.Pattern != "performance_issue" {
+		t.Errorf("Expected WARN performance_issue entry, got %s %q", entry.Level, entry.Pattern)
+	}
+	if entry.Context["metric"] != metricGCPauses {
+		t.Errorf("Expected metric %s, got %v", metricGCPauses, entry.Context["metric"])
+	}
+	if entry.Suggestion == "" {
+		t.Error("Expected a suggestion for GC pauses")
+	}
+}
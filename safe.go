@@ -0,0 +1,56 @@
+package vibelogger
+
+import (
+	"fmt"
+	"runtime/debug"
+	"strings"
+)
+
+// InternalOperation is the operation of entries the logger writes about its own failures
+const InternalOperation = "vibelogger_internal"
+
+// PanicError is returned in place of a panic raised by a user-supplied function
+// such as a LogOption, Sink or ConfigValidator
+type PanicError struct {
+	Source string      // Kind of function that panicked, e.g. "log_option"
+	Value  interface{} // Value passed to panic
+	Stack  []string    // Stack of the panicking goroutine
+}
+
+// Error implements error
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("%s panicked: %v", e.Source, e.Value)
+}
+
+// callSafely runs fn and converts a panic into a *PanicError
+func callSafely(source string, fn func()) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &PanicError{Source: source, Value: r, Stack: panicStack()}
+		}
+	}()
+	fn()
+	return nil
+}
+
+// panicStack returns the stack of a recovered panic, one line per element
+func panicStack() []string {
+	var lines []string
+	for _, line := range strings.Split(string(debug.Stack()), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// logPanic writes an internal ERROR entry describing a recovered panic.
+// It must not be called with the logger's mutex held.
+func (l *Logger) logPanic(p *PanicError) {
+	l.Log(ERROR, InternalOperation, p.Error(),
+		withStackTrace(p.Stack),
+		WithContext(map[string]interface{}{
+			"panic":        fmt.Sprint(p.Value),
+			"panic_source": p.Source,
+		}))
+}
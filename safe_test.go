@@ -0,0 +1,61 @@
+package vibelogger
+
+import (
+	"errors"
+	"testing"
+)
+
+type panickingSink struct{}
+
+func (panickingSink) Write(entry *LogEntry) error { panic("sink exploded") }
+func (panickingSink) Close() error                { panic("close exploded") }
+
+func TestPanickingOptionIsRecovered(t *testing.T) {
+	logger := NewLoggerWithConfig("safe_test", &LoggerConfig{EnableMemoryLog: true, MemoryLogLimit: 10})
+
+	explode := func(entry *LogEntry) { panic("option exploded") }
+	err := logger.Info("test", "Entry with a broken option",
+		WithContext(map[string]interface{}{"kept": true}), explode)
+	if err != nil {
+		t.Fatalf("Expected the entry to be written, got %v", err)
+	}
+
+	logs := logger.GetMemoryLogs()
+	if len(logs) != 2 {
+		t.Fatalf("Expected the entry and an internal error entry, got %d entries", len(logs))
+	}
+	if logs[0].Message != "Entry with a broken option" || logs[0].Context["kept"] != true {
+		t.Errorf("Expected the entry with the working options applied, got %+v", logs[0])
+	}
+
+	internal := logs[1]
+	if internal.Level != ERROR || internal.Operation != InternalOperation {
+		t.Fatalf("Expected internal ERROR entry, got %s %s", internal.Level, internal.Operation)
+	}
+	if internal.Context["panic"] != "option exploded" || internal.Context["panic_source"] != "log_option" {
+		t.Errorf("Unexpected panic context: %v", internal.Context)
+	}
+	if len(internal.StackTrace) == 0 {
+		t.Error("Expected the stack of the panic")
+	}
+}
+
+func TestPanickingSinkIsRecovered(t *testing.T) {
+	logger := NewLoggerWithConfig("safe_test", &LoggerConfig{})
+	recorder := &recordingSink{}
+	logger.AddSink(panickingSink{})
+	logger.AddSink(recorder)
+
+	err := logger.Info("test", "Entry")
+	var panicErr *PanicError
+	if !errors.As(err, &panicErr) || panicErr.Value != "sink exploded" {
+		t.Fatalf("Expected a PanicError from the sink, got %v", err)
+	}
+	if len(recorder.Entries()) != 1 {
+		t.Error("Expected later sinks to still receive the entry")
+	}
+
+	if err := logger.Close(); !errors.As(err, &panicErr) {
+		t.Errorf("Expected a PanicError from closing the sink, got %v", err)
+	}
+}
@@ -0,0 +1,158 @@
+package vibelogger
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// Control character handling modes
+const (
+	ControlCharsKeep   = "keep"   // Strings are written as given (default)
+	ControlCharsStrip  = "strip"  // Line breaks and tabs become spaces, other control characters are removed
+	ControlCharsEscape = "escape" // Control characters are replaced by visible escapes such as \n or \x1b
+)
+
+// isValidControlChars checks if the control character mode is supported
+func isValidControlChars(mode string) bool {
+	return mode == "" || mode == ControlCharsKeep || mode == ControlCharsStrip || mode == ControlCharsEscape
+}
+
+// stringSanitizer rewrites strings according to the control character setting
+type stringSanitizer struct {
+	controlChars string
+}
+
+// newStringSanitizer returns a sanitizer for the configuration, or nil if it would change nothing
+func newStringSanitizer(config *LoggerConfig) *stringSanitizer {
+	mode := config.ControlChars
+	if mode == ControlCharsKeep || mode == "" {
+		return nil
+	}
+	return &stringSanitizer{controlChars: mode}
+}
+
+// sanitizeEntry applies the sanitizer to the free-text fields and context strings of an entry
+func (s *stringSanitizer) sanitizeEntry(entry *LogEntry) {
+	entry.Operation = s.sanitize(entry.Operation)
+	entry.Message = s.sanitize(entry.Message)
+	for i, line := range entry.MessageLines {
+		entry.MessageLines[i] = s.sanitize(line)
+	}
+	entry.HumanNote = s.sanitize(entry.HumanNote)
+	entry.AITodo = s.sanitize(entry.AITodo)
+	for i, tag := range entry.Tags {
+		entry.Tags[i] = s.sanitize(tag)
+	}
+	for key, value := range entry.Context {
+		entry.Context[key] = s.sanitizeValue(value)
+	}
+}
+
+// sanitizeValue sanitizes strings, including those nested in maps and slices
+func (s *stringSanitizer) sanitizeValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		return s.sanitize(v)
+	case []string:
+		sanitized := make([]string, len(v))
+		for i, item := range v {
+			sanitized[i] = s.sanitize(item)
+		}
+		return sanitized
+	case []interface{}:
+		sanitized := make([]interface{}, len(v))
+		for i, item := range v {
+			sanitized[i] = s.sanitizeValue(item)
+		}
+		return sanitized
+	case map[string]interface{}:
+		sanitized := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			sanitized[key] = s.sanitizeValue(item)
+		}
+		return sanitized
+	}
+	return value
+}
+
+// sanitize rewrites a single string
+func (s *stringSanitizer) sanitize(str string) string {
+	if !s.needsRewrite(str) {
+		return str
+	}
+
+	var b strings.Builder
+	b.Grow(len(str))
+	for _, r := range str {
+		switch {
+		case unicode.IsControl(r) && s.controlChars == ControlCharsStrip:
+			if r == '\n' || r == '\r' || r == '\t' {
+				b.WriteByte(' ')
+			}
+		case unicode.IsControl(r) && s.controlChars == ControlCharsEscape:
+			b.WriteString(escapeControl(r))
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// needsRewrite avoids allocating for strings that are already clean
+func (s *stringSanitizer) needsRewrite(str string) bool {
+	for _, r := range str {
+		if unicode.IsControl(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// escapeControl returns the visible escape of a control character
+func escapeControl(r rune) string {
+	switch r {
+	case '\n':
+		return `\n`
+	case '\r':
+		return `\r`
+	case '\t':
+		return `\t`
+	}
+	if r < 0x100 {
+		return fmt.Sprintf(`\x%02x`, r)
+	}
+	return fmt.Sprintf(`\u%04x`, r)
+}
+
+// escapeNonASCII replaces the non-ASCII characters of encoded JSON by \uXXXX
+// escapes, see LoggerConfig.EscapeNonASCII. They only occur inside JSON
+// strings, so the result decodes to the same values.
+func escapeNonASCII(data []byte) []byte {
+	i := 0
+	for i < len(data) && data[i] < utf8.RuneSelf {
+		i++
+	}
+	if i == len(data) {
+		return data
+	}
+
+	const hex = "0123456789abcdef"
+	escaped := make([]byte, i, len(data)+32)
+	copy(escaped, data[:i])
+	for i < len(data) {
+		if data[i] < utf8.RuneSelf {
+			escaped = append(escaped, data[i])
+			i++
+			continue
+		}
+		r, size := utf8.DecodeRune(data[i:])
+		for _, unit := range utf16.Encode([]rune{r}) {
+			escaped = append(escaped, '\\', 'u', hex[unit>>12&0xf], hex[unit>>8&0xf], hex[unit>>4&0xf], hex[unit&0xf])
+		}
+		i += size
+	}
+	return escaped
+}
@@ -0,0 +1,112 @@
+package vibelogger
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestStringSanitizer(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   LoggerConfig
+		input    string
+		expected string
+	}{
+		{"strip", LoggerConfig{ControlChars: ControlCharsStrip}, "line1\nline2\ttab\x1b[31mred\x00", "line1 line2 tab[31mred"},
+		{"escape", LoggerConfig{ControlChars: ControlCharsEscape}, "line1\r\nline2\x1b", `line1\r\nline2\x1b`},
+		{"clean string", LoggerConfig{ControlChars: ControlCharsEscape}, "plain text", "plain text"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sanitizer := newStringSanitizer(&tt.config)
+			if got := sanitizer.sanitize(tt.input); got != tt.expected {
+				t.Errorf("sanitize(%q) = %q, expected %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+
+	if newStringSanitizer(&LoggerConfig{ControlChars: ControlCharsKeep}) != nil {
+		t.Error("Expected no sanitizer when nothing is rewritten")
+	}
+}
+
+func TestEscapeNonASCII(t *testing.T) {
+	config := DefaultConfig()
+	config.FilePath = filepath.Join(t.TempDir(), "app.log")
+	config.ConsoleOutput = ConsoleOff
+	config.EscapeNonASCII = true
+	logger, err := CreateFileLoggerWithConfig("escape_test", config)
+	if err != nil {
+		t.Fatalf("failed to create logger: %v", err)
+	}
+	logger.Info("menu", "café 😀", WithContext(map[string]interface{}{"dish": "crème\nbrûlée"}))
+	if err := logger.Close(); err != nil {
+		t.Fatalf("failed to close logger: %v", err)
+	}
+
+	// The file is plain ASCII with a single JSON escape per character
+	data, err := os.ReadFile(config.FilePath)
+	if err != nil {
+		t.Fatalf("failed to read log file: %v", err)
+	}
+	if !strings.Contains(string(data), `"caf\u00e9 \ud83d\ude00"`) || !strings.Contains(string(data), `cr\u00e8me\nbr\u00fbl\u00e9e`) {
+		t.Errorf("expected escaped non-ASCII characters, got %s", data)
+	}
+	for _, b := range data {
+		if b >= 0x80 {
+			t.Fatalf("expected an ASCII-only file, got %s", data)
+		}
+	}
+
+	// Readers decode the original text
+	result, err := ReadLogFile(config.FilePath)
+	if err != nil || len(result.Entries) != 1 {
+		t.Fatalf("expected one entry, got %+v (%v)", result, err)
+	}
+	if entry := result.Entries[0]; entry.Message != "café 😀" || entry.Context["dish"] != "crème\nbrûlée" {
+		t.Errorf("expected the original text after decoding, got %q %v", entry.Message, entry.Context)
+	}
+
+	if got := string(escapeNonASCII([]byte(`{"a":"plain"}`))); got != `{"a":"plain"}` {
+		t.Errorf("expected ASCII JSON unchanged, got %s", got)
+	}
+}
+
+func TestSanitizedEntries(t *testing.T) {
+	logger := NewLoggerWithConfig("sanitize_test", &LoggerConfig{
+		EnableMemoryLog: true,
+		MemoryLogLimit:  10,
+		ControlChars:    ControlCharsEscape,
+	})
+
+	payload := map[string]interface{}{"body": "a\nb"}
+	logger.Info("webhook", "Received\npayload", WithContext(map[string]interface{}{
+		"raw":     "x\ny",
+		"payload": payload,
+		"lines":   []string{"1\n", "2"},
+		"count":   2,
+	}))
+
+	entry := logger.GetMemoryLogs()[0]
+	if entry.Message != `Received\npayload` {
+		t.Errorf("Expected escaped message, got %q", entry.Message)
+	}
+	if entry.Context["raw"] != `x\ny` {
+		t.Errorf("Expected escaped context string, got %q", entry.Context["raw"])
+	}
+	if nested := entry.Context["payload"].(map[string]interface{}); nested["body"] != `a\nb` {
+		t.Errorf("Expected nested strings to be escaped, got %q", nested["body"])
+	}
+	if payload["body"] != "a\nb" {
+		t.Error("Expected the caller's map not to be modified")
+	}
+	if lines := entry.Context["lines"].([]string); lines[0] != `1\n` {
+		t.Errorf("Expected string slices to be escaped, got %q", lines)
+	}
+	if entry.Context["count"] != 2 {
+		t.Errorf("Expected non-string values to be kept, got %v", entry.Context["count"])
+	}
+}
@@ -0,0 +1,162 @@
+package vibelogger
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+// FieldType is the expected type of a context field
+type FieldType string
+
+// Context field types
+const (
+	FieldString FieldType = "string"
+	FieldInt    FieldType = "int"
+	FieldFloat  FieldType = "float"
+	FieldBool   FieldType = "bool"
+)
+
+// Handling of context values that do not match the schema
+const (
+	SchemaCoerce = "coerce" // Convert values where possible; others are kept and listed in schema_errors (default)
+	SchemaReject = "reject" // Entries with mismatches are not written and Log returns an *EntryValidationError
+)
+
+// ContextSchema maps context keys to their expected types, so invariants such as
+// "user_id is always a string" hold across a codebase. Keys not in the schema
+// are not checked.
+type ContextSchema map[string]FieldType
+
+// isValidSchemaMode checks if the schema mode is supported
+func isValidSchemaMode(mode string) bool {
+	return mode == "" || mode == SchemaCoerce || mode == SchemaReject
+}
+
+// SetContextSchema checks the context of every entry against schema according
+// to LoggerConfig.ContextSchemaMode. A nil schema disables the check.
+func (l *Logger) SetContextSchema(schema ContextSchema) error {
+	for key, typ := range schema {
+		switch typ {
+		case FieldString, FieldInt, FieldFloat, FieldBool:
+		default:
+			return fmt.Errorf("unsupported type %q for context key %q", typ, key)
+		}
+	}
+
+	copied := make(ContextSchema, len(schema))
+	for key, typ := range schema {
+		copied[key] = typ
+	}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if len(copied) == 0 {
+		copied = nil
+	}
+	l.schema = copied
+	return nil
+}
+
+// applySchema checks the entry's context and returns the mismatches. With
+// coerce set, convertible values are replaced by their converted form and the
+// remaining mismatches are listed in the schema_errors context field.
+func (l *Logger) applySchema(entry *LogEntry, coerce bool) []string {
+	l.mutex.Lock()
+	schema := l.schema
+	l.mutex.Unlock()
+	if schema == nil || len(entry.Context) == 0 {
+		return nil
+	}
+
+	// Sorted keys keep the problem list stable
+	keys := make([]string, 0, len(entry.Context))
+	for key := range entry.Context {
+		if _, ok := schema[key]; ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var problems []string
+	for _, key := range keys {
+		value := entry.Context[key]
+		converted, exact, ok := convertField(value, schema[key])
+		if exact {
+			continue
+		}
+		if coerce && ok {
+			entry.Context[key] = converted
+			continue
+		}
+		problems = append(problems, fmt.Sprintf("context %q must be %s, got %T", key, schema[key], value))
+	}
+	if coerce && len(problems) > 0 {
+		entry.Context["schema_errors"] = problems
+	}
+	return problems
+}
+
+// convertField converts value to typ. exact reports that value already has the
+// type, ok that the conversion succeeded.
+func convertField(value interface{}, typ FieldType) (converted interface{}, exact, ok bool) {
+	v := reflect.ValueOf(value)
+	switch typ {
+	case FieldString:
+		if s, isString := value.(string); isString {
+			return s, true, true
+		}
+		if value == nil {
+			return nil, false, false
+		}
+		switch v.Kind() {
+		case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+			return fmt.Sprint(value), false, true
+		}
+		if s, isStringer := value.(fmt.Stringer); isStringer {
+			return s.String(), false, true
+		}
+	case FieldInt:
+		switch v.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return value, true, true
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return value, true, true
+		case reflect.Float32, reflect.Float64:
+			f := v.Float()
+			if f == math.Trunc(f) && math.Abs(f) < 1<<53 {
+				return int64(f), false, true
+			}
+		case reflect.String:
+			if n, err := strconv.ParseInt(v.String(), 10, 64); err == nil {
+				return n, false, true
+			}
+		}
+	case FieldFloat:
+		switch v.Kind() {
+		case reflect.Float32, reflect.Float64:
+			return value, true, true
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return float64(v.Int()), false, true
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return float64(v.Uint()), false, true
+		case reflect.String:
+			if f, err := strconv.ParseFloat(v.String(), 64); err == nil && !math.IsNaN(f) && !math.IsInf(f, 0) {
+				return f, false, true
+			}
+		}
+	case FieldBool:
+		switch v.Kind() {
+		case reflect.Bool:
+			return value, true, true
+		case reflect.String:
+			if b, err := strconv.ParseBool(v.String()); err == nil {
+				return b, false, true
+			}
+		}
+	}
+	return nil, false, false
+}
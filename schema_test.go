@@ -0,0 +1,110 @@
+package vibelogger
+
+import (
+	"errors"
+	"testing"
+)
+
+func newSchemaLogger(t *testing.T, mode string) *Logger {
+	t.Helper()
+	logger := NewLoggerWithConfig("schema", &LoggerConfig{
+		AutoSave:          false,
+		EnableMemoryLog:   true,
+		MemoryLogLimit:    10,
+		ContextSchemaMode: mode,
+	})
+	t.Cleanup(func() { logger.Close() })
+
+	err := logger.SetContextSchema(ContextSchema{
+		"user_id":  FieldString,
+		"attempts": FieldInt,
+		"ratio":    FieldFloat,
+		"admin":    FieldBool,
+	})
+	if err != nil {
+		t.Fatalf("SetContextSchema failed: %v", err)
+	}
+	return logger
+}
+
+func TestContextSchemaCoerce(t *testing.T) {
+	logger := newSchemaLogger(t, "")
+
+	err := logger.Info("login", "User logged in", WithContext(map[string]interface{}{
+		"user_id":  42,
+		"attempts": "3",
+		"ratio":    1,
+		"admin":    "true",
+		"other":    []int{1},
+	}))
+	if err != nil {
+		t.Fatalf("Info failed: %v", err)
+	}
+
+	ctx := logger.GetMemoryLogs()[0].Context
+	if ctx["user_id"] != "42" {
+		t.Errorf("user_id = %#v, want \"42\"", ctx["user_id"])
+	}
+	if ctx["attempts"] != int64(3) {
+		t.Errorf("attempts = %#v, want int64(3)", ctx["attempts"])
+	}
+	if ctx["ratio"] != float64(1) {
+		t.Errorf("ratio = %#v, want 1.0", ctx["ratio"])
+	}
+	if ctx["admin"] != true {
+		t.Errorf("admin = %#v, want true", ctx["admin"])
+	}
+	if _, ok := ctx["schema_errors"]; ok {
+		t.Errorf("unexpected schema_errors: %v", ctx["schema_errors"])
+	}
+}
+
+func TestContextSchemaCoerceFailure(t *testing.T) {
+	logger := newSchemaLogger(t, SchemaCoerce)
+
+	logger.Info("login", "User logged in", WithContext(map[string]interface{}{
+		"attempts": "many",
+		"ratio":    2.5,
+	}))
+
+	ctx := logger.GetMemoryLogs()[0].Context
+	if ctx["attempts"] != "many" {
+		t.Errorf("unconvertible value should be kept, got %#v", ctx["attempts"])
+	}
+	problems, ok := ctx["schema_errors"].([]string)
+	if !ok || len(problems) != 1 {
+		t.Fatalf("schema_errors = %#v, want one problem", ctx["schema_errors"])
+	}
+}
+
+func TestContextSchemaReject(t *testing.T) {
+	logger := newSchemaLogger(t, SchemaReject)
+
+	err := logger.Info("login", "User logged in", WithContext(map[string]interface{}{"user_id": 42}))
+	var validationErr *EntryValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected EntryValidationError, got %v", err)
+	}
+	if len(logger.GetMemoryLogs()) != 0 {
+		t.Error("rejected entry should not be written")
+	}
+
+	if err := logger.Info("login", "User logged in", WithContext(map[string]interface{}{"user_id": "42"})); err != nil {
+		t.Errorf("matching entry rejected: %v", err)
+	}
+}
+
+func TestSetContextSchemaInvalidType(t *testing.T) {
+	logger := newSchemaLogger(t, "")
+	if err := logger.SetContextSchema(ContextSchema{"user_id": "uuid"}); err == nil {
+		t.Error("expected error for unsupported type")
+	}
+}
+
+func TestContextSchemaModeValidation(t *testing.T) {
+	config := DefaultConfig()
+	config.ContextSchemaMode = "drop"
+	if err := config.Validate(); err == nil {
+		t.Error("expected error for invalid schema mode")
+	}
+}
@@ -0,0 +1,54 @@
+package vibelogger
+
+// ScopedLogger writes through a Logger with options bound to every entry, such
+// as the correlation ID of one request. It owns no resources and needs no Close.
+type ScopedLogger struct {
+	logger   *Logger
+	parent   *ScopedLogger // Scoped logger this one was derived from, nil below the Logger
+	options  []LogOption
+	settings *ChildSettings // Overrides set with WithSettings, nil to inherit everything
+}
+
+// With returns a scoped logger that applies options to every entry
+func (l *Logger) With(options ...LogOption) *ScopedLogger {
+	return &ScopedLogger{logger: l, options: options}
+}
+
+// With returns a nested scoped logger with additional options
+func (s *ScopedLogger) With(options ...LogOption) *ScopedLogger {
+	bound := make([]LogOption, 0, len(s.options)+len(options))
+	bound = append(bound, s.options...)
+	return &ScopedLogger{logger: s.logger, parent: s, options: append(bound, options...)}
+}
+
+// Logger returns the logger the scoped logger writes to
+func (s *ScopedLogger) Logger() *Logger {
+	return s.logger
+}
+
+// Log writes an entry; bound options are applied before the given ones
+func (s *ScopedLogger) Log(level LogLevel, operation, message string, options ...LogOption) error {
+	all := make([]LogOption, 0, len(s.options)+len(options))
+	all = append(all, s.options...)
+	return s.logger.log(s, level, operation, message, append(all, options...)...)
+}
+
+// Info logs an info level message
+func (s *ScopedLogger) Info(operation, message string, options ...LogOption) error {
+	return s.Log(INFO, operation, message, options...)
+}
+
+// Warn logs a warning level message
+func (s *ScopedLogger) Warn(operation, message string, options ...LogOption) error {
+	return s.Log(WARN, operation, message, options...)
+}
+
+// Error logs an error level message
+func (s *ScopedLogger) Error(operation, message string, options ...LogOption) error {
+	return s.Log(ERROR, operation, message, options...)
+}
+
+// Debug logs a debug level message
+func (s *ScopedLogger) Debug(operation, message string, options ...LogOption) error {
+	return s.Log(DEBUG, operation, message, options...)
+}
@@ -0,0 +1,38 @@
+package vibelogger
+
+import "testing"
+
+func TestScopedLogger(t *testing.T) {
+	logger := NewLoggerWithConfig("scoped_test", &LoggerConfig{
+		AutoSave:        false,
+		EnableMemoryLog: true,
+		MemoryLogLimit:  10,
+	})
+
+	request := logger.With(WithCorrelationID("req-1"), WithUserID("alice"))
+	step := request.With(WithContext(map[string]interface{}{"step": "charge"}))
+
+	request.Info("checkout", "Started")
+	step.Error("checkout", "Card declined", WithCorrelationID("override"))
+	logger.Info("checkout", "Unscoped")
+
+	logs := logger.GetMemoryLogs()
+	if len(logs) != 3 {
+		t.Fatalf("Expected 3 entries, got %d", len(logs))
+	}
+	if logs[0].CorrelationID != "req-1" || logs[0].Context["user_id"] != "alice" || logs[0].Context["step"] != nil {
+		t.Errorf("Unexpected scoped entry: %+v", logs[0])
+	}
+	if logs[1].Level != ERROR || logs[1].Context["step"] != "charge" || logs[1].Context["user_id"] != "alice" {
+		t.Errorf("Expected nested options to be combined, got %+v", logs[1])
+	}
+	if logs[1].CorrelationID != "override" {
+		t.Errorf("Expected call options to override bound ones, got %q", logs[1].CorrelationID)
+	}
+	if logs[2].CorrelationID != "" {
+		t.Error("Expected the parent logger to be unaffected")
+	}
+	if step.Logger() != logger {
+		t.Error("Expected Logger to return the parent")
+	}
+}
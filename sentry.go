@@ -0,0 +1,226 @@
+package vibelogger
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// SentrySink forwards ERROR entries to a Sentry-compatible service such as
+// Sentry or GlitchTip. The local log files remain the source of truth; wrap the
+// sink in an AsyncSink to keep network latency out of the logging path.
+type SentrySink struct {
+	endpoint    string
+	auth        string
+	environment string
+	release     string
+	client      *http.Client
+}
+
+// SentryOption customizes a SentrySink
+type SentryOption func(*SentrySink)
+
+// WithSentryEnvironment sets the environment reported with each event
+func WithSentryEnvironment(environment string) SentryOption {
+	return func(s *SentrySink) {
+		s.environment = environment
+	}
+}
+
+// WithSentryRelease overrides the release, which defaults to the VCS revision
+// or module version from the build information
+func WithSentryRelease(release string) SentryOption {
+	return func(s *SentrySink) {
+		s.release = release
+	}
+}
+
+// NewSentrySink creates a sink for a DSN of the form
+// https://<public_key>@<host>/<project_id>
+func NewSentrySink(dsn string, options ...SentryOption) (*SentrySink, error) {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Sentry DSN: %w", err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || u.User == nil || u.User.Username() == "" {
+		return nil, fmt.Errorf("invalid Sentry DSN: must be http(s)://<public_key>@<host>/<project_id>")
+	}
+	path := strings.Trim(u.Path, "/")
+	slash := strings.LastIndex(path, "/")
+	prefix, project := "", path
+	if slash >= 0 {
+		prefix, project = "/"+path[:slash], path[slash+1:]
+	}
+	if project == "" {
+		return nil, fmt.Errorf("invalid Sentry DSN: missing project ID")
+	}
+
+	s := &SentrySink{
+		endpoint: fmt.Sprintf("%s://%s%s/api/%s/store/", u.Scheme, u.Host, prefix, project),
+		auth: fmt.Sprintf("Sentry sentry_version=7, sentry_client=vibe-logger-go/%s, sentry_key=%s",
+			GetVersion(), u.User.Username()),
+		release: defaultSentryRelease(),
+		client:  &http.Client{Timeout: DefaultHTTPSinkTimeout},
+	}
+	if secret, ok := u.User.Password(); ok && secret != "" {
+		s.auth += ", sentry_secret=" + secret
+	}
+	for _, opt := range options {
+		opt(s)
+	}
+	return s, nil
+}
+
+// defaultSentryRelease derives the release from the build information
+func defaultSentryRelease() string {
+	info := GetBuildInfo()
+	if info.VCSRevision != "" {
+		return info.VCSRevision
+	}
+	if info.ModuleVersion != "" && info.ModuleVersion != "(devel)" {
+		return info.ModuleVersion
+	}
+	return ""
+}
+
+// sentryFrame is a stack frame in the Sentry event format
+type sentryFrame struct {
+	Filename string `json:"filename"`
+	Function string `json:"function"`
+	Lineno   int    `json:"lineno"`
+	InApp    bool   `json:"in_app"`
+}
+
+// sentryEvent is the subset of the Sentry event payload filled from an entry
+type sentryEvent struct {
+	EventID     string                 `json:"event_id"`
+	Timestamp   string                 `json:"timestamp"`
+	Level       string                 `json:"level"`
+	Platform    string                 `json:"platform"`
+	Message     string                 `json:"message"`
+	Release     string                 `json:"release,omitempty"`
+	Environment string                 `json:"environment,omitempty"`
+	Fingerprint []string               `json:"fingerprint"`
+	Tags        map[string]string      `json:"tags,omitempty"`
+	Extra       map[string]interface{} `json:"extra,omitempty"`
+	Exception   *sentryExceptions      `json:"exception,omitempty"`
+}
+
+type sentryExceptions struct {
+	Values []sentryException `json:"values"`
+}
+
+type sentryException struct {
+	Type       string            `json:"type"`
+	Value      string            `json:"value"`
+	Stacktrace *sentryStacktrace `json:"stacktrace,omitempty"`
+}
+
+type sentryStacktrace struct {
+	Frames []sentryFrame `json:"frames"`
+}
+
+// Write forwards ERROR entries and ignores lower levels
+func (s *SentrySink) Write(entry *LogEntry) error {
+	if getSeverityScore(entry.Level) < getSeverityScore(ERROR) {
+		return nil
+	}
+
+	payload, err := json.Marshal(s.newEvent(entry))
+	if err != nil {
+		return fmt.Errorf("failed to marshal Sentry event: %w", err)
+	}
+	req, err := http.NewRequest(http.MethodPost, s.endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Sentry-Auth", s.auth)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send Sentry event: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("Sentry rejected event: %d %s", resp.StatusCode, http.StatusText(resp.StatusCode))
+	}
+	return nil
+}
+
+// newEvent converts an entry to a Sentry event
+func (s *SentrySink) newEvent(entry *LogEntry) *sentryEvent {
+	event := &sentryEvent{
+		EventID:     newSentryEventID(),
+		Timestamp:   entry.Timestamp.UTC().Format(time.RFC3339Nano),
+		Level:       "error",
+		Platform:    "go",
+		Message:     entry.FullMessage(),
+		Release:     s.release,
+		Environment: s.environment,
+		Fingerprint: []string{ErrorFingerprint(entry)},
+		Tags:        make(map[string]string),
+		Extra:       entry.Context,
+	}
+
+	tags := map[string]string{
+		"operation":      entry.Operation,
+		"category":       entry.Category,
+		"pattern":        entry.Pattern,
+		"correlation_id": entry.CorrelationID,
+		"entry_id":       entry.ID,
+	}
+	for key, value := range tags {
+		if value != "" {
+			event.Tags[key] = value
+		}
+	}
+
+	exception := sentryException{Type: entry.Operation, Value: entry.FullMessage()}
+	if frames := sentryFrames(entry.StackTrace); len(frames) > 0 {
+		exception.Stacktrace = &sentryStacktrace{Frames: frames}
+	}
+	event.Exception = &sentryExceptions{Values: []sentryException{exception}}
+	return event
+}
+
+// sentryFrames converts a stack trace to Sentry frames, which are ordered
+// from the outermost call to the innermost
+func sentryFrames(stack []string) []sentryFrame {
+	frames := make([]sentryFrame, 0, len(stack))
+	for i := len(stack) - 1; i >= 0; i-- {
+		marked := strings.HasPrefix(stack[i], AppFrameMarker)
+		frame, ok := parseStackFrame(strings.TrimPrefix(stack[i], AppFrameMarker))
+		if !ok {
+			continue
+		}
+		frames = append(frames, sentryFrame{
+			Filename: frame.File,
+			Function: frame.Function,
+			Lineno:   frame.Line,
+			InApp:    marked || isAppFrame(frame),
+		})
+	}
+	return frames
+}
+
+// newSentryEventID returns a random 32-character hex event ID
+func newSentryEventID() string {
+	var id [16]byte
+	rand.Read(id[:])
+	return hex.EncodeToString(id[:])
+}
+
+// Close releases idle connections
+func (s *SentrySink) Close() error {
+	s.client.CloseIdleConnections()
+	return nil
+}
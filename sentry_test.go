@@ -0,0 +1,104 @@
+package vibelogger
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewSentrySinkDSN(t *testing.T) {
+	sink, err := NewSentrySink("https://abc123@sentry.example.com/prefix/42")
+	if err != nil {
+		t.Fatalf("NewSentrySink failed: %v", err)
+	}
+	if sink.endpoint != "https://sentry.example.com/prefix/api/42/store/" {
+		t.Errorf("unexpected endpoint: %s", sink.endpoint)
+	}
+	if !strings.Contains(sink.auth, "sentry_key=abc123") {
+		t.Errorf("unexpected auth header: %s", sink.auth)
+	}
+
+	for _, dsn := range []string{"", "https://sentry.example.com/42", "https://abc@sentry.example.com/", "ftp://abc@host/1"} {
+		if _, err := NewSentrySink(dsn); err == nil {
+			t.Errorf("expected error for DSN %q", dsn)
+		}
+	}
+}
+
+func TestSentrySinkForwardsErrors(t *testing.T) {
+	var events []sentryEvent
+	var auth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("X-Sentry-Auth")
+		var event sentryEvent
+		if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+			t.Errorf("invalid event: %v", err)
+		}
+		events = append(events, event)
+	}))
+	defer server.Close()
+
+	dsn := strings.Replace(server.URL, "http://", "http://key@", 1) + "/7"
+	sink, err := NewSentrySink(dsn, WithSentryEnvironment("production"), WithSentryRelease("v1.2.3"))
+	if err != nil {
+		t.Fatalf("NewSentrySink failed: %v", err)
+	}
+	defer sink.Close()
+
+	sink.Write(&LogEntry{Timestamp: time.Now(), Level: WARN, Operation: "cache", Message: "Miss"})
+	entry := &LogEntry{
+		Timestamp: time.Now(),
+		Level:     ERROR,
+		Operation: "payment",
+		Message:   "Card declined",
+		Pattern:   "validation_error",
+		Context:   map[string]interface{}{"order_id": "A-1"},
+		StackTrace: []string{
+			AppFrameMarker + "/src/app/pay.go:10 github.com/acme/app.Pay",
+			"/src/app/main.go:5 main.main",
+		},
+	}
+	if err := sink.Write(entry); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	if len(events) != 1 {
+		t.Fatalf("expected only the ERROR entry to be forwarded, got %d events", len(events))
+	}
+	event := events[0]
+	if !strings.Contains(auth, "sentry_key=key") {
+		t.Errorf("unexpected auth header: %s", auth)
+	}
+	if event.Level != "error" || event.Release != "v1.2.3" || event.Environment != "production" || len(event.EventID) != 32 {
+		t.Errorf("unexpected event: %+v", event)
+	}
+	if len(event.Fingerprint) != 1 || event.Fingerprint[0] != ErrorFingerprint(entry) {
+		t.Errorf("unexpected fingerprint: %v", event.Fingerprint)
+	}
+	if event.Tags["operation"] != "payment" || event.Extra["order_id"] != "A-1" {
+		t.Errorf("unexpected tags or extra: %v %v", event.Tags, event.Extra)
+	}
+	frames := event.Exception.Values[0].Stacktrace.Frames
+	if len(frames) != 2 || frames[1].Function != "github.com/acme/app.Pay" || !frames[1].InApp || frames[1].Lineno != 10 {
+		t.Errorf("unexpected frames: %+v", frames)
+	}
+}
+
+func TestErrorFingerprint(t *testing.T) {
+	a := &LogEntry{Operation: "payment", Pattern: "network_error", Message: "timeout after 3s"}
+	b := &LogEntry{Operation: "payment", Pattern: "network_error", Message: "timeout after 5s"}
+	c := &LogEntry{Operation: "refund", Pattern: "network_error"}
+	if ErrorFingerprint(a) != ErrorFingerprint(b) {
+		t.Error("entries differing only in message should share a fingerprint")
+	}
+	if ErrorFingerprint(a) == ErrorFingerprint(c) {
+		t.Error("different operations should not share a fingerprint")
+	}
+	explicit := &LogEntry{Context: map[string]interface{}{"fingerprint": "custom"}}
+	if ErrorFingerprint(explicit) != "custom" {
+		t.Error("explicit fingerprint should take precedence")
+	}
+}
@@ -0,0 +1,57 @@
+package vibelogger
+
+import "sort"
+
+// Session is the story of one session: its entries in time order
+type Session struct {
+	ID       string
+	Entries  []LogEntry
+	Errors   int      // Entries at ERROR level
+	Requests []string // Distinct correlation IDs in order of appearance
+	Levels   map[LogLevel]int
+}
+
+// GetSession returns the memory log entries of a session in time order
+func (l *Logger) GetSession(sessionID string) *Session {
+	return NewSession(sessionID, l.GetMemoryLogs())
+}
+
+// NewSession collects the entries of sessionID, e.g. from ReadLogFile results
+func NewSession(sessionID string, entries []LogEntry) *Session {
+	query := Query{SessionID: sessionID}
+	session := &Session{
+		ID:      sessionID,
+		Entries: query.Filter(entries),
+		Levels:  make(map[LogLevel]int),
+	}
+	sort.SliceStable(session.Entries, func(i, j int) bool {
+		return session.Entries[i].Timestamp.Before(session.Entries[j].Timestamp)
+	})
+
+	seen := make(map[string]bool)
+	for i := range session.Entries {
+		entry := &session.Entries[i]
+		session.Levels[entry.Level]++
+		if entry.Level == ERROR {
+			session.Errors++
+		}
+		if entry.CorrelationID != "" && !seen[entry.CorrelationID] {
+			seen[entry.CorrelationID] = true
+			session.Requests = append(session.Requests, entry.CorrelationID)
+		}
+	}
+	return session
+}
+
+// SessionIDs returns the distinct session IDs of entries in order of appearance
+func SessionIDs(entries []LogEntry) []string {
+	var ids []string
+	seen := make(map[string]bool)
+	for i := range entries {
+		if id := entries[i].SessionID; id != "" && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
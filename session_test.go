@@ -0,0 +1,44 @@
+package vibelogger
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGetSession(t *testing.T) {
+	logger := NewLoggerWithConfig("session", &LoggerConfig{AutoSave: false, EnableMemoryLog: true, MemoryLogLimit: 10})
+	defer logger.Close()
+
+	task := logger.With(WithSessionID("task-42"))
+	task.Info("plan", "Planning refactor", WithCorrelationID("req-1"))
+	logger.Info("other", "Unrelated entry")
+	task.Error("apply_patch", "Patch failed", WithCorrelationID("req-2"))
+	task.Info("retry", "Retrying patch", WithCorrelationID("req-2"))
+
+	session := logger.GetSession("task-42")
+	if len(session.Entries) != 3 {
+		t.Fatalf("expected 3 session entries, got %d", len(session.Entries))
+	}
+	if session.Errors != 1 || session.Levels[INFO] != 2 {
+		t.Errorf("unexpected counts: errors=%d levels=%v", session.Errors, session.Levels)
+	}
+	if len(session.Requests) != 2 || session.Requests[0] != "req-1" || session.Requests[1] != "req-2" {
+		t.Errorf("unexpected requests: %v", session.Requests)
+	}
+}
+
+func TestNewSessionOrdersEntries(t *testing.T) {
+	now := time.Now()
+	entries := []LogEntry{
+		{Timestamp: now.Add(time.Second), SessionID: "s1", Message: "second"},
+		{Timestamp: now, SessionID: "s2", Message: "other"},
+		{Timestamp: now, SessionID: "s1", Message: "first"},
+	}
+	session := NewSession("s1", entries)
+	if len(session.Entries) != 2 || session.Entries[0].Message != "first" {
+		t.Errorf("unexpected session entries: %+v", session.Entries)
+	}
+	if ids := SessionIDs(entries); len(ids) != 2 || ids[0] != "s1" || ids[1] != "s2" {
+		t.Errorf("unexpected session IDs: %v", ids)
+	}
+}
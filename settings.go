@@ -0,0 +1,126 @@
+package vibelogger
+
+import "strings"
+
+// ChildSettings overrides the filter and redaction settings of a child logger.
+// Zero fields inherit the value of the parent, so configuring the root logger
+// configures every child that does not override the setting.
+type ChildSettings struct {
+	MinLevel   LogLevel // Minimum level; empty inherits
+	SampleRate float64  // Fraction of DEBUG and INFO entries kept; 0 inherits, 1 keeps all
+	RedactKeys []string // Context keys redacted in addition to the inherited ones
+}
+
+// EffectiveSettings are the settings an entry of a logger is written with
+type EffectiveSettings struct {
+	MinLevel   LogLevel `json:"min_level,omitempty"` // Empty when every level is kept
+	SampleRate float64  `json:"sample_rate"`         // 1 keeps every DEBUG and INFO entry
+	RedactKeys []string `json:"redact_keys,omitempty"`
+}
+
+// parseRedactKeys parses LoggerConfig.RedactKeys into lower-case key names
+func parseRedactKeys(s string) []string {
+	var keys []string
+	for _, key := range strings.Split(s, ",") {
+		if key = strings.ToLower(strings.TrimSpace(key)); key != "" {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// WithSettings returns a child logger that applies settings on top of the
+// logger's configuration and binds options to every entry
+func (l *Logger) WithSettings(settings ChildSettings, options ...LogOption) *ScopedLogger {
+	return l.With(options...).withSettings(settings)
+}
+
+// WithSettings returns a child logger that applies settings on top of the
+// inherited ones and binds additional options to every entry
+func (s *ScopedLogger) WithSettings(settings ChildSettings, options ...LogOption) *ScopedLogger {
+	return s.With(options...).withSettings(settings)
+}
+
+// withSettings attaches settings to a freshly created child
+func (s *ScopedLogger) withSettings(settings ChildSettings) *ScopedLogger {
+	settings.RedactKeys = parseRedactKeys(strings.Join(settings.RedactKeys, ","))
+	s.settings = &settings
+	return s
+}
+
+// ResolveEffectiveSettings returns the settings entries of the logger are
+// currently written with, including open debug windows
+func (l *Logger) ResolveEffectiveSettings() EffectiveSettings {
+	return l.resolveSettings(nil)
+}
+
+// ResolveEffectiveSettings returns the settings entries of the child logger are
+// currently written with: the root configuration, overridden by each ancestor
+// from the root down, then widened by open debug windows
+func (s *ScopedLogger) ResolveEffectiveSettings() EffectiveSettings {
+	return s.logger.resolveSettings(s)
+}
+
+// resolveSettings computes the effective settings of scope, or of the logger
+// itself when scope is nil
+func (l *Logger) resolveSettings(scope *ScopedLogger) EffectiveSettings {
+	l.mutex.Lock()
+	settings := EffectiveSettings{
+		MinLevel:   l.minLevel,
+		SampleRate: sampleRate(l.config.SampleRate),
+		RedactKeys: l.redactKeys,
+	}
+	l.mutex.Unlock()
+
+	var chain []*ChildSettings
+	for s := scope; s != nil; s = s.parent {
+		if s.settings != nil {
+			chain = append(chain, s.settings)
+		}
+	}
+	for i := len(chain) - 1; i >= 0; i-- {
+		override := chain[i]
+		if override.MinLevel != "" {
+			settings.MinLevel = override.MinLevel
+		}
+		if override.SampleRate > 0 {
+			settings.SampleRate = sampleRate(override.SampleRate)
+		}
+		if len(override.RedactKeys) > 0 {
+			keys := make([]string, 0, len(settings.RedactKeys)+len(override.RedactKeys))
+			keys = append(keys, settings.RedactKeys...)
+			settings.RedactKeys = append(keys, override.RedactKeys...)
+		}
+	}
+
+	settings.MinLevel, settings.SampleRate = l.windows.apply(settings.MinLevel, settings.SampleRate)
+	return settings
+}
+
+// redactEntry replaces the values of the given context keys, including keys of
+// nested maps. Nested maps are copied, so values owned by the caller stay intact.
+func redactEntry(entry *LogEntry, keys []string) {
+	if len(keys) == 0 {
+		return
+	}
+	for key, value := range entry.Context {
+		entry.Context[key] = redactValue(key, value, keys)
+	}
+}
+
+// redactValue returns the value stored under key with redaction applied
+func redactValue(key string, value interface{}, keys []string) interface{} {
+	for _, redacted := range keys {
+		if strings.EqualFold(key, redacted) {
+			return RedactedValue
+		}
+	}
+	if nested, ok := value.(map[string]interface{}); ok {
+		copied := make(map[string]interface{}, len(nested))
+		for k, v := range nested {
+			copied[k] = redactValue(k, v, keys)
+		}
+		return copied
+	}
+	return value
+}
@@ -0,0 +1,87 @@
+package vibelogger
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestChildSettingsInheritance(t *testing.T) {
+	logger := NewLoggerWithConfig("settings_test", &LoggerConfig{
+		EnableMemoryLog: true,
+		MemoryLogLimit:  100,
+		MinLevel:        "WARN",
+		SampleRate:      0.5,
+		RedactKeys:      "password, Token",
+	})
+
+	payments := logger.WithSettings(ChildSettings{MinLevel: INFO, RedactKeys: []string{"card_number"}})
+	checkout := payments.With(WithCorrelationID("req-1"))
+	verbose := checkout.WithSettings(ChildSettings{MinLevel: DEBUG, SampleRate: 1})
+
+	root := logger.ResolveEffectiveSettings()
+	if root.MinLevel != WARN || root.SampleRate != 0.5 || !reflect.DeepEqual(root.RedactKeys, []string{"password", "token"}) {
+		t.Errorf("Unexpected root settings: %+v", root)
+	}
+	inherited := checkout.ResolveEffectiveSettings()
+	if inherited.MinLevel != INFO || inherited.SampleRate != 0.5 {
+		t.Errorf("Expected the level of the parent and the root sample rate, got %+v", inherited)
+	}
+	if !reflect.DeepEqual(inherited.RedactKeys, []string{"password", "token", "card_number"}) {
+		t.Errorf("Expected redaction keys to accumulate, got %v", inherited.RedactKeys)
+	}
+	leaf := verbose.ResolveEffectiveSettings()
+	if leaf.MinLevel != DEBUG || leaf.SampleRate != 1 {
+		t.Errorf("Expected the child overrides to win, got %+v", leaf)
+	}
+
+	// Reconfiguring the root reaches children that do not override the setting
+	updated := *logger.config
+	updated.SampleRate = 0.25
+	updated.RedactKeys = "secret"
+	if err := logger.UpdateConfig(&updated); err != nil {
+		t.Fatalf("Failed to update config: %v", err)
+	}
+	inherited = checkout.ResolveEffectiveSettings()
+	if inherited.SampleRate != 0.25 || !reflect.DeepEqual(inherited.RedactKeys, []string{"secret", "card_number"}) {
+		t.Errorf("Expected the root change to cascade, got %+v", inherited)
+	}
+
+	// Debug windows widen the settings of the whole tree
+	stop := logger.OpenDebugWindow(context.Background(), DebugWindow{})
+	if got := checkout.ResolveEffectiveSettings(); got.MinLevel != DEBUG || got.SampleRate != 1 {
+		t.Errorf("Expected the debug window to apply to children, got %+v", got)
+	}
+	stop()
+
+	logger.Debug("filtered", "Below the root level")
+	verbose.Debug("filtered", "Kept by the leaf override", WithContext(map[string]interface{}{
+		"secret":  "s3cr3t",
+		"payment": map[string]interface{}{"card_number": "4111111111111111", "amount": 10},
+	}))
+	var kept []LogEntry
+	for _, entry := range logger.GetMemoryLogs() {
+		if entry.Operation == "filtered" {
+			kept = append(kept, entry)
+		}
+	}
+	if len(kept) != 1 {
+		t.Fatalf("Expected only the leaf entry, got %d", len(kept))
+	}
+	if kept[0].Context["secret"] != RedactedValue {
+		t.Errorf("Expected secret to be redacted, got %v", kept[0].Context["secret"])
+	}
+	payment := kept[0].Context["payment"].(map[string]interface{})
+	if payment["card_number"] != RedactedValue || payment["amount"] != 10 {
+		t.Errorf("Expected nested redaction, got %v", payment)
+	}
+
+	// Readers find the redacted fields without the writer's settings
+	if fields := kept[0].RedactedFields(); len(fields) != 2 || fields[0] != "payment.card_number" || fields[1] != "secret" {
+		t.Errorf("Expected the redacted fields, got %v", fields)
+	}
+	query := Query{Redacted: true}
+	if matched := query.Filter(logger.GetMemoryLogs()); len(matched) != 1 || matched[0].Operation != "filtered" {
+		t.Errorf("Expected only the redacted entry to match, got %d entries", len(matched))
+	}
+}
@@ -0,0 +1,252 @@
+package vibelogger
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sync"
+)
+
+// shardQueueSize is the number of entries each shard buffers before Log blocks
+const shardQueueSize = 256
+
+// shardRecord is an entry encoded by a shard, or a Flush barrier
+type shardRecord struct {
+	logger  *Logger // Destination of the entry
+	entry   LogEntry
+	data    []byte        // Encoded entry, nil when encoding is left to the file writer
+	seq     uint64        // Write-ahead journal sequence number, 0 when not journaled
+	barrier chan struct{} // Closed by the file writer once everything before it is written
+}
+
+// shardedWriter spreads entries over several goroutines that encode them in
+// parallel and hand the results to a single goroutine writing the files. One
+// writer may serve several loggers, see LoggerManager.
+//
+// Ordering: entries of one logger with the same correlation ID (or session ID,
+// or operation when neither is set) always go to the same shard and are written
+// in the order they were logged. Entries on different shards may be interleaved in any order;
+// readers should sort by timestamp when a global order matters.
+type shardedWriter struct {
+	shards  []chan shardRecord
+	batches chan []shardRecord
+	running sync.WaitGroup // Shard goroutines
+	done    chan struct{}  // Closed when the file writer has stopped
+
+	closeMutex sync.RWMutex // Guards closed against sends racing with close
+	closed     bool
+
+	errMutex sync.Mutex
+	failed   int64
+	lastErr  error
+}
+
+// newShardedWriter starts n shard goroutines and the file writer
+func newShardedWriter(n int) *shardedWriter {
+	w := &shardedWriter{
+		shards:  make([]chan shardRecord, n),
+		batches: make(chan []shardRecord, n),
+		done:    make(chan struct{}),
+	}
+	for i := range w.shards {
+		w.shards[i] = make(chan shardRecord, shardQueueSize)
+		w.running.Add(1)
+		go w.runShard(w.shards[i])
+	}
+	go w.runWriter()
+	return w
+}
+
+// shardFor picks the shard of an entry from its logger and ordering key
+func (w *shardedWriter) shardFor(logger *Logger, entry *LogEntry) chan shardRecord {
+	key := entry.CorrelationID
+	if key == "" {
+		key = entry.SessionID
+	}
+	if key == "" {
+		key = entry.Operation
+	}
+	h := fnv.New32a()
+	h.Write([]byte(logger.name))
+	h.Write([]byte{0})
+	h.Write([]byte(key))
+	return w.shards[h.Sum32()%uint32(len(w.shards))]
+}
+
+// submit queues the entry for logger and reports whether it was accepted. It is
+// safe on a nil writer and returns false after close, so callers fall back to a
+// direct write.
+func (w *shardedWriter) submit(logger *Logger, entry LogEntry, seq uint64) bool {
+	if w == nil {
+		return false
+	}
+	w.closeMutex.RLock()
+	defer w.closeMutex.RUnlock()
+	if w.closed {
+		return false
+	}
+	w.shardFor(logger, &entry) <- shardRecord{logger: logger, entry: entry, seq: seq}
+	return true
+}
+
+// runShard encodes queued entries and forwards them in batches
+func (w *shardedWriter) runShard(queue chan shardRecord) {
+	defer w.running.Done()
+	for rec := range queue {
+		batch := []shardRecord{w.encode(rec)}
+		// Take what is already queued, but never hold back a barrier
+		for rec.barrier == nil && len(batch) < maxAsyncBatch {
+			var ok bool
+			select {
+			case rec, ok = <-queue:
+			default:
+			}
+			if !ok {
+				break
+			}
+			batch = append(batch, w.encode(rec))
+		}
+		w.batches <- batch
+	}
+}
+
+// encode serializes the entry of rec outside the logger mutex
+func (w *shardedWriter) encode(rec shardRecord) shardRecord {
+	if rec.barrier != nil {
+		return rec
+	}
+	// On failure data stays nil and the file writer reports the error
+	l := rec.logger
+	if l.config.Hardened {
+		// Encoding happens outside log, whose panic guard does not reach here
+		if p := callSafely("shard_encoder", func() { rec.data, _ = l.encodeFileEntry(&rec.entry) }); p != nil {
+			l.reportError(p)
+		}
+		return rec
+	}
+	rec.data, _ = l.encodeFileEntry(&rec.entry)
+	return rec
+}
+
+// runWriter writes encoded batches until all shards have stopped
+func (w *shardedWriter) runWriter() {
+	defer close(w.done)
+	for batch := range w.batches {
+		w.write(batch)
+	}
+}
+
+// write writes one batch, taking each logger's mutex once per run of its
+// entries and committing the run to the logger's journal
+func (w *shardedWriter) write(batch []shardRecord) {
+	var l *Logger
+	var written []uint64 // Journal sequence numbers of the current run
+	commit := func() {
+		if l != nil && len(written) > 0 {
+			l.journal.commit(written...)
+		}
+		written = written[:0]
+	}
+	release := func() {
+		if l == nil {
+			return
+		}
+		commit()
+		rm := l.rotationMgr
+		l.mutex.Unlock()
+		// The warnings are queued on the shards this goroutine has to keep draining
+		if rm.hasWarnings() {
+			go l.reportRotationWarnings(rm)
+		}
+	}
+	defer release()
+
+	for i := range batch {
+		rec := &batch[i]
+		if rec.barrier != nil {
+			commit()
+			close(rec.barrier)
+			continue
+		}
+		if rec.logger != l {
+			release()
+			l = rec.logger
+			l.mutex.Lock()
+		}
+		if err := l.writeEntryLocked(rec.entry, rec.data); err != nil {
+			w.errMutex.Lock()
+			w.failed++
+			w.lastErr = err
+			w.errMutex.Unlock()
+		}
+		if rec.seq != 0 {
+			written = append(written, rec.seq)
+		}
+	}
+}
+
+// flush waits until every entry submitted before the call has been written
+func (w *shardedWriter) flush() {
+	if w == nil {
+		return
+	}
+	w.closeMutex.RLock()
+	if w.closed {
+		w.closeMutex.RUnlock()
+		return
+	}
+	barriers := make([]chan struct{}, len(w.shards))
+	for i, shard := range w.shards {
+		barriers[i] = make(chan struct{})
+		shard <- shardRecord{barrier: barriers[i]}
+	}
+	w.closeMutex.RUnlock()
+
+	for _, barrier := range barriers {
+		<-barrier
+	}
+}
+
+// close writes the remaining entries and stops all goroutines. It returns the
+// last write error, if any, and is safe on a nil writer.
+func (w *shardedWriter) close() error {
+	if w == nil {
+		return nil
+	}
+	w.closeMutex.Lock()
+	if w.closed {
+		w.closeMutex.Unlock()
+		return nil
+	}
+	w.closed = true
+	for _, shard := range w.shards {
+		close(shard)
+	}
+	w.closeMutex.Unlock()
+
+	w.running.Wait()
+	close(w.batches)
+	<-w.done
+
+	w.errMutex.Lock()
+	defer w.errMutex.Unlock()
+	if w.lastErr != nil {
+		return fmt.Errorf("failed to write %d sharded entries: %w", w.failed, w.lastErr)
+	}
+	return nil
+}
+
+// Flush waits until all entries logged before the call have been written.
+// Without WriterShards entries are written synchronously and Flush returns at once.
+func (l *Logger) Flush() {
+	l.shards.flush()
+}
+
+// closeShards stops the logger's own sharded writer, or only flushes a writer
+// shared through a LoggerManager, which closes it in CloseAll
+func (l *Logger) closeShards() error {
+	if l.shared != nil && l.shards == l.shared.shards {
+		l.shards.flush()
+		return nil
+	}
+	return l.shards.close()
+}
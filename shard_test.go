@@ -0,0 +1,93 @@
+package vibelogger
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+)
+
+func TestShardedWriterOrdering(t *testing.T) {
+	defer os.RemoveAll("test_logs")
+
+	config := DefaultConfig()
+	config.FilePath = "test_logs/sharded_test.log"
+	config.WriterShards = 4
+
+	logger, err := CreateFileLoggerWithConfig("sharded_test", config)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+
+	const workers, perWorker = 8, 50
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < perWorker; i++ {
+				logger.Info("sharded_op", fmt.Sprintf("%d", i), WithCorrelationID(fmt.Sprintf("worker-%d", w)))
+			}
+		}(w)
+	}
+	wg.Wait()
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	result, err := ReadLogFile(config.FilePath)
+	if err != nil {
+		t.Fatalf("Failed to read log file: %v", err)
+	}
+	if len(result.Entries) != workers*perWorker || !result.CleanShutdown() {
+		t.Fatalf("Expected %d entries and a clean shutdown, got %d, clean=%v",
+			workers*perWorker, len(result.Entries), result.CleanShutdown())
+	}
+
+	// Entries of one correlation ID keep the order they were logged in
+	next := make(map[string]int)
+	for _, entry := range result.Entries {
+		if want := fmt.Sprintf("%d", next[entry.CorrelationID]); entry.Message != want {
+			t.Fatalf("Expected message %s for %s, got %s", want, entry.CorrelationID, entry.Message)
+		}
+		next[entry.CorrelationID]++
+	}
+}
+
+func TestShardedWriterFlush(t *testing.T) {
+	defer os.RemoveAll("test_logs")
+
+	config := DefaultConfig()
+	config.FilePath = "test_logs/sharded_flush_test.log"
+	config.WriterShards = 2
+	config.EnableMemoryLog = true
+
+	logger, err := CreateFileLoggerWithConfig("sharded_flush_test", config)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	defer logger.Close()
+
+	for i := 0; i < 20; i++ {
+		logger.Info(fmt.Sprintf("op_%d", i), "Queued entry")
+	}
+	logger.Flush()
+	if count := logger.MemoryLogCount(); count != 20 {
+		t.Errorf("Expected 20 written entries after Flush, got %d", count)
+	}
+
+	// After Close entries are written synchronously
+	logger.Close()
+	logger.Flush()
+	if err := logger.Info("late_op", "After close"); err != nil {
+		t.Errorf("Expected a direct write after Close, got %v", err)
+	}
+}
+
+func TestWriterShardsValidation(t *testing.T) {
+	config := DefaultConfig()
+	config.WriterShards = MaxWriterShards + 1
+	if err := config.Validate(); err == nil {
+		t.Error("Expected validation to fail for too many writer shards")
+	}
+}
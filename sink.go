@@ -0,0 +1,60 @@
+package vibelogger
+
+import "fmt"
+
+// Sink receives every entry written by a Logger in addition to its primary output.
+// Write is called with the logger's write lock held, so sinks see entries in order.
+type Sink interface {
+	Write(entry *LogEntry) error
+	Close() error
+}
+
+// BatchSink is implemented by sinks that can write several entries at once,
+// such as network sinks sending one request per batch. AsyncSink uses it to
+// write queued entries together.
+type BatchSink interface {
+	Sink
+	WriteBatch(entries []LogEntry) error
+}
+
+// AddSink registers an additional destination for log entries
+func (l *Logger) AddSink(sink Sink) {
+	if sink == nil {
+		return
+	}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.sinks = append(l.sinks, sink)
+}
+
+// writeSinks forwards an entry to all registered sinks. Every sink is tried;
+// the first failure is returned. A panicking sink is reported as a *PanicError.
+func (l *Logger) writeSinks(entry *LogEntry) error {
+	var firstErr error
+	for _, sink := range l.sinks {
+		var err error
+		if p := callSafely("sink", func() { err = sink.Write(entry) }); p != nil {
+			err = p
+		}
+		if err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("failed to write to sink: %w", err)
+		}
+	}
+	return firstErr
+}
+
+// closeSinks closes all registered sinks and returns the first failure
+func (l *Logger) closeSinks() error {
+	var firstErr error
+	for _, sink := range l.sinks {
+		var err error
+		if p := callSafely("sink", func() { err = sink.Close() }); p != nil {
+			err = p
+		}
+		if err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("failed to close sink: %w", err)
+		}
+	}
+	l.sinks = nil
+	return firstErr
+}
@@ -0,0 +1,68 @@
+package vibelogger
+
+import (
+	"errors"
+	"sync"
+	"testing"
+)
+
+// recordingSink keeps every entry it receives for assertions
+type recordingSink struct {
+	mutex    sync.Mutex
+	entries  []LogEntry
+	writeErr error
+	closed   bool
+}
+
+func (s *recordingSink) Write(entry *LogEntry) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.entries = append(s.entries, *entry)
+	return s.writeErr
+}
+
+func (s *recordingSink) Close() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.closed = true
+	return nil
+}
+
+func (s *recordingSink) Entries() []LogEntry {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	entries := make([]LogEntry, len(s.entries))
+	copy(entries, s.entries)
+	return entries
+}
+
+func TestAddSink(t *testing.T) {
+	logger := NewLoggerWithConfig("sink_test", &LoggerConfig{AutoSave: false})
+
+	first := &recordingSink{writeErr: errors.New("sink unavailable")}
+	second := &recordingSink{}
+	logger.AddSink(first)
+	logger.AddSink(second)
+	logger.AddSink(nil) // Ignored
+
+	err := logger.Info("test", "Sent to sinks")
+	if err == nil {
+		t.Error("Expected sink failure to be reported")
+	}
+
+	// A failing sink must not prevent delivery to the others
+	if len(first.Entries()) != 1 || len(second.Entries()) != 1 {
+		t.Fatalf("Expected both sinks to receive the entry, got %d and %d",
+			len(first.Entries()), len(second.Entries()))
+	}
+	if second.Entries()[0].Operation != "test" {
+		t.Errorf("Unexpected entry: %+v", second.Entries()[0])
+	}
+
+	if err := logger.Close(); err != nil {
+		t.Errorf("Failed to close logger: %v", err)
+	}
+	if !first.closed || !second.closed {
+		t.Error("Expected sinks to be closed with the logger")
+	}
+}
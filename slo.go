@@ -0,0 +1,186 @@
+package vibelogger
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"sync"
+	"time"
+)
+
+// sloBuckets is the number of buckets a rolling SLO window is divided into
+const sloBuckets = 60
+
+// SLORule turns the entries of operations into success/failure signals, e.g.
+// "checkout entries at ERROR or above are failures, 99.9% must succeed over an
+// hour". Every logged entry of a matching operation counts as one event.
+type SLORule struct {
+	Operation    string        // Operation name or path.Match pattern; empty matches every operation
+	FailureLevel LogLevel      // Entries at or above this level are failures; ERROR when empty
+	Objective    float64       // Target success ratio, e.g. 0.999
+	Window       time.Duration // Rolling period the burn is computed over
+}
+
+// SLOStatus is the error budget burn of one operation within its rolling window
+type SLOStatus struct {
+	Operation       string        `json:"operation"`
+	Objective       float64       `json:"objective"`
+	Window          time.Duration `json:"window"`
+	Total           int64         `json:"total"`            // Events within the window
+	Failures        int64         `json:"failures"`         // Failed events within the window
+	BurnRate        float64       `json:"burn_rate"`        // Failure ratio divided by the error budget; above 1 exhausts the budget early
+	BudgetRemaining float64       `json:"budget_remaining"` // Share of the window's error budget left, negative when overspent
+}
+
+// sloBucket counts events of one slice of the window
+type sloBucket struct {
+	start    int64 // Slice number, i.e. Unix nanoseconds divided by the slice width
+	total    int64
+	failures int64
+}
+
+// sloCounter tracks the events of one operation
+type sloCounter struct {
+	rule    *SLORule
+	buckets [sloBuckets]sloBucket
+}
+
+// sliceWidth is the duration covered by one bucket
+func (c *sloCounter) sliceWidth() int64 {
+	return max(int64(c.rule.Window)/sloBuckets, 1)
+}
+
+// record counts an event at ts
+func (c *sloCounter) record(ts time.Time, failed bool) {
+	slice := ts.UnixNano() / c.sliceWidth()
+	bucket := &c.buckets[slice%sloBuckets]
+	if bucket.start != slice {
+		*bucket = sloBucket{start: slice}
+	}
+	bucket.total++
+	if failed {
+		bucket.failures++
+	}
+}
+
+// status sums the buckets that are still within the window at now
+func (c *sloCounter) status(operation string, now time.Time) SLOStatus {
+	status := SLOStatus{Operation: operation, Objective: c.rule.Objective, Window: c.rule.Window, BudgetRemaining: 1}
+	current := now.UnixNano() / c.sliceWidth()
+	for _, bucket := range c.buckets {
+		if bucket.start > current-sloBuckets && bucket.start <= current {
+			status.Total += bucket.total
+			status.Failures += bucket.failures
+		}
+	}
+	if status.Total > 0 {
+		budget := 1 - c.rule.Objective
+		status.BurnRate = float64(status.Failures) / float64(status.Total) / budget
+		status.BudgetRemaining = 1 - status.BurnRate
+	}
+	return status
+}
+
+// sloState holds the rules and the counters per operation
+type sloState struct {
+	mutex    sync.Mutex
+	rules    []SLORule
+	counters map[string]*sloCounter
+}
+
+// SetSLORules replaces the SLO rules of the logger and resets the counters.
+// Each operation is tracked by the first rule matching it, and its burn is
+// reported by Stats and in summary entries. Calling it without rules disables
+// tracking.
+func (l *Logger) SetSLORules(rules ...SLORule) error {
+	for i, rule := range rules {
+		if rule.Objective <= 0 || rule.Objective >= 1 {
+			return fmt.Errorf("slo rule %d: objective must be between 0 and 1 exclusive: %v", i, rule.Objective)
+		}
+		if rule.Window <= 0 {
+			return fmt.Errorf("slo rule %d: window must be positive", i)
+		}
+		if rule.FailureLevel != "" {
+			if _, err := ParseLevel(string(rule.FailureLevel)); err != nil {
+				return fmt.Errorf("slo rule %d: %w", i, err)
+			}
+		}
+		if _, err := path.Match(rule.Operation, ""); err != nil {
+			return fmt.Errorf("slo rule %d: invalid operation pattern %q: %w", i, rule.Operation, err)
+		}
+	}
+
+	var state *sloState
+	if len(rules) > 0 {
+		state = &sloState{
+			rules:    make([]SLORule, len(rules)),
+			counters: make(map[string]*sloCounter),
+		}
+		for i, rule := range rules {
+			rule.FailureLevel, _ = ParseLevel(string(rule.FailureLevel))
+			if rule.FailureLevel == "" {
+				rule.FailureLevel = ERROR
+			}
+			state.rules[i] = rule
+		}
+	}
+	l.mutex.Lock()
+	l.slo = state
+	l.mutex.Unlock()
+	return nil
+}
+
+// recordSLO counts a written entry against the rule matching its operation
+func (l *Logger) recordSLO(entry *LogEntry) {
+	l.mutex.Lock()
+	state := l.slo
+	l.mutex.Unlock()
+	if state == nil {
+		return
+	}
+
+	state.mutex.Lock()
+	defer state.mutex.Unlock()
+
+	counter, ok := state.counters[entry.Operation]
+	if !ok {
+		for i := range state.rules {
+			rule := &state.rules[i]
+			if matched, _ := path.Match(rule.Operation, entry.Operation); rule.Operation == "" || matched {
+				counter = &sloCounter{rule: rule}
+				break
+			}
+		}
+		// Unmatched operations are remembered as nil to skip the rules next time
+		state.counters[entry.Operation] = counter
+	}
+	if counter == nil {
+		return
+	}
+	counter.record(entry.Timestamp, getSeverityScore(entry.Level) >= getSeverityScore(counter.rule.FailureLevel))
+}
+
+// sloStatus returns the burn of every tracked operation, sorted by operation
+func (l *Logger) sloStatus() []SLOStatus {
+	l.mutex.Lock()
+	state := l.slo
+	l.mutex.Unlock()
+	if state == nil {
+		return nil
+	}
+
+	state.mutex.Lock()
+	defer state.mutex.Unlock()
+
+	now := time.Now()
+	var statuses []SLOStatus
+	for operation, counter := range state.counters {
+		if counter != nil {
+			statuses = append(statuses, counter.status(operation, now))
+		}
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Operation < statuses[j].Operation
+	})
+	return statuses
+}
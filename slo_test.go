@@ -0,0 +1,83 @@
+package vibelogger
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestSLOBurnTracking(t *testing.T) {
+	logger := NewLoggerWithConfig("slo_test", &LoggerConfig{EnableMemoryLog: true})
+
+	err := logger.SetSLORules(
+		SLORule{Operation: "checkout*", Objective: 0.9, Window: time.Hour},
+		SLORule{Operation: "search", FailureLevel: "warn", Objective: 0.5, Window: time.Minute},
+	)
+	if err != nil {
+		t.Fatalf("Failed to set SLO rules: %v", err)
+	}
+
+	for i := 0; i < 8; i++ {
+		logger.Info("checkout_submit", "Order placed")
+	}
+	logger.Error("checkout_submit", "Payment failed")
+	logger.Warn("checkout_submit", "Slow payment") // Below the failure level
+	logger.Warn("search", "Degraded results")
+	logger.Info("search", "Results returned")
+	logger.Info("untracked", "Not covered by a rule")
+
+	stats := logger.Stats()
+	if len(stats.SLOs) != 2 {
+		t.Fatalf("Expected 2 tracked operations, got %+v", stats.SLOs)
+	}
+	checkout, search := stats.SLOs[0], stats.SLOs[1]
+	if checkout.Operation != "checkout_submit" || checkout.Total != 10 || checkout.Failures != 1 {
+		t.Errorf("Unexpected checkout status: %+v", checkout)
+	}
+	// 10% failures against a 10% budget burns it exactly
+	if math.Abs(checkout.BurnRate-1) > 1e-9 || math.Abs(checkout.BudgetRemaining) > 1e-9 {
+		t.Errorf("Expected a burn rate of 1, got %+v", checkout)
+	}
+	if search.Operation != "search" || search.Failures != 1 || search.BurnRate != 1 || search.Window != time.Minute {
+		t.Errorf("Unexpected search status: %+v", search)
+	}
+
+	// The summary reports the burn of every tracked operation
+	logger.writeSummary()
+	logs := logger.GetMemoryLogs()
+	summary := logs[len(logs)-1]
+	burn, ok := summary.Context["slo_burn"].(map[string]interface{})
+	if !ok || burn["checkout_submit"] == nil || burn["search"] == nil {
+		t.Errorf("Expected SLO burn in the summary, got %v", summary.Context["slo_burn"])
+	}
+
+	if err := logger.SetSLORules(SLORule{Objective: 1, Window: time.Hour}); err == nil {
+		t.Error("Expected an error for an objective of 1")
+	}
+	if err := logger.SetSLORules(SLORule{Objective: 0.99}); err == nil {
+		t.Error("Expected an error for a missing window")
+	}
+	logger.SetSLORules()
+	if len(logger.Stats().SLOs) != 0 {
+		t.Error("Expected tracking to stop without rules")
+	}
+}
+
+func TestSLOWindowExpiry(t *testing.T) {
+	counter := &sloCounter{rule: &SLORule{Objective: 0.99, Window: time.Minute}}
+	start := time.Unix(1700000000, 0)
+
+	counter.record(start, true)
+	counter.record(start.Add(30*time.Second), false)
+	if status := counter.status("op", start.Add(45*time.Second)); status.Total != 2 || status.Failures != 1 {
+		t.Errorf("Expected both events within the window, got %+v", status)
+	}
+	// The failure leaves the window after a minute
+	status := counter.status("op", start.Add(70*time.Second))
+	if status.Total != 1 || status.Failures != 0 || status.BudgetRemaining != 1 {
+		t.Errorf("Expected only the success to remain, got %+v", status)
+	}
+	if status := counter.status("op", start.Add(2*time.Hour)); status.Total != 0 {
+		t.Errorf("Expected an empty window, got %+v", status)
+	}
+}
@@ -0,0 +1,197 @@
+package vibelogger
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// SlogOperationKey is the attribute key that sets the operation of entries
+// written through a SlogHandler
+const SlogOperationKey = "operation"
+
+// DefaultSlogOperation is the operation of slog records without an operation attribute
+const DefaultSlogOperation = "slog"
+
+// SlogHandlerOptions configures a SlogHandler
+type SlogHandlerOptions struct {
+	// Level is the minimum slog level handled; when nil the logger's effective
+	// minimum level decides
+	Level slog.Leveler
+	// Operation is used for records without an operation attribute
+	// (DefaultSlogOperation if empty)
+	Operation string
+}
+
+// SlogHandler is a log/slog.Handler writing records through a Logger, so
+// slog-based applications get the AI-optimized fields, rotation and project
+// layout without changing their call sites
+type SlogHandler struct {
+	logger  *Logger
+	options SlogHandlerOptions
+	bound   []slogAttrs // Attributes added by WithAttrs, in order
+	groups  []string    // Groups opened by WithGroup
+}
+
+// slogAttrs are attributes bound under the groups open at the time
+type slogAttrs struct {
+	groups []string
+	attrs  []slog.Attr
+}
+
+// NewSlogHandler returns a slog handler backed by logger. opts may be nil.
+//
+// Records become entries with the record's message. The attribute
+// SlogOperationKey at the top level sets the operation; an error under the
+// "error" key is recorded like WithError; other attributes go to the context,
+// with groups as nested maps. slog levels below INFO map to DEBUG, below WARN
+// to INFO, below ERROR to WARN and the rest to ERROR. Correlation and trace
+// IDs stored in the record's context are applied as by WithTraceFromContext.
+func NewSlogHandler(logger *Logger, opts *SlogHandlerOptions) *SlogHandler {
+	h := &SlogHandler{logger: logger}
+	if opts != nil {
+		h.options = *opts
+	}
+	if h.options.Operation == "" {
+		h.options.Operation = DefaultSlogOperation
+	}
+	return h
+}
+
+// NewSlogLogger returns a slog.Logger writing through logger
+func NewSlogLogger(logger *Logger) *slog.Logger {
+	return slog.New(NewSlogHandler(logger, nil))
+}
+
+// slogLevel maps a slog level to a log level
+func slogLevel(level slog.Level) LogLevel {
+	switch {
+	case level < slog.LevelInfo:
+		return DEBUG
+	case level < slog.LevelWarn:
+		return INFO
+	case level < slog.LevelError:
+		return WARN
+	default:
+		return ERROR
+	}
+}
+
+// Enabled reports whether records of level are written
+func (h *SlogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	if h.options.Level != nil {
+		return level >= h.options.Level.Level()
+	}
+	minLevel := h.logger.resolveSettings(nil).MinLevel
+	return minLevel == "" || getSeverityScore(slogLevel(level)) >= getSeverityScore(minLevel)
+}
+
+// Handle writes the record as an entry
+func (h *SlogHandler) Handle(ctx context.Context, r slog.Record) error {
+	b := slogEntryBuilder{operation: h.options.Operation, fields: make(map[string]interface{})}
+	for _, bound := range h.bound {
+		for _, attr := range bound.attrs {
+			b.add(bound.groups, attr)
+		}
+	}
+	r.Attrs(func(attr slog.Attr) bool {
+		b.add(h.groups, attr)
+		return true
+	})
+
+	options := []LogOption{WithContext(b.fields)}
+	if b.err != nil {
+		options = append(options, WithError(b.err))
+	}
+	if ctx != nil {
+		options = append(options, WithTraceFromContext(ctx))
+	}
+	return h.logger.log(nil, slogLevel(r.Level), b.operation, r.Message, options...)
+}
+
+// WithAttrs returns a handler adding attrs to every record
+func (h *SlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	clone := *h
+	clone.bound = append(h.bound[:len(h.bound):len(h.bound)], slogAttrs{groups: h.groups, attrs: attrs})
+	return &clone
+}
+
+// WithGroup returns a handler nesting the attributes added afterwards under name
+func (h *SlogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	clone := *h
+	clone.groups = append(h.groups[:len(h.groups):len(h.groups)], name)
+	return &clone
+}
+
+// slogEntryBuilder collects the fields of one record
+type slogEntryBuilder struct {
+	operation string
+	err       error
+	fields    map[string]interface{}
+}
+
+// add stores attr under groups, creating the group maps as values arrive so
+// empty groups are left out
+func (b *slogEntryBuilder) add(groups []string, attr slog.Attr) {
+	attr.Value = attr.Value.Resolve()
+	if attr.Equal(slog.Attr{}) {
+		return
+	}
+	if len(groups) == 0 {
+		switch attr.Key {
+		case SlogOperationKey:
+			if attr.Value.Kind() == slog.KindString && attr.Value.String() != "" {
+				b.operation = attr.Value.String()
+				return
+			}
+		case "error":
+			if err, ok := attr.Value.Any().(error); ok {
+				b.err = err
+				return
+			}
+		}
+	}
+
+	if attr.Value.Kind() == slog.KindGroup {
+		members := attr.Value.Group()
+		if attr.Key != "" {
+			groups = append(groups[:len(groups):len(groups)], attr.Key)
+		}
+		for _, member := range members {
+			b.add(groups, member)
+		}
+		return
+	}
+
+	target := b.fields
+	for _, group := range groups {
+		nested, ok := target[group].(map[string]interface{})
+		if !ok {
+			nested = make(map[string]interface{})
+			target[group] = nested
+		}
+		target = nested
+	}
+	target[attr.Key] = slogValue(attr.Value)
+}
+
+// slogValue converts a resolved slog value to a context value
+func slogValue(v slog.Value) interface{} {
+	switch v.Kind() {
+	case slog.KindDuration:
+		return v.Duration().String()
+	case slog.KindTime:
+		return v.Time().UTC().Format(time.RFC3339Nano)
+	case slog.KindAny:
+		if err, ok := v.Any().(error); ok {
+			return err.Error()
+		}
+	}
+	return v.Any()
+}
@@ -0,0 +1,72 @@
+package vibelogger
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestSlogHandler(t *testing.T) {
+	logger := NewLoggerWithConfig("slog", &LoggerConfig{AutoSave: false, EnableMemoryLog: true})
+	defer logger.Close()
+
+	slogger := slog.New(NewSlogHandler(logger, nil)).With("service", "api").WithGroup("req")
+	ctx := ContextWithCorrelationID(context.Background(), "corr-1")
+	slogger.ErrorContext(ctx, "Card declined",
+		slog.String("operation", "payment"),
+		slog.String("method", "POST"),
+		slog.Duration("took", 1500*time.Millisecond),
+		slog.Group("empty"))
+	NewSlogLogger(logger).Warn("Disk almost full", "error", errors.New("quota"), slog.Group("", "operation", "storage"))
+
+	logs := logger.GetMemoryLogs()
+	if len(logs) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(logs))
+	}
+	entry := logs[0]
+	if entry.Level != ERROR || entry.Message != "Card declined" || entry.CorrelationID != "corr-1" {
+		t.Errorf("unexpected entry: %s %q %q", entry.Level, entry.Message, entry.CorrelationID)
+	}
+	// Within a group the operation key is an ordinary attribute
+	if entry.Operation != DefaultSlogOperation || entry.Context["service"] != "api" {
+		t.Errorf("unexpected operation or bound attributes: %s %v", entry.Operation, entry.Context)
+	}
+	req, _ := entry.Context["req"].(map[string]interface{})
+	if req["method"] != "POST" || req["operation"] != "payment" || req["took"] != "1.5s" {
+		t.Errorf("expected grouped attributes, got %v", entry.Context["req"])
+	}
+	if _, ok := req["empty"]; ok {
+		t.Error("empty groups should be left out")
+	}
+
+	entry = logs[1]
+	if entry.Level != WARN || entry.Operation != "storage" || entry.Context["error"] != "quota" || entry.Context["error_type"] == nil {
+		t.Errorf("unexpected entry: %s %s %v", entry.Level, entry.Operation, entry.Context)
+	}
+}
+
+func TestSlogHandlerLevels(t *testing.T) {
+	logger := NewLoggerWithConfig("slog_levels", &LoggerConfig{AutoSave: false, EnableMemoryLog: true, MinLevel: "INFO"})
+	defer logger.Close()
+
+	handler := NewSlogHandler(logger, nil)
+	if handler.Enabled(context.Background(), slog.LevelDebug) || !handler.Enabled(context.Background(), slog.LevelInfo) {
+		t.Error("expected the logger's minimum level to decide")
+	}
+	handler = NewSlogHandler(logger, &SlogHandlerOptions{Level: slog.LevelWarn, Operation: "legacy"})
+	if handler.Enabled(context.Background(), slog.LevelInfo) {
+		t.Error("expected the handler's level to decide")
+	}
+
+	slogger := slog.New(handler)
+	slogger.Log(context.Background(), slog.LevelWarn+2, "Retrying")
+	logs := logger.GetMemoryLogs()
+	if len(logs) != 1 || logs[0].Level != WARN || logs[0].Operation != "legacy" {
+		t.Errorf("unexpected entries: %+v", logs)
+	}
+	if slogLevel(slog.LevelDebug) != DEBUG || slogLevel(slog.LevelError+4) != ERROR {
+		t.Error("unexpected level mapping")
+	}
+}
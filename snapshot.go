@@ -0,0 +1,153 @@
+package vibelogger
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Snapshot copies the current log file to dest and truncates the active file
+// in place, for backup jobs that must not interrupt logging. Entries queued on
+// WriterShards are written first; writes and rotations wait while the copy is
+// made, so every entry ends up in exactly one of the two files. dest is
+// replaced atomically and finalized with a snapshot footer; the active file
+// keeps its path and open handle and restarts with a new header. Files written
+// next to the main file, such as the error file, are not included. A
+// sandboxed logger resolves dest against its SandboxDir and rejects paths
+// outside it. dest must not be one of the logger's own files.
+func (l *Logger) Snapshot(dest string) error {
+	l.Flush()
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if l.file == nil {
+		return fmt.Errorf("log file is closed")
+	}
+	dest, err := l.config.sandboxPath(dest)
+	if err != nil {
+		return fmt.Errorf("invalid snapshot destination: %w", err)
+	}
+	if rm := l.rotationMgr; rm != nil {
+		rm.mutex.Lock()
+		defer rm.mutex.Unlock()
+	}
+	if err := l.checkSnapshotDest(dest); err != nil {
+		return err
+	}
+
+	if err := l.copySnapshot(dest); err != nil {
+		return err
+	}
+
+	if err := l.file.Truncate(0); err != nil {
+		return fmt.Errorf("failed to truncate log file: %w", err)
+	}
+	l.fileEntries = 0
+	headerSize, err := l.writeHeader()
+	if err != nil {
+		return fmt.Errorf("failed to write header after snapshot: %w", err)
+	}
+	l.currentSize = headerSize
+	if rm := l.rotationMgr; rm != nil {
+		rm.cachedFileSize = headerSize
+		rm.lastSizeSync = time.Now()
+	}
+	return nil
+}
+
+// checkSnapshotDest rejects a dest that is the active file, a rotated file or
+// a split file of the logger; renaming the snapshot over it would destroy the
+// entries it holds. The caller must hold the mutex and the rotation mutex.
+func (l *Logger) checkSnapshotDest(dest string) error {
+	absDest, err := filepath.Abs(dest)
+	if err != nil {
+		return fmt.Errorf("invalid snapshot destination: %w", err)
+	}
+	destInfo, statErr := os.Stat(dest)
+
+	for _, path := range l.snapshotProtectedFiles() {
+		absPath, err := filepath.Abs(path)
+		if err == nil && absPath == absDest {
+			return fmt.Errorf("invalid snapshot destination: %s is a file of the logger", dest)
+		}
+		// Symlinks and hard links reach the same file under another name
+		if statErr != nil {
+			continue
+		}
+		if info, err := os.Stat(path); err == nil && os.SameFile(destInfo, info) {
+			return fmt.Errorf("invalid snapshot destination: %s is a file of the logger", dest)
+		}
+	}
+	return nil
+}
+
+// snapshotProtectedFiles lists the active file, the rotated files and the
+// files of split file loggers with their rotated files. The caller must hold
+// the mutex and the rotation mutex.
+func (l *Logger) snapshotProtectedFiles() []string {
+	files := []string{l.filePath}
+	if rm := l.rotationMgr; rm != nil {
+		files = append(files, rm.rotatedFiles...)
+	}
+	for _, sink := range l.sinks {
+		var child *Logger
+		switch s := sink.(type) {
+		case *fileSink:
+			child = s.logger
+		case *metricsSink:
+			child = s.logger
+		default:
+			continue
+		}
+		files = append(files, child.filePath)
+		files = append(files, child.GetRotatedFiles()...)
+	}
+	return files
+}
+
+// copySnapshot writes the current file and a snapshot footer to a temporary
+// file next to dest and renames it to dest. The caller must hold the mutex.
+func (l *Logger) copySnapshot(dest string) error {
+	src, err := os.Open(l.filePath)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+	defer src.Close()
+
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(dest), "."+filepath.Base(dest)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create snapshot: %w", err)
+	}
+	defer os.Remove(tmp.Name()) // No-op once renamed
+
+	if _, err := io.Copy(tmp, src); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to copy log file: %w", err)
+	}
+	if l.fileMarkersEnabled() {
+		footer, err := l.encodeFileRecord(l.newFooter(FooterReasonSnapshot))
+		if err == nil {
+			_, err = tmp.Write(footer)
+		}
+		if err != nil {
+			tmp.Close()
+			return fmt.Errorf("failed to write snapshot footer: %w", err)
+		}
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to sync snapshot: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close snapshot: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), dest); err != nil {
+		return fmt.Errorf("failed to move snapshot into place: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package vibelogger
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	config := DefaultConfig()
+	config.FilePath = filepath.Join(dir, "app.log")
+	config.WriterShards = 2
+	logger, err := CreateFileLoggerWithConfig("snapshot", config)
+	if err != nil {
+		t.Fatalf("failed to create logger: %v", err)
+	}
+
+	// Snapshots taken while writers keep logging must not lose or repeat entries
+	var wg sync.WaitGroup
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				logger.Info("checkout", "Order placed")
+			}
+		}()
+	}
+	var snapshots []string
+	for i := 0; i < 3; i++ {
+		dest := filepath.Join(dir, "backup", fmt.Sprintf("app-%d.log", i))
+		if err := logger.Snapshot(dest); err != nil {
+			t.Fatalf("snapshot %d failed: %v", i, err)
+		}
+		snapshots = append(snapshots, dest)
+	}
+	wg.Wait()
+	if err := logger.Close(); err != nil {
+		t.Fatalf("failed to close logger: %v", err)
+	}
+
+	total := 0
+	for _, path := range append(snapshots, config.FilePath) {
+		result, err := ReadLogFile(path)
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", path, err)
+		}
+		if !result.CleanShutdown() {
+			t.Errorf("expected %s to have a header and a footer", path)
+		}
+		if path != config.FilePath && result.Footer.Reason != FooterReasonSnapshot {
+			t.Errorf("expected a snapshot footer, got %+v", result.Footer)
+		}
+		total += len(result.Entries)
+	}
+	if total != 200 {
+		t.Errorf("expected 200 entries across the snapshots and the log, got %d", total)
+	}
+
+	if err := logger.Snapshot(filepath.Join(dir, "closed.log")); err == nil {
+		t.Error("expected an error for a closed logger")
+	}
+}
+
+func TestSnapshotSandbox(t *testing.T) {
+	sandbox := t.TempDir()
+	logger, err := CreateFileLoggerWithConfig("snapshot", HardenedConfig(sandbox))
+	if err != nil {
+		t.Fatalf("failed to create logger: %v", err)
+	}
+	defer logger.Close()
+	logger.Info("checkout", "Order placed")
+
+	if err := logger.Snapshot(filepath.Join(t.TempDir(), "outside.log")); err == nil {
+		t.Error("expected a destination outside the sandbox to be rejected")
+	}
+	if err := logger.Snapshot("../outside.log"); err == nil {
+		t.Error("expected a relative destination leaving the sandbox to be rejected")
+	}
+	if err := logger.Snapshot("backup/app.log"); err != nil {
+		t.Fatalf("snapshot failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(sandbox, "backup", "app.log")); err != nil {
+		t.Errorf("expected the snapshot below the sandbox: %v", err)
+	}
+}
+
+func TestSnapshotRejectsOwnFiles(t *testing.T) {
+	dir := t.TempDir()
+	config := DefaultConfig()
+	config.FilePath = filepath.Join(dir, "app.log")
+	config.SplitErrorFile = true
+	logger, err := CreateFileLoggerWithConfig("snapshot", config)
+	if err != nil {
+		t.Fatalf("failed to create logger: %v", err)
+	}
+	defer logger.Close()
+	logger.Error("checkout", "Payment failed")
+	if err := logger.ForceRotation(); err != nil {
+		t.Fatalf("failed to rotate: %v", err)
+	}
+	rotated := logger.GetRotatedFiles()
+	if len(rotated) != 1 {
+		t.Fatalf("expected 1 rotated file, got %v", rotated)
+	}
+	link := filepath.Join(dir, "link.log")
+	if err := os.Symlink(config.FilePath, link); err != nil {
+		t.Fatalf("failed to create symlink: %v", err)
+	}
+
+	for _, dest := range []string{
+		config.FilePath,
+		filepath.Join(dir, ".", "app.log"),
+		rotated[0],
+		errorFilePath(config.FilePath),
+		link,
+	} {
+		if err := logger.Snapshot(dest); err == nil {
+			t.Errorf("expected snapshot to %s to be rejected", dest)
+		}
+	}
+	result, err := ReadLogFile(rotated[0])
+	if err != nil || len(result.Entries) != 1 || result.Footer == nil || result.Footer.Reason != FooterReasonRotation {
+		t.Errorf("expected the rotated file to be untouched, got %+v (err: %v)", result, err)
+	}
+}
@@ -0,0 +1,145 @@
+package vibelogger
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// SourceSnippetLines is the number of lines shown before and after the line of
+// the top application frame
+const SourceSnippetLines = 3
+
+// maxSnippetFileSize skips generated or vendored files too large to be useful
+const maxSnippetFileSize = 2 << 20
+
+// packagePrefix identifies frames inside the logger itself. It is derived from
+// a function of the package so it follows the module path of the build.
+var packagePrefix = func() string {
+	name := runtime.FuncForPC(reflect.ValueOf(parseStackFrame).Pointer()).Name()
+	return name[:strings.LastIndex(name, ".")+1]
+}()
+
+// stackFrame is a parsed "file:line function" stack trace entry
+type stackFrame struct {
+	File     string
+	Line     int
+	Function string
+}
+
+// parseStackFrame splits a stack trace entry produced by getStackTrace
+func parseStackFrame(s string) (stackFrame, bool) {
+	space := strings.LastIndex(s, " ")
+	if space < 0 {
+		return stackFrame{}, false
+	}
+	location, function := s[:space], s[space+1:]
+	colon := strings.LastIndex(location, ":")
+	if colon < 0 {
+		return stackFrame{}, false
+	}
+	line, err := strconv.Atoi(location[colon+1:])
+	if err != nil {
+		return stackFrame{}, false
+	}
+	return stackFrame{File: location[:colon], Line: line, Function: function}, true
+}
+
+// isAppFrame reports whether a frame belongs to the application rather than
+// the Go runtime or the logger
+func isAppFrame(frame stackFrame) bool {
+	if strings.HasPrefix(frame.Function, "runtime.") || strings.HasPrefix(frame.Function, "testing.") {
+		return false
+	}
+	return !isLoggerFrame(frame)
+}
+
+// isLoggerFrame reports whether a frame is inside the logger. The logger's own
+// tests count as application code.
+func isLoggerFrame(frame stackFrame) bool {
+	return strings.HasPrefix(frame.Function, packagePrefix) && !strings.HasSuffix(frame.File, "_test.go")
+}
+
+// topAppFrame returns the frame marked by stackFilter.apply, or else the first
+// application frame of a stack trace
+func topAppFrame(stack []string) (stackFrame, bool) {
+	for _, s := range stack {
+		if strings.HasPrefix(s, AppFrameMarker) {
+			return parseStackFrame(strings.TrimPrefix(s, AppFrameMarker))
+		}
+	}
+	for _, s := range stack {
+		if frame, ok := parseStackFrame(s); ok && isAppFrame(frame) {
+			return frame, true
+		}
+	}
+	return stackFrame{}, false
+}
+
+// attachSourceSnippet adds the source lines around the top application frame
+// to the context. Nothing is added when the source file is not available,
+// e.g. in binaries deployed without their sources.
+func attachSourceSnippet(entry *LogEntry) {
+	frame, ok := topAppFrame(entry.StackTrace)
+	if !ok {
+		return
+	}
+	lines, first, err := readSourceLines(frame.File, frame.Line, SourceSnippetLines)
+	if err != nil || len(lines) == 0 {
+		return
+	}
+
+	if entry.Context == nil {
+		entry.Context = make(map[string]interface{})
+	}
+	entry.Context["source"] = map[string]interface{}{
+		"file":       frame.File,
+		"line":       frame.Line,
+		"function":   frame.Function,
+		"first_line": first,
+		"lines":      lines,
+	}
+}
+
+// readSourceLines returns up to context lines before and after line, along
+// with the number of the first returned line
+func readSourceLines(filename string, line, context int) ([]string, int, error) {
+	info, err := os.Stat(filename)
+	if err != nil {
+		return nil, 0, err
+	}
+	if info.Size() > maxSnippetFileSize {
+		return nil, 0, fmt.Errorf("source file too large: %d bytes", info.Size())
+	}
+
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer file.Close()
+
+	first := line - context
+	if first < 1 {
+		first = 1
+	}
+	last := line + context
+
+	var lines []string
+	scanner := bufio.NewScanner(file)
+	for n := 1; n <= last && scanner.Scan(); n++ {
+		if n >= first {
+			lines = append(lines, scanner.Text())
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, 0, err
+	}
+	if line-first >= len(lines) {
+		return nil, 0, fmt.Errorf("line %d beyond end of %s", line, filename)
+	}
+	return lines, first, nil
+}
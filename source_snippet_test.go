@@ -0,0 +1,72 @@
+package vibelogger
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseStackFrame(t *testing.T) {
+	frame, ok := parseStackFrame("/src/app/main.go:42 main.handleRequest")
+	if !ok || frame.File != "/src/app/main.go" || frame.Line != 42 || frame.Function != "main.handleRequest" {
+		t.Errorf("unexpected frame: %+v (ok=%v)", frame, ok)
+	}
+	if _, ok := parseStackFrame("garbage"); ok {
+		t.Error("expected parse failure")
+	}
+}
+
+func TestTopAppFrame(t *testing.T) {
+	stack := []string{
+		"/src/vibe-logger-go/scoped.go:50 github.com/sumee-139/vibe-logger-go.(*ScopedLogger).Error",
+		"/src/app/main.go:42 main.handleRequest",
+		"/usr/local/go/src/runtime/proc.go:250 runtime.main",
+	}
+	frame, ok := topAppFrame(stack)
+	if !ok || frame.Function != "main.handleRequest" {
+		t.Errorf("unexpected top frame: %+v", frame)
+	}
+}
+
+func TestReadSourceLines(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "main.go")
+	os.WriteFile(file, []byte("1\n2\n3\n4\n5\n6\n7\n8\n"), 0644)
+
+	lines, first, err := readSourceLines(file, 2, 3)
+	if err != nil || first != 1 || strings.Join(lines, ",") != "1,2,3,4,5" {
+		t.Errorf("unexpected lines %v from %d (err=%v)", lines, first, err)
+	}
+	lines, first, _ = readSourceLines(file, 7, 3)
+	if first != 4 || strings.Join(lines, ",") != "4,5,6,7,8" {
+		t.Errorf("unexpected lines %v from %d", lines, first)
+	}
+	if _, _, err := readSourceLines(file, 20, 3); err == nil {
+		t.Error("expected error for a line beyond the end of the file")
+	}
+}
+
+func TestSourceSnippetOnError(t *testing.T) {
+	logger := NewLoggerWithConfig("snippet", &LoggerConfig{AutoSave: false, EnableMemoryLog: true, MemoryLogLimit: 10, SourceSnippets: true})
+	defer logger.Close()
+
+	logger.Error("snippet_test", "Something failed") // snippet marker
+	logger.Warn("snippet_test", "Only a warning")
+
+	logs := logger.GetMemoryLogs()
+	source, ok := logs[0].Context["source"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected source snippet, got context %v", logs[0].Context)
+	}
+	if !strings.HasSuffix(source["file"].(string), "source_snippet_test.go") {
+		t.Errorf("unexpected snippet file: %v", source["file"])
+	}
+	lines := source["lines"].([]string)
+	line, first := source["line"].(int), source["first_line"].(int)
+	if !strings.Contains(lines[line-first], "snippet marker") {
+		t.Errorf("snippet does not contain the logging line: %v", lines)
+	}
+	if _, ok := logs[1].Context["source"]; ok {
+		t.Error("warnings should not carry a source snippet")
+	}
+}
@@ -0,0 +1,88 @@
+package vibelogger
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// fileSink copies matching entries to a separate file with its own rotation.
+// It backs the error file and category routes.
+type fileSink struct {
+	logger *Logger
+	match  func(entry *LogEntry) bool
+}
+
+// errorFilePath derives the error file path from the main log file path,
+// e.g. logs/app.log becomes logs/app_error.log
+func errorFilePath(path string) string {
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + "_error" + ext
+}
+
+// childFileConfig returns a copy of config for a file written next to the main
+// log file, with periodic entries, the janitor and further file splitting
+// disabled
+func childFileConfig(config *LoggerConfig, path string) *LoggerConfig {
+	child := *config
+	child.FilePath = path
+	child.Mode = ModeFile
+	child.AutoSave = true
+	child.SplitErrorFile = false
+	child.MetricsFile = false
+	child.WriterShards = 0
+	child.CategoryRoutes = ""
+	child.EnableMemoryLog = false
+	child.HeartbeatInterval = 0
+	child.SummaryInterval = 0
+	child.RuntimeMonitorInterval = 0
+	child.QueueStatsInterval = 0
+	child.HistogramInterval = 0
+	child.JanitorInterval = 0 // The main logger's janitor covers the whole tree
+	return &child
+}
+
+// newErrorFileSink opens the error file next to mainPath
+func newErrorFileSink(name, mainPath string, config *LoggerConfig) (*fileSink, error) {
+	errConfig := childFileConfig(config, errorFilePath(mainPath))
+	if config.ErrorMaxFileSize > 0 {
+		errConfig.MaxFileSize = config.ErrorMaxFileSize
+	}
+	if config.ErrorMaxRotatedFiles > 0 {
+		errConfig.MaxRotatedFiles = config.ErrorMaxRotatedFiles
+	}
+
+	logger, err := CreateFileLoggerWithConfig(name+"_error", errConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create error log file: %w", err)
+	}
+	return &fileSink{logger: logger, match: func(entry *LogEntry) bool {
+		return getSeverityScore(entry.Level) >= getSeverityScore(ERROR)
+	}}, nil
+}
+
+// Write appends matching entries to the file
+func (s *fileSink) Write(entry *LogEntry) error {
+	if !s.match(entry) {
+		return nil
+	}
+
+	l := s.logger
+	jsonData, err := l.encodeFileEntry(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal log entry: %w", err)
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if l.file == nil {
+		return nil
+	}
+	l.stats.recordEntry(entry, recordSize(jsonData))
+	return l.writeFile(jsonData)
+}
+
+// Close finalizes the file
+func (s *fileSink) Close() error {
+	return s.logger.Close()
+}
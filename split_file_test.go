@@ -0,0 +1,117 @@
+package vibelogger
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestErrorFilePath(t *testing.T) {
+	tests := map[string]string{
+		"logs/app.log":                "logs/app_error.log",
+		"logs/default/app_123.log":    "logs/default/app_123_error.log",
+		"logs/no_extension":           "logs/no_extension_error",
+		"logs/with.dots/service.json": "logs/with.dots/service_error.json",
+	}
+	for path, expected := range tests {
+		if got := errorFilePath(path); got != expected {
+			t.Errorf("errorFilePath(%q) = %q, expected %q", path, got, expected)
+		}
+	}
+}
+
+func TestSplitErrorFile(t *testing.T) {
+	defer os.RemoveAll("test_logs")
+
+	config := DefaultConfig()
+	config.FilePath = "test_logs/split_test.log"
+	config.SplitErrorFile = true
+	config.ErrorMaxFileSize = 2048
+
+	logger, err := CreateFileLoggerWithConfig("split_test", config)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	logger.Info("test", "Info entry")
+	logger.Warn("test", "Warn entry")
+	logger.Error("test", "Error entry")
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Failed to close logger: %v", err)
+	}
+
+	main, err := ReadLogFile(config.FilePath)
+	if err != nil {
+		t.Fatalf("Failed to read main file: %v", err)
+	}
+	if len(main.Entries) != 3 {
+		t.Errorf("Expected 3 entries in the main file, got %d", len(main.Entries))
+	}
+
+	errors, err := ReadLogFile("test_logs/split_test_error.log")
+	if err != nil {
+		t.Fatalf("Failed to read error file: %v", err)
+	}
+	if len(errors.Entries) != 1 || errors.Entries[0].Message != "Error entry" {
+		t.Fatalf("Expected only the error entry in the error file, got %v", errors.Entries)
+	}
+	if !errors.CleanShutdown() || errors.Footer.EntryCount != 1 {
+		t.Errorf("Expected the error file to be closed cleanly with 1 entry")
+	}
+	if errors.Header.Config.MaxFileSize != 2048 {
+		t.Errorf("Expected error file size limit 2048, got %d", errors.Header.Config.MaxFileSize)
+	}
+}
+
+// childLoggers returns the loggers of the files written next to the main file
+func childLoggers(l *Logger) []*Logger {
+	var children []*Logger
+	for _, sink := range l.sinks {
+		switch s := sink.(type) {
+		case *fileSink:
+			children = append(children, s.logger)
+		case *metricsSink:
+			children = append(children, s.logger)
+		}
+	}
+	return children
+}
+
+func TestSplitFilesShareBackgroundWorkers(t *testing.T) {
+	config := DefaultConfig()
+	config.SandboxDir = t.TempDir()
+	config.ProjectName = "split-workers"
+	config.ConsoleOutput = ConsoleOff
+	config.SplitErrorFile = true
+	config.MetricsFile = true
+	config.CategoryRoutes = "database=db.log"
+	config.JanitorInterval = time.Hour
+	config.HistogramInterval = time.Hour
+
+	logger, err := CreateFileLoggerWithConfig("split_workers", config)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	defer logger.Close()
+
+	children := childLoggers(logger)
+	if len(children) != 3 {
+		t.Fatalf("Expected error, metrics and route loggers, got %d", len(children))
+	}
+	janitors, histograms := 0, 0
+	for _, l := range append(children, logger) {
+		l.bgMutex.Lock()
+		if l.janitor != nil {
+			janitors++
+		}
+		if l.histograms != nil {
+			histograms++
+		}
+		l.bgMutex.Unlock()
+	}
+	if janitors != 1 || logger.janitor == nil {
+		t.Errorf("Expected exactly one janitor on the main logger, got %d", janitors)
+	}
+	if histograms != 1 || logger.histograms == nil {
+		t.Errorf("Expected histogram reports only from the main logger, got %d reporters", histograms)
+	}
+}
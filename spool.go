@@ -0,0 +1,187 @@
+package vibelogger
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Spool defaults used by NewSpoolSink for non-positive values
+const (
+	DefaultSpoolSize  = 64 * 1024 * 1024
+	DefaultSpoolRetry = 5 * time.Second
+)
+
+// ErrSpoolFull is returned when an entry could neither be delivered nor spooled
+var ErrSpoolFull = errors.New("spool is full")
+
+// SpoolSink protects a network sink against outages. Entries that cannot be
+// delivered are appended to a bounded NDJSON spool file and replayed in order
+// once the destination accepts entries again. While the spool is not empty,
+// new entries are queued behind it so ordering is kept. A spool left behind by
+// a previous run is replayed as well; entries keep their IDs, so a Receiver
+// that saw an entry before a crash drops it as a duplicate.
+type SpoolSink struct {
+	sink      Sink
+	path      string
+	maxSize   int64
+	retry     time.Duration
+	mutex     sync.Mutex
+	size      int64     // Bytes in the spool file
+	pending   int64     // Entries in the spool file
+	dropped   int64     // Entries lost because the spool was full
+	nextRetry time.Time // Earliest time of the next delivery attempt after a failure
+}
+
+// NewSpoolSink wraps sink with a spool file at path limited to maxSize bytes.
+// Delivery is retried at most every retry interval while the destination fails.
+func NewSpoolSink(sink Sink, path string, maxSize int64, retry time.Duration) (*SpoolSink, error) {
+	if maxSize <= 0 {
+		maxSize = DefaultSpoolSize
+	}
+	if retry <= 0 {
+		retry = DefaultSpoolRetry
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create spool directory: %w", err)
+	}
+
+	s := &SpoolSink{sink: sink, path: path, maxSize: maxSize, retry: retry}
+	if data, err := os.ReadFile(path); err == nil {
+		s.size = int64(len(data))
+		s.pending = int64(bytes.Count(data, []byte("\n")))
+	} else if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read spool file: %w", err)
+	}
+	return s, nil
+}
+
+// Write delivers the entry, or spools it if the destination is unavailable.
+// It only fails when the entry is lost because the spool is full.
+func (s *SpoolSink) Write(entry *LogEntry) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.pending > 0 && time.Now().After(s.nextRetry) {
+		s.replay()
+	}
+	if s.pending == 0 && time.Now().After(s.nextRetry) {
+		if err := s.deliver(entry); err == nil {
+			return nil
+		}
+		s.nextRetry = time.Now().Add(s.retry)
+	}
+	return s.append(entry)
+}
+
+// deliver writes one entry to the wrapped sink, converting panics into errors
+func (s *SpoolSink) deliver(entry *LogEntry) error {
+	var err error
+	if p := callSafely("sink", func() { err = s.sink.Write(entry) }); p != nil {
+		err = p
+	}
+	return err
+}
+
+// append adds an entry to the end of the spool file
+func (s *SpoolSink) append(entry *LogEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal log entry: %w", err)
+	}
+	data = append(data, '\n')
+	if s.size+int64(len(data)) > s.maxSize {
+		s.dropped++
+		return ErrSpoolFull
+	}
+
+	file, err := os.OpenFile(s.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open spool file: %w", err)
+	}
+	defer file.Close()
+	if _, err := file.Write(data); err != nil {
+		return fmt.Errorf("failed to write spool file: %w", err)
+	}
+	s.size += int64(len(data))
+	s.pending++
+	return nil
+}
+
+// replay delivers spooled entries in order until one fails, then keeps the
+// undelivered rest in the spool. Unreadable lines are skipped.
+func (s *SpoolSink) replay() {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			s.size, s.pending = 0, 0
+		}
+		return
+	}
+
+	offset := 0
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), len(data)+1)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		var entry LogEntry
+		if err := json.Unmarshal(line, &entry); err == nil {
+			if err := s.deliver(&entry); err != nil {
+				s.nextRetry = time.Now().Add(s.retry)
+				break
+			}
+		}
+		offset += len(line) + 1
+	}
+
+	if offset >= len(data) {
+		os.Remove(s.path)
+		s.size, s.pending = 0, 0
+		return
+	}
+	// Replace the spool atomically; if that fails the delivered entries are
+	// sent again later and can be deduplicated by their IDs
+	rest := data[offset:]
+	tmpPath := s.path + ".tmp"
+	if err := os.WriteFile(tmpPath, rest, 0600); err != nil {
+		return
+	}
+	if err := os.Rename(tmpPath, s.path); err != nil {
+		os.Remove(tmpPath)
+		return
+	}
+	s.size = int64(len(rest))
+	s.pending = int64(bytes.Count(rest, []byte("\n")))
+}
+
+// Pending returns the number of spooled entries waiting for delivery
+func (s *SpoolSink) Pending() int64 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.pending
+}
+
+// Dropped returns the number of entries lost because the spool was full
+func (s *SpoolSink) Dropped() int64 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.dropped
+}
+
+// Close makes a last delivery attempt and closes the wrapped sink. Entries that
+// are still spooled stay on disk for the next run.
+func (s *SpoolSink) Close() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.pending > 0 {
+		s.replay()
+	}
+	return s.sink.Close()
+}
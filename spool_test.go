@@ -0,0 +1,91 @@
+package vibelogger
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSpoolSinkReplaysInOrder(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "spool", "http.spool")
+	inner := &recordingSink{writeErr: errors.New("connection refused")}
+	sink, err := NewSpoolSink(inner, path, 0, time.Millisecond)
+	if err != nil {
+		t.Fatalf("Failed to create spool sink: %v", err)
+	}
+
+	for _, message := range []string{"first", "second"} {
+		if err := sink.Write(&LogEntry{ID: NewEntryID(), Message: message}); err != nil {
+			t.Fatalf("Expected the entry to be spooled, got %v", err)
+		}
+	}
+	if sink.Pending() != 2 {
+		t.Fatalf("Expected 2 spooled entries, got %d", sink.Pending())
+	}
+
+	// The destination recovers
+	inner.mutex.Lock()
+	inner.writeErr = nil
+	inner.entries = nil
+	inner.mutex.Unlock()
+	time.Sleep(2 * time.Millisecond)
+
+	if err := sink.Write(&LogEntry{ID: NewEntryID(), Message: "third"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(inner.entries) != 3 {
+		t.Fatalf("Expected 3 delivered entries, got %d", len(inner.entries))
+	}
+	for i, message := range []string{"first", "second", "third"} {
+		if inner.entries[i].Message != message {
+			t.Errorf("Expected %s at %d, got %s", message, i, inner.entries[i].Message)
+		}
+	}
+	if sink.Pending() != 0 {
+		t.Errorf("Expected an empty spool, got %d entries", sink.Pending())
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("Expected the spool file to be removed after replay")
+	}
+}
+
+func TestSpoolSinkSurvivesRestart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "http.spool")
+	down := &recordingSink{writeErr: errors.New("connection refused")}
+	sink, _ := NewSpoolSink(down, path, 0, time.Hour)
+	sink.Write(&LogEntry{ID: "01ARYZ6S41TSV4RRFFQ69G5FAV", Message: "before restart"})
+	sink.Close()
+
+	up := &recordingSink{}
+	sink, err := NewSpoolSink(up, path, 0, time.Hour)
+	if err != nil {
+		t.Fatalf("Failed to reopen spool: %v", err)
+	}
+	if sink.Pending() != 1 {
+		t.Fatalf("Expected the spooled entry to survive a restart, got %d", sink.Pending())
+	}
+	sink.Write(&LogEntry{Message: "after restart"})
+
+	if len(up.entries) != 2 || up.entries[0].ID != "01ARYZ6S41TSV4RRFFQ69G5FAV" {
+		t.Errorf("Expected the spooled entry to be replayed first with its ID, got %v", up.entries)
+	}
+}
+
+func TestSpoolSinkIsBounded(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "http.spool")
+	inner := &recordingSink{writeErr: errors.New("connection refused")}
+	sink, _ := NewSpoolSink(inner, path, 300, time.Hour)
+
+	var lost error
+	for i := 0; i < 10 && lost == nil; i++ {
+		lost = sink.Write(&LogEntry{Message: "entry"})
+	}
+	if !errors.Is(lost, ErrSpoolFull) || sink.Dropped() != 1 {
+		t.Errorf("Expected ErrSpoolFull once the spool is full, got %v (dropped %d)", lost, sink.Dropped())
+	}
+	if info, err := os.Stat(path); err != nil || info.Size() > 300 {
+		t.Errorf("Expected the spool to stay within 300 bytes, got %v %v", info, err)
+	}
+}
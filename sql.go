@@ -0,0 +1,155 @@
+package vibelogger
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// MaxSQLLength is the number of characters of a normalized statement kept by WithSanitizedSQL
+const MaxSQLLength = 1000
+
+// sqlValueList matches lists of placeholders such as "(?, ?, ?)" produced by IN clauses
+var sqlValueList = regexp.MustCompile(`\(\s*\?(?:\s*,\s*\?)+\s*\)`)
+
+// WithSanitizedSQL records a SQL statement without its literal values. String and
+// numeric literals are replaced by "?", comments and extra whitespace are removed
+// and value lists are collapsed, so the same query always produces the same
+// sql_fingerprint. Parameter values are never logged, only their count and types.
+// Statements longer than MaxSQLLength are truncated.
+func WithSanitizedSQL(query string, args ...interface{}) LogOption {
+	normalized := NormalizeSQL(query)
+	fingerprint := SQLFingerprint(normalized)
+
+	truncated := false
+	if runes := []rune(normalized); len(runes) > MaxSQLLength {
+		normalized = string(runes[:MaxSQLLength]) + "…"
+		truncated = true
+	}
+
+	argTypes := make([]string, len(args))
+	for i, arg := range args {
+		argTypes[i] = fmt.Sprintf("%T", arg)
+	}
+
+	return func(entry *LogEntry) {
+		if entry.Context == nil {
+			entry.Context = make(map[string]interface{})
+		}
+		entry.Context["sql"] = normalized
+		entry.Context["sql_fingerprint"] = fingerprint
+		entry.Context["sql_param_count"] = len(args)
+		if len(args) > 0 {
+			entry.Context["sql_param_types"] = argTypes
+		}
+		if truncated {
+			entry.Context["sql_truncated"] = true
+		}
+	}
+}
+
+// SQLFingerprint returns a short stable hash of a normalized statement
+func SQLFingerprint(normalized string) string {
+	sum := sha256.Sum256([]byte(strings.ToLower(normalized)))
+	return hex.EncodeToString(sum[:8])
+}
+
+// NormalizeSQL replaces literals in a statement with "?" and removes comments
+// and redundant whitespace. Quoted identifiers and placeholders are kept.
+func NormalizeSQL(query string) string {
+	var b strings.Builder
+	runes := []rune(query)
+	space := false // Whitespace seen since the last token
+	glue := false  // The next token attaches to the last one
+
+	// emit writes s, collapsing whitespace between tokens into one space
+	emit := func(s string) {
+		if space && !glue && b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+		space, glue = false, false
+		b.WriteString(s)
+	}
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			space = true
+		case r == '-' && i+1 < len(runes) && runes[i+1] == '-':
+			// Line comment
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+			space = true
+		case r == '/' && i+1 < len(runes) && runes[i+1] == '*':
+			// Block comment
+			i += 2
+			for i+1 < len(runes) && !(runes[i] == '*' && runes[i+1] == '/') {
+				i++
+			}
+			i++
+			space = true
+		case r == '\'':
+			// String literal, '' is an escaped quote
+			for i++; i < len(runes); i++ {
+				if runes[i] == '\'' {
+					if i+1 < len(runes) && runes[i+1] == '\'' {
+						i++
+						continue
+					}
+					break
+				}
+			}
+			emit("?")
+		case r == '"' || r == '`':
+			// Quoted identifier
+			start := i
+			for i++; i < len(runes) && runes[i] != r; i++ {
+			}
+			end := i + 1
+			if end > len(runes) {
+				end = len(runes)
+			}
+			emit(string(runes[start:end]))
+		case unicode.IsDigit(r) || (r == '.' && i+1 < len(runes) && unicode.IsDigit(runes[i+1])):
+			// Numeric literal
+			for i+1 < len(runes) && (unicode.IsDigit(runes[i+1]) || runes[i+1] == '.' ||
+				runes[i+1] == 'e' || runes[i+1] == 'E' || runes[i+1] == 'x' || runes[i+1] == 'X' ||
+				(runes[i+1] >= 'a' && runes[i+1] <= 'f') || (runes[i+1] >= 'A' && runes[i+1] <= 'F')) {
+				i++
+			}
+			emit("?")
+		case isSQLIdentRune(r) || r == '$' || r == ':' || r == '@':
+			// Identifier, keyword or placeholder such as $1, :name, @p1
+			start := i
+			for i+1 < len(runes) && (isSQLIdentRune(runes[i+1]) || unicode.IsDigit(runes[i+1])) {
+				i++
+			}
+			emit(string(runes[start : i+1]))
+		case r == ',' || r == ')' || r == ';':
+			// Attach to the previous token
+			b.WriteRune(r)
+			space = r == ','
+		case r == '(' || r == '.':
+			// Attach to the next token
+			emit(string(r))
+			glue = true
+		default:
+			// Operators are separated by spaces
+			emit(string(r))
+			space = true
+		}
+	}
+
+	normalized := strings.TrimSuffix(b.String(), ";")
+	return sqlValueList.ReplaceAllString(normalized, "(?)")
+}
+
+// isSQLIdentRune reports whether r can start or continue an identifier
+func isSQLIdentRune(r rune) bool {
+	return unicode.IsLetter(r) || r == '_'
+}
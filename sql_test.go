@@ -0,0 +1,58 @@
+package vibelogger
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNormalizeSQL(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected string
+	}{
+		{"SELECT * FROM users WHERE id = 42", "SELECT * FROM users WHERE id = ?"},
+		{"select name from users where email = 'a@b.c' and age > 3.5", "select name from users where email = ? and age > ?"},
+		{"SELECT 'it''s', \"quoted col\" FROM t", "SELECT ?, \"quoted col\" FROM t"},
+		{"SELECT *\n  FROM orders -- recent only\n WHERE created > $1 /* index hint */", "SELECT * FROM orders WHERE created > $1"},
+		{"SELECT * FROM t WHERE id IN (1, 2, 3);", "SELECT * FROM t WHERE id IN (?)"},
+		{"INSERT INTO t (a, b) VALUES (:a, 0x1F)", "INSERT INTO t (a, b) VALUES (:a, ?)"},
+		{"SELECT t1.col FROM table1 t1", "SELECT t1.col FROM table1 t1"},
+		{"SELECT count( * ) FROM t WHERE x IN ( 'a' , 'b' )", "SELECT count(*) FROM t WHERE x IN (?)"},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizeSQL(tt.query); got != tt.expected {
+			t.Errorf("NormalizeSQL(%q)\n got: %q\nwant: %q", tt.query, got, tt.expected)
+		}
+	}
+}
+
+func TestWithSanitizedSQL(t *testing.T) {
+	entry := &LogEntry{}
+	WithSanitizedSQL("SELECT * FROM users WHERE id = 42 AND name = 'alice'", 42, "alice")(entry)
+
+	if entry.Context["sql"] != "SELECT * FROM users WHERE id = ? AND name = ?" {
+		t.Errorf("Unexpected sanitized SQL: %v", entry.Context["sql"])
+	}
+	if entry.Context["sql_param_count"] != 2 {
+		t.Errorf("Expected 2 parameters, got %v", entry.Context["sql_param_count"])
+	}
+	types := entry.Context["sql_param_types"].([]string)
+	if types[0] != "int" || types[1] != "string" {
+		t.Errorf("Unexpected parameter types: %v", types)
+	}
+
+	// Queries differing only in literals share a fingerprint
+	other := &LogEntry{}
+	WithSanitizedSQL("select *  from users where id = 7 and name = 'bob'")(other)
+	if entry.Context["sql_fingerprint"] != other.Context["sql_fingerprint"] {
+		t.Errorf("Expected equal fingerprints, got %v and %v",
+			entry.Context["sql_fingerprint"], other.Context["sql_fingerprint"])
+	}
+
+	long := &LogEntry{}
+	WithSanitizedSQL("SELECT " + strings.Repeat("col, ", MaxSQLLength) + "x FROM t")(long)
+	if long.Context["sql_truncated"] != true || len([]rune(long.Context["sql"].(string))) != MaxSQLLength+1 {
+		t.Error("Expected long statements to be truncated")
+	}
+}
@@ -0,0 +1,60 @@
+package vibelogger
+
+import "strings"
+
+// AppFrameMarker prefixes the first application frame of a stack trace
+const AppFrameMarker = "> "
+
+// stackFilter decides which stack frames belong to the application
+type stackFilter struct {
+	prefixes []string // Function prefixes of application code; empty leaves traces unchanged
+	verbose  bool     // Keep library and runtime frames
+}
+
+// newStackFilter builds the filter from the configuration
+func newStackFilter(config *LoggerConfig) stackFilter {
+	var prefixes []string
+	for _, prefix := range strings.Split(config.StackPrefixes, ",") {
+		if prefix = strings.TrimSpace(prefix); prefix != "" {
+			prefixes = append(prefixes, prefix)
+		}
+	}
+	return stackFilter{prefixes: prefixes, verbose: config.StackTraceVerbose}
+}
+
+// isApp reports whether a frame is application code
+func (f stackFilter) isApp(frame stackFrame) bool {
+	for _, prefix := range f.prefixes {
+		if strings.HasPrefix(frame.Function, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// apply marks the first application frame and, unless verbose, drops frames
+// outside the application. Without prefixes or without any application frame
+// the trace is returned unchanged.
+func (f stackFilter) apply(stack []string) []string {
+	if len(f.prefixes) == 0 {
+		return stack
+	}
+	filtered := make([]string, 0, len(stack))
+	marked := false
+	for _, s := range stack {
+		frame, ok := parseStackFrame(s)
+		app := ok && f.isApp(frame)
+		if app && !marked {
+			filtered = append(filtered, AppFrameMarker+s)
+			marked = true
+			continue
+		}
+		if app || f.verbose {
+			filtered = append(filtered, s)
+		}
+	}
+	if !marked {
+		return stack
+	}
+	return filtered
+}
@@ -0,0 +1,67 @@
+package vibelogger
+
+import (
+	"strings"
+	"testing"
+)
+
+var sampleStack = []string{
+	"/src/vibe-logger-go/scoped.go:50 github.com/sumee-139/vibe-logger-go.(*ScopedLogger).Error",
+	"/src/app/handler.go:42 github.com/acme/app/handler.Serve",
+	"/go/pkg/mod/github.com/lib/router.go:10 github.com/lib/router.Dispatch",
+	"/src/app/main.go:12 github.com/acme/app.main",
+	"/usr/local/go/src/runtime/proc.go:250 runtime.main",
+}
+
+func TestStackFilterPrefixes(t *testing.T) {
+	filter := newStackFilter(&LoggerConfig{StackPrefixes: "github.com/acme/, "})
+	stack := filter.apply(sampleStack)
+	expected := []string{AppFrameMarker + sampleStack[1], sampleStack[3]}
+	if strings.Join(stack, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("unexpected filtered stack:\n%s", strings.Join(stack, "\n"))
+	}
+
+	frame, ok := topAppFrame(stack)
+	if !ok || frame.Function != "github.com/acme/app/handler.Serve" {
+		t.Errorf("unexpected top frame: %+v", frame)
+	}
+}
+
+func TestStackFilterVerbose(t *testing.T) {
+	filter := newStackFilter(&LoggerConfig{StackPrefixes: "github.com/acme/", StackTraceVerbose: true})
+	stack := filter.apply(sampleStack)
+	if len(stack) != len(sampleStack) || stack[1] != AppFrameMarker+sampleStack[1] {
+		t.Errorf("verbose trace should keep all frames and mark the app frame: %v", stack)
+	}
+}
+
+func TestStackFilterDefault(t *testing.T) {
+	stack := newStackFilter(&LoggerConfig{}).apply(sampleStack)
+	if strings.Join(stack, "\n") != strings.Join(sampleStack, "\n") {
+		t.Errorf("default filter should leave the trace unchanged: %v", stack)
+	}
+
+	// Without any application frame the trace is left alone
+	filter := newStackFilter(&LoggerConfig{StackPrefixes: "github.com/other/"})
+	if stack := filter.apply(sampleStack); len(stack) != len(sampleStack) || stack[1] != sampleStack[1] {
+		t.Errorf("unexpected stack: %v", stack)
+	}
+}
+
+func TestStackTraceStartsAtCaller(t *testing.T) {
+	if packagePrefix != "github.com/sumee-139/vibe-logger-go." {
+		t.Errorf("unexpected package prefix %q", packagePrefix)
+	}
+
+	logger := NewLoggerWithConfig("stack_test", &LoggerConfig{EnableMemoryLog: true, MemoryLogLimit: 10})
+	defer logger.Close()
+	logger.Error("test", "Direct")
+	logger.Log(ERROR, "test", "Through Log")
+	logger.With().Error("test", "Scoped")
+
+	for _, entry := range logger.GetMemoryLogs() {
+		if len(entry.StackTrace) == 0 || !strings.HasSuffix(entry.StackTrace[0], ".TestStackTraceStartsAtCaller") {
+			t.Errorf("%s: expected the trace to start at the caller, got %v", entry.Message, entry.StackTrace)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package vibelogger
+
+import (
+	"sync"
+	"time"
+)
+
+// Stats is a point-in-time snapshot of a logger's activity
+type Stats struct {
+	StartTime      time.Time          `json:"start_time"`
+	Uptime         time.Duration      `json:"uptime"`
+	Project        string             `json:"project"`
+	TotalEntries   int64              `json:"total_entries"`
+	TotalBytes     int64              `json:"total_bytes"` // Encoded size of the entries, including newlines
+	EntriesByLevel map[LogLevel]int64 `json:"entries_by_level"`
+	TopOperations  []OperationVolume  `json:"top_operations,omitempty"` // Operations with the highest recent volume
+	Queues         []QueueStats       `json:"queues,omitempty"`         // Telemetry of asynchronous sinks
+	SLOs           []SLOStatus        `json:"slos,omitempty"`           // Error budget burn per operation, see SetSLORules
+	SLAViolations  map[string]int64   `json:"sla_violations,omitempty"` // Entries over their latency budget per operation, see SetLatencyBudgets
+}
+
+// loggerStats accumulates counters for Stats
+type loggerStats struct {
+	mutex        sync.Mutex
+	startTime    time.Time
+	project      string
+	totalEntries int64
+	totalBytes   int64
+	byLevel      map[LogLevel]int64
+	volumes      volumeTracker
+	slaViolation map[string]int64
+	window       summaryWindow // Counts since the last summary entry
+}
+
+// summaryWindow counts entries since the last summary
+type summaryWindow struct {
+	start     time.Time
+	total     int64
+	bytes     int64
+	byLevel   map[LogLevel]int64
+	byPattern map[string]int64
+	slaByOp   map[string]int64 // SLA violations per operation
+}
+
+// newSummaryWindow creates an empty window starting at start
+func newSummaryWindow(start time.Time) summaryWindow {
+	return summaryWindow{
+		start:     start,
+		byLevel:   make(map[LogLevel]int64),
+		byPattern: make(map[string]int64),
+		slaByOp:   make(map[string]int64),
+	}
+}
+
+// newLoggerStats creates counters of a logger of project starting now
+func newLoggerStats(project string) *loggerStats {
+	now := time.Now()
+	return &loggerStats{
+		startTime:    now,
+		project:      project,
+		byLevel:      make(map[LogLevel]int64),
+		volumes:      volumeTracker{operations: make(map[string]*operationVolume)},
+		slaViolation: make(map[string]int64),
+		window:       newSummaryWindow(now),
+	}
+}
+
+// recordEntry counts an entry accepted for writing, encoded as size bytes
+// (0 if encoding failed)
+func (s *loggerStats) recordEntry(entry *LogEntry, size int64) {
+	addProjectVolume(s.project, size)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.totalEntries++
+	s.totalBytes += size
+	s.byLevel[entry.Level]++
+	s.volumes.add(entry.Operation, size, time.Now())
+	violation, _ := entry.Context["sla_violation"].(bool)
+	if violation {
+		s.slaViolation[entry.Operation]++
+	}
+
+	// Summaries count the application's entries, not their own or the heartbeat
+	if _, builtin := builtinOperations[entry.Operation]; builtin {
+		return
+	}
+	s.window.total++
+	s.window.bytes += size
+	s.window.byLevel[entry.Level]++
+	if entry.Pattern != "" {
+		s.window.byPattern[entry.Pattern]++
+	}
+	if violation {
+		s.window.slaByOp[entry.Operation]++
+	}
+}
+
+// takeWindow returns the counts since the previous call and starts a new window
+func (s *loggerStats) takeWindow() summaryWindow {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	window := s.window
+	s.window = newSummaryWindow(time.Now())
+	return window
+}
+
+// snapshot returns a copy of the current counters
+func (s *loggerStats) snapshot() Stats {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	byLevel := make(map[LogLevel]int64, len(s.byLevel))
+	for level, count := range s.byLevel {
+		byLevel[level] = count
+	}
+	stats := Stats{
+		StartTime:      s.startTime,
+		Uptime:         time.Since(s.startTime),
+		Project:        s.project,
+		TotalEntries:   s.totalEntries,
+		TotalBytes:     s.totalBytes,
+		EntriesByLevel: byLevel,
+		TopOperations:  s.volumes.top(topVolumeOperations, time.Now()),
+	}
+	if len(s.slaViolation) > 0 {
+		stats.SLAViolations = make(map[string]int64, len(s.slaViolation))
+		for operation, count := range s.slaViolation {
+			stats.SLAViolations[operation] = count
+		}
+	}
+	return stats
+}
+
+// Stats returns a snapshot of the logger's activity since it was created
+func (l *Logger) Stats() Stats {
+	stats := l.stats.snapshot()
+	stats.Queues = l.queueStats()
+	stats.SLOs = l.sloStatus()
+	return stats
+}
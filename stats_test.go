@@ -0,0 +1,30 @@
+package vibelogger
+
+import (
+	"testing"
+)
+
+func TestStatsCountsEntries(t *testing.T) {
+	logger := NewLoggerWithConfig("stats_test", &LoggerConfig{AutoSave: false})
+
+	logger.Info("test", "one")
+	logger.Info("test", "two")
+	logger.Error("test", "three")
+
+	stats := logger.Stats()
+	if stats.TotalEntries != 3 {
+		t.Errorf("Expected 3 total entries, got %d", stats.TotalEntries)
+	}
+	if stats.EntriesByLevel[INFO] != 2 || stats.EntriesByLevel[ERROR] != 1 {
+		t.Errorf("Unexpected per-level counts: %v", stats.EntriesByLevel)
+	}
+	if stats.StartTime.IsZero() || stats.Uptime <= 0 {
+		t.Errorf("Expected start time and uptime to be set, got %v / %v", stats.StartTime, stats.Uptime)
+	}
+
+	// Snapshots must not share state with the logger
+	stats.EntriesByLevel[INFO] = 100
+	if logger.Stats().EntriesByLevel[INFO] != 2 {
+		t.Error("Stats should return a copy of the counters")
+	}
+}
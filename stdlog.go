@@ -0,0 +1,57 @@
+package vibelogger
+
+import (
+	"log"
+	"strings"
+)
+
+// DefaultStdLogOperation is the operation of standard library log lines
+// without a recognizable operation prefix
+const DefaultStdLogOperation = "stdlog"
+
+// maxStdLogOperation is the longest prefix taken as an operation
+const maxStdLogOperation = 64
+
+// NewStdLogger returns a standard library log.Logger writing every line as an
+// entry of level, so legacy code using log.Printf funnels into the structured
+// pipeline. The operation is taken from a leading "name: " or "[name] " prefix
+// such as "http: TLS handshake error", else DefaultStdLogOperation is used.
+// The returned logger adds no timestamp or prefix of its own; the entry
+// carries the time.
+func NewStdLogger(l *Logger, level LogLevel) *log.Logger {
+	w := &lineWriter{logger: l, level: level, operation: DefaultStdLogOperation, parse: true}
+	return log.New(w, "", 0)
+}
+
+// parseStdLogLine splits a log line into operation and message. Lines without
+// an operation prefix keep fallback as operation.
+func parseStdLogLine(line, fallback string) (operation, message string) {
+	if strings.HasPrefix(line, "[") {
+		if end := strings.Index(line, "] "); end > 1 && isStdLogOperation(line[1:end]) {
+			return line[1:end], strings.TrimSpace(line[end+2:])
+		}
+	}
+	if end := strings.Index(line, ": "); end > 0 && isStdLogOperation(line[:end]) {
+		if message := strings.TrimSpace(line[end+2:]); message != "" {
+			return line[:end], message
+		}
+	}
+	return fallback, line
+}
+
+// isStdLogOperation reports whether s looks like an operation name: a letter
+// followed by letters, digits and "_.-/"
+func isStdLogOperation(s string) bool {
+	if len(s) > maxStdLogOperation {
+		return false
+	}
+	for i, r := range s {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z':
+		case i > 0 && (r >= '0' && r <= '9' || strings.ContainsRune("_.-/", r)):
+		default:
+			return false
+		}
+	}
+	return s != ""
+}
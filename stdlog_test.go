@@ -0,0 +1,45 @@
+package vibelogger
+
+import "testing"
+
+func TestNewStdLogger(t *testing.T) {
+	logger := NewLoggerWithConfig("stdlog", &LoggerConfig{AutoSave: false, EnableMemoryLog: true})
+	defer logger.Close()
+
+	std := NewStdLogger(logger, WARN)
+	std.Printf("http: TLS handshake error from %s: EOF", "10.0.0.1:5000")
+	std.Print("[cache] evicted 12 keys")
+	std.Println("starting worker pool of 4")
+
+	logs := logger.GetMemoryLogs()
+	if len(logs) != 3 {
+		t.Fatalf("expected 3 entries, got %d", len(logs))
+	}
+	expected := []struct{ operation, message string }{
+		{"http", "TLS handshake error from 10.0.0.1:5000: EOF"},
+		{"cache", "evicted 12 keys"},
+		{DefaultStdLogOperation, "starting worker pool of 4"},
+	}
+	for i, want := range expected {
+		if logs[i].Level != WARN || logs[i].Operation != want.operation || logs[i].Message != want.message {
+			t.Errorf("entry %d: got %s %s %q", i, logs[i].Level, logs[i].Operation, logs[i].Message)
+		}
+	}
+}
+
+func TestParseStdLogLine(t *testing.T) {
+	tests := []struct{ line, operation, message string }{
+		{"db.pool: connection reset", "db.pool", "connection reset"},
+		{"Error: 2 files failed", "Error", "2 files failed"},
+		{"took 3s: done", "fallback", "took 3s: done"},
+		{"2024/01/02 10:00:00 ready", "fallback", "2024/01/02 10:00:00 ready"},
+		{"[] empty", "fallback", "[] empty"},
+		{"note: ", "fallback", "note: "},
+	}
+	for _, tt := range tests {
+		operation, message := parseStdLogLine(tt.line, "fallback")
+		if operation != tt.operation || message != tt.message {
+			t.Errorf("%q: got %q %q", tt.line, operation, message)
+		}
+	}
+}
@@ -0,0 +1,366 @@
+package vibelogger
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// streamKeepAlive is how often a stream without entries sends a comment or
+// ping so proxies do not close it
+const streamKeepAlive = 15 * time.Second
+
+// websocketGUID is appended to the client key to compute the accept header (RFC 6455)
+const websocketGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// WebSocket opcodes
+const (
+	wsText  = 0x1
+	wsClose = 0x8
+	wsPing  = 0x9
+	wsPong  = 0xA
+)
+
+// maxWebsocketFrame limits the frames read from clients, which have nothing
+// to send but control frames
+const maxWebsocketFrame = 64 * 1024
+
+// StreamMessage is a WebSocket message of StreamHandler
+type StreamMessage struct {
+	Type    string    `json:"type"`              // "entry" or "dropped"
+	Entry   *LogEntry `json:"entry,omitempty"`   // The new entry
+	Dropped int64     `json:"dropped,omitempty"` // Total entries dropped for this client so far
+}
+
+// StreamHandler returns an http.Handler pushing the new entries of logger that
+// match the request's query parameters (see ParseQueryParams) to the client
+// until it disconnects or the logger is closed.
+//
+// WebSocket upgrade requests receive one StreamMessage per text message.
+// Other requests receive Server-Sent Events: "entry" events carrying the entry
+// as JSON with its ID as event ID, e.g. for curl -N. Entries a slow client
+// cannot keep up with are dropped rather than slowing logging down; both
+// protocols announce the total with a "dropped" message.
+func StreamHandler(logger *Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query, err := ParseQueryParams(r.URL.Query())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if isWebsocketUpgrade(r) {
+			serveWebsocketStream(logger, query, w, r)
+			return
+		}
+		serveEventStream(logger, query, w, r)
+	})
+}
+
+// ParseQueryParams builds a Query from URL query parameters: text, levels and
+// tags (comma-separated), operation, correlation_id, session_id, since (RFC
+// 3339 time or a duration before now such as 1h) and until (the same).
+func ParseQueryParams(params url.Values) (Query, error) {
+	return parseQueryParams(params, time.Now())
+}
+
+// parseQueryParams implements ParseQueryParams relative to now
+func parseQueryParams(params url.Values, now time.Time) (Query, error) {
+	query := Query{
+		Text:          params.Get("text"),
+		Operation:     params.Get("operation"),
+		CorrelationID: params.Get("correlation_id"),
+		SessionID:     params.Get("session_id"),
+		Tags:          ParseTags(params.Get("tags")),
+	}
+	for _, name := range ParseLevels(params.Get("levels")) {
+		level, err := ParseLevel(string(name))
+		if err != nil {
+			return query, err
+		}
+		query.Levels = append(query.Levels, level)
+	}
+	var err error
+	if redacted := params.Get("redacted"); redacted != "" {
+		if query.Redacted, err = strconv.ParseBool(redacted); err != nil {
+			return query, fmt.Errorf("invalid redacted %q (must be true or false)", redacted)
+		}
+	}
+	if query.Since, err = parseQueryTime(params.Get("since"), now); err != nil {
+		return query, err
+	}
+	if query.Until, err = parseQueryTime(params.Get("until"), now); err != nil {
+		return query, err
+	}
+	return query, nil
+}
+
+// parseQueryTime accepts an RFC 3339 time or a duration before now
+func parseQueryTime(s string, now time.Time) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if d, err := time.ParseDuration(s); err == nil {
+		return now.Add(-d), nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %q (must be RFC 3339 or a duration like 1h)", s)
+	}
+	return t, nil
+}
+
+// serveEventStream sends matching entries as Server-Sent Events
+func serveEventStream(logger *Logger, query Query, w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	sub := logger.Subscribe(query, 0)
+	defer sub.Close()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	// The comment tells clients the subscription is in place
+	fmt.Fprint(w, ": connected\n\n")
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(streamKeepAlive)
+	defer keepAlive.Stop()
+	var dropped int64
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-keepAlive.C:
+			fmt.Fprint(w, ": keep-alive\n\n")
+		case entry, ok := <-sub.Entries():
+			if !ok {
+				return
+			}
+			data, err := json.Marshal(entry)
+			if err != nil {
+				continue
+			}
+			fmt.Fprintf(w, "event: entry\nid: %s\ndata: %s\n\n", entry.ID, data)
+			if n := sub.Dropped(); n != dropped {
+				dropped = n
+				fmt.Fprintf(w, "event: dropped\ndata: %d\n\n", n)
+			}
+		}
+		flusher.Flush()
+	}
+}
+
+// isWebsocketUpgrade reports whether r asks for a WebSocket connection
+func isWebsocketUpgrade(r *http.Request) bool {
+	return headerHasToken(r.Header, "Connection", "upgrade") && headerHasToken(r.Header, "Upgrade", "websocket")
+}
+
+// headerHasToken reports whether a comma-separated header contains token, ignoring case
+func headerHasToken(header http.Header, name, token string) bool {
+	for _, value := range header.Values(name) {
+		for _, part := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(part), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// websocketAccept computes the Sec-WebSocket-Accept value for a client key
+func websocketAccept(key string) string {
+	sum := sha1.Sum([]byte(key + websocketGUID))
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// serveWebsocketStream completes the WebSocket handshake and sends matching
+// entries as text messages
+func serveWebsocketStream(logger *Logger, query Query, w http.ResponseWriter, r *http.Request) {
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if r.Method != http.MethodGet || key == "" || r.Header.Get("Sec-WebSocket-Version") != "13" {
+		w.Header().Set("Sec-WebSocket-Version", "13")
+		http.Error(w, "unsupported WebSocket request", http.StatusBadRequest)
+		return
+	}
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "WebSocket is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	sub := logger.Subscribe(query, 0)
+	defer sub.Close()
+
+	conn, rw, err := hijacker.Hijack()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	fmt.Fprintf(rw, "HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\nSec-WebSocket-Accept: %s\r\n\r\n", websocketAccept(key))
+	if rw.Flush() != nil {
+		return
+	}
+
+	// The reader answers pings and ends the stream when the client closes
+	frames := make(chan wsFrame)
+	done := make(chan struct{})
+	defer close(done)
+	go readWebsocketFrames(rw.Reader, frames, done)
+
+	keepAlive := time.NewTicker(streamKeepAlive)
+	defer keepAlive.Stop()
+	var dropped int64
+	for {
+		var err error
+		select {
+		case frame, ok := <-frames:
+			if !ok {
+				return
+			}
+			switch frame.opcode {
+			case wsPing:
+				err = writeWebsocketFrame(conn, wsPong, frame.payload)
+			case wsClose:
+				writeWebsocketFrame(conn, wsClose, closePayload(frame.payload))
+				return
+			}
+		case <-keepAlive.C:
+			err = writeWebsocketFrame(conn, wsPing, nil)
+		case entry, ok := <-sub.Entries():
+			if !ok {
+				// The logger was closed: 1001 going away
+				writeWebsocketFrame(conn, wsClose, []byte{0x03, 0xE9})
+				return
+			}
+			err = writeStreamMessage(conn, StreamMessage{Type: "entry", Entry: &entry})
+			if n := sub.Dropped(); err == nil && n != dropped {
+				dropped = n
+				err = writeStreamMessage(conn, StreamMessage{Type: "dropped", Dropped: n})
+			}
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// wsFrame is a frame received from a WebSocket client
+type wsFrame struct {
+	opcode  byte
+	payload []byte
+}
+
+// readWebsocketFrames forwards control frames until the connection fails, a
+// close frame arrives or done is closed
+func readWebsocketFrames(r *bufio.Reader, frames chan<- wsFrame, done <-chan struct{}) {
+	defer close(frames)
+	for {
+		frame, err := readWebsocketFrame(r)
+		if err != nil {
+			return
+		}
+		if frame.opcode != wsPing && frame.opcode != wsClose {
+			continue // Clients have nothing to say; data frames are ignored
+		}
+		select {
+		case frames <- frame:
+		case <-done:
+			return
+		}
+		if frame.opcode == wsClose {
+			return
+		}
+	}
+}
+
+// readWebsocketFrame reads one masked client frame
+func readWebsocketFrame(r *bufio.Reader) (wsFrame, error) {
+	var header [2]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return wsFrame{}, err
+	}
+	frame := wsFrame{opcode: header[0] & 0x0F}
+	masked := header[1]&0x80 != 0
+	length := uint64(header[1] & 0x7F)
+	switch length {
+	case 126:
+		var ext [2]byte
+		if _, err := io.ReadFull(r, ext[:]); err != nil {
+			return frame, err
+		}
+		length = uint64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err := io.ReadFull(r, ext[:]); err != nil {
+			return frame, err
+		}
+		length = binary.BigEndian.Uint64(ext[:])
+	}
+	if !masked || length > maxWebsocketFrame {
+		return frame, fmt.Errorf("invalid WebSocket frame")
+	}
+
+	var mask [4]byte
+	if _, err := io.ReadFull(r, mask[:]); err != nil {
+		return frame, err
+	}
+	frame.payload = make([]byte, length)
+	if _, err := io.ReadFull(r, frame.payload); err != nil {
+		return frame, err
+	}
+	for i := range frame.payload {
+		frame.payload[i] ^= mask[i%4]
+	}
+	return frame, nil
+}
+
+// writeWebsocketFrame writes one unmasked server frame
+func writeWebsocketFrame(conn net.Conn, opcode byte, payload []byte) error {
+	header := []byte{0x80 | opcode}
+	switch n := len(payload); {
+	case n < 126:
+		header = append(header, byte(n))
+	case n <= 0xFFFF:
+		header = append(header, 126)
+		header = binary.BigEndian.AppendUint16(header, uint16(n))
+	default:
+		header = append(header, 127)
+		header = binary.BigEndian.AppendUint64(header, uint64(n))
+	}
+	conn.SetWriteDeadline(time.Now().Add(streamKeepAlive))
+	_, err := conn.Write(append(header, payload...))
+	return err
+}
+
+// writeStreamMessage sends a message as a text frame
+func writeStreamMessage(conn net.Conn, message StreamMessage) error {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return nil // Skip entries that cannot be encoded
+	}
+	return writeWebsocketFrame(conn, wsText, data)
+}
+
+// closePayload echoes the status code of a client's close frame
+func closePayload(payload []byte) []byte {
+	if len(payload) >= 2 {
+		return payload[:2]
+	}
+	return nil
+}
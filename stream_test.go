@@ -0,0 +1,141 @@
+package vibelogger
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// readServerFrame reads one unmasked frame sent by the server
+func readServerFrame(t *testing.T, r *bufio.Reader) (byte, []byte) {
+	t.Helper()
+	var header [2]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		t.Fatalf("failed to read frame: %v", err)
+	}
+	length := int(header[1] & 0x7F)
+	if length == 126 {
+		var ext [2]byte
+		io.ReadFull(r, ext[:])
+		length = int(binary.BigEndian.Uint16(ext[:]))
+	}
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		t.Fatalf("failed to read payload: %v", err)
+	}
+	return header[0] & 0x0F, payload
+}
+
+// writeClientFrame writes one masked frame as a client does
+func writeClientFrame(conn net.Conn, opcode byte, payload []byte) {
+	mask := []byte{1, 2, 3, 4}
+	frame := []byte{0x80 | opcode, 0x80 | byte(len(payload))}
+	frame = append(frame, mask...)
+	for i, b := range payload {
+		frame = append(frame, b^mask[i%4])
+	}
+	conn.Write(frame)
+}
+
+func TestStreamHandlerWebsocket(t *testing.T) {
+	logger := NewLoggerWithConfig("stream", &LoggerConfig{AutoSave: false})
+	defer logger.Close()
+	server := httptest.NewServer(StreamHandler(logger))
+	defer server.Close()
+
+	conn, err := net.Dial("tcp", strings.TrimPrefix(server.URL, "http://"))
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+	io.WriteString(conn, "GET /?levels=error&operation=payment HTTP/1.1\r\nHost: test\r\n"+
+		"Upgrade: websocket\r\nConnection: keep-alive, Upgrade\r\n"+
+		"Sec-WebSocket-Key: dGhlIHNhbXBsZSBub25jZQ==\r\nSec-WebSocket-Version: 13\r\n\r\n")
+
+	reader := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(reader, nil)
+	if err != nil {
+		t.Fatalf("failed to read handshake: %v", err)
+	}
+	if resp.StatusCode != http.StatusSwitchingProtocols || resp.Header.Get("Sec-WebSocket-Accept") != "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=" {
+		t.Fatalf("unexpected handshake: %d %v", resp.StatusCode, resp.Header)
+	}
+
+	logger.Error("checkout", "Out of stock")
+	logger.Error("payment", "Card declined")
+	opcode, payload := readServerFrame(t, reader)
+	var message StreamMessage
+	if err := json.Unmarshal(payload, &message); err != nil || opcode != wsText {
+		t.Fatalf("expected a text message, got opcode %d: %s", opcode, payload)
+	}
+	if message.Type != "entry" || message.Entry.Operation != "payment" {
+		t.Errorf("expected the matching entry, got %+v", message)
+	}
+
+	writeClientFrame(conn, wsPing, []byte("hi"))
+	if opcode, payload := readServerFrame(t, reader); opcode != wsPong || string(payload) != "hi" {
+		t.Errorf("expected a pong echoing the ping, got %d %q", opcode, payload)
+	}
+	writeClientFrame(conn, wsClose, []byte{0x03, 0xE8})
+	if opcode, payload := readServerFrame(t, reader); opcode != wsClose || len(payload) != 2 {
+		t.Errorf("expected the close to be answered, got %d %q", opcode, payload)
+	}
+}
+
+func TestStreamHandlerRejectsBadRequests(t *testing.T) {
+	logger := NewLoggerWithConfig("stream_bad", &LoggerConfig{AutoSave: false})
+	defer logger.Close()
+	handler := StreamHandler(logger)
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest("GET", "/?since=yesterday", nil))
+	if recorder.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for an invalid time, got %d", recorder.Code)
+	}
+
+	request := httptest.NewRequest("GET", "/", nil)
+	request.Header.Set("Connection", "Upgrade")
+	request.Header.Set("Upgrade", "websocket")
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	if recorder.Code != http.StatusBadRequest || recorder.Header().Get("Sec-WebSocket-Version") != "13" {
+		t.Errorf("expected 400 for a handshake without key, got %d", recorder.Code)
+	}
+}
+
+func TestParseQueryParams(t *testing.T) {
+	now := time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC)
+	params := url.Values{
+		"levels":         {"warn,error"},
+		"tags":           {"billing, migration"},
+		"correlation_id": {"req-1"},
+		"since":          {"1h"},
+		"until":          {"2024-01-02T11:30:00Z"},
+		"redacted":       {"true"},
+	}
+	query, err := parseQueryParams(params, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(query.Levels) != 2 || len(query.Tags) != 2 || query.CorrelationID != "req-1" || !query.Redacted {
+		t.Errorf("unexpected query: %+v", query)
+	}
+	if !query.Since.Equal(now.Add(-time.Hour)) || query.Until.Minute() != 30 {
+		t.Errorf("unexpected time range: %v %v", query.Since, query.Until)
+	}
+	if _, err := parseQueryParams(url.Values{"levels": {"loud"}}, now); err == nil {
+		t.Error("expected an error for an unknown level")
+	}
+	if _, err := parseQueryParams(url.Values{"redacted": {"maybe"}}, now); err == nil {
+		t.Error("expected an error for an invalid redacted flag")
+	}
+}
@@ -0,0 +1,177 @@
+package vibelogger
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// MaxStructDepth is the nesting depth WithStruct follows before writing a
+// placeholder
+const MaxStructDepth = 8
+
+// Placeholders written by WithStruct instead of a value
+const (
+	structCycle    = "[cycle]"
+	structMaxDepth = "[max depth]"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// WithStruct adds value under key as a map built by reflection, so domain
+// objects can be logged without hand-building maps. Field names follow the
+// json tag when present. Fields tagged `vibelog:"-"` are skipped and fields
+// tagged `vibelog:"redact"` are written as [REDACTED]; unexported fields are
+// never written. Nesting beyond MaxStructDepth and reference cycles are
+// replaced by placeholders instead of being followed.
+func WithStruct(key string, value interface{}) LogOption {
+	return func(entry *LogEntry) {
+		if entry.Context == nil {
+			entry.Context = make(map[string]interface{})
+		}
+		walker := structWalker{visiting: make(map[uintptr]bool)}
+		entry.Context[key] = walker.value(reflect.ValueOf(value), 0)
+	}
+}
+
+// structWalker converts values while tracking the pointers on the current path
+type structWalker struct {
+	visiting map[uintptr]bool
+}
+
+// value converts v into maps, slices and plain values that encode as JSON
+func (w *structWalker) value(v reflect.Value, depth int) interface{} {
+	if !v.IsValid() {
+		return nil
+	}
+	if depth >= MaxStructDepth {
+		return structMaxDepth
+	}
+	if err, ok := asError(v); ok {
+		return err.Error()
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		if v.Kind() == reflect.Ptr {
+			// Only references already on the path form a cycle; shared values are written twice
+			ptr := v.Pointer()
+			if w.visiting[ptr] {
+				return structCycle
+			}
+			w.visiting[ptr] = true
+			defer delete(w.visiting, ptr)
+		}
+		return w.value(v.Elem(), depth)
+	case reflect.Struct:
+		if v.Type() == timeType {
+			return v.Interface()
+		}
+		return w.structFields(v, depth)
+	case reflect.Map:
+		if v.IsNil() {
+			return nil
+		}
+		ptr := v.Pointer()
+		if w.visiting[ptr] {
+			return structCycle
+		}
+		w.visiting[ptr] = true
+		defer delete(w.visiting, ptr)
+
+		fields := make(map[string]interface{}, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			fields[fmt.Sprint(iter.Key().Interface())] = w.value(iter.Value(), depth+1)
+		}
+		return fields
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return nil
+		}
+		// Byte slices are usually payloads; keep them as JSON encodes them
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return v.Interface()
+		}
+		items := make([]interface{}, v.Len())
+		for i := range items {
+			items[i] = w.value(v.Index(i), depth+1)
+		}
+		return items
+	case reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		return v.Type().String()
+	}
+
+	if v.CanInterface() {
+		return v.Interface()
+	}
+	return nil
+}
+
+// asError returns the error held by v. Nil pointers are not treated as errors,
+// since calling Error on them would usually panic.
+func asError(v reflect.Value) (error, bool) {
+	if !v.CanInterface() {
+		return nil, false
+	}
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice:
+		if v.IsNil() {
+			return nil, false
+		}
+	}
+	err, ok := v.Interface().(error)
+	return err, ok
+}
+
+// structFields converts the exported fields of a struct
+func (w *structWalker) structFields(v reflect.Value, depth int) map[string]interface{} {
+	t := v.Type()
+	fields := make(map[string]interface{}, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		tag := field.Tag.Get("vibelog")
+		if tag == "-" {
+			continue
+		}
+
+		name := field.Name
+		if jsonName, _, _ := strings.Cut(field.Tag.Get("json"), ","); jsonName == "-" {
+			continue
+		} else if jsonName != "" {
+			name = jsonName
+		}
+
+		if tag == "redact" {
+			fields[name] = RedactedValue
+			continue
+		}
+		value := w.value(v.Field(i), depth+1)
+		// Like encoding/json, promote the fields of untagged embedded structs
+		if embedded, ok := value.(map[string]interface{}); ok && field.Anonymous && name == field.Name && isStructType(field.Type) {
+			for k, v := range embedded {
+				if _, exists := fields[k]; !exists {
+					fields[k] = v
+				}
+			}
+			continue
+		}
+		fields[name] = value
+	}
+	return fields
+}
+
+// isStructType reports whether t is a struct or a pointer to one
+func isStructType(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct
+}
@@ -0,0 +1,118 @@
+package vibelogger
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+type AuditInfo struct {
+	CreatedBy string
+}
+
+type structCard struct {
+	Number string `vibelog:"redact"`
+	Brand  string `json:"brand"`
+}
+
+type structCustomer struct {
+	AuditInfo
+	ID       int64             `json:"id"`
+	Email    string            `json:"email,omitempty"`
+	Password string            `vibelog:"-"`
+	Internal string            `json:"-"`
+	Card     *structCard       `json:"card"`
+	Tags     []string          `json:"tags"`
+	Labels   map[string]string `json:"labels"`
+	Since    time.Time         `json:"since"`
+	LastErr  error             `json:"last_error"`
+	Referrer *structCustomer   `json:"referrer"`
+	secret   string
+}
+
+func TestWithStruct(t *testing.T) {
+	since := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	customer := &structCustomer{
+		AuditInfo: AuditInfo{CreatedBy: "admin"},
+		ID:        42,
+		Email:     "a@example.com",
+		Password:  "hunter2",
+		Internal:  "hidden",
+		Card:      &structCard{Number: "4111111111111111", Brand: "visa"},
+		Tags:      []string{"vip"},
+		Labels:    map[string]string{"tier": "gold"},
+		Since:     since,
+		LastErr:   errors.New("card declined"),
+		secret:    "never logged",
+	}
+	customer.Referrer = customer // Cycle
+
+	entry := &LogEntry{}
+	WithStruct("customer", customer)(entry)
+	fields, ok := entry.Context["customer"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("Expected a map, got %T", entry.Context["customer"])
+	}
+
+	if fields["id"] != int64(42) || fields["email"] != "a@example.com" || fields["CreatedBy"] != "admin" {
+		t.Errorf("Unexpected plain fields: %v", fields)
+	}
+	for _, key := range []string{"Password", "Internal", "secret", "AuditInfo"} {
+		if _, present := fields[key]; present {
+			t.Errorf("Expected %s to be omitted", key)
+		}
+	}
+	card := fields["card"].(map[string]interface{})
+	if card["Number"] != RedactedValue || card["brand"] != "visa" {
+		t.Errorf("Expected the card number to be redacted, got %v", card)
+	}
+	if fields["referrer"] != structCycle {
+		t.Errorf("Expected the cycle to be cut, got %v", fields["referrer"])
+	}
+	if fields["last_error"] != "card declined" || fields["since"] != since {
+		t.Errorf("Unexpected error or time field: %v / %v", fields["last_error"], fields["since"])
+	}
+	if tags := fields["tags"].([]interface{}); len(tags) != 1 || tags[0] != "vip" {
+		t.Errorf("Unexpected slice: %v", tags)
+	}
+	if labels := fields["labels"].(map[string]interface{}); labels["tier"] != "gold" {
+		t.Errorf("Unexpected map: %v", labels)
+	}
+}
+
+func TestWithStructDepthLimit(t *testing.T) {
+	type node struct {
+		Next *node
+	}
+	// Distinct nodes are no cycle, so the depth limit has to stop the walk
+	head := &node{}
+	current := head
+	for i := 0; i < MaxStructDepth*2; i++ {
+		current.Next = &node{}
+		current = current.Next
+	}
+
+	entry := &LogEntry{}
+	WithStruct("list", head)(entry)
+	value := entry.Context["list"]
+	depth := 0
+	for {
+		fields, ok := value.(map[string]interface{})
+		if !ok {
+			break
+		}
+		value = fields["Next"]
+		depth++
+	}
+	if value != structMaxDepth || depth != MaxStructDepth {
+		t.Errorf("Expected the walk to stop after %d levels, got %d ending in %v", MaxStructDepth, depth, value)
+	}
+
+	// Values shared without a cycle are written each time
+	shared := &structCard{Brand: "visa"}
+	WithStruct("cards", []*structCard{shared, shared})(entry)
+	cards := entry.Context["cards"].([]interface{})
+	if cards[1].(map[string]interface{})["brand"] != "visa" {
+		t.Errorf("Expected shared values to be repeated, got %v", cards)
+	}
+}
@@ -0,0 +1,99 @@
+package vibelogger
+
+import "sync/atomic"
+
+// DefaultSubscriptionBuffer is the channel capacity of a subscription when
+// Subscribe is given none
+const DefaultSubscriptionBuffer = 256
+
+// Subscription delivers the entries of a logger matching a filter, see
+// Logger.Subscribe
+type Subscription struct {
+	logger  *Logger
+	filter  Query
+	entries chan LogEntry
+	dropped atomic.Int64
+	closed  bool // Guarded by the logger's mutex
+}
+
+// Subscribe returns a subscription receiving every entry written from now on
+// that matches filter, so other components such as metrics adapters, UI panels
+// or tests can react to log events without tailing files. An empty Query
+// matches all entries.
+//
+// Entries are delivered in write order. Logging never waits for subscribers:
+// when the channel's buffer (DefaultSubscriptionBuffer if buffer is not
+// positive) is full, the entry is dropped and counted in Dropped. The entries'
+// maps are shared with other subscribers and must not be modified. The channel
+// is closed by Close or when the logger is closed.
+func (l *Logger) Subscribe(filter Query, buffer int) *Subscription {
+	if buffer <= 0 {
+		buffer = DefaultSubscriptionBuffer
+	}
+	s := &Subscription{logger: l, filter: filter, entries: make(chan LogEntry, buffer)}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if l.closed {
+		s.closeLocked()
+		return s
+	}
+	l.subscriptions = append(l.subscriptions, s)
+	return s
+}
+
+// Entries returns the channel of matching entries
+func (s *Subscription) Entries() <-chan LogEntry {
+	return s.entries
+}
+
+// Dropped returns the number of matching entries lost because the channel was full
+func (s *Subscription) Dropped() int64 {
+	return s.dropped.Load()
+}
+
+// Close ends the subscription and closes its channel. It is safe to call more
+// than once.
+func (s *Subscription) Close() {
+	l := s.logger
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	for i, sub := range l.subscriptions {
+		if sub == s {
+			l.subscriptions = append(l.subscriptions[:i], l.subscriptions[i+1:]...)
+			break
+		}
+	}
+	s.closeLocked()
+}
+
+// closeLocked closes the channel once. The caller must hold the logger's mutex.
+func (s *Subscription) closeLocked() {
+	if !s.closed {
+		s.closed = true
+		close(s.entries)
+	}
+}
+
+// publish offers a written entry to the subscriptions. The caller must hold the mutex.
+func (l *Logger) publish(entry *LogEntry) {
+	for _, s := range l.subscriptions {
+		if !s.filter.Match(entry) {
+			continue
+		}
+		select {
+		case s.entries <- *entry:
+		default:
+			s.dropped.Add(1)
+		}
+	}
+}
+
+// closeSubscriptions ends all subscriptions when the logger is closed. The
+// caller must hold the mutex.
+func (l *Logger) closeSubscriptions() {
+	for _, s := range l.subscriptions {
+		s.closeLocked()
+	}
+	l.subscriptions = nil
+}
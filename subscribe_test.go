@@ -0,0 +1,55 @@
+package vibelogger
+
+import (
+	"testing"
+)
+
+func TestSubscribe(t *testing.T) {
+	logger := NewLoggerWithConfig("subscribe", &LoggerConfig{AutoSave: false})
+
+	errors := logger.Subscribe(Query{Levels: []LogLevel{ERROR}}, 0)
+	all := logger.Subscribe(Query{}, 2)
+
+	logger.Info("checkout", "Order placed")
+	logger.Error("payment", "Card declined")
+	logger.Info("checkout", "Order placed")
+
+	entry := <-errors.Entries()
+	if entry.Operation != "payment" || entry.Level != ERROR {
+		t.Errorf("unexpected entry: %s %s", entry.Level, entry.Operation)
+	}
+	select {
+	case entry := <-errors.Entries():
+		t.Errorf("entry %s should not match the filter", entry.Operation)
+	default:
+	}
+
+	// Logging never blocks on a full subscriber
+	if first := <-all.Entries(); first.Operation != "checkout" {
+		t.Errorf("expected entries in write order, got %s", first.Operation)
+	}
+	if second := <-all.Entries(); second.Operation != "payment" {
+		t.Errorf("expected entries in write order, got %s", second.Operation)
+	}
+	if all.Dropped() != 1 {
+		t.Errorf("expected 1 dropped entry, got %d", all.Dropped())
+	}
+
+	errors.Close()
+	errors.Close()
+	if _, ok := <-errors.Entries(); ok {
+		t.Error("Close should close the channel")
+	}
+	logger.Error("payment", "Card declined")
+
+	logger.Close()
+	if entry, ok := <-all.Entries(); !ok || entry.Operation != "payment" {
+		t.Error("entries delivered before Close should stay readable")
+	}
+	if _, ok := <-all.Entries(); ok {
+		t.Error("closing the logger should close the channel")
+	}
+	if _, ok := <-logger.Subscribe(Query{}, 0).Entries(); ok {
+		t.Error("subscriptions of a closed logger should be closed")
+	}
+}
@@ -0,0 +1,152 @@
+package vibelogger
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+)
+
+// SuggestionProvider proposes a debugging suggestion for an entry. An empty
+// result lets the next provider try.
+type SuggestionProvider interface {
+	Suggest(entry *LogEntry) string
+}
+
+// SuggestionFunc adapts a function, e.g. a call into an external runbook
+// service, to SuggestionProvider
+type SuggestionFunc func(entry *LogEntry) string
+
+// Suggest calls f
+func (f SuggestionFunc) Suggest(entry *LogEntry) string {
+	return f(entry)
+}
+
+// builtinSuggestions wraps the rules the logger ships with
+type builtinSuggestions struct{}
+
+// BuiltinSuggestions returns the built-in suggestion rules, which only cover
+// warnings and errors
+func BuiltinSuggestions() SuggestionProvider {
+	return builtinSuggestions{}
+}
+
+// Suggest applies the built-in rules
+func (builtinSuggestions) Suggest(entry *LogEntry) string {
+	return generateAISuggestion(entry.Level, entry.Operation, entry.FullMessage())
+}
+
+// SuggestionRule maps matching entries to an organization-specific suggestion.
+// Empty match fields match any entry.
+type SuggestionRule struct {
+	Contains   []string   `json:"contains,omitempty"`  // Any of these phrases in operation or message (case-insensitive)
+	Operation  string     `json:"operation,omitempty"` // Operation name or path.Match pattern
+	Pattern    string     `json:"pattern,omitempty"`   // Detected pattern such as "database_error"
+	Levels     []LogLevel `json:"levels,omitempty"`    // Levels the rule applies to
+	Suggestion string     `json:"suggestion"`
+}
+
+// SuggestionRules is a SuggestionProvider that returns the suggestion of the
+// first matching rule
+type SuggestionRules []SuggestionRule
+
+// LoadSuggestionRules reads rules from a JSON file holding an array of
+// SuggestionRule objects
+func LoadSuggestionRules(filename string) (SuggestionRules, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read suggestion rules: %w", err)
+	}
+
+	var rules SuggestionRules
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("failed to parse suggestion rules: %w", err)
+	}
+	for i, rule := range rules {
+		if rule.Suggestion == "" {
+			return nil, fmt.Errorf("suggestion rule %d has no suggestion", i)
+		}
+		if _, err := path.Match(rule.Operation, ""); err != nil {
+			return nil, fmt.Errorf("suggestion rule %d: invalid operation pattern %q: %w", i, rule.Operation, err)
+		}
+	}
+	return rules, nil
+}
+
+// Suggest returns the suggestion of the first rule matching the entry
+func (rules SuggestionRules) Suggest(entry *LogEntry) string {
+	for i := range rules {
+		if rules[i].matches(entry) {
+			return rules[i].Suggestion
+		}
+	}
+	return ""
+}
+
+// matches reports whether the rule applies to the entry
+func (r *SuggestionRule) matches(entry *LogEntry) bool {
+	if len(r.Levels) > 0 {
+		found := false
+		for _, level := range r.Levels {
+			found = found || level == entry.Level
+		}
+		if !found {
+			return false
+		}
+	}
+	if r.Pattern != "" && entry.Pattern != r.Pattern {
+		return false
+	}
+	if r.Operation != "" {
+		if ok, _ := path.Match(r.Operation, entry.Operation); !ok {
+			return false
+		}
+	}
+	if len(r.Contains) > 0 {
+		combined := strings.ToLower(entry.Operation + " " + entry.FullMessage())
+		phrases := make([]string, len(r.Contains))
+		for i, phrase := range r.Contains {
+			phrases[i] = strings.ToLower(phrase)
+		}
+		if !containsAny(combined, phrases) {
+			return false
+		}
+	}
+	return true
+}
+
+// SetSuggestionProviders replaces the providers consulted for each entry's
+// suggestion. Providers are tried in order and the first non-empty suggestion
+// wins; include BuiltinSuggestions() to keep the built-in rules. Calling it
+// without providers restores the built-in rules only.
+func (l *Logger) SetSuggestionProviders(providers ...SuggestionProvider) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.suggestions = append([]SuggestionProvider(nil), providers...)
+}
+
+// suggest asks the providers for a suggestion. A panicking provider is
+// skipped and returned for reporting after the entry is written.
+func (l *Logger) suggest(entry *LogEntry) (string, []*PanicError) {
+	l.mutex.Lock()
+	providers := l.suggestions
+	l.mutex.Unlock()
+	// Panic reports use the built-in rules so a failing provider cannot recurse
+	if len(providers) == 0 || entry.Operation == InternalOperation {
+		return builtinSuggestions{}.Suggest(entry), nil
+	}
+
+	var panics []*PanicError
+	for _, provider := range providers {
+		var suggestion string
+		if err := callSafely("suggestion_provider", func() { suggestion = provider.Suggest(entry) }); err != nil {
+			panics = append(panics, err.(*PanicError))
+			continue
+		}
+		if suggestion != "" {
+			return suggestion, panics
+		}
+	}
+	return "", panics
+}
@@ -0,0 +1,88 @@
+package vibelogger
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func newSuggestionLogger() *Logger {
+	return NewLoggerWithConfig("suggestion", &LoggerConfig{AutoSave: false, EnableMemoryLog: true, MemoryLogLimit: 10})
+}
+
+func TestDefaultSuggestions(t *testing.T) {
+	logger := newSuggestionLogger()
+	defer logger.Close()
+
+	logger.Error("db_connect", "connection refused")
+	if got := logger.GetMemoryLogs()[0].Suggestion; got != "Check database connectivity and connection pool settings" {
+		t.Errorf("unexpected built-in suggestion: %q", got)
+	}
+}
+
+func TestSuggestionProviderOrder(t *testing.T) {
+	logger := newSuggestionLogger()
+	defer logger.Close()
+
+	rules := SuggestionRules{{
+		Contains:   []string{"Connection Refused"},
+		Levels:     []LogLevel{ERROR},
+		Suggestion: "See runbook DB-12",
+	}}
+	logger.SetSuggestionProviders(
+		SuggestionFunc(func(entry *LogEntry) string { panic("provider failure") }),
+		rules,
+		BuiltinSuggestions(),
+	)
+
+	logger.Error("db_connect", "connection refused")
+	logger.Warn("db_connect", "connection refused")
+	logger.Info("startup", "ready")
+
+	logs := logger.GetMemoryLogs()
+	var entries []LogEntry
+	for _, entry := range logs {
+		if entry.Operation != InternalOperation {
+			entries = append(entries, entry)
+		}
+	}
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 entries, got %d", len(entries))
+	}
+	if entries[0].Suggestion != "See runbook DB-12" {
+		t.Errorf("user rule should win, got %q", entries[0].Suggestion)
+	}
+	if entries[1].Suggestion != "Check database connectivity and connection pool settings" {
+		t.Errorf("built-in rules should apply when the user rule does not match, got %q", entries[1].Suggestion)
+	}
+	if entries[2].Suggestion != "" {
+		t.Errorf("expected no suggestion, got %q", entries[2].Suggestion)
+	}
+	if len(logs) == len(entries) {
+		t.Error("panicking provider should be reported")
+	}
+}
+
+func TestLoadSuggestionRules(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "rules.json")
+	os.WriteFile(file, []byte(`[{"operation": "payment_*", "pattern": "network_error", "suggestion": "Check the payment gateway status page"}]`), 0644)
+
+	rules, err := LoadSuggestionRules(file)
+	if err != nil {
+		t.Fatalf("LoadSuggestionRules failed: %v", err)
+	}
+	entry := LogEntry{Level: ERROR, Operation: "payment_capture", Pattern: "network_error"}
+	if got := rules.Suggest(&entry); got != "Check the payment gateway status page" {
+		t.Errorf("unexpected suggestion: %q", got)
+	}
+	entry.Operation = "login"
+	if got := rules.Suggest(&entry); got != "" {
+		t.Errorf("expected no suggestion, got %q", got)
+	}
+
+	os.WriteFile(file, []byte(`[{"operation": "payment_*"}]`), 0644)
+	if _, err := LoadSuggestionRules(file); err == nil {
+		t.Error("expected error for a rule without suggestion")
+	}
+}
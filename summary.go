@@ -0,0 +1,95 @@
+package vibelogger
+
+import (
+	"fmt"
+	"time"
+)
+
+// StartSummaries writes an INFO "log_summary" entry every interval with the
+// number of entries per level and per known pattern since the previous summary,
+// so trends are visible without reading every line. Entries the logger writes
+// itself, such as heartbeats and earlier summaries, are not counted. Calling it
+// again replaces the previous summarizer; a non-positive interval only stops it.
+func (l *Logger) StartSummaries(interval time.Duration) {
+	l.StopSummaries()
+	if interval <= 0 {
+		return
+	}
+
+	// Start counting from now rather than from logger creation
+	l.stats.takeWindow()
+
+	task := startPeriodicTask(interval, l.writeSummary)
+	l.bgMutex.Lock()
+	l.summarizer = task
+	l.bgMutex.Unlock()
+}
+
+// StopSummaries stops the summary goroutine if one is running
+func (l *Logger) StopSummaries() {
+	l.bgMutex.Lock()
+	task := l.summarizer
+	l.summarizer = nil
+	l.bgMutex.Unlock()
+
+	task.Stop()
+}
+
+// writeSummary logs the counts of the window that just ended
+func (l *Logger) writeSummary() {
+	window := l.stats.takeWindow()
+	end := time.Now()
+
+	byLevel := make(map[string]int64, len(window.byLevel))
+	for level, count := range window.byLevel {
+		byLevel[string(level)] = count
+	}
+
+	message := fmt.Sprintf("%d entries in the last %s (%d errors, %d warnings)",
+		window.total, end.Sub(window.start).Round(time.Second),
+		window.byLevel[ERROR], window.byLevel[WARN])
+
+	fields := map[string]interface{}{
+		"period_start":       window.start.UTC().Format(time.RFC3339),
+		"period_end":         end.UTC().Format(time.RFC3339),
+		"total_entries":      window.total,
+		"total_bytes":        window.bytes,
+		"entries_by_level":   byLevel,
+		"entries_by_pattern": window.byPattern,
+	}
+
+	// Report operations that exceeded their latency budget
+	if len(window.slaByOp) > 0 {
+		var violations int64
+		for _, count := range window.slaByOp {
+			violations += count
+		}
+		fields["sla_violations"] = window.slaByOp
+		message += fmt.Sprintf(", %d over latency budget", violations)
+	}
+
+	// Report the error budget burn of operations tracked by SLO rules
+	if statuses := l.sloStatus(); len(statuses) > 0 {
+		burning := 0
+		slos := make(map[string]interface{}, len(statuses))
+		for _, status := range statuses {
+			slos[status.Operation] = map[string]interface{}{
+				"objective":        status.Objective,
+				"window":           status.Window.String(),
+				"total":            status.Total,
+				"failures":         status.Failures,
+				"burn_rate":        status.BurnRate,
+				"budget_remaining": status.BudgetRemaining,
+			}
+			if status.BurnRate > 1 {
+				burning++
+			}
+		}
+		fields["slo_burn"] = slos
+		if burning > 0 {
+			message += fmt.Sprintf(", %d operations burning error budget too fast", burning)
+		}
+	}
+
+	l.Info("log_summary", message, WithContext(fields))
+}
@@ -0,0 +1,61 @@
+package vibelogger
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSummaries(t *testing.T) {
+	logger := NewLoggerWithConfig("summary_test", &LoggerConfig{EnableMemoryLog: true})
+	logger.Info("before", "Not part of any summary")
+
+	logger.StartSummaries(time.Hour)
+	logger.Info("test", "Regular entry")
+	logger.Error("db", "database connection refused")
+	logger.Warn("test", "Warning entry")
+	logger.Info("heartbeat", "Logger is alive")
+
+	// Trigger the summaries directly instead of waiting for the ticker
+	logger.writeSummary()
+	logger.writeSummary()
+	logger.StopSummaries()
+
+	logs := logger.GetMemoryLogs()
+	if len(logs) != 7 {
+		t.Fatalf("Expected 7 entries, got %d", len(logs))
+	}
+
+	first := logs[5]
+	if first.Operation != "log_summary" {
+		t.Fatalf("Expected log_summary entry, got %s", first.Operation)
+	}
+	if first.Context["total_entries"] != int64(3) {
+		t.Errorf("Expected 3 entries in first summary, got %v", first.Context["total_entries"])
+	}
+	byLevel := first.Context["entries_by_level"].(map[string]int64)
+	if byLevel["INFO"] != 1 || byLevel["ERROR"] != 1 || byLevel["WARN"] != 1 {
+		t.Errorf("Unexpected per-level counts: %v", byLevel)
+	}
+	byPattern := first.Context["entries_by_pattern"].(map[string]int64)
+	if byPattern["database_error"] != 1 {
+		t.Errorf("Expected database pattern to be counted, got %v", byPattern)
+	}
+
+	// Entries of the logger itself, such as the heartbeat and the first
+	// summary, are not counted
+	second := logs[6]
+	if second.Context["total_entries"] != int64(0) {
+		t.Errorf("Expected 0 entries in second summary, got %v", second.Context["total_entries"])
+	}
+}
+
+func TestSummariesFromConfig(t *testing.T) {
+	logger := NewLoggerWithConfig("summary_test", &LoggerConfig{EnableMemoryLog: true, SummaryInterval: 10 * time.Millisecond})
+	logger.startBackgroundWorkers()
+	defer logger.Close()
+
+	logs := waitForMemoryLogs(t, logger, 1)
+	if logs[0].Operation != "log_summary" {
+		t.Errorf("Expected log_summary entry, got %s", logs[0].Operation)
+	}
+}
@@ -0,0 +1,142 @@
+package vibelogger
+
+import (
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// TestingTB is the part of testing.TB used by NewTestLogger. *testing.T,
+// *testing.B and *testing.F satisfy it; keeping it small avoids importing the
+// testing package into every binary using the logger.
+type TestingTB interface {
+	Helper()
+	Name() string
+	Cleanup(func())
+	Failed() bool
+	Logf(format string, args ...interface{})
+	Errorf(format string, args ...interface{})
+	Fatalf(format string, args ...interface{})
+}
+
+// TestLoggerOption customizes NewTestLogger
+type TestLoggerOption func(*testLoggerOptions)
+
+// testLoggerOptions holds the settings applied by TestLoggerOption values
+type testLoggerOptions struct {
+	config        *LoggerConfig
+	attachOnFail  bool
+	failureBudget int
+}
+
+// WithTestConfig bases the test logger on config instead of DefaultConfig.
+// The config is copied; ProjectName is only set when it is empty.
+func WithTestConfig(config *LoggerConfig) TestLoggerOption {
+	return func(o *testLoggerOptions) {
+		o.config = config
+	}
+}
+
+// WithFailureOutput attaches the entries written during the test to the test
+// output when it fails, rendered with FormatForLLM within tokenBudget (0 for
+// no limit)
+func WithFailureOutput(tokenBudget int) TestLoggerOption {
+	return func(o *testLoggerOptions) {
+		o.attachOnFail = true
+		o.failureBudget = tokenBudget
+	}
+}
+
+// NewTestLogger creates a file logger for the running test. The project is the
+// last element of the calling test's package path (without a _test suffix) and
+// the logger name is the test name, so each test writes to
+// logs/<package>/<Test>_<timestamp>.log.
+// The logger is closed when the test and its subtests finish. Creation
+// failures fail the test.
+func NewTestLogger(t TestingTB, options ...TestLoggerOption) *Logger {
+	t.Helper()
+	opts := testLoggerOptions{}
+	for _, option := range options {
+		option(&opts)
+	}
+
+	config := DefaultConfig()
+	if opts.config != nil {
+		copied := *opts.config
+		config = &copied
+	}
+	if config.ProjectName == "" && config.FilePath == "" {
+		config.ProjectName = callerPackage(2)
+	}
+
+	logger, err := CreateFileLoggerWithConfig(testLogName(t.Name()), config)
+	if err != nil {
+		t.Fatalf("failed to create test logger: %v", err)
+		return nil
+	}
+
+	var (
+		mutex    sync.Mutex
+		captured []LogEntry
+	)
+	if opts.attachOnFail {
+		logger.OnWrite(func(event WriteEvent) {
+			mutex.Lock()
+			captured = append(captured, *event.Entry)
+			mutex.Unlock()
+		})
+	}
+
+	t.Cleanup(func() {
+		if opts.attachOnFail && t.Failed() {
+			mutex.Lock()
+			entries := captured
+			mutex.Unlock()
+			logger.mutex.Lock()
+			path := logger.filePath
+			logger.mutex.Unlock()
+			if len(entries) > 0 {
+				t.Logf("vibe log %s:\n%s", path, FormatForLLM(entries, opts.failureBudget))
+			}
+		}
+		if err := logger.Close(); err != nil {
+			t.Errorf("failed to close test logger: %v", err)
+		}
+	})
+	return logger
+}
+
+// callerPackage returns the last element of the package path skip frames up
+// the stack, usable as a project name, or "default" when it cannot be determined
+func callerPackage(skip int) string {
+	pc, _, _, ok := runtime.Caller(skip)
+	if !ok {
+		return "default"
+	}
+	fn := runtime.FuncForPC(pc)
+	if fn == nil {
+		return "default"
+	}
+	// Function names look like github.com/org/repo/pkg_test.TestName.func1
+	name := fn.Name()
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+	name, _, _ = strings.Cut(name, ".")
+	name = strings.TrimSuffix(name, "_test")
+	if name = testLogName(name); name == "" {
+		return "default"
+	}
+	return name
+}
+
+// testLogName replaces the characters of a test name that are not allowed in
+// project and file names, e.g. the slashes of subtests
+func testLogName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_' || r == '-' {
+			return r
+		}
+		return '_'
+	}, name)
+}
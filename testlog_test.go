@@ -0,0 +1,89 @@
+package vibelogger
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fakeTB records what NewTestLogger reports to a test
+type fakeTB struct {
+	name     string
+	failed   bool
+	cleanups []func()
+	logs     []string
+	errors   []string
+}
+
+func (f *fakeTB) Helper()           {}
+func (f *fakeTB) Name() string      { return f.name }
+func (f *fakeTB) Failed() bool      { return f.failed }
+func (f *fakeTB) Cleanup(fn func()) { f.cleanups = append(f.cleanups, fn) }
+func (f *fakeTB) Logf(format string, args ...interface{}) {
+	f.logs = append(f.logs, fmt.Sprintf(format, args...))
+}
+func (f *fakeTB) Errorf(format string, args ...interface{}) {
+	f.errors = append(f.errors, fmt.Sprintf(format, args...))
+}
+func (f *fakeTB) Fatalf(format string, args ...interface{}) {
+	f.errors = append(f.errors, fmt.Sprintf(format, args...))
+}
+
+// finish runs the cleanups in reverse order like the testing package
+func (f *fakeTB) finish() {
+	for i := len(f.cleanups) - 1; i >= 0; i-- {
+		f.cleanups[i]()
+	}
+}
+
+func TestNewTestLogger(t *testing.T) {
+	dir := filepath.Join("test_logs", "testlog")
+	defer os.RemoveAll(dir)
+	config := DefaultConfig()
+	config.FilePath = filepath.Join(dir, "passing.log")
+
+	passing := &fakeTB{name: "TestCheckout/valid card"}
+	logger := NewTestLogger(passing, WithTestConfig(config), WithFailureOutput(0))
+	logger.Info("checkout", "Order placed")
+	passing.finish()
+	if len(passing.logs) != 0 || len(passing.errors) != 0 {
+		t.Errorf("Expected no output for a passing test, got %v %v", passing.logs, passing.errors)
+	}
+	if logger.file != nil {
+		t.Error("Expected the logger to be closed on cleanup")
+	}
+
+	config.FilePath = filepath.Join(dir, "failing.log")
+	failing := &fakeTB{name: "TestRefund"}
+	logger = NewTestLogger(failing, WithTestConfig(config), WithFailureOutput(0))
+	logger.Error("refund", "Gateway rejected the refund")
+	failing.failed = true
+	failing.finish()
+	if len(failing.logs) != 1 || !strings.Contains(failing.logs[0], "Gateway rejected the refund") ||
+		!strings.Contains(failing.logs[0], config.FilePath) {
+		t.Errorf("Expected the captured entries in the failure output, got %v", failing.logs)
+	}
+	if config.ProjectName != "" {
+		t.Error("Expected the caller's config to be left untouched")
+	}
+}
+
+func TestNewTestLoggerNaming(t *testing.T) {
+	if got := callerPackage(1); got != "vibe-logger-go" {
+		t.Errorf("Expected the package of the test, got %q", got)
+	}
+	if got := testLogName("TestCheckout/valid card#01"); got != "TestCheckout_valid_card_01" {
+		t.Errorf("Unexpected file-safe name: %q", got)
+	}
+
+	// Cleanups run last-in first-out, so the directory is removed after the logger closes
+	dir := filepath.Join("logs", "vibe-logger-go")
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	logger := NewTestLogger(t)
+	logger.Info("naming", "Written to the package project")
+	if !strings.HasPrefix(logger.filePath, filepath.Join(dir, "TestNewTestLoggerNaming_")) {
+		t.Errorf("Expected logs/<package>/<test>_*.log, got %s", logger.filePath)
+	}
+}
@@ -0,0 +1,215 @@
+package vibelogger
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// Header names used to propagate correlation across services
+const (
+	HeaderTraceparent   = "Traceparent"
+	HeaderCorrelationID = "X-Correlation-ID"
+	HeaderRequestID     = "X-Request-ID"
+)
+
+// contextKey keys the values this package stores in a context.Context
+type contextKey int
+
+const (
+	correlationIDKey contextKey = iota
+	traceContextKey
+)
+
+// TraceContext is a W3C Trace Context position: the trace an operation belongs
+// to and the span that is currently active
+type TraceContext struct {
+	TraceID string // 32 lowercase hex characters
+	SpanID  string // 16 lowercase hex characters
+	Sampled bool
+}
+
+// NewTraceContext starts a new sampled trace with a random trace and span ID
+func NewTraceContext() TraceContext {
+	return TraceContext{TraceID: randomHex(16), SpanID: randomHex(8), Sampled: true}
+}
+
+// ChildSpan returns a position in the same trace with a new span ID
+func (tc TraceContext) ChildSpan() TraceContext {
+	return TraceContext{TraceID: tc.TraceID, SpanID: randomHex(8), Sampled: tc.Sampled}
+}
+
+// Traceparent renders the traceparent header value, e.g.
+// 00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01
+func (tc TraceContext) Traceparent() string {
+	flags := "00"
+	if tc.Sampled {
+		flags = "01"
+	}
+	return fmt.Sprintf("00-%s-%s-%s", tc.TraceID, tc.SpanID, flags)
+}
+
+// ParseTraceparent parses a traceparent header value. Unknown future versions
+// are accepted as long as their first four fields are valid.
+func ParseTraceparent(value string) (TraceContext, bool) {
+	parts := strings.Split(strings.TrimSpace(value), "-")
+	if len(parts) < 4 {
+		return TraceContext{}, false
+	}
+	version, traceID, spanID, flags := parts[0], parts[1], parts[2], parts[3]
+	if !isLowerHex(version, 2) || version == "ff" || (version == "00" && len(parts) != 4) {
+		return TraceContext{}, false
+	}
+	if !isLowerHex(traceID, 32) || traceID == strings.Repeat("0", 32) {
+		return TraceContext{}, false
+	}
+	if !isLowerHex(spanID, 16) || spanID == strings.Repeat("0", 16) {
+		return TraceContext{}, false
+	}
+	if !isLowerHex(flags, 2) {
+		return TraceContext{}, false
+	}
+	flagBits, _ := hex.DecodeString(flags)
+	return TraceContext{TraceID: traceID, SpanID: spanID, Sampled: flagBits[0]&1 == 1}, true
+}
+
+// isLowerHex reports whether s consists of n lowercase hex digits
+func isLowerHex(s string, n int) bool {
+	if len(s) != n {
+		return false
+	}
+	for _, c := range s {
+		if !((c >= '0' && c <= '9') || (c >= 'a' && c <= 'f')) {
+			return false
+		}
+	}
+	return true
+}
+
+// randomHex returns n random bytes as lowercase hex
+func randomHex(n int) string {
+	b := make([]byte, n)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// ContextWithCorrelationID returns a context carrying the correlation ID
+func ContextWithCorrelationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, correlationIDKey, id)
+}
+
+// CorrelationIDFromContext returns the correlation ID stored in ctx, if any
+func CorrelationIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(correlationIDKey).(string)
+	return id
+}
+
+// ContextWithTraceContext returns a context carrying the trace position
+func ContextWithTraceContext(ctx context.Context, tc TraceContext) context.Context {
+	return context.WithValue(ctx, traceContextKey, tc)
+}
+
+// TraceContextFromContext returns the trace position stored in ctx, if any
+func TraceContextFromContext(ctx context.Context) (TraceContext, bool) {
+	tc, ok := ctx.Value(traceContextKey).(TraceContext)
+	return tc, ok
+}
+
+// WithTraceFromContext sets the entry's correlation ID and adds trace_id and
+// span_id to the context from the values stored in ctx
+func WithTraceFromContext(ctx context.Context) LogOption {
+	return func(entry *LogEntry) {
+		if id := CorrelationIDFromContext(ctx); id != "" {
+			entry.CorrelationID = id
+		}
+		if tc, ok := TraceContextFromContext(ctx); ok {
+			if entry.Context == nil {
+				entry.Context = make(map[string]interface{})
+			}
+			entry.Context["trace_id"] = tc.TraceID
+			entry.Context["span_id"] = tc.SpanID
+		}
+	}
+}
+
+// Correlation holds the correlation identifiers received with a request
+type Correlation struct {
+	CorrelationID string       // X-Correlation-ID, else X-Request-ID, else the trace ID
+	RequestID     string       // X-Request-ID as sent by the caller
+	Trace         TraceContext // Caller's span from traceparent
+	HasTrace      bool
+}
+
+// ExtractCorrelation reads traceparent, X-Request-ID and X-Correlation-ID from
+// incoming headers. It accepts http.Header as well as gRPC metadata (metadata.MD),
+// which both are maps of header names to values; names are matched case-insensitively.
+func ExtractCorrelation(headers map[string][]string) Correlation {
+	var c Correlation
+	if tc, ok := ParseTraceparent(headerValue(headers, HeaderTraceparent)); ok {
+		c.Trace, c.HasTrace = tc, true
+	}
+	c.RequestID = headerValue(headers, HeaderRequestID)
+	c.CorrelationID = headerValue(headers, HeaderCorrelationID)
+	if c.CorrelationID == "" {
+		c.CorrelationID = c.RequestID
+	}
+	if c.CorrelationID == "" && c.HasTrace {
+		c.CorrelationID = c.Trace.TraceID
+	}
+	return c
+}
+
+// headerValue returns the first non-empty value of a header, ignoring case
+func headerValue(headers map[string][]string, name string) string {
+	for key, values := range headers {
+		if !strings.EqualFold(key, name) {
+			continue
+		}
+		for _, v := range values {
+			if v = strings.TrimSpace(v); v != "" {
+				return v
+			}
+		}
+	}
+	return ""
+}
+
+// Bind stores the correlation ID and trace position in ctx so that outgoing
+// requests made through Transport continue the caller's trace
+func (c Correlation) Bind(ctx context.Context) context.Context {
+	if c.CorrelationID != "" {
+		ctx = ContextWithCorrelationID(ctx, c.CorrelationID)
+	}
+	if c.HasTrace {
+		ctx = ContextWithTraceContext(ctx, c.Trace)
+	}
+	return ctx
+}
+
+// Options returns log options recording the correlation on an entry
+func (c Correlation) Options() []LogOption {
+	var options []LogOption
+	if c.CorrelationID != "" {
+		options = append(options, WithCorrelationID(c.CorrelationID))
+	}
+	fields := make(map[string]interface{})
+	if c.RequestID != "" {
+		fields["request_id"] = c.RequestID
+	}
+	if c.HasTrace {
+		fields["trace_id"] = c.Trace.TraceID
+		fields["parent_span_id"] = c.Trace.SpanID
+	}
+	if len(fields) > 0 {
+		options = append(options, WithContext(fields))
+	}
+	return options
+}
+
+// ForRequest returns a scoped logger recording the correlation of an incoming
+// request, given its http.Header or gRPC metadata
+func (l *Logger) ForRequest(headers map[string][]string) *ScopedLogger {
+	return l.With(ExtractCorrelation(headers).Options()...)
+}
@@ -0,0 +1,123 @@
+package vibelogger
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestParseTraceparent(t *testing.T) {
+	tc, ok := ParseTraceparent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	if !ok || tc.TraceID != "4bf92f3577b34da6a3ce929d0e0e4736" || tc.SpanID != "00f067aa0ba902b7" || !tc.Sampled {
+		t.Fatalf("Unexpected trace context: %+v %v", tc, ok)
+	}
+	if tc.Traceparent() != "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01" {
+		t.Errorf("Expected the header to round-trip, got %s", tc.Traceparent())
+	}
+	if _, ok := ParseTraceparent("01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00-future"); !ok {
+		t.Error("Expected future versions with extra fields to be accepted")
+	}
+
+	invalid := []string{
+		"",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",
+		"00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra",
+	}
+	for _, value := range invalid {
+		if _, ok := ParseTraceparent(value); ok {
+			t.Errorf("Expected %q to be rejected", value)
+		}
+	}
+}
+
+func TestChildSpan(t *testing.T) {
+	parent := NewTraceContext()
+	child := parent.ChildSpan()
+	if child.TraceID != parent.TraceID || child.SpanID == parent.SpanID {
+		t.Errorf("Expected a new span in the same trace, got %+v from %+v", child, parent)
+	}
+	if _, ok := ParseTraceparent(child.Traceparent()); !ok {
+		t.Errorf("Expected a valid traceparent, got %s", child.Traceparent())
+	}
+}
+
+func TestWithTraceFromContext(t *testing.T) {
+	tc := NewTraceContext()
+	ctx := ContextWithTraceContext(ContextWithCorrelationID(context.Background(), "req-1"), tc)
+
+	entry := &LogEntry{}
+	WithTraceFromContext(ctx)(entry)
+	if entry.CorrelationID != "req-1" || entry.Context["trace_id"] != tc.TraceID || entry.Context["span_id"] != tc.SpanID {
+		t.Errorf("Expected correlation and trace IDs from the context, got %+v", entry)
+	}
+
+	entry = &LogEntry{}
+	WithTraceFromContext(context.Background())(entry)
+	if entry.CorrelationID != "" || entry.Context != nil {
+		t.Errorf("Expected an empty context to add nothing, got %+v", entry)
+	}
+}
+
+func TestExtractCorrelation(t *testing.T) {
+	header := http.Header{}
+	header.Set("Traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	header.Set("X-Request-ID", "req-7")
+
+	c := ExtractCorrelation(header)
+	if !c.HasTrace || c.Trace.TraceID != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("Expected the trace from traceparent, got %+v", c)
+	}
+	if c.RequestID != "req-7" || c.CorrelationID != "req-7" {
+		t.Errorf("Expected X-Request-ID as correlation ID, got %+v", c)
+	}
+
+	// gRPC metadata uses lowercase keys
+	metadata := map[string][]string{
+		"x-correlation-id": {"corr-1"},
+		"traceparent":      {"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"},
+	}
+	if c := ExtractCorrelation(metadata); c.CorrelationID != "corr-1" || !c.HasTrace {
+		t.Errorf("Expected values from gRPC metadata, got %+v", c)
+	}
+
+	// Without IDs the trace ID correlates the entries
+	traceOnly := map[string][]string{"traceparent": {"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"}}
+	if c := ExtractCorrelation(traceOnly); c.CorrelationID != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("Expected the trace ID as correlation ID, got %q", c.CorrelationID)
+	}
+	if c := ExtractCorrelation(http.Header{"Traceparent": {"garbage"}}); c.HasTrace || c.CorrelationID != "" {
+		t.Errorf("Expected nothing from an invalid traceparent, got %+v", c)
+	}
+}
+
+func TestForRequest(t *testing.T) {
+	logger := NewLoggerWithConfig("for_request_test", &LoggerConfig{
+		AutoSave:        false,
+		EnableMemoryLog: true,
+		MemoryLogLimit:  10,
+	})
+	header := http.Header{}
+	header.Set("Traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	header.Set("X-Request-ID", "req-7")
+
+	logger.ForRequest(header).Info("checkout", "Started")
+	entry := logger.GetMemoryLogs()[0]
+	if entry.CorrelationID != "req-7" || entry.Context["request_id"] != "req-7" {
+		t.Errorf("Expected the request ID on the entry, got %+v", entry)
+	}
+	if entry.Context["trace_id"] != "4bf92f3577b34da6a3ce929d0e0e4736" || entry.Context["parent_span_id"] != "00f067aa0ba902b7" {
+		t.Errorf("Expected the caller's trace on the entry, got %v", entry.Context)
+	}
+
+	ctx := ExtractCorrelation(header).Bind(context.Background())
+	if CorrelationIDFromContext(ctx) != "req-7" {
+		t.Error("Expected Bind to store the correlation ID")
+	}
+	if tc, ok := TraceContextFromContext(ctx); !ok || tc.SpanID != "00f067aa0ba902b7" {
+		t.Error("Expected Bind to store the caller's span")
+	}
+}
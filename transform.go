@@ -0,0 +1,260 @@
+package vibelogger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// templateFuncs are available to the templates of NewTemplateFormatter
+var templateFuncs = template.FuncMap{
+	"json":    templateJSON,
+	"flatten": flattenFields,
+	"upper":   strings.ToUpper,
+	"lower":   strings.ToLower,
+}
+
+// NewTemplateFormatter creates a Formatter executing a text/template for every
+// entry, e.g. for a sink that must emit the envelope a legacy collector
+// expects. The template receives the fields of the entry as encoded in the log
+// file, so {{.message}} or {{.context.user_id}} address them, and can use the
+// functions json (encode a value as JSON), flatten (a nested map as one map
+// with dotted keys), upper and lower. Trailing newlines are removed from the
+// output.
+//
+//	{"msg": {{json .message}}, "sev": {{json (lower .level)}}}
+func NewTemplateFormatter(text string) (Formatter, error) {
+	tmpl, err := template.New("entry").Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid entry template: %w", err)
+	}
+	return FormatterFunc(func(entry *LogEntry) ([]byte, error) {
+		fields, err := entryFields(entry)
+		if err != nil {
+			return nil, err
+		}
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, fields); err != nil {
+			return nil, fmt.Errorf("failed to execute entry template: %w", err)
+		}
+		return bytes.TrimRight(buf.Bytes(), "\r\n"), nil
+	}), nil
+}
+
+// templateJSON encodes a value for a template
+func templateJSON(value interface{}) (string, error) {
+	data, err := json.Marshal(value)
+	return string(data), err
+}
+
+// entryFields returns the fields of an entry as encoded in the log file,
+// keeping numbers exact
+func entryFields(entry *LogEntry) (map[string]interface{}, error) {
+	data, err := marshalEntry(entry)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal log entry: %w", err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var fields map[string]interface{}
+	if err := decoder.Decode(&fields); err != nil {
+		return nil, fmt.Errorf("failed to decode log entry: %w", err)
+	}
+	return fields, nil
+}
+
+// flattenFields returns a nested map as one map with dotted keys
+func flattenFields(fields map[string]interface{}) map[string]interface{} {
+	flat := make(map[string]interface{})
+	var walk func(prefix string, m map[string]interface{})
+	walk = func(prefix string, m map[string]interface{}) {
+		for key, value := range m {
+			if nested, ok := value.(map[string]interface{}); ok && len(nested) > 0 {
+				walk(prefix+key+".", nested)
+				continue
+			}
+			flat[prefix+key] = value
+		}
+	}
+	walk("", fields)
+	return flat
+}
+
+// fieldRule is one rule of a field mapping
+type fieldRule struct {
+	source   []string    // Path of the field read, empty for constants
+	target   []string    // Path of the field written, the prefix for wildcards
+	wildcard string      // "*" moves the children of source, "**" its flattened leaves
+	constant interface{} // Value of constant rules
+	drop     bool        // Remove source
+}
+
+// fieldMapping is the Formatter of NewFieldMapping
+type fieldMapping struct {
+	rules []fieldRule
+}
+
+// NewFieldMapping creates a Formatter encoding entries as JSON objects
+// reshaped by a comma-separated list of rules applied in order to the fields
+// of the entry as encoded in the log file. Dotted paths address nested
+// fields and create nested objects when written.
+//
+//	message=msg              rename a field
+//	context.user_id=user.id  move a nested field
+//	-environment             remove a field
+//	service="checkout"       set a constant (a JSON value)
+//	context.*=attr_*         move the children of an object, prefixing their names
+//	context.**=*             move the leaves of an object, flattened to dotted names
+//
+// Fields not mentioned are kept.
+func NewFieldMapping(spec string) (Formatter, error) {
+	mapping := &fieldMapping{}
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		rule, err := parseFieldRule(part)
+		if err != nil {
+			return nil, err
+		}
+		mapping.rules = append(mapping.rules, rule)
+	}
+	if len(mapping.rules) == 0 {
+		return nil, fmt.Errorf("field mapping has no rules")
+	}
+	return mapping, nil
+}
+
+// parseFieldRule parses one rule of a field mapping
+func parseFieldRule(part string) (fieldRule, error) {
+	if strings.HasPrefix(part, "-") {
+		path := strings.TrimSpace(part[1:])
+		if path == "" {
+			return fieldRule{}, fmt.Errorf("invalid field rule %q: missing field", part)
+		}
+		return fieldRule{source: strings.Split(path, "."), drop: true}, nil
+	}
+
+	source, target, ok := strings.Cut(part, "=")
+	source, target = strings.TrimSpace(source), strings.TrimSpace(target)
+	if !ok || source == "" || target == "" {
+		return fieldRule{}, fmt.Errorf("invalid field rule %q (must be source=target, -field or field=<JSON value>)", part)
+	}
+
+	// A JSON value other than a bare name is a constant for the field on the left
+	if strings.ContainsAny(target[:1], "\"[{-0123456789") || target == "true" || target == "false" || target == "null" {
+		var value interface{}
+		if err := json.Unmarshal([]byte(target), &value); err != nil {
+			return fieldRule{}, fmt.Errorf("invalid constant in field rule %q: %w", part, err)
+		}
+		return fieldRule{target: strings.Split(source, "."), constant: value}, nil
+	}
+
+	rule := fieldRule{source: strings.Split(source, ".")}
+	last := rule.source[len(rule.source)-1]
+	if last == "*" || last == "**" {
+		if !strings.HasSuffix(target, "*") || strings.Count(target, "*") != 1 || len(rule.source) == 1 {
+			return fieldRule{}, fmt.Errorf("invalid field rule %q: wildcards must end both sides, e.g. context.*=attr_*", part)
+		}
+		rule.wildcard = last
+		rule.source = rule.source[:len(rule.source)-1]
+		rule.target = strings.Split(strings.TrimSuffix(target, "*"), ".")
+		return rule, nil
+	}
+	if strings.Contains(source, "*") || strings.Contains(target, "*") {
+		return fieldRule{}, fmt.Errorf("invalid field rule %q: wildcards must end both sides", part)
+	}
+	rule.target = strings.Split(target, ".")
+	return rule, nil
+}
+
+// Format implements Formatter
+func (m *fieldMapping) Format(entry *LogEntry) ([]byte, error) {
+	fields, err := entryFields(entry)
+	if err != nil {
+		return nil, err
+	}
+	for _, rule := range m.rules {
+		rule.apply(fields)
+	}
+	return json.Marshal(fields)
+}
+
+// apply applies the rule to fields
+func (r fieldRule) apply(fields map[string]interface{}) {
+	switch {
+	case r.source == nil:
+		setField(fields, r.target, r.constant)
+	case r.drop:
+		removeField(fields, r.source)
+	case r.wildcard != "":
+		object, ok := getField(fields, r.source).(map[string]interface{})
+		if !ok {
+			return
+		}
+		removeField(fields, r.source)
+		if r.wildcard == "**" {
+			object = flattenFields(object)
+		}
+		keys := make([]string, 0, len(object))
+		for key := range object {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		head, prefix := r.target[:len(r.target)-1], r.target[len(r.target)-1]
+		for _, key := range keys {
+			setField(fields, append(append([]string{}, head...), prefix+key), object[key])
+		}
+	default:
+		value := getField(fields, r.source)
+		if value == nil {
+			return
+		}
+		removeField(fields, r.source)
+		setField(fields, r.target, value)
+	}
+}
+
+// getField returns the value at path, nil when there is none
+func getField(fields map[string]interface{}, path []string) interface{} {
+	var value interface{} = fields
+	for _, key := range path {
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		value = m[key]
+	}
+	return value
+}
+
+// setField writes value at path, replacing non-objects on the way by objects
+func setField(fields map[string]interface{}, path []string, value interface{}) {
+	m := fields
+	for _, key := range path[:len(path)-1] {
+		next, ok := m[key].(map[string]interface{})
+		if !ok {
+			next = make(map[string]interface{})
+			m[key] = next
+		}
+		m = next
+	}
+	m[path[len(path)-1]] = value
+}
+
+// removeField deletes the value at path
+func removeField(fields map[string]interface{}, path []string) {
+	m := fields
+	for _, key := range path[:len(path)-1] {
+		next, ok := m[key].(map[string]interface{})
+		if !ok {
+			return
+		}
+		m = next
+	}
+	delete(m, path[len(path)-1])
+}
@@ -0,0 +1,102 @@
+package vibelogger
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// transformEntry is the entry reshaped by the transformation tests
+func transformEntry() *LogEntry {
+	return &LogEntry{
+		Timestamp: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Level:     ERROR,
+		Operation: "payment",
+		Message:   "Card declined",
+		Context: map[string]interface{}{
+			"amount":  int64(9007199254740993),
+			"user_id": "u-1",
+			"card":    map[string]interface{}{"brand": "visa"},
+		},
+		Environment: map[string]string{"go_version": "go1.21"},
+		Severity:    4,
+	}
+}
+
+func TestFieldMapping(t *testing.T) {
+	mapping, err := NewFieldMapping(`message=msg, context.user_id=user.id, -environment, -severity, service="checkout", context.**=attr_*`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, err := mapping.Format(transformEntry())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var record map[string]interface{}
+	decoder := json.NewDecoder(strings.NewReader(string(data)))
+	decoder.UseNumber()
+	decoder.Decode(&record)
+	if record["msg"] != "Card declined" || record["service"] != "checkout" || record["level"] != "ERROR" {
+		t.Errorf("expected renamed and constant fields, got %s", data)
+	}
+	if user, _ := record["user"].(map[string]interface{}); user["id"] != "u-1" {
+		t.Errorf("expected the user ID to move, got %s", data)
+	}
+	if record["attr_card.brand"] != "visa" || record["attr_amount"] != json.Number("9007199254740993") {
+		t.Errorf("expected the flattened context with exact numbers, got %s", data)
+	}
+	for _, removed := range []string{"message", "context", "environment", "severity"} {
+		if _, ok := record[removed]; ok {
+			t.Errorf("expected %s to be removed, got %s", removed, data)
+		}
+	}
+
+	for _, invalid := range []string{"", "message", "=msg", "-", "context.*=attr", "context*=x*", "count=1x"} {
+		if _, err := NewFieldMapping(invalid); err == nil {
+			t.Errorf("expected an error for %q", invalid)
+		}
+	}
+}
+
+func TestTemplateFormatter(t *testing.T) {
+	formatter, err := NewTemplateFormatter(`{"msg":{{json .message}},"sev":{{json (lower .level)}}{{range $k, $v := flatten .context}},"ctx_{{$k}}":{{json $v}}{{end}}}` + "\n")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, err := formatter.Format(transformEntry())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := `{"msg":"Card declined","sev":"error","ctx_amount":9007199254740993,"ctx_card.brand":"visa","ctx_user_id":"u-1"}`
+	if string(data) != expected {
+		t.Errorf("unexpected record:\n%s\nexpected:\n%s", data, expected)
+	}
+	if _, err := NewTemplateFormatter("{{.message"); err == nil {
+		t.Error("expected an error for an invalid template")
+	}
+}
+
+func TestHTTPSinkFormatter(t *testing.T) {
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		body = string(data)
+	}))
+	defer server.Close()
+
+	mapping, _ := NewFieldMapping("message=msg")
+	sink := NewHTTPSink(server.URL, "", WithHTTPFormatter(mapping))
+	defer sink.Close()
+	if err := sink.WriteBatch([]LogEntry{{Message: "a"}, {Message: "b"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(body, "\n"), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[1], `"msg":"b"`) {
+		t.Errorf("expected one mapped record per line, got %q", body)
+	}
+}
@@ -0,0 +1,82 @@
+package vibelogger
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Transport is an http.RoundTripper that propagates the correlation ID and W3C
+// trace context of each request's context to the called service and logs a
+// summary of every request. Requests without a trace context start a new trace.
+type Transport struct {
+	Base   http.RoundTripper // Underlying transport; http.DefaultTransport when nil
+	Logger *Logger           // Receives the request summaries; nothing is logged when nil
+}
+
+// NewTransport wraps base (http.DefaultTransport when nil) and logs to logger
+func NewTransport(logger *Logger, base http.RoundTripper) *Transport {
+	return &Transport{Base: base, Logger: logger}
+}
+
+// RoundTrip injects the correlation headers, performs the request and logs it
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	span := NewTraceContext()
+	if parent, ok := TraceContextFromContext(ctx); ok {
+		span = parent.ChildSpan()
+	}
+	correlationID := CorrelationIDFromContext(ctx)
+
+	// RoundTrippers must not modify the caller's request
+	out := req.Clone(ctx)
+	out.Header.Set(HeaderTraceparent, span.Traceparent())
+	if correlationID != "" {
+		out.Header.Set(HeaderCorrelationID, correlationID)
+	}
+
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	start := time.Now()
+	resp, err := base.RoundTrip(out)
+	t.logRequest(out, resp, err, span, correlationID, time.Since(start))
+	return resp, err
+}
+
+// logRequest writes the summary of one outgoing request. The query string is
+// left out because it often carries credentials.
+func (t *Transport) logRequest(req *http.Request, resp *http.Response, err error, span TraceContext, correlationID string, elapsed time.Duration) {
+	if t.Logger == nil {
+		return
+	}
+
+	target := *req.URL
+	target.RawQuery = ""
+	target.User = nil
+	fields := map[string]interface{}{
+		"method":   req.Method,
+		"url":      target.String(),
+		"trace_id": span.TraceID,
+		"span_id":  span.SpanID,
+	}
+	options := []LogOption{WithContext(fields), WithDuration(elapsed)}
+	if correlationID != "" {
+		options = append(options, WithCorrelationID(correlationID))
+	}
+
+	if err != nil {
+		t.Logger.Error("http_client", fmt.Sprintf("%s %s failed", req.Method, target.String()),
+			append(options, WithError(err))...)
+		return
+	}
+
+	fields["status"] = resp.StatusCode
+	level := INFO
+	if resp.StatusCode >= 500 {
+		level = WARN
+	}
+	t.Logger.Log(level, "http_client",
+		fmt.Sprintf("%s %s returned %d", req.Method, target.String(), resp.StatusCode), options...)
+}
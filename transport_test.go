@@ -0,0 +1,84 @@
+package vibelogger
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestTransportPropagatesTraceContext(t *testing.T) {
+	var received http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Clone()
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	logger := NewLoggerWithConfig("transport_test", &LoggerConfig{
+		AutoSave:        false,
+		EnableMemoryLog: true,
+		MemoryLogLimit:  10,
+	})
+	client := &http.Client{Transport: NewTransport(logger, nil)}
+
+	parent := NewTraceContext()
+	ctx := ContextWithTraceContext(ContextWithCorrelationID(context.Background(), "req-42"), parent)
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/orders?token=secret", nil)
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	if received.Get(HeaderCorrelationID) != "req-42" {
+		t.Errorf("Expected the correlation ID header, got %q", received.Get(HeaderCorrelationID))
+	}
+	span, ok := ParseTraceparent(received.Get(HeaderTraceparent))
+	if !ok || span.TraceID != parent.TraceID || span.SpanID == parent.SpanID {
+		t.Errorf("Expected a child span of the caller's trace, got %q", received.Get(HeaderTraceparent))
+	}
+	if req.Header.Get(HeaderTraceparent) != "" {
+		t.Error("Expected the caller's request to be left unchanged")
+	}
+
+	logs := logger.GetMemoryLogs()
+	if len(logs) != 1 {
+		t.Fatalf("Expected one request summary, got %d", len(logs))
+	}
+	entry := logs[0]
+	if entry.Operation != "http_client" || entry.Level != WARN || entry.CorrelationID != "req-42" {
+		t.Errorf("Expected a WARN summary for a 503 with the correlation ID, got %s %s %q", entry.Level, entry.Operation, entry.CorrelationID)
+	}
+	if entry.Context["status"] != 503 || entry.Context["span_id"] != span.SpanID {
+		t.Errorf("Unexpected summary context: %v", entry.Context)
+	}
+	if strings.Contains(entry.Context["url"].(string), "secret") {
+		t.Errorf("Expected the query string to be left out, got %s", entry.Context["url"])
+	}
+}
+
+func TestTransportStartsTraceAndLogsErrors(t *testing.T) {
+	logger := NewLoggerWithConfig("transport_error_test", &LoggerConfig{
+		AutoSave:        false,
+		EnableMemoryLog: true,
+		MemoryLogLimit:  10,
+	})
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL
+	server.Close()
+
+	client := &http.Client{Transport: NewTransport(logger, nil)}
+	if _, err := client.Get(url); err == nil {
+		t.Fatal("Expected an error for a closed server")
+	}
+
+	logs := logger.GetMemoryLogs()
+	if len(logs) != 1 || logs[0].Level != ERROR {
+		t.Fatalf("Expected an ERROR summary, got %v", logs)
+	}
+	if traceID, _ := logs[0].Context["trace_id"].(string); len(traceID) != 32 {
+		t.Errorf("Expected a new trace to be started, got %q", traceID)
+	}
+}
@@ -0,0 +1,117 @@
+// Package ui serves an embeddable single-page log viewer for a vibelogger
+// Logger: filtering, live tail and correlation pivoting for a single service
+// without any external dependency.
+package ui
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/sumee-139/vibe-logger-go"
+)
+
+// DefaultLimit caps the entries returned by the entries endpoint unless the
+// request asks otherwise
+const DefaultLimit = 500
+
+// MaxLimit is the largest limit a request may ask for
+const MaxLimit = 10000
+
+//go:embed index.html
+var indexHTML []byte
+
+// viewer serves the page and its endpoints for one logger
+type viewer struct {
+	logger *vibelogger.Logger
+}
+
+// entriesResponse is the body of the entries endpoint
+type entriesResponse struct {
+	Entries []vibelogger.LogEntry `json:"entries"`
+	Total   int                   `json:"total"` // Matching entries before the limit
+}
+
+// Handler returns an http.Handler serving the viewer of logger. Mount it under
+// a prefix with http.StripPrefix:
+//
+//	mux.Handle("/logs/", http.StripPrefix("/logs", ui.Handler(logger)))
+//
+// It serves the page at "/", the most recent matching entries of the current
+// log file (or the memory logs without a file) as JSON at "/api/entries" and a
+// live tail of new matching entries at "/api/stream", see
+// vibelogger.StreamHandler. Both endpoints filter by the query parameters of
+// vibelogger.ParseQueryParams; the entries endpoint also takes limit (default
+// DefaultLimit).
+//
+// The viewer shows every entry of the logger to anyone reaching it; protect it
+// like any other debug endpoint, e.g. with vibelogger.RequireAccess.
+func Handler(logger *vibelogger.Logger) http.Handler {
+	v := &viewer{logger: logger}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", v.serveIndex)
+	mux.HandleFunc("/api/entries", v.serveEntries)
+	mux.Handle("/api/stream", vibelogger.StreamHandler(logger))
+	return mux
+}
+
+// serveIndex serves the single-page viewer
+func (v *viewer) serveIndex(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" && r.URL.Path != "/index.html" {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Write(indexHTML)
+}
+
+// serveEntries answers the most recent entries matching the request
+func (v *viewer) serveEntries(w http.ResponseWriter, r *http.Request) {
+	query, err := vibelogger.ParseQueryParams(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	limit := DefaultLimit
+	if s := r.URL.Query().Get("limit"); s != "" {
+		if limit, err = strconv.Atoi(s); err != nil || limit <= 0 || limit > MaxLimit {
+			http.Error(w, fmt.Sprintf("invalid limit %q (must be 1 to %d)", s, MaxLimit), http.StatusBadRequest)
+			return
+		}
+	}
+
+	entries, err := v.entries()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	matched := query.Filter(entries)
+	response := entriesResponse{Entries: matched, Total: len(matched)}
+	if len(matched) > limit {
+		response.Entries = matched[len(matched)-limit:]
+	}
+	if response.Entries == nil {
+		response.Entries = []vibelogger.LogEntry{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// entries returns the entries of the current log file, or the memory logs
+// when the logger writes no file
+func (v *viewer) entries() ([]vibelogger.LogEntry, error) {
+	path := v.logger.GetFilePath()
+	if path == "" {
+		return v.logger.GetMemoryLogs(), nil
+	}
+	v.logger.Flush()
+	result, err := vibelogger.ReadLogFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return result.Entries, nil
+}
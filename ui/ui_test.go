@@ -0,0 +1,118 @@
+package ui
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sumee-139/vibe-logger-go"
+)
+
+// newTestLogger creates a file logger in a temporary directory
+func newTestLogger(t *testing.T) *vibelogger.Logger {
+	t.Helper()
+	config := vibelogger.DefaultConfig()
+	config.FilePath = filepath.Join(t.TempDir(), "app.log")
+	logger, err := vibelogger.CreateFileLoggerWithConfig("ui", config)
+	if err != nil {
+		t.Fatalf("failed to create logger: %v", err)
+	}
+	t.Cleanup(func() { logger.Close() })
+	return logger
+}
+
+func TestHandlerEntries(t *testing.T) {
+	logger := newTestLogger(t)
+	logger.Info("checkout", "Order placed", vibelogger.WithCorrelationID("req-1"))
+	logger.Error("payment", "Card declined", vibelogger.WithCorrelationID("req-1"))
+	logger.Info("checkout", "Order placed", vibelogger.WithCorrelationID("req-2"))
+
+	server := httptest.NewServer(http.StripPrefix("/logs", Handler(logger)))
+	defer server.Close()
+
+	get := func(path string) (*http.Response, entriesResponse) {
+		t.Helper()
+		resp, err := http.Get(server.URL + path)
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		defer resp.Body.Close()
+		var body entriesResponse
+		if resp.StatusCode == http.StatusOK {
+			json.NewDecoder(resp.Body).Decode(&body)
+		}
+		return resp, body
+	}
+
+	_, body := get("/logs/api/entries?correlation_id=req-1")
+	if body.Total != 2 || body.Entries[1].Operation != "payment" {
+		t.Errorf("expected the entries of req-1, got %+v", body)
+	}
+	_, body = get("/logs/api/entries?levels=info&limit=1")
+	if body.Total != 2 || len(body.Entries) != 1 || body.Entries[0].CorrelationID != "req-2" {
+		t.Errorf("expected the latest INFO entry, got %+v", body)
+	}
+	if resp, _ := get("/logs/api/entries?levels=loud"); resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected 400 for an unknown level, got %d", resp.StatusCode)
+	}
+
+	resp, err := http.Get(server.URL + "/logs/")
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/html") {
+		t.Errorf("expected the page, got %d %s", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+}
+
+func TestHandlerStream(t *testing.T) {
+	logger := newTestLogger(t)
+	server := httptest.NewServer(Handler(logger))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/api/stream?levels=error")
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.Header.Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("unexpected content type %q", resp.Header.Get("Content-Type"))
+	}
+
+	// The subscription exists once the first comment arrives
+	reader := bufio.NewReader(resp.Body)
+	if line, _ := reader.ReadString('\n'); !strings.HasPrefix(line, ":") {
+		t.Fatalf("expected a comment, got %q", line)
+	}
+	logger.Info("checkout", "Order placed")
+	logger.Error("payment", "Card declined")
+
+	done := make(chan string, 1)
+	go func() {
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			if strings.HasPrefix(line, "data: ") {
+				done <- strings.TrimPrefix(line, "data: ")
+				return
+			}
+		}
+	}()
+	select {
+	case data := <-done:
+		var entry vibelogger.LogEntry
+		if err := json.Unmarshal([]byte(data), &entry); err != nil || entry.Operation != "payment" {
+			t.Errorf("expected the ERROR entry, got %q", data)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no entry streamed")
+	}
+}
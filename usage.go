@@ -0,0 +1,144 @@
+package vibelogger
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// Categories of the entries written by LogDeprecation and LogFeatureFlag,
+// also added as tags
+const (
+	CategoryDeprecation = "deprecation"
+	CategoryFeatureFlag = "feature_flag"
+)
+
+// usageState remembers which deprecations and flag exposures were logged
+type usageState struct {
+	mutex    sync.Mutex
+	interval time.Duration // Repeat entries after this long; 0 logs each key once
+	keys     map[string]*usageRecord
+}
+
+// newUsageState returns the deduplication state configured by config, nil
+// when every call is logged
+func newUsageState(config *LoggerConfig) *usageState {
+	if !config.UsageDedup {
+		return nil
+	}
+	return &usageState{interval: config.UsageDedupInterval, keys: make(map[string]*usageRecord)}
+}
+
+// usageRecord is the last entry written for a key and the calls suppressed since
+type usageRecord struct {
+	logged     time.Time
+	suppressed int64
+}
+
+// LogDeprecation records the use of a deprecated feature as a WARN entry in
+// the deprecation category, with the call site of the function calling it,
+// so deprecated usage can be found in the logs before removalVersion ships.
+// It is meant to be called at the top of the deprecated function:
+//
+//	func (c *Client) FetchAll() {
+//		logger.LogDeprecation("Client.FetchAll", "v3.0.0")
+//		...
+//	}
+//
+// With UsageDedup each feature and call site is logged once, or once per
+// UsageDedupInterval with the number of calls in between; suppressed calls
+// return nil.
+func (l *Logger) LogDeprecation(feature, removalVersion string, options ...LogOption) error {
+	callSite := usageCallSite()
+	occurrences, ok := l.recordUsage("deprecation\x00" + feature + "\x00" + removalVersion + "\x00" + callSite)
+	if !ok {
+		return nil
+	}
+
+	message := fmt.Sprintf("Deprecated %s used", feature)
+	if removalVersion != "" {
+		message += fmt.Sprintf(", to be removed in %s", removalVersion)
+	}
+	options = append([]LogOption{
+		WithCategory(CategoryDeprecation),
+		WithTags(CategoryDeprecation),
+		WithContext(map[string]interface{}{
+			"deprecated_feature": feature,
+			"removal_version":    removalVersion,
+			"call_site":          callSite,
+			"occurrences":        occurrences,
+		}),
+	}, options...)
+	return l.Warn("deprecation", message, options...)
+}
+
+// LogFeatureFlag records that a feature flag was evaluated to a variant as an
+// INFO entry in the feature_flag category, so flag exposure can be measured
+// from the logs. Deduplication works as with LogDeprecation, per flag and
+// variant.
+func (l *Logger) LogFeatureFlag(flag, variant string, options ...LogOption) error {
+	occurrences, ok := l.recordUsage("feature_flag\x00" + flag + "\x00" + variant)
+	if !ok {
+		return nil
+	}
+
+	options = append([]LogOption{
+		WithCategory(CategoryFeatureFlag),
+		WithTags(CategoryFeatureFlag),
+		WithContext(map[string]interface{}{
+			"feature_flag": flag,
+			"variant":      variant,
+			"occurrences":  occurrences,
+		}),
+	}, options...)
+	return l.Info("feature_flag", fmt.Sprintf("Feature flag %s evaluated to %s", flag, variant), options...)
+}
+
+// recordUsage records a call with the deduplication state of the current
+// configuration, see usageState.record
+func (l *Logger) recordUsage(key string) (int64, bool) {
+	l.mutex.Lock()
+	usage := l.usage
+	l.mutex.Unlock()
+	return usage.record(key)
+}
+
+// record counts a use of key and reports whether an entry should be written,
+// with the number of uses it stands for. A nil state does not deduplicate.
+func (s *usageState) record(key string) (int64, bool) {
+	if s == nil {
+		return 1, true
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := time.Now()
+	rec, ok := s.keys[key]
+	if !ok {
+		s.keys[key] = &usageRecord{logged: now}
+		return 1, true
+	}
+	if s.interval <= 0 || now.Sub(rec.logged) < s.interval {
+		rec.suppressed++
+		return 0, false
+	}
+	occurrences := rec.suppressed + 1
+	rec.logged = now
+	rec.suppressed = 0
+	return occurrences, true
+}
+
+// usageCallSite returns the caller of the function that called LogDeprecation
+func usageCallSite() string {
+	pc, file, line, ok := runtime.Caller(3)
+	if !ok {
+		return "unknown"
+	}
+	name := "unknown"
+	if fn := runtime.FuncForPC(pc); fn != nil {
+		name = fn.Name()
+	}
+	return fmt.Sprintf("%s (%s:%d)", name, filepath.Base(file), line)
+}
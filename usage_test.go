@@ -0,0 +1,101 @@
+package vibelogger
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// deprecatedFetch stands for a deprecated library function
+func deprecatedFetch(logger *Logger) {
+	logger.LogDeprecation("Client.Fetch", "v3.0.0")
+}
+
+func TestLogDeprecation(t *testing.T) {
+	config := DefaultConfig()
+	config.AutoSave = false
+	config.EnableMemoryLog = true
+	logger := NewLoggerWithConfig("usage", config)
+	defer logger.Close()
+
+	for i := 0; i < 3; i++ {
+		deprecatedFetch(logger)
+	}
+	deprecatedFetch(logger) // Another call site
+
+	logs := logger.GetMemoryLogs()
+	if len(logs) != 2 {
+		t.Fatalf("expected one entry per call site, got %d", len(logs))
+	}
+	entry := logs[0]
+	if entry.Level != WARN || entry.Operation != "deprecation" || entry.Category != CategoryDeprecation || !entry.HasTag(CategoryDeprecation) {
+		t.Errorf("unexpected entry: %s %s %s %v", entry.Level, entry.Operation, entry.Category, entry.Tags)
+	}
+	if entry.Message != "Deprecated Client.Fetch used, to be removed in v3.0.0" {
+		t.Errorf("unexpected message: %s", entry.Message)
+	}
+	callSite, _ := entry.Context["call_site"].(string)
+	if !strings.Contains(callSite, "TestLogDeprecation") || !strings.Contains(callSite, "usage_test.go") {
+		t.Errorf("expected the caller of the deprecated function, got %q", callSite)
+	}
+	if entry.Context["deprecated_feature"] != "Client.Fetch" || entry.Context["removal_version"] != "v3.0.0" || entry.Context["occurrences"] != int64(1) {
+		t.Errorf("unexpected context: %v", entry.Context)
+	}
+	if logs[1].Context["call_site"] == callSite {
+		t.Error("call sites should be told apart")
+	}
+}
+
+func TestLogFeatureFlag(t *testing.T) {
+	config := DefaultConfig()
+	config.AutoSave = false
+	config.EnableMemoryLog = true
+	config.UsageDedupInterval = time.Hour
+	logger := NewLoggerWithConfig("usage_flags", config)
+	defer logger.Close()
+
+	logger.LogFeatureFlag("new_checkout", "treatment", WithUserID("u1"))
+	logger.LogFeatureFlag("new_checkout", "treatment")
+	logger.LogFeatureFlag("new_checkout", "control")
+
+	logs := logger.GetMemoryLogs()
+	if len(logs) != 2 {
+		t.Fatalf("expected one entry per variant, got %d", len(logs))
+	}
+	entry := logs[0]
+	if entry.Level != INFO || entry.Category != CategoryFeatureFlag || entry.Context["feature_flag"] != "new_checkout" || entry.Context["variant"] != "treatment" {
+		t.Errorf("unexpected entry: %s %s %v", entry.Level, entry.Category, entry.Context)
+	}
+	if entry.Context["user_id"] != "u1" {
+		t.Errorf("options should be applied, got %v", entry.Context)
+	}
+
+	// After the interval the entry is repeated with the calls in between
+	logger.usage.keys["feature_flag\x00new_checkout\x00treatment"].logged = time.Now().Add(-2 * time.Hour)
+	logger.LogFeatureFlag("new_checkout", "treatment")
+	logs = logger.GetMemoryLogs()
+	if len(logs) != 3 || logs[2].Context["occurrences"] != int64(2) {
+		t.Errorf("expected a repeated entry counting 2 calls, got %d entries", len(logs))
+	}
+}
+
+func TestUsageWithoutDedup(t *testing.T) {
+	logger := NewLoggerWithConfig("usage_all", &LoggerConfig{AutoSave: false, EnableMemoryLog: true})
+	defer logger.Close()
+
+	logger.LogFeatureFlag("beta", "on")
+	logger.LogFeatureFlag("beta", "on")
+	if n := len(logger.GetMemoryLogs()); n != 2 {
+		t.Errorf("expected every call to be logged without UsageDedup, got %d", n)
+	}
+
+	// Deduplication can be turned on at runtime
+	if err := logger.UpdateConfig(&LoggerConfig{AutoSave: false, EnableMemoryLog: true, UsageDedup: true}); err != nil {
+		t.Fatalf("failed to update config: %v", err)
+	}
+	logger.LogFeatureFlag("beta", "on")
+	logger.LogFeatureFlag("beta", "on")
+	if n := len(logger.GetMemoryLogs()); n != 3 {
+		t.Errorf("expected UsageDedup to apply after UpdateConfig, got %d entries", n)
+	}
+}
@@ -0,0 +1,99 @@
+package vibelogger
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Entry validation modes
+const (
+	ValidationOff    = "off"    // Entries are written as given (default)
+	ValidationFix    = "fix"    // Invalid parts are repaired, e.g. unserializable values are stringified
+	ValidationReject = "reject" // Invalid entries are not written and Log returns an *EntryValidationError
+)
+
+// EntryValidationError describes why an entry was rejected
+type EntryValidationError struct {
+	Operation string
+	Problems  []string
+}
+
+// Error implements error
+func (e *EntryValidationError) Error() string {
+	return fmt.Sprintf("invalid log entry for operation %q: %s", e.Operation, strings.Join(e.Problems, "; "))
+}
+
+// isValidEntryValidation checks if the validation mode is supported
+func isValidEntryValidation(mode string) bool {
+	return mode == "" || mode == ValidationOff || mode == ValidationFix || mode == ValidationReject
+}
+
+// validateEntry checks an entry before it is written and returns its problems.
+// With fix set, each problem is repaired in place.
+func validateEntry(entry *LogEntry, fix bool) []string {
+	var problems []string
+
+	if strings.TrimSpace(entry.Operation) == "" {
+		problems = append(problems, "operation is empty")
+		if fix {
+			entry.Operation = "unknown"
+		}
+	}
+	if !utf8.ValidString(entry.Operation) {
+		problems = append(problems, "operation is not valid UTF-8")
+		if fix {
+			entry.Operation = strings.ToValidUTF8(entry.Operation, "�")
+		}
+	}
+	if !utf8.ValidString(entry.Message) {
+		problems = append(problems, "message is not valid UTF-8")
+		if fix {
+			entry.Message = strings.ToValidUTF8(entry.Message, "�")
+		}
+	}
+
+	// Sorted keys keep the problem list stable
+	keys := make([]string, 0, len(entry.Context))
+	for key := range entry.Context {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value := entry.Context[key]
+		if problem := checkContextValue(value); problem != "" {
+			problems = append(problems, fmt.Sprintf("context %q %s", key, problem))
+			if fix {
+				entry.Context[key] = fmt.Sprint(value)
+			}
+		}
+	}
+	return problems
+}
+
+// checkContextValue returns why a context value cannot be encoded, or ""
+func checkContextValue(value interface{}) string {
+	switch v := value.(type) {
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return fmt.Sprintf("is %v, which JSON cannot represent", v)
+		}
+		return ""
+	case float32:
+		return checkContextValue(float64(v))
+	case string:
+		if !utf8.ValidString(v) {
+			return "is not valid UTF-8"
+		}
+		return ""
+	}
+
+	if _, err := json.Marshal(value); err != nil {
+		return fmt.Sprintf("is not JSON-serializable (%T): %v", value, err)
+	}
+	return ""
+}
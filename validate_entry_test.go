@@ -0,0 +1,73 @@
+package vibelogger
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestEntryValidationFix(t *testing.T) {
+	logger := NewLoggerWithConfig("validation_test", &LoggerConfig{
+		EnableMemoryLog: true,
+		MemoryLogLimit:  10,
+		EntryValidation: ValidationFix,
+	})
+
+	err := logger.Info("", "bad \xff message", WithContext(map[string]interface{}{
+		"ratio":   math.NaN(),
+		"channel": make(chan int),
+		"ok":      1,
+	}))
+	if err != nil {
+		t.Fatalf("Expected the entry to be fixed and written, got %v", err)
+	}
+
+	entry := logger.GetMemoryLogs()[0]
+	if entry.Operation != "unknown" {
+		t.Errorf("Expected empty operation to be replaced, got %q", entry.Operation)
+	}
+	if entry.Message != "bad � message" {
+		t.Errorf("Expected invalid UTF-8 to be replaced, got %q", entry.Message)
+	}
+	if entry.Context["ratio"] != "NaN" {
+		t.Errorf("Expected NaN to be stringified, got %v", entry.Context["ratio"])
+	}
+	if _, ok := entry.Context["channel"].(string); !ok {
+		t.Errorf("Expected channel to be stringified, got %T", entry.Context["channel"])
+	}
+	if entry.Context["ok"] != 1 {
+		t.Errorf("Expected valid values to be kept, got %v", entry.Context["ok"])
+	}
+}
+
+func TestEntryValidationReject(t *testing.T) {
+	logger := NewLoggerWithConfig("validation_test", &LoggerConfig{
+		EnableMemoryLog: true,
+		MemoryLogLimit:  10,
+		EntryValidation: ValidationReject,
+	})
+
+	err := logger.Info("metrics", "Computed ratio", WithContext(map[string]interface{}{"ratio": math.Inf(1)}))
+	var validationErr *EntryValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Expected an EntryValidationError, got %v", err)
+	}
+	if len(validationErr.Problems) != 1 || validationErr.Operation != "metrics" {
+		t.Errorf("Unexpected problems: %v", validationErr.Problems)
+	}
+	if len(logger.GetMemoryLogs()) != 0 {
+		t.Error("Expected rejected entries not to be written")
+	}
+
+	if err := logger.Info("metrics", "Valid entry"); err != nil {
+		t.Errorf("Expected valid entries to be written, got %v", err)
+	}
+}
+
+func TestInvalidEntryValidationMode(t *testing.T) {
+	config := DefaultConfig()
+	config.EntryValidation = "strict"
+	if err := config.Validate(); err == nil {
+		t.Error("Expected validation to fail for unsupported entry validation mode")
+	}
+}
@@ -1,6 +1,10 @@
 package vibelogger
 
-import "fmt"
+import (
+	"fmt"
+	"runtime/debug"
+	"sync"
+)
 
 // Version information for the vibe-logger-go package
 const (
@@ -25,16 +29,32 @@ const (
 
 // VersionInfo contains detailed version information
 type VersionInfo struct {
-	Version    string `json:"version"`
-	Major      int    `json:"major"`
-	Minor      int    `json:"minor"`
-	Patch      int    `json:"patch"`
-	Prerelease string `json:"prerelease,omitempty"`
-	BuildMeta  string `json:"build_metadata,omitempty"`
-	GoVersion  string `json:"go_version"`
-	UserAgent  string `json:"user_agent"`
+	Version    string     `json:"version"`
+	Major      int        `json:"major"`
+	Minor      int        `json:"minor"`
+	Patch      int        `json:"patch"`
+	Prerelease string     `json:"prerelease,omitempty"`
+	BuildMeta  string     `json:"build_metadata,omitempty"`
+	GoVersion  string     `json:"go_version"`
+	UserAgent  string     `json:"user_agent"`
+	Build      *BuildInfo `json:"build,omitempty"`
+}
+
+// BuildInfo describes the binary that embeds the logger, as reported by runtime/debug
+type BuildInfo struct {
+	ModulePath    string `json:"module_path,omitempty"`
+	ModuleVersion string `json:"module_version,omitempty"`
+	VCS           string `json:"vcs,omitempty"`
+	VCSRevision   string `json:"vcs_revision,omitempty"`
+	VCSTime       string `json:"vcs_time,omitempty"`
+	VCSModified   bool   `json:"vcs_modified"`
 }
 
+var (
+	buildInfoOnce   sync.Once
+	cachedBuildInfo *BuildInfo
+)
+
 // GetVersion returns the current version string
 func GetVersion() string {
 	if VersionPrerelease != "" {
@@ -60,7 +80,61 @@ func GetVersionInfo() *VersionInfo {
 		BuildMeta:  BuildMetadata,
 		GoVersion:  getGoVersion(),
 		UserAgent:  fmt.Sprintf("vibe-logger-go/%s", GetVersion()),
+		Build:      GetBuildInfo(),
+	}
+}
+
+// GetBuildInfo returns module and VCS information of the running binary.
+// It returns nil when the binary was built without module support.
+func GetBuildInfo() *BuildInfo {
+	buildInfoOnce.Do(func() {
+		info, ok := debug.ReadBuildInfo()
+		if !ok {
+			return
+		}
+
+		build := &BuildInfo{
+			ModulePath:    info.Main.Path,
+			ModuleVersion: info.Main.Version,
+		}
+		for _, setting := range info.Settings {
+			switch setting.Key {
+			case "vcs":
+				build.VCS = setting.Value
+			case "vcs.revision":
+				build.VCSRevision = setting.Value
+			case "vcs.time":
+				build.VCSTime = setting.Value
+			case "vcs.modified":
+				build.VCSModified = setting.Value == "true"
+			}
+		}
+		cachedBuildInfo = build
+	})
+
+	if cachedBuildInfo == nil {
+		return nil
+	}
+	// Return a copy so callers cannot alter the cached value
+	build := *cachedBuildInfo
+	return &build
+}
+
+// Fields returns the build information as flat log context fields.
+// Empty values are omitted so entries only carry what is actually known.
+func (b *BuildInfo) Fields() map[string]interface{} {
+	fields := make(map[string]interface{})
+	if b == nil {
+		return fields
+	}
+	if b.ModuleVersion != "" {
+		fields["module_version"] = b.ModuleVersion
+	}
+	if b.VCSRevision != "" {
+		fields["vcs_revision"] = b.VCSRevision
+		fields["vcs_modified"] = b.VCSModified
 	}
+	return fields
 }
 
 // IsStableVersion returns true if this is a stable (non-prerelease) version
@@ -191,3 +191,51 @@ func TestVersionConstants_SemVer(t *testing.T) {
 		t.Errorf("Version length seems unreasonable: '%s' (length: %d)", version, len(version))
 	}
 }
+
+func TestBuildInfoFields(t *testing.T) {
+	build := &BuildInfo{
+		ModulePath:    "example.com/app",
+		ModuleVersion: "v1.2.3",
+		VCSRevision:   "abc123",
+		VCSModified:   true,
+	}
+
+	fields := build.Fields()
+	if fields["module_version"] != "v1.2.3" {
+		t.Errorf("Expected module_version 'v1.2.3', got '%v'", fields["module_version"])
+	}
+	if fields["vcs_revision"] != "abc123" {
+		t.Errorf("Expected vcs_revision 'abc123', got '%v'", fields["vcs_revision"])
+	}
+	if fields["vcs_modified"] != true {
+		t.Errorf("Expected vcs_modified true, got '%v'", fields["vcs_modified"])
+	}
+
+	// Unknown values should be omitted rather than logged as empty strings
+	empty := (&BuildInfo{}).Fields()
+	if len(empty) != 0 {
+		t.Errorf("Expected no fields for empty build info, got %v", empty)
+	}
+
+	var nilBuild *BuildInfo
+	if len(nilBuild.Fields()) != 0 {
+		t.Error("Expected no fields for nil build info")
+	}
+}
+
+func TestGetBuildInfoReturnsCopy(t *testing.T) {
+	build := GetBuildInfo()
+	if build == nil {
+		t.Skip("Build info not available in this binary")
+	}
+
+	build.ModuleVersion = "modified"
+	if GetBuildInfo().ModuleVersion == "modified" {
+		t.Error("GetBuildInfo should return a copy of the cached value")
+	}
+
+	versionInfo := GetVersionInfo()
+	if versionInfo.Build == nil {
+		t.Error("VersionInfo.Build should be set when build info is available")
+	}
+}
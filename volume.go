@@ -0,0 +1,166 @@
+package vibelogger
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// VolumeHalfLife is the half-life of the recent volume of operations in Stats
+const VolumeHalfLife = time.Hour
+
+// Limits of the operation volume ranking
+const (
+	maxTrackedOperations = 1000 // Operations tracked per logger; the quietest are evicted beyond
+	topVolumeOperations  = 20   // Operations reported in Stats.TopOperations
+)
+
+// OperationVolume is the logging volume of one operation. Entries and Bytes
+// count since the operation entered the ranking; the recent values decay
+// with VolumeHalfLife and rank operations by their current volume.
+type OperationVolume struct {
+	Operation     string  `json:"operation"`
+	Entries       int64   `json:"entries"`
+	Bytes         int64   `json:"bytes"`
+	RecentEntries float64 `json:"recent_entries"`
+	RecentBytes   float64 `json:"recent_bytes"`
+}
+
+// ProjectVolume is the logging volume of all loggers of a project in this process
+type ProjectVolume struct {
+	Project string `json:"project"`
+	Entries int64  `json:"entries"`
+	Bytes   int64  `json:"bytes"`
+}
+
+// operationVolume accumulates the volume of one operation
+type operationVolume struct {
+	entries       int64
+	bytes         int64
+	recentEntries float64
+	recentBytes   float64
+	updated       time.Time
+}
+
+// decay brings the recent values forward to now
+func (v *operationVolume) decay(now time.Time) {
+	if elapsed := now.Sub(v.updated); elapsed > 0 {
+		factor := math.Exp2(-float64(elapsed) / float64(VolumeHalfLife))
+		v.recentEntries *= factor
+		v.recentBytes *= factor
+	}
+	v.updated = now
+}
+
+// volumeTracker ranks the operations of a logger by volume. It is guarded by
+// the mutex of loggerStats.
+type volumeTracker struct {
+	operations map[string]*operationVolume
+}
+
+// add counts an entry of size bytes
+func (t *volumeTracker) add(operation string, size int64, now time.Time) {
+	v, ok := t.operations[operation]
+	if !ok {
+		if len(t.operations) >= maxTrackedOperations {
+			t.evict(now)
+		}
+		v = &operationVolume{updated: now}
+		t.operations[operation] = v
+	}
+	v.decay(now)
+	v.entries++
+	v.bytes += size
+	v.recentEntries++
+	v.recentBytes += float64(size)
+}
+
+// evict drops the tenth of the operations with the lowest recent volume, so
+// the map stays bounded however many distinct operations are logged
+func (t *volumeTracker) evict(now time.Time) {
+	ranked := t.ranked(now)
+	for _, v := range ranked[len(ranked)-maxTrackedOperations/10:] {
+		delete(t.operations, v.Operation)
+	}
+}
+
+// ranked returns all operations ordered by recent bytes, largest first
+func (t *volumeTracker) ranked(now time.Time) []OperationVolume {
+	ranked := make([]OperationVolume, 0, len(t.operations))
+	for operation, v := range t.operations {
+		v.decay(now)
+		ranked = append(ranked, OperationVolume{
+			Operation:     operation,
+			Entries:       v.entries,
+			Bytes:         v.bytes,
+			RecentEntries: v.recentEntries,
+			RecentBytes:   v.recentBytes,
+		})
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].RecentBytes != ranked[j].RecentBytes {
+			return ranked[i].RecentBytes > ranked[j].RecentBytes
+		}
+		return ranked[i].Operation < ranked[j].Operation
+	})
+	return ranked
+}
+
+// top returns the n operations with the highest recent volume
+func (t *volumeTracker) top(n int, now time.Time) []OperationVolume {
+	ranked := t.ranked(now)
+	if len(ranked) > n {
+		ranked = ranked[:n]
+	}
+	return ranked
+}
+
+// recordSize is the number of bytes a record takes in a file, with its newline
+func recordSize(data []byte) int64 {
+	if data == nil {
+		return 0
+	}
+	return int64(len(data) + 1)
+}
+
+// projectCounter counts the volume of one project
+type projectCounter struct {
+	entries atomic.Int64
+	bytes   atomic.Int64
+}
+
+// projectVolumes holds a *projectCounter per project for every logger of the
+// process; atomic counters keep loggers of different projects independent
+var projectVolumes sync.Map
+
+// addProjectVolume counts an entry of size bytes for project
+func addProjectVolume(project string, size int64) {
+	counter, ok := projectVolumes.Load(project)
+	if !ok {
+		counter, _ = projectVolumes.LoadOrStore(project, &projectCounter{})
+	}
+	c := counter.(*projectCounter)
+	c.entries.Add(1)
+	c.bytes.Add(size)
+}
+
+// ProjectVolumes returns the entries and bytes written by all loggers of this
+// process per project since it started, largest first. Copies of entries in
+// error, metrics and category files count towards their project as well.
+func ProjectVolumes() []ProjectVolume {
+	var volumes []ProjectVolume
+	projectVolumes.Range(func(key, value interface{}) bool {
+		c := value.(*projectCounter)
+		volumes = append(volumes, ProjectVolume{Project: key.(string), Entries: c.entries.Load(), Bytes: c.bytes.Load()})
+		return true
+	})
+	sort.Slice(volumes, func(i, j int) bool {
+		if volumes[i].Bytes != volumes[j].Bytes {
+			return volumes[i].Bytes > volumes[j].Bytes
+		}
+		return volumes[i].Project < volumes[j].Project
+	})
+	return volumes
+}
@@ -0,0 +1,73 @@
+package vibelogger
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestStatsVolume(t *testing.T) {
+	logger := NewLoggerWithConfig("volume", &LoggerConfig{AutoSave: false, ProjectName: "volume-test"})
+	defer logger.Close()
+
+	for i := 0; i < 5; i++ {
+		logger.Info("checkout", "Order placed with a rather long message to make it the largest")
+	}
+	logger.Info("login", "ok")
+	logger.Info("login", "ok")
+
+	stats := logger.Stats()
+	if stats.Project != "volume-test" {
+		t.Errorf("expected the project, got %q", stats.Project)
+	}
+	if len(stats.TopOperations) != 2 {
+		t.Fatalf("expected 2 operations, got %+v", stats.TopOperations)
+	}
+	checkout, login := stats.TopOperations[0], stats.TopOperations[1]
+	if checkout.Operation != "checkout" || checkout.Entries != 5 || login.Entries != 2 {
+		t.Errorf("expected operations ranked by volume, got %+v", stats.TopOperations)
+	}
+	if stats.TotalBytes != checkout.Bytes+login.Bytes || checkout.Bytes <= login.Bytes {
+		t.Errorf("unexpected bytes: total %d, operations %+v", stats.TotalBytes, stats.TopOperations)
+	}
+
+	var project ProjectVolume
+	for _, v := range ProjectVolumes() {
+		if v.Project == "volume-test" {
+			project = v
+		}
+	}
+	if project.Entries != 7 || project.Bytes != stats.TotalBytes {
+		t.Errorf("expected the project volume to match the logger, got %+v", project)
+	}
+
+	logger.writeSummary()
+	logs := logger.stats.snapshot()
+	if logs.TotalEntries != 8 {
+		t.Errorf("expected the summary entry to be counted, got %d", logs.TotalEntries)
+	}
+}
+
+func TestVolumeTrackerDecayAndEviction(t *testing.T) {
+	tracker := volumeTracker{operations: make(map[string]*operationVolume)}
+	start := time.Now()
+	tracker.add("old", 1000, start)
+	tracker.add("new", 600, start.Add(VolumeHalfLife))
+
+	top := tracker.top(1, start.Add(VolumeHalfLife))
+	if top[0].Operation != "new" {
+		t.Errorf("expected recent volume to outrank decayed volume, got %+v", top)
+	}
+	ranked := tracker.ranked(start.Add(VolumeHalfLife))
+	if old := ranked[1]; old.Bytes != 1000 || math.Abs(old.RecentBytes-500) > 1 {
+		t.Errorf("expected half of the old volume after one half-life, got %+v", old)
+	}
+
+	for i := 0; i < maxTrackedOperations+10; i++ {
+		tracker.add(fmt.Sprintf("op_%d", i), int64(i+1), start.Add(VolumeHalfLife))
+	}
+	if len(tracker.operations) > maxTrackedOperations {
+		t.Errorf("expected at most %d tracked operations, got %d", maxTrackedOperations, len(tracker.operations))
+	}
+}
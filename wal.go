@@ -0,0 +1,287 @@
+package vibelogger
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+)
+
+// walCompactSize is the journal size beyond which records of written entries
+// are dropped while other entries are still queued
+const walCompactSize = 4 * 1024 * 1024
+
+// walRecord is a line of the write-ahead journal: a queued entry, or the
+// sequence numbers of entries that reached the log file
+type walRecord struct {
+	Seq   uint64    `json:"seq,omitempty"`
+	Entry *LogEntry `json:"entry,omitempty"`
+	Done  []uint64  `json:"done,omitempty"`
+}
+
+// writeAheadJournal keeps the entries queued on the writer shards on disk
+// until the file writer has written them, so a crash loses none of them. The
+// journal stores entries as plain JSON and is emptied whenever no entry is
+// queued, so the hot path pays a single append instead of the full encoding.
+// All methods are safe on a nil journal.
+type writeAheadJournal struct {
+	path    string
+	mutex   sync.Mutex
+	file    *os.File // nil once closed
+	size    int64
+	next    uint64
+	pending map[uint64]struct{} // Journaled entries not yet written to the log file
+}
+
+// openWriteAheadJournal opens the journal at path and returns the entries a
+// previous run journaled but did not write, in the order they were logged.
+// The caller writes them to the log file and then calls reset.
+func openWriteAheadJournal(path string) (*writeAheadJournal, []LogEntry, error) {
+	recovered, err := readWriteAheadJournal(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read write-ahead journal: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open write-ahead journal: %w", err)
+	}
+	j := &writeAheadJournal{path: path, file: file, pending: make(map[uint64]struct{})}
+	if stat, err := file.Stat(); err == nil {
+		j.size = stat.Size()
+	}
+	return j, recovered, nil
+}
+
+// readWriteAheadJournal returns the journaled entries without a done record.
+// Unreadable lines, such as one cut short by a crash, are skipped.
+func readWriteAheadJournal(path string) ([]LogEntry, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var seqs []uint64
+	entries := make(map[uint64]LogEntry)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), len(data)+1)
+	for scanner.Scan() {
+		var record walRecord
+		if json.Unmarshal(scanner.Bytes(), &record) != nil {
+			continue
+		}
+		if record.Entry != nil && record.Seq != 0 {
+			seqs = append(seqs, record.Seq)
+			entries[record.Seq] = *record.Entry
+		}
+		for _, seq := range record.Done {
+			delete(entries, seq)
+		}
+	}
+
+	var recovered []LogEntry
+	for _, seq := range seqs {
+		if entry, ok := entries[seq]; ok {
+			recovered = append(recovered, entry)
+		}
+	}
+	return recovered, nil
+}
+
+// append journals an entry before it is queued and returns its sequence
+// number, 0 when there is no journal
+func (j *writeAheadJournal) append(entry *LogEntry) (uint64, error) {
+	if j == nil {
+		return 0, nil
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal journal record: %w", err)
+	}
+
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+	if j.file == nil {
+		return 0, nil
+	}
+	seq := j.next + 1
+	line := make([]byte, 0, len(data)+32)
+	line = append(line, `{"seq":`...)
+	line = strconv.AppendUint(line, seq, 10)
+	line = append(line, `,"entry":`...)
+	line = append(line, data...)
+	line = append(line, "}\n"...)
+	if _, err := j.file.Write(line); err != nil {
+		return 0, fmt.Errorf("failed to write journal record: %w", err)
+	}
+	j.next = seq
+	j.size += int64(len(line))
+	j.pending[seq] = struct{}{}
+	return seq, nil
+}
+
+// commit marks journaled entries as written to the log file. The journal is
+// truncated once nothing is pending, and compacted when it grows large while
+// entries stay queued. Zero sequence numbers are ignored.
+func (j *writeAheadJournal) commit(seqs ...uint64) {
+	if j == nil {
+		return
+	}
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	done := seqs[:0:0]
+	for _, seq := range seqs {
+		if _, ok := j.pending[seq]; ok {
+			delete(j.pending, seq)
+			done = append(done, seq)
+		}
+	}
+	if len(done) == 0 || j.file == nil {
+		return
+	}
+	if len(j.pending) == 0 {
+		if j.file.Truncate(0) == nil {
+			j.size = 0
+			return
+		}
+	}
+
+	data, err := json.Marshal(walRecord{Done: done})
+	if err != nil {
+		return
+	}
+	if n, err := j.file.Write(append(data, '\n')); err == nil {
+		j.size += int64(n)
+	}
+	if j.size > walCompactSize {
+		j.compact()
+	}
+}
+
+// compact rewrites the journal with the pending entries only. On failure the
+// journal is left as it is; done records keep it correct. The caller must
+// hold the mutex.
+func (j *writeAheadJournal) compact() {
+	data, err := os.ReadFile(j.path)
+	if err != nil {
+		return
+	}
+	var rest []byte
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), len(data)+1)
+	for scanner.Scan() {
+		var record struct {
+			Seq uint64 `json:"seq"`
+		}
+		if json.Unmarshal(scanner.Bytes(), &record) != nil {
+			continue
+		}
+		if _, ok := j.pending[record.Seq]; ok {
+			rest = append(rest, scanner.Bytes()...)
+			rest = append(rest, '\n')
+		}
+	}
+
+	tmpPath := j.path + ".tmp"
+	if err := os.WriteFile(tmpPath, rest, 0600); err != nil {
+		return
+	}
+	file, err := os.OpenFile(tmpPath, os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		os.Remove(tmpPath)
+		return
+	}
+	if err := os.Rename(tmpPath, j.path); err != nil {
+		file.Close()
+		os.Remove(tmpPath)
+		return
+	}
+	j.file.Close()
+	j.file = file
+	j.size = int64(len(rest))
+}
+
+// reset empties the journal after the recovered entries have been written
+func (j *writeAheadJournal) reset() error {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+	if err := j.file.Truncate(0); err != nil {
+		return fmt.Errorf("failed to reset write-ahead journal: %w", err)
+	}
+	j.size = 0
+	return nil
+}
+
+// close closes the journal and removes it when every entry was written
+func (j *writeAheadJournal) close() error {
+	if j == nil {
+		return nil
+	}
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+	if j.file == nil {
+		return nil
+	}
+	err := j.file.Close()
+	j.file = nil
+	if len(j.pending) == 0 {
+		os.Remove(j.path)
+	}
+	return err
+}
+
+// release closes the journal but keeps it on disk, for recovered entries that
+// could not be written and must be replayed by the next run
+func (j *writeAheadJournal) release() {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+	if j.file != nil {
+		j.file.Close()
+		j.file = nil
+	}
+}
+
+// writeAheadJournalPath returns the journal of a logger: a hidden file next
+// to its log file, so log readers, rotation and the janitor skip it. Loggers
+// writing to timestamped files name it after the logger instead of the file,
+// so a restarted process finds the journal of the previous run.
+func (l *Logger) writeAheadJournalPath() string {
+	name := l.name
+	if l.config.FilePath != "" {
+		name = filepath.Base(l.filePath)
+	}
+	return filepath.Join(filepath.Dir(l.filePath), "."+name+".wal")
+}
+
+// openJournal journals the entries queued on the writer shards, after writing
+// the entries a crashed run left in the journal. It returns the number of
+// recovered entries.
+func (l *Logger) openJournal() (int, error) {
+	journal, recovered, err := openWriteAheadJournal(l.writeAheadJournalPath())
+	if err != nil {
+		return 0, err
+	}
+	for _, entry := range recovered {
+		rm, err := l.writeEntryUnlocked(entry)
+		l.reportRotationWarnings(rm)
+		if err != nil {
+			journal.release()
+			return 0, fmt.Errorf("failed to write recovered entries: %w", err)
+		}
+	}
+	if len(recovered) > 0 {
+		if err := journal.reset(); err != nil {
+			journal.close()
+			return 0, err
+		}
+	}
+	l.journal = journal
+	return len(recovered), nil
+}
@@ -0,0 +1,169 @@
+package vibelogger
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWriteAheadJournal(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	config := DefaultConfig()
+	config.FilePath = path
+	config.WriterShards = 4
+	config.WriteAheadJournal = true
+	logger, err := CreateFileLoggerWithConfig("wal", config)
+	if err != nil {
+		t.Fatalf("failed to create logger: %v", err)
+	}
+
+	journalPath := logger.writeAheadJournalPath()
+	if journalPath != filepath.Join(filepath.Dir(path), ".app.log.wal") {
+		t.Errorf("expected a hidden journal next to the log file, got %s", journalPath)
+	}
+
+	for i := 0; i < 50; i++ {
+		logger.Info("checkout", "Order placed")
+	}
+	logger.Flush()
+	if stat, err := os.Stat(journalPath); err != nil || stat.Size() != 0 {
+		t.Errorf("expected an empty journal once everything is written, got %v", err)
+	}
+
+	logger.Close()
+	if _, err := os.Stat(journalPath); !os.IsNotExist(err) {
+		t.Errorf("expected Close to remove the journal, got %v", err)
+	}
+	result, err := ReadLogFile(path)
+	if err != nil || len(result.Entries) != 50 {
+		t.Errorf("expected 50 entries, got %d (%v)", len(result.Entries), err)
+	}
+}
+
+func TestWriteAheadJournalRecovery(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+
+	// A crashed run journaled two entries and wrote only the first
+	journal, _, err := openWriteAheadJournal(filepath.Join(filepath.Dir(path), ".app.log.wal"))
+	if err != nil {
+		t.Fatalf("failed to open journal: %v", err)
+	}
+	written, _ := journal.append(&LogEntry{ID: "written", Level: INFO, Operation: "checkout", Message: "Order placed"})
+	journal.append(&LogEntry{ID: "queued", Level: ERROR, Operation: "payment", Message: "Card declined"})
+	journal.append(&LogEntry{ID: "third", Level: INFO, Operation: "checkout", Message: "Order placed"})
+	journal.commit(written)
+	journal.file.WriteString(`{"seq":4,"entry":{"id":"cut`) // Cut short by the crash
+	journal.file.Close()
+
+	config := DefaultConfig()
+	config.FilePath = path
+	config.WriterShards = 2
+	config.WriteAheadJournal = true
+	logger, err := CreateFileLoggerWithConfig("wal_recovery", config)
+	if err != nil {
+		t.Fatalf("failed to create logger: %v", err)
+	}
+	logger.Close()
+
+	result, err := ReadLogFile(path)
+	if err != nil {
+		t.Fatalf("failed to read log: %v", err)
+	}
+	var ids []string
+	var recovered interface{}
+	for _, entry := range result.Entries {
+		ids = append(ids, entry.ID)
+		if entry.Operation == "log_recovery" {
+			recovered = entry.Context["recovered_entries"]
+		}
+	}
+	if len(ids) != 3 || ids[0] != "queued" || ids[1] != "third" {
+		t.Errorf("expected the unwritten entries in order, got %v", ids)
+	}
+	if recovered != float64(2) {
+		t.Errorf("expected a recovery entry counting 2 entries, got %v", recovered)
+	}
+}
+
+func TestWriteAheadJournalRestart(t *testing.T) {
+	// Timestamped log files change names between runs; the journal does not
+	config := DefaultConfig()
+	config.SandboxDir = t.TempDir()
+	config.ProjectName = "wal-restart"
+	config.WriterShards = 2
+	config.WriteAheadJournal = true
+	dir := filepath.Join(config.SandboxDir, "logs", "wal-restart")
+	os.MkdirAll(dir, 0755)
+	journal, _, err := openWriteAheadJournal(filepath.Join(dir, ".worker.wal"))
+	if err != nil {
+		t.Fatalf("failed to open journal: %v", err)
+	}
+	journal.append(&LogEntry{ID: "queued", Level: ERROR, Operation: "payment", Message: "Card declined"})
+	journal.file.Close() // Crash
+
+	logger, err := CreateFileLoggerWithConfig("worker", config)
+	if err != nil {
+		t.Fatalf("failed to create logger: %v", err)
+	}
+	logger.Close()
+
+	entries, err := ReadLogDir(dir)
+	if err != nil {
+		t.Fatalf("failed to read logs: %v", err)
+	}
+	var found bool
+	for _, entry := range entries {
+		found = found || entry.ID == "queued"
+	}
+	if !found {
+		t.Errorf("expected the previous run's entry to be recovered, got %+v", entries)
+	}
+}
+
+func TestWriteAheadJournalRelease(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".app.log.wal")
+	journal, _, _ := openWriteAheadJournal(path)
+	journal.append(&LogEntry{ID: "queued", Level: INFO, Operation: "op", Message: "m"})
+	journal.file.Close() // Crash
+
+	// Recovered entries that could not be written stay in the journal
+	journal, recovered, err := openWriteAheadJournal(path)
+	if err != nil || len(recovered) != 1 {
+		t.Fatalf("expected one recovered entry, got %d (%v)", len(recovered), err)
+	}
+	journal.release()
+	if entries, err := readWriteAheadJournal(path); err != nil || len(entries) != 1 {
+		t.Errorf("expected the journal to be kept, got %d entries (%v)", len(entries), err)
+	}
+}
+
+func TestWriteAheadJournalCompact(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".app.log.wal")
+	journal, _, err := openWriteAheadJournal(path)
+	if err != nil {
+		t.Fatalf("failed to open journal: %v", err)
+	}
+	defer journal.close()
+
+	var seqs []uint64
+	for _, id := range []string{"a", "b", "c"} {
+		seq, _ := journal.append(&LogEntry{ID: id, Level: INFO, Operation: "op", Message: "m"})
+		seqs = append(seqs, seq)
+	}
+	journal.commit(seqs[0], seqs[2])
+	journal.mutex.Lock()
+	journal.compact()
+	journal.mutex.Unlock()
+
+	data, _ := os.ReadFile(path)
+	if strings.Count(string(data), "\n") != 1 || !strings.Contains(string(data), `"id":"b"`) {
+		t.Errorf("expected only the pending entry after compaction, got %s", data)
+	}
+	// Appends go to the compacted file
+	journal.append(&LogEntry{ID: "d", Level: INFO, Operation: "op", Message: "m"})
+	entries, err := readWriteAheadJournal(path)
+	if err != nil || len(entries) != 2 || entries[0].ID != "b" || entries[1].ID != "d" {
+		t.Errorf("unexpected pending entries: %+v (%v)", entries, err)
+	}
+}
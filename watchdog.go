@@ -0,0 +1,127 @@
+package vibelogger
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxStackDumpSize bounds the buffer used to sample goroutine stacks
+const maxStackDumpSize = 1 << 20
+
+// OperationWatch tracks a long-running operation started with WatchOperation
+type OperationWatch struct {
+	logger    *Logger
+	operation string
+	start     time.Time
+	done      chan struct{}
+	endOnce   sync.Once
+}
+
+// WatchOperation starts a watchdog for an operation that may hang without logging.
+// If End is not called within warnAfter, a WARN entry with a stack sample of the
+// calling goroutine is written. If ctx's deadline passes first, an ERROR entry is
+// written. A warnAfter of zero disables the warning.
+func (l *Logger) WatchOperation(ctx context.Context, operation string, warnAfter time.Duration) *OperationWatch {
+	w := &OperationWatch{
+		logger:    l,
+		operation: operation,
+		start:     time.Now(),
+		done:      make(chan struct{}),
+	}
+	go w.run(ctx, warnAfter, currentGoroutineID())
+	return w
+}
+
+// End stops the watchdog and returns the elapsed time of the operation
+func (w *OperationWatch) End() time.Duration {
+	w.endOnce.Do(func() { close(w.done) })
+	return time.Since(w.start)
+}
+
+// run waits for the operation to end, the warning threshold or the context
+func (w *OperationWatch) run(ctx context.Context, warnAfter time.Duration, goroutineID string) {
+	var warnC <-chan time.Time
+	if warnAfter > 0 {
+		timer := time.NewTimer(warnAfter)
+		defer timer.Stop()
+		warnC = timer.C
+	}
+
+	for {
+		select {
+		case <-w.done:
+			return
+		case <-warnC:
+			warnC = nil
+			w.logger.Warn(w.operation, fmt.Sprintf("Operation still running after %s", warnAfter),
+				WithDuration(time.Since(w.start)),
+				WithContext(map[string]interface{}{"watchdog": "slow_operation"}),
+				withStackTrace(goroutineStack(goroutineID)))
+		case <-ctx.Done():
+			if ctx.Err() == context.DeadlineExceeded {
+				w.logger.Error(w.operation, "Operation did not finish before its context deadline",
+					WithDuration(time.Since(w.start)),
+					WithContext(map[string]interface{}{"watchdog": "deadline_exceeded"}),
+					withStackTrace(goroutineStack(goroutineID)))
+			}
+			return
+		}
+	}
+}
+
+// withStackTrace sets an explicit stack trace on the entry
+func withStackTrace(stack []string) LogOption {
+	return func(entry *LogEntry) {
+		entry.StackTrace = stack
+	}
+}
+
+// currentGoroutineID returns the ID of the calling goroutine as reported by runtime.Stack
+func currentGoroutineID() string {
+	buf := make([]byte, 64)
+	buf = buf[:runtime.Stack(buf, false)]
+	// Format: "goroutine 123 [running]:"
+	fields := bytes.Fields(buf)
+	if len(fields) < 2 {
+		return ""
+	}
+	return string(fields[1])
+}
+
+// goroutineStack returns the stack of the goroutine with the given ID, one line per element.
+// It returns nil if the goroutine no longer exists.
+func goroutineStack(id string) []string {
+	if id == "" {
+		return nil
+	}
+
+	buf := make([]byte, 64*1024)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) || len(buf) >= maxStackDumpSize {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]byte, len(buf)*2)
+	}
+
+	prefix := "goroutine " + id + " "
+	for _, block := range strings.Split(string(buf), "\n\n") {
+		if !strings.HasPrefix(block, prefix) {
+			continue
+		}
+		var lines []string
+		for _, line := range strings.Split(block, "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				lines = append(lines, line)
+			}
+		}
+		return lines
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package vibelogger
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+// waitForMemoryLogs polls until the logger holds at least n memory entries
+func waitForMemoryLogs(t *testing.T, logger *Logger, n int) []LogEntry {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if logs := logger.GetMemoryLogs(); len(logs) >= n {
+			return logs
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("Timed out waiting for %d memory log entries", n)
+	return nil
+}
+
+func TestWatchOperationWarnsOnSlowOperation(t *testing.T) {
+	logger := NewLoggerWithConfig("watchdog_test", &LoggerConfig{EnableMemoryLog: true})
+
+	watch := logger.WatchOperation(context.Background(), "slow_job", 20*time.Millisecond)
+	logs := waitForMemoryLogs(t, logger, 1)
+	watch.End()
+
+	entry := logs[0]
+	if entry.Level != WARN || entry.Operation != "slow_job" {
+		t.Fatalf("Expected WARN for slow_job, got %s %s", entry.Level, entry.Operation)
+	}
+	if entry.Context["watchdog"] != "slow_operation" {
+		t.Errorf("Expected watchdog context, got %v", entry.Context)
+	}
+	// The stack sample must come from the watched goroutine, not the watchdog
+	stack := strings.Join(entry.StackTrace, "\n")
+	if !strings.Contains(stack, "TestWatchOperationWarnsOnSlowOperation") {
+		t.Errorf("Expected stack sample of the caller, got %v", entry.StackTrace)
+	}
+}
+
+func TestWatchOperationEndedInTime(t *testing.T) {
+	logger := NewLoggerWithConfig("watchdog_test", &LoggerConfig{EnableMemoryLog: true})
+
+	watch := logger.WatchOperation(context.Background(), "fast_job", 50*time.Millisecond)
+	if elapsed := watch.End(); elapsed <= 0 {
+		t.Errorf("Expected positive elapsed time, got %v", elapsed)
+	}
+	watch.End() // Ending twice is safe
+
+	time.Sleep(80 * time.Millisecond)
+	if logs := logger.GetMemoryLogs(); len(logs) != 0 {
+		t.Errorf("Expected no entries for an operation ended in time, got %d", len(logs))
+	}
+}
+
+func TestWatchOperationDeadlineExceeded(t *testing.T) {
+	logger := NewLoggerWithConfig("watchdog_test", &LoggerConfig{EnableMemoryLog: true})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	watch := logger.WatchOperation(ctx, "hanging_job", 0)
+	defer watch.End()
+
+	logs := waitForMemoryLogs(t, logger, 1)
+	entry := logs[0]
+	if entry.Level != ERROR || entry.Context["watchdog"] != "deadline_exceeded" {
+		t.Errorf("Expected deadline ERROR, got %s %v", entry.Level, entry.Context)
+	}
+	if !strings.Contains(strings.Join(entry.StackTrace, "\n"), "TestWatchOperationDeadlineExceeded") {
+		t.Errorf("Expected stack sample of the caller, got %v", entry.StackTrace)
+	}
+}
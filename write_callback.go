@@ -0,0 +1,43 @@
+package vibelogger
+
+// WriteEvent describes one written entry to an OnWrite callback
+type WriteEvent struct {
+	Entry *LogEntry // The final entry; must not be modified
+	Data  []byte    // Record as serialized for the file or stdout, without the newline; nil if encoding failed
+	Err   error     // First error of the file, stdout or sink writes; nil on success
+}
+
+// WriteCallback observes written entries, see Logger.OnWrite
+type WriteCallback func(event WriteEvent)
+
+// OnWrite registers a callback invoked after every entry has been written to
+// all outputs, including failed writes. It suits mirroring, auditing or counting
+// without implementing a Sink.
+//
+// Callbacks run with the logger's write lock held, in registration order, so
+// they see entries in the order they were written. They must not log through
+// the same logger and should return quickly.
+// A panicking callback is recovered and reported through OnError, or on stderr
+// without handlers; the write is unaffected.
+func (l *Logger) OnWrite(callback WriteCallback) {
+	if callback == nil {
+		return
+	}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.writeCallbacks = append(l.writeCallbacks, callback)
+}
+
+// notifyWrite invokes the OnWrite callbacks. The caller must hold the mutex.
+func (l *Logger) notifyWrite(entry *LogEntry, data []byte, err error) {
+	if len(l.writeCallbacks) == 0 {
+		return
+	}
+	event := WriteEvent{Entry: entry, Data: data, Err: err}
+	for _, callback := range l.writeCallbacks {
+		// The mutex is held, so a panic cannot be logged as an entry
+		if p := callSafely("write_callback", func() { callback(event) }); p != nil {
+			l.reportError(p)
+		}
+	}
+}
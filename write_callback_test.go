@@ -0,0 +1,46 @@
+package vibelogger
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestOnWrite(t *testing.T) {
+	logger := NewLoggerWithConfig("write_callback_test", &LoggerConfig{AutoSave: false, Mode: ModeStdout})
+	var buf bytes.Buffer
+	logger.stdout = &buf
+
+	var events []WriteEvent
+	logger.OnWrite(func(event WriteEvent) { events = append(events, event) })
+	logger.OnWrite(func(event WriteEvent) { panic("broken callback") })
+	logger.OnWrite(nil) // Ignored
+
+	if err := logger.Info("first", "Written"); err != nil {
+		t.Fatalf("Expected a panicking callback not to fail the write: %v", err)
+	}
+	logger.AddSink(&recordingSink{writeErr: errors.New("sink unavailable")})
+	if err := logger.Warn("second", "Sink fails"); err == nil {
+		t.Error("Expected the sink failure to be returned")
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(events))
+	}
+	if events[0].Entry.Operation != "first" || events[0].Err != nil {
+		t.Errorf("Unexpected first event: %+v", events[0])
+	}
+	// Data is the record as written to stdout
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	if len(lines) != 2 || !bytes.Equal(events[0].Data, lines[0]) {
+		t.Errorf("Expected Data to match the written line, got %s", events[0].Data)
+	}
+	var decoded LogEntry
+	if err := json.Unmarshal(events[1].Data, &decoded); err != nil || decoded.Operation != "second" {
+		t.Errorf("Expected the serialized second entry, got %s (%v)", events[1].Data, err)
+	}
+	if events[1].Err == nil {
+		t.Error("Expected the write result to carry the sink failure")
+	}
+}
@@ -0,0 +1,84 @@
+package vibelogger
+
+import (
+	"bytes"
+	"io"
+	"sync"
+)
+
+// maxWriterLine is the longest line a Writer buffers; longer lines are split
+// into several entries
+const maxWriterLine = 64 * 1024
+
+// lineWriter turns the lines written to it into entries
+type lineWriter struct {
+	logger    *Logger
+	level     LogLevel
+	operation string
+	parse     bool // Take the operation from the line, see parseStdLogLine
+
+	mutex   sync.Mutex
+	pending []byte // Start of a line not yet terminated
+}
+
+// Writer returns an io.Writer logging every line written to it as an entry of
+// level and operation, so libraries that only accept an io.Writer, such as
+// http.Server.ErrorLog through log.New, can write into the logger. Entries are
+// enriched like any other.
+//
+// Each newline ends an entry; a trailing "\r" is dropped and empty lines are
+// skipped. A line without its newline is held until the rest arrives, and
+// lines longer than 64 KiB are split. The writer is safe for concurrent use;
+// Write returns the first error of the entries it wrote.
+func (l *Logger) Writer(level LogLevel, operation string) io.Writer {
+	return &lineWriter{logger: l, level: level, operation: operation}
+}
+
+// Write logs the complete lines in p
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	var firstErr error
+	emit := func(line []byte) {
+		line = bytes.TrimSuffix(line, []byte("\r"))
+		for len(line) > 0 {
+			n := len(line)
+			if n > maxWriterLine {
+				n = maxWriterLine
+			}
+			operation, message := w.operation, string(line[:n])
+			if w.parse {
+				operation, message = parseStdLogLine(message, w.operation)
+			}
+			if err := w.logger.log(nil, w.level, operation, message); err != nil && firstErr == nil {
+				firstErr = err
+			}
+			line = line[n:]
+		}
+	}
+
+	data := p
+	for len(data) > 0 {
+		i := bytes.IndexByte(data, '\n')
+		if i < 0 {
+			w.pending = append(w.pending, data...)
+			if full := len(w.pending) - len(w.pending)%maxWriterLine; full > 0 {
+				emit(w.pending[:full])
+				w.pending = append([]byte(nil), w.pending[full:]...)
+			}
+			break
+		}
+		if len(w.pending) > 0 {
+			emit(append(w.pending, data[:i]...))
+			w.pending = w.pending[:0]
+		} else {
+			emit(data[:i])
+		}
+		data = data[i+1:]
+	}
+	if len(w.pending) == 0 {
+		w.pending = nil
+	}
+	return len(p), firstErr
+}
@@ -0,0 +1,116 @@
+package vibelogger
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// WriterSink writes every entry as one line to an io.Writer, encoded by its
+// own Formatter. Added next to the primary output it lets one logger tee its
+// entries, e.g. JSON to the log file, colored text to stdout and a mapped
+// format to a collector.
+type WriterSink struct {
+	mutex     sync.Mutex
+	w         io.Writer
+	closer    io.Closer // Closed by Close when the sink opened the writer
+	formatter Formatter
+}
+
+// NewWriterSink creates a sink writing to w. A nil formatter writes entries
+// as the JSON of the log file.
+func NewWriterSink(w io.Writer, formatter Formatter) *WriterSink {
+	return &WriterSink{w: w, formatter: formatter}
+}
+
+// NewFileSink creates a sink appending to the file at path, creating it and
+// its directory when missing. The file is not rotated.
+func NewFileSink(path string, formatter Formatter) (*WriterSink, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create sink directory: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open sink file: %w", err)
+	}
+	return &WriterSink{w: file, closer: file, formatter: formatter}, nil
+}
+
+// Write implements Sink
+func (s *WriterSink) Write(entry *LogEntry) error {
+	var data []byte
+	var err error
+	if s.formatter != nil {
+		data, err = s.formatter.Format(entry)
+	} else {
+		data, err = marshalEntry(entry)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to format log entry: %w", err)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.w == nil {
+		return nil
+	}
+	_, err = s.w.Write(append(data, '\n'))
+	return err
+}
+
+// Close closes the file of NewFileSink; writers passed to NewWriterSink are
+// left open
+func (s *WriterSink) Close() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.w = nil
+	if s.closer == nil {
+		return nil
+	}
+	closer := s.closer
+	s.closer = nil
+	return closer.Close()
+}
+
+// filterSink is the Sink of NewFilterSink
+type filterSink struct {
+	sink  Sink
+	match func(entry *LogEntry) bool
+}
+
+// NewFilterSink wraps sink so that it only receives the entries for which
+// match returns true, e.g. to send only warnings and errors to a collector
+// while the log file keeps everything.
+func NewFilterSink(sink Sink, match func(entry *LogEntry) bool) Sink {
+	return &filterSink{sink: sink, match: match}
+}
+
+// NewLevelSink wraps sink so that it only receives entries at or above
+// minLevel, e.g. to alert on errors while the log file keeps DEBUG entries.
+// LoggerConfig.MinLevel applies first: entries it drops reach no sink.
+func NewLevelSink(sink Sink, minLevel LogLevel) Sink {
+	return NewFilterSink(sink, func(entry *LogEntry) bool {
+		return atLeastLevel(entry.Level, minLevel)
+	})
+}
+
+// atLeastLevel reports whether level is at or above minLevel; an empty
+// minLevel admits every level
+func atLeastLevel(level, minLevel LogLevel) bool {
+	return minLevel == "" || getSeverityScore(level) >= getSeverityScore(minLevel)
+}
+
+// Write implements Sink
+func (s *filterSink) Write(entry *LogEntry) error {
+	if !s.match(entry) {
+		return nil
+	}
+	return s.sink.Write(entry)
+}
+
+// Close implements Sink
+func (s *filterSink) Close() error {
+	return s.sink.Close()
+}
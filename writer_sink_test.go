@@ -0,0 +1,93 @@
+package vibelogger
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestTeeSinks(t *testing.T) {
+	config := DefaultConfig()
+	config.FilePath = filepath.Join(t.TempDir(), "app.log")
+	config.ConsoleOutput = ConsoleOff
+	logger, err := CreateFileLoggerWithConfig("tee", config)
+	if err != nil {
+		t.Fatalf("failed to create logger: %v", err)
+	}
+
+	var console bytes.Buffer
+	mapping, err := NewFieldMapping("message=msg, -context")
+	if err != nil {
+		t.Fatalf("invalid mapping: %v", err)
+	}
+	mappedPath := filepath.Join(t.TempDir(), "mapped", "app.ndjson")
+	mapped, err := NewFileSink(mappedPath, mapping)
+	if err != nil {
+		t.Fatalf("failed to create file sink: %v", err)
+	}
+	remote := &recordingSink{}
+	logger.AddSink(NewWriterSink(&console, &ConsoleFormatter{}))
+	logger.AddSink(mapped)
+	logger.AddSink(NewFilterSink(remote, func(entry *LogEntry) bool {
+		return getSeverityScore(entry.Level) >= getSeverityScore(WARN)
+	}))
+
+	logger.Info("checkout", "Order placed", WithContext(map[string]interface{}{"order": 7}))
+	logger.Warn("checkout", "Payment retried")
+	if err := logger.Close(); err != nil {
+		t.Fatalf("failed to close logger: %v", err)
+	}
+
+	// Every destination has its own encoding and filter
+	result, err := ReadLogFile(config.FilePath)
+	if err != nil || len(result.Entries) != 2 {
+		t.Fatalf("expected both entries in the log file, got %+v (%v)", result, err)
+	}
+	lines := strings.Split(strings.TrimSpace(console.String()), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[0], "INFO  checkout") || !strings.Contains(lines[0], "order=7") {
+		t.Errorf("expected text lines on the console, got %q", console.String())
+	}
+	data, err := os.ReadFile(mappedPath)
+	if err != nil {
+		t.Fatalf("failed to read mapped file: %v", err)
+	}
+	if !strings.Contains(string(data), `"msg":"Order placed"`) || strings.Contains(string(data), `"order"`) {
+		t.Errorf("expected mapped records, got %s", data)
+	}
+	if entries := remote.Entries(); len(entries) != 1 || entries[0].Level != WARN || !remote.closed {
+		t.Errorf("expected only the warning sent to the closed remote sink, got %+v", entries)
+	}
+
+	// Entries arriving after Close are dropped
+	if err := mapped.Write(&LogEntry{Message: "late"}); err != nil {
+		t.Errorf("expected writes after close to be ignored, got %v", err)
+	}
+}
+
+func TestLevelSink(t *testing.T) {
+	logger := NewLoggerWithConfig("level_sink", &LoggerConfig{AutoSave: false, MinLevel: "INFO", ConsoleMinLevel: "WARN"})
+	var console bytes.Buffer
+	logger.consoleOut = &console
+	alerts := &recordingSink{}
+	everything := &recordingSink{}
+	logger.AddSink(NewLevelSink(alerts, ERROR))
+	logger.AddSink(NewLevelSink(everything, ""))
+
+	logger.Debug("checkout", "Cart loaded")
+	logger.Info("checkout", "Order placed")
+	logger.Warn("checkout", "Payment retried")
+	logger.Error("checkout", "Payment failed")
+
+	if entries := alerts.Entries(); len(entries) != 1 || entries[0].Level != ERROR {
+		t.Errorf("expected only the error in the alert sink, got %+v", entries)
+	}
+	// The global minimum level applies before any sink
+	if entries := everything.Entries(); len(entries) != 3 {
+		t.Errorf("expected INFO and above in the unfiltered sink, got %d entries", len(entries))
+	}
+	if out := console.String(); strings.Contains(out, "Order placed") || !strings.Contains(out, "Payment retried") {
+		t.Errorf("expected WARN and above on the console, got %q", console.String())
+	}
+}
@@ -0,0 +1,52 @@
+package vibelogger
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"testing"
+)
+
+func TestLoggerWriter(t *testing.T) {
+	logger := NewLoggerWithConfig("writer", &LoggerConfig{AutoSave: false, EnableMemoryLog: true})
+	defer logger.Close()
+
+	w := logger.Writer(ERROR, "http_server")
+	stdlog := log.New(w, "", 0)
+	stdlog.Printf("http: TLS handshake error from %s: EOF", "10.0.0.1:5000")
+
+	fmt.Fprint(w, "first line\r\n\nsecond ")
+	if n := len(logger.GetMemoryLogs()); n != 2 {
+		t.Fatalf("a line without its newline should be held, got %d entries", n)
+	}
+	fmt.Fprint(w, "line\n")
+
+	logs := logger.GetMemoryLogs()
+	if len(logs) != 3 {
+		t.Fatalf("expected 3 entries, got %d", len(logs))
+	}
+	entry := logs[0]
+	if entry.Level != ERROR || entry.Operation != "http_server" || entry.Message != "http: TLS handshake error from 10.0.0.1:5000: EOF" {
+		t.Errorf("unexpected entry: %s %s %q", entry.Level, entry.Operation, entry.Message)
+	}
+	if entry.Severity == 0 || entry.Category == "" {
+		t.Error("entries should be enriched")
+	}
+	if logs[1].Message != "first line" || logs[2].Message != "second line" {
+		t.Errorf("unexpected messages: %q %q", logs[1].Message, logs[2].Message)
+	}
+}
+
+func TestLoggerWriterLongLine(t *testing.T) {
+	logger := NewLoggerWithConfig("writer_long", &LoggerConfig{AutoSave: false, EnableMemoryLog: true})
+	defer logger.Close()
+
+	w := logger.Writer(INFO, "dump")
+	w.Write([]byte(strings.Repeat("x", maxWriterLine+10)))
+	w.Write([]byte("\n"))
+
+	logs := logger.GetMemoryLogs()
+	if len(logs) != 2 || len(logs[0].Message) != maxWriterLine || len(logs[1].Message) != 10 {
+		t.Errorf("expected the long line to be split, got %d entries", len(logs))
+	}
+}